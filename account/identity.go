@@ -296,6 +296,13 @@ func IdentityFilterByUsername(username string) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// IdentityFilterByUsernameIgnoreCase is a gorm filter by 'username', ignoring the case
+func IdentityFilterByUsernameIgnoreCase(username string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("lower(username) = lower(?)", username).Limit(1)
+	}
+}
+
 // IdentityFilterByProfileURL is a gorm filter by 'profile_url'
 func IdentityFilterByProfileURL(profileURL string) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -310,6 +317,13 @@ func IdentityFilterByID(identityID uuid.UUID) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// IdentityFilterByIDs is a gorm filter for a set of Identity IDs.
+func IdentityFilterByIDs(identityIDs []uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("id IN (?)", identityIDs)
+	}
+}
+
 // IdentityWithUser is a gorm filter for preloading the User relationship.
 func IdentityWithUser() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -8,9 +8,16 @@ import (
 
 	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/mention"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
 )
 
 //An Application stands for a particular implementation of the business logic of our application
@@ -32,6 +39,18 @@ type Application interface {
 	Areas() area.Repository
 	OauthStates() auth.OauthStateReferenceRepository
 	Codebases() codebase.Repository
+	WorkItemLinkRevisions() link.RevisionRepository
+	WorkItemEvents() event.Repository
+	WorkItemTemplates() template.Repository
+	Filters() filter.Repository
+	CommentReactions() comment.ReactionRepository
+	Mentions() mention.Repository
+	WorkItemReferences() reference.Repository
+	IterationCarryOvers() iteration.CarryOverRepository
+	IterationCapacities() iteration.CapacityRepository
+	SpaceTemplates() spacetemplate.Repository
+	SpaceImportMappings() archive.MappingRepository
+	WorkItemRevisions() workitem.RevisionRepository
 }
 
 // A Transaction abstracts a database transaction. The repositories created for the transaction object make changes inside the the transaction
@@ -4,7 +4,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application/repository"
 	"github.com/fabric8-services/fabric8-wit/criteria"
-	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/search"
 
 	"context"
 
@@ -33,5 +33,5 @@ type TrackerQueryRepository interface {
 
 // SearchRepository encapsulates searching of woritems,users,etc
 type SearchRepository interface {
-	SearchFullText(ctx context.Context, searchStr string, start *int, length *int, spaceID *string) ([]workitem.WorkItem, uint64, error)
+	SearchFullText(ctx context.Context, searchStr string, filter criteria.Expression, highlight *search.HighlightOptions, start *int, length *int, spaceID *string) ([]search.Result, uint64, error)
 }
@@ -2,6 +2,7 @@ package area
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/fabric8-services/fabric8-wit/application/repository"
@@ -9,6 +10,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/path"
+	"github.com/fabric8-services/fabric8-wit/space"
 
 	"github.com/goadesign/goa"
 	"github.com/jinzhu/gorm"
@@ -18,6 +20,19 @@ import (
 
 const APIStringTypeAreas = "areas"
 
+// The rules by which a new work item created in an area without assignee gets
+// one
+const (
+	// DefaultAssigneeRuleNone leaves the work item unassigned
+	DefaultAssigneeRuleNone = "none"
+	// DefaultAssigneeRuleFixed assigns the work item to the default assignee
+	// of the area
+	DefaultAssigneeRuleFixed = "fixed"
+	// DefaultAssigneeRuleRoundRobin assigns the work item to each owner of the
+	// area in turn
+	DefaultAssigneeRuleRoundRobin = "round-robin"
+)
+
 // Area describes a single Area
 type Area struct {
 	gormsupport.Lifecycle
@@ -26,6 +41,13 @@ type Area struct {
 	Path    path.Path
 	Name    string
 	Version int
+	// DefaultAssigneeRule tells how a new work item of the area without
+	// assignee gets one (see the DefaultAssigneeRule constants)
+	DefaultAssigneeRule string
+	// DefaultAssigneeID is the identity assigned with the fixed rule
+	DefaultAssigneeID *uuid.UUID `sql:"type:uuid"`
+	// LastAssigneeID is the owner assigned last with the round-robin rule
+	LastAssigneeID *uuid.UUID `sql:"type:uuid"`
 }
 
 // GetETagData returns the field values to use to generate the ETag
@@ -54,6 +76,12 @@ type Repository interface {
 	ListChildren(ctx context.Context, parentArea *Area) ([]Area, error)
 	Query(funcs ...func(*gorm.DB) *gorm.DB) ([]Area, error)
 	Root(ctx context.Context, spaceID uuid.UUID) (*Area, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Move(ctx context.Context, id uuid.UUID, parentID uuid.UUID) (*Area, error)
+	Save(ctx context.Context, a Area) (*Area, error)
+	ListOwners(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	SetOwners(ctx context.Context, id uuid.UUID, identityIDs []uuid.UUID) error
+	NextDefaultAssignee(ctx context.Context, id uuid.UUID) (*uuid.UUID, error)
 }
 
 // NewAreaRepository creates a new storage type.
@@ -69,7 +97,13 @@ type GormAreaRepository struct {
 // Create creates a new record.
 func (m *GormAreaRepository) Create(ctx context.Context, u *Area) error {
 	defer goa.MeasureSince([]string{"goa", "db", "area", "create"}, time.Now())
+	if err := space.CheckNotArchived(ctx, m.db, u.SpaceID); err != nil {
+		return err
+	}
 	u.ID = uuid.NewV4()
+	if u.DefaultAssigneeRule == "" {
+		u.DefaultAssigneeRule = DefaultAssigneeRuleNone
+	}
 	err := m.db.Create(u).Error
 	if err != nil {
 		// ( name, spaceID ,path ) needs to be unique
@@ -82,6 +116,52 @@ func (m *GormAreaRepository) Create(ctx context.Context, u *Area) error {
 	return nil
 }
 
+// Save updates the name and the default assignee rule of the given area. The
+// version must be the same as the one of the stored area.
+// returns NotFoundError, BadParameterError, VersionConflictError or InternalError
+func (m *GormAreaRepository) Save(ctx context.Context, a Area) (*Area, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "save"}, time.Now())
+	stored, err := m.Load(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := space.CheckNotArchived(ctx, m.db, stored.SpaceID); err != nil {
+		return nil, err
+	}
+	if err := checkDefaultAssigneeRule(a); err != nil {
+		return nil, err
+	}
+	if a.DefaultAssigneeRule != DefaultAssigneeRuleFixed {
+		a.DefaultAssigneeID = nil
+	}
+	// the turn of the owners is kept as long as the rule is round-robin
+	lastAssigneeID := stored.LastAssigneeID
+	if a.DefaultAssigneeRule != DefaultAssigneeRuleRoundRobin {
+		lastAssigneeID = nil
+	}
+	tx := m.db.Model(&Area{}).Where("id = ? AND version = ?", a.ID, a.Version).Updates(map[string]interface{}{
+		"name":                  a.Name,
+		"version":               a.Version + 1,
+		"default_assignee_rule": a.DefaultAssigneeRule,
+		"default_assignee_id":   a.DefaultAssigneeID,
+		"last_assignee_id":      lastAssigneeID,
+	})
+	if err := tx.Error; err != nil {
+		if gormsupport.IsUniqueViolation(err, "areas_name_space_id_path_unique") {
+			return nil, errors.NewBadParameterError("name & space_id & path", a.Name+" & "+a.SpaceID.String()+" & "+stored.Path.String()).Expected("unique")
+		}
+		log.Error(ctx, map[string]interface{}{
+			"area_id": a.ID,
+			"err":     err,
+		}, "unable to save the area")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if tx.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	return m.Load(ctx, a.ID)
+}
+
 // List all Areas related to a single item
 func (m *GormAreaRepository) List(ctx context.Context, spaceID uuid.UUID) ([]Area, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "Area", "query"}, time.Now())
@@ -160,6 +240,94 @@ func (m *GormAreaRepository) Root(ctx context.Context, spaceID uuid.UUID) (*Area
 	return &rootArea[0], nil
 }
 
+// Delete deletes the given area along with all its child areas. The root area
+// of a space can not be deleted.
+func (m *GormAreaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "delete"}, time.Now())
+	a, err := m.Load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if a.Path.IsEmpty() {
+		return errors.NewBadParameterError("id", id).Expected("area which is not the root area of the space")
+	}
+	if err := space.CheckNotArchived(ctx, m.db, a.SpaceID); err != nil {
+		return err
+	}
+	tx := m.db.Where("space_id = ? AND (id = ? OR path <@ ?)", a.SpaceID, id, path.ToExpression(a.Path, a.ID)).Delete(&Area{})
+	if err := tx.Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"area_id": id,
+			"err":     err,
+		}, "unable to delete the area")
+		return errors.NewInternalError(ctx, err)
+	}
+	log.Debug(ctx, map[string]interface{}{
+		"area_id": id,
+		"deleted": tx.RowsAffected,
+	}, "area deleted along with its child areas")
+	return nil
+}
+
+// Move moves the given area, along with all its child areas, under the given
+// parent area of the same space. The paths of the whole subtree are
+// rewritten, so the caller is expected to run this within a transaction. The
+// root area of a space can not be moved.
+func (m *GormAreaRepository) Move(ctx context.Context, id uuid.UUID, parentID uuid.UUID) (*Area, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "move"}, time.Now())
+	a, err := m.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Path.IsEmpty() {
+		return nil, errors.NewBadParameterError("id", id).Expected("area which is not the root area of the space")
+	}
+	if err := space.CheckNotArchived(ctx, m.db, a.SpaceID); err != nil {
+		return nil, err
+	}
+	parent, err := m.Load(ctx, parentID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("parent", parentID).Expected("existing area")
+	}
+	if !uuid.Equal(parent.SpaceID, a.SpaceID) {
+		return nil, errors.NewBadParameterError("parent", parentID).Expected("area of the same space")
+	}
+	if uuid.Equal(parent.ID, a.ID) || parent.Path.Contains(a.ID) {
+		return nil, errors.NewBadParameterError("parent", parentID).Expected("area which is neither the moved area nor one of its children")
+	}
+	newPath := append(parent.Path, parent.ID)
+	if newPath.Convert() == a.Path.Convert() {
+		return a, nil
+	}
+	err = m.db.Model(&Area{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"path":    newPath,
+		"version": a.Version + 1,
+	}).Error
+	if err != nil {
+		if gormsupport.IsUniqueViolation(err, "areas_name_space_id_path_unique") {
+			return nil, errors.NewBadParameterError("name & space_id & path", a.Name+" & "+a.SpaceID.String()+" & "+newPath.String()).Expected("unique")
+		}
+		log.Error(ctx, map[string]interface{}{
+			"area_id": id,
+			"err":     err,
+		}, "unable to move the area")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	// the children keep the part of their path that follows the parent of the
+	// moved area
+	query := fmt.Sprintf(`UPDATE %s SET path = CAST(? AS ltree) || subpath(path, ?), version = version + 1, updated_at = now()
+		WHERE space_id = ? AND path <@ ? AND deleted_at IS NULL`, m.TableName())
+	err = m.db.Exec(query, newPath.Convert(), len(a.Path), a.SpaceID, path.ToExpression(a.Path, a.ID)).Error
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"area_id": id,
+			"err":     err,
+		}, "unable to move the child areas")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return m.Load(ctx, id)
+}
+
 // Query exposes an open ended Query model for Area
 func (m *GormAreaRepository) Query(funcs ...func(*gorm.DB) *gorm.DB) ([]Area, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "area", "query"}, time.Now())
@@ -191,6 +359,14 @@ func FilterByName(name string) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// FilterByAncestor is a gorm filter for the areas located anywhere below the
+// given area.
+func FilterByAncestor(ancestor Area) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("path <@ ?", path.ToExpression(ancestor.Path, ancestor.ID))
+	}
+}
+
 // FilterByPath is a gorm filter by 'path' of the parent area for any given area.
 func FilterByPath(pathOfParent path.Path) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -13,6 +13,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/path"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
@@ -407,3 +408,268 @@ func (test *TestAreaRepository) TestListParentTree() {
 	}
 
 }
+
+func (test *TestAreaRepository) TestDeleteArea() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := area.NewAreaRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: uuid.NewV4().String(),
+	})
+	require.Nil(t, err)
+	root := area.Area{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+
+	t.Run("delete with children", func(t *testing.T) {
+		// given
+		parent := area.Area{Name: "Backend", SpaceID: sp.ID, Path: path.Path{root.ID}}
+		require.Nil(t, repo.Create(context.Background(), &parent))
+		child := area.Area{Name: "Database", SpaceID: sp.ID, Path: path.Path{root.ID, parent.ID}}
+		require.Nil(t, repo.Create(context.Background(), &child))
+		sibling := area.Area{Name: "Frontend", SpaceID: sp.ID, Path: path.Path{root.ID}}
+		require.Nil(t, repo.Create(context.Background(), &sibling))
+		// when
+		err := repo.Delete(context.Background(), parent.ID)
+		// then
+		require.Nil(t, err)
+		_, err = repo.Load(context.Background(), parent.ID)
+		assert.IsType(t, errs.NotFoundError{}, err)
+		_, err = repo.Load(context.Background(), child.ID)
+		assert.IsType(t, errs.NotFoundError{}, err)
+		_, err = repo.Load(context.Background(), sibling.ID)
+		assert.Nil(t, err)
+		// and an area with the same name can be created again
+		recreated := area.Area{Name: "Backend", SpaceID: sp.ID, Path: path.Path{root.ID}}
+		assert.Nil(t, repo.Create(context.Background(), &recreated))
+	})
+
+	t.Run("delete root area", func(t *testing.T) {
+		// when
+		err := repo.Delete(context.Background(), root.ID)
+		// then
+		require.IsType(t, errs.BadParameterError{}, err)
+	})
+}
+
+func (test *TestAreaRepository) TestMoveArea() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := area.NewAreaRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: uuid.NewV4().String(),
+	})
+	require.Nil(t, err)
+	/*
+		Root ---> Backend ---> Database ---> Migrations
+		     ---> Frontend
+	*/
+	root := area.Area{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+	backend := area.Area{Name: "Backend", SpaceID: sp.ID, Path: path.Path{root.ID}}
+	require.Nil(t, repo.Create(context.Background(), &backend))
+	frontend := area.Area{Name: "Frontend", SpaceID: sp.ID, Path: path.Path{root.ID}}
+	require.Nil(t, repo.Create(context.Background(), &frontend))
+	database := area.Area{Name: "Database", SpaceID: sp.ID, Path: path.Path{root.ID, backend.ID}}
+	require.Nil(t, repo.Create(context.Background(), &database))
+	migrations := area.Area{Name: "Migrations", SpaceID: sp.ID, Path: path.Path{root.ID, backend.ID, database.ID}}
+	require.Nil(t, repo.Create(context.Background(), &migrations))
+
+	t.Run("move with children", func(t *testing.T) {
+		// when
+		moved, err := repo.Move(context.Background(), database.ID, frontend.ID)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, path.Path{root.ID, frontend.ID}.Convert(), moved.Path.Convert())
+		assert.Equal(t, database.Version+1, moved.Version)
+		child, err := repo.Load(context.Background(), migrations.ID)
+		require.Nil(t, err)
+		assert.Equal(t, path.Path{root.ID, frontend.ID, database.ID}.Convert(), child.Path.Convert())
+		children, err := repo.Query(area.FilterBySpaceID(sp.ID), area.FilterByAncestor(backend))
+		require.Nil(t, err)
+		assert.Empty(t, children)
+	})
+
+	t.Run("move under one of its children", func(t *testing.T) {
+		// when
+		_, err := repo.Move(context.Background(), database.ID, migrations.ID)
+		// then
+		require.IsType(t, errs.BadParameterError{}, err)
+	})
+
+	t.Run("move root area", func(t *testing.T) {
+		// when
+		_, err := repo.Move(context.Background(), root.ID, backend.ID)
+		// then
+		require.IsType(t, errs.BadParameterError{}, err)
+	})
+
+	t.Run("move under unknown area", func(t *testing.T) {
+		// when
+		_, err := repo.Move(context.Background(), backend.ID, uuid.NewV4())
+		// then
+		require.IsType(t, errs.BadParameterError{}, err)
+	})
+}
+
+func (test *TestAreaRepository) TestOwners() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := area.NewAreaRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: uuid.NewV4().String(),
+	})
+	require.Nil(t, err)
+	a := area.Area{Name: "Backend", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &a))
+	var identityIDs []uuid.UUID
+	for i := 0; i < 3; i++ {
+		identity, err := testsupport.CreateTestIdentity(test.DB, "area-owner-"+uuid.NewV4().String(), "test")
+		require.Nil(t, err)
+		identityIDs = append(identityIDs, identity.ID)
+	}
+
+	t.Run("set and list owners", func(t *testing.T) {
+		// when
+		err := repo.SetOwners(context.Background(), a.ID, identityIDs[:2])
+		// then
+		require.Nil(t, err)
+		owners, err := repo.ListOwners(context.Background(), a.ID)
+		require.Nil(t, err)
+		assert.Equal(t, identityIDs[:2], owners)
+	})
+
+	t.Run("replace owners", func(t *testing.T) {
+		// when
+		err := repo.SetOwners(context.Background(), a.ID, identityIDs[1:])
+		// then
+		require.Nil(t, err)
+		owners, err := repo.ListOwners(context.Background(), a.ID)
+		require.Nil(t, err)
+		assert.Equal(t, identityIDs[1:], owners)
+	})
+
+	t.Run("unknown area", func(t *testing.T) {
+		// when
+		err := repo.SetOwners(context.Background(), uuid.NewV4(), identityIDs)
+		// then
+		require.IsType(t, errs.NotFoundError{}, err)
+	})
+}
+
+func (test *TestAreaRepository) TestNextDefaultAssignee() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := area.NewAreaRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: uuid.NewV4().String(),
+	})
+	require.Nil(t, err)
+	var identityIDs []uuid.UUID
+	for i := 0; i < 2; i++ {
+		identity, err := testsupport.CreateTestIdentity(test.DB, "area-owner-"+uuid.NewV4().String(), "test")
+		require.Nil(t, err)
+		identityIDs = append(identityIDs, identity.ID)
+	}
+	createArea := func(name string) area.Area {
+		a := area.Area{Name: name, SpaceID: sp.ID}
+		require.Nil(t, repo.Create(context.Background(), &a))
+		require.Nil(t, repo.SetOwners(context.Background(), a.ID, identityIDs))
+		return a
+	}
+
+	t.Run("none", func(t *testing.T) {
+		// given
+		a := createArea("Unassigned")
+		// when
+		assigneeID, err := repo.NextDefaultAssignee(context.Background(), a.ID)
+		// then
+		require.Nil(t, err)
+		assert.Nil(t, assigneeID)
+	})
+
+	t.Run("fixed", func(t *testing.T) {
+		// given
+		a := createArea("Fixed")
+		a.DefaultAssigneeRule = area.DefaultAssigneeRuleFixed
+		a.DefaultAssigneeID = &identityIDs[1]
+		_, err := repo.Save(context.Background(), a)
+		require.Nil(t, err)
+		for i := 0; i < 2; i++ {
+			// when
+			assigneeID, err := repo.NextDefaultAssignee(context.Background(), a.ID)
+			// then
+			require.Nil(t, err)
+			require.NotNil(t, assigneeID)
+			assert.Equal(t, identityIDs[1], *assigneeID)
+		}
+	})
+
+	t.Run("round-robin", func(t *testing.T) {
+		// given
+		a := createArea("Round Robin")
+		a.DefaultAssigneeRule = area.DefaultAssigneeRuleRoundRobin
+		_, err := repo.Save(context.Background(), a)
+		require.Nil(t, err)
+		for _, expected := range []uuid.UUID{identityIDs[0], identityIDs[1], identityIDs[0]} {
+			// when
+			assigneeID, err := repo.NextDefaultAssignee(context.Background(), a.ID)
+			// then
+			require.Nil(t, err)
+			require.NotNil(t, assigneeID)
+			assert.Equal(t, expected, *assigneeID)
+		}
+	})
+}
+
+func (test *TestAreaRepository) TestSaveArea() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := area.NewAreaRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: uuid.NewV4().String(),
+	})
+	require.Nil(t, err)
+	a := area.Area{Name: "Backend", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &a))
+	assert.Equal(t, area.DefaultAssigneeRuleNone, a.DefaultAssigneeRule)
+
+	t.Run("ok", func(t *testing.T) {
+		// given
+		a.Name = "Server"
+		// when
+		saved, err := repo.Save(context.Background(), a)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, "Server", saved.Name)
+		assert.Equal(t, a.Version+1, saved.Version)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		// when
+		_, err := repo.Save(context.Background(), a)
+		// then
+		require.IsType(t, errs.VersionConflictError{}, err)
+	})
+
+	t.Run("fixed rule without default assignee", func(t *testing.T) {
+		// given
+		loaded, err := repo.Load(context.Background(), a.ID)
+		require.Nil(t, err)
+		loaded.DefaultAssigneeRule = area.DefaultAssigneeRuleFixed
+		// when
+		_, err = repo.Save(context.Background(), *loaded)
+		// then
+		require.IsType(t, errs.BadParameterError{}, err)
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		// given
+		loaded, err := repo.Load(context.Background(), a.ID)
+		require.Nil(t, err)
+		loaded.DefaultAssigneeRule = "random"
+		// when
+		_, err = repo.Save(context.Background(), *loaded)
+		// then
+		require.IsType(t, errs.BadParameterError{}, err)
+	})
+}
@@ -0,0 +1,155 @@
+package area
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Owner links an area to one of the identities owning it
+type Owner struct {
+	AreaID     uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	IdentityID uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	CreatedAt  time.Time
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (o Owner) TableName() string {
+	return "area_owners"
+}
+
+// ListOwners returns the IDs of the identities owning the given area, in the
+// order in which they were added.
+func (m *GormAreaRepository) ListOwners(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "listowners"}, time.Now())
+	var owners []Owner
+	if err := m.db.Where("area_id = ?", id).Order("created_at, identity_id").Find(&owners).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"area_id": id,
+			"err":     err,
+		}, "unable to list the owners of the area")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	result := make([]uuid.UUID, len(owners))
+	for i, o := range owners {
+		result[i] = o.IdentityID
+	}
+	return result, nil
+}
+
+// SetOwners replaces the owners of the given area with the given identities.
+// The owners which are kept keep their turn for the round-robin rule.
+func (m *GormAreaRepository) SetOwners(ctx context.Context, id uuid.UUID, identityIDs []uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "setowners"}, time.Now())
+	a, err := m.Load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := space.CheckNotArchived(ctx, m.db, a.SpaceID); err != nil {
+		return err
+	}
+	current, err := m.ListOwners(ctx, id)
+	if err != nil {
+		return err
+	}
+	kept := map[uuid.UUID]bool{}
+	for _, identityID := range identityIDs {
+		kept[identityID] = true
+	}
+	existing := map[uuid.UUID]bool{}
+	for _, identityID := range current {
+		existing[identityID] = true
+		if kept[identityID] {
+			continue
+		}
+		if err := m.db.Where("area_id = ? AND identity_id = ?", id, identityID).Delete(&Owner{}).Error; err != nil {
+			return errors.NewInternalError(ctx, err)
+		}
+	}
+	for _, identityID := range identityIDs {
+		if existing[identityID] {
+			continue
+		}
+		existing[identityID] = true
+		if err := m.db.Create(&Owner{AreaID: id, IdentityID: identityID}).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"area_id":     id,
+				"identity_id": identityID,
+				"err":         err,
+			}, "unable to add the owner of the area")
+			return errors.NewInternalError(ctx, err)
+		}
+	}
+	return nil
+}
+
+// NextDefaultAssignee returns the identity to which a new work item of the
+// given area without assignee should be assigned, according to the default
+// assignee rule of the area, or nil if it should stay unassigned. With the
+// round-robin rule, the returned owner is recorded as the last assignee.
+func (m *GormAreaRepository) NextDefaultAssignee(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "area", "nextdefaultassignee"}, time.Now())
+	var a Area
+	// lock the area so that concurrent work items get different owners
+	tx := m.db.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&a)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("Area", id.String())
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	switch a.DefaultAssigneeRule {
+	case DefaultAssigneeRuleFixed:
+		return a.DefaultAssigneeID, nil
+	case DefaultAssigneeRuleRoundRobin:
+		owners, err := m.ListOwners(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(owners) == 0 {
+			return nil, nil
+		}
+		next := owners[0]
+		if a.LastAssigneeID != nil {
+			for i, owner := range owners {
+				if uuid.Equal(owner, *a.LastAssigneeID) {
+					next = owners[(i+1)%len(owners)]
+					break
+				}
+			}
+		}
+		// this is bookkeeping, so neither the version nor the update time of
+		// the area change
+		if err := m.db.Model(&a).UpdateColumn("last_assignee_id", next).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"area_id": id,
+				"err":     err,
+			}, "unable to record the last assignee of the area")
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		return &next, nil
+	}
+	return nil, nil
+}
+
+// checkDefaultAssigneeRule returns a BadParameterError if the default
+// assignee rule of the given area is unknown or lacks its default assignee.
+func checkDefaultAssigneeRule(a Area) error {
+	switch a.DefaultAssigneeRule {
+	case DefaultAssigneeRuleNone, DefaultAssigneeRuleRoundRobin:
+		return nil
+	case DefaultAssigneeRuleFixed:
+		if a.DefaultAssigneeID == nil {
+			return errors.NewBadParameterError("default_assignee_id", nil).Expected("identity to assign with the fixed rule")
+		}
+		return nil
+	}
+	return errors.NewBadParameterError("default_assignee_rule", a.DefaultAssigneeRule).Expected("one of none, fixed or round-robin")
+}
@@ -2,6 +2,7 @@ package comment
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
@@ -11,17 +12,30 @@ import (
 // Comment describes a single comment
 type Comment struct {
 	gormsupport.Lifecycle
-	ID        uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"` // This is the ID PK field
-	ParentID  uuid.UUID `sql:"type:uuid"`
-	CreatedBy uuid.UUID `sql:"type:uuid"` // Belongs To Identity
-	Body      string
-	Markup    string
+	ID       uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"` // This is the ID PK field
+	ParentID uuid.UUID `sql:"type:uuid"`
+	// the comment this comment replies to, if any. It has the same parent.
+	ParentCommentID *uuid.UUID `sql:"type:uuid"`
+	CreatedBy       uuid.UUID  `sql:"type:uuid"` // Belongs To Identity
+	Body            string
+	Markup          string
+	// the reactions to the comment, when loaded with the reaction repository
+	Reactions []ReactionSummary `gorm:"-"`
 }
 
 // GetETagData returns the field values to use to generate the ETag
 func (m Comment) GetETagData() []interface{} {
-	// using the 'ID' and 'UpdatedAt' (converted to number of seconds since epoch) fields
-	return []interface{}{m.ID, strconv.FormatInt(m.UpdatedAt.Unix(), 10)}
+	// using the 'ID' and 'UpdatedAt' (converted to number of seconds since epoch) fields,
+	// and the reactions, which do not change the comment itself
+	data := []interface{}{m.ID, strconv.FormatInt(m.UpdatedAt.Unix(), 10)}
+	if len(m.Reactions) > 0 {
+		reactions := make([]string, len(m.Reactions))
+		for i, r := range m.Reactions {
+			reactions[i] = r.Emoji + ":" + strconv.Itoa(len(r.Identities))
+		}
+		data = append(data, strings.Join(reactions, ","))
+	}
+	return data
 }
 
 // GetLastModified returns the last modification time
@@ -8,6 +8,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/goadesign/goa"
 	"github.com/jinzhu/gorm"
 
@@ -22,6 +23,8 @@ type Repository interface {
 	Save(ctx context.Context, comment *Comment, modifier uuid.UUID) error
 	Delete(ctx context.Context, commentID uuid.UUID, suppressor uuid.UUID) error
 	List(ctx context.Context, parent uuid.UUID, start *int, limit *int) ([]Comment, uint64, error)
+	ListTopLevel(ctx context.Context, parent uuid.UUID, start *int, limit *int) ([]Comment, uint64, error)
+	ListReplies(ctx context.Context, commentIDs []uuid.UUID, limit int) ([]Comment, uint64, error)
 	Load(ctx context.Context, id uuid.UUID) (*Comment, error)
 	Count(ctx context.Context, parentID uuid.UUID) (int, error)
 }
@@ -43,9 +46,32 @@ func (m *GormCommentRepository) TableName() string {
 	return "comments"
 }
 
+// repliesQuery selects the IDs of the replies to the given comments, at any depth
+const repliesQuery = `WITH RECURSIVE replies AS (
+	SELECT id FROM comments WHERE parent_comment_id IN (?) AND deleted_at IS NULL
+	UNION
+	SELECT c.id FROM comments c JOIN replies r ON c.parent_comment_id = r.id WHERE c.deleted_at IS NULL
+) SELECT id FROM replies`
+
 // Create creates a new record.
 func (m *GormCommentRepository) Create(ctx context.Context, comment *Comment, creatorID uuid.UUID) error {
 	defer goa.MeasureSince([]string{"goa", "db", "comment", "create"}, time.Now())
+	if err := space.CheckWorkItemsNotArchived(ctx, m.db, comment.ParentID); err != nil {
+		return err
+	}
+	if comment.ParentCommentID != nil {
+		// a reply belongs to the same parent as the comment it replies to
+		parentComment, err := m.Load(ctx, *comment.ParentCommentID)
+		if err != nil {
+			if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+				return errors.NewBadParameterError("parent comment", comment.ParentCommentID.String()).Expected("an existing comment")
+			}
+			return errs.WithStack(err)
+		}
+		if !uuid.Equal(parentComment.ParentID, comment.ParentID) {
+			return errors.NewBadParameterError("parent comment", comment.ParentCommentID.String()).Expected("a comment with the same parent")
+		}
+	}
 	comment.ID = uuid.NewV4()
 	// make sure no comment is created with an empty 'markup' value
 	if comment.Markup == "" {
@@ -88,6 +114,9 @@ func (m *GormCommentRepository) Save(ctx context.Context, comment *Comment, modi
 
 		return errors.NewInternalError(ctx, err)
 	}
+	if err := space.CheckWorkItemsNotArchived(ctx, m.db, c.ParentID); err != nil {
+		return err
+	}
 	// make sure no comment is created with an empty 'markup' value
 	if comment.Markup == "" {
 		comment.Markup = rendering.SystemMarkupDefault
@@ -112,7 +141,7 @@ func (m *GormCommentRepository) Save(ctx context.Context, comment *Comment, modi
 	return nil
 }
 
-// Delete a single comment
+// Delete a single comment along with its replies
 func (m *GormCommentRepository) Delete(ctx context.Context, commentID uuid.UUID, suppressorID uuid.UUID) error {
 	if commentID == uuid.Nil {
 		return errors.NewNotFoundError("comment", commentID.String())
@@ -120,6 +149,9 @@ func (m *GormCommentRepository) Delete(ctx context.Context, commentID uuid.UUID,
 	// fetch the id and parent id of the comment to delete, to store them in the new revision.
 	c := Comment{}
 	tx := m.db.Select("id, parent_id").Where("id = ?", commentID).Find(&c)
+	if err := space.CheckWorkItemsNotArchived(ctx, m.db, c.ParentID); err != nil {
+		return err
+	}
 	m.db.Delete(c)
 	if tx.RowsAffected == 0 {
 		return errors.NewNotFoundError("comment", commentID.String())
@@ -131,14 +163,75 @@ func (m *GormCommentRepository) Delete(ctx context.Context, commentID uuid.UUID,
 	if err := m.revisionRepository.Create(ctx, suppressorID, RevisionTypeDelete, c); err != nil {
 		return errs.Wrapf(err, "error while deleting work item")
 	}
+	// the replies do not make sense without the comment they reply to
+	var replies []Comment
+	if err := m.db.Select("id, parent_id").Where("id IN ("+repliesQuery+")", []uuid.UUID{commentID}).Find(&replies).Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	for _, reply := range replies {
+		if err := m.db.Delete(reply).Error; err != nil {
+			return errors.NewInternalError(ctx, err)
+		}
+		if err := m.revisionRepository.Create(ctx, suppressorID, RevisionTypeDelete, reply); err != nil {
+			return errs.Wrapf(err, "error while deleting the replies to the comment")
+		}
+	}
+	log.Debug(ctx, map[string]interface{}{
+		"comment_id": commentID,
+		"replies":    len(replies),
+	}, "Comment deleted!")
 	return nil
 }
 
-// List all comments related to a single item
+// List all comments related to a single item, including the replies
 func (m *GormCommentRepository) List(ctx context.Context, parentID uuid.UUID, start *int, limit *int) ([]Comment, uint64, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "comment", "query"}, time.Now())
+	return m.list(ctx, m.db.Model(&Comment{}).Where("parent_id = ?", parentID), start, limit)
+}
+
+// ListTopLevel lists the comments related to a single item which do not
+// reply to another comment
+func (m *GormCommentRepository) ListTopLevel(ctx context.Context, parentID uuid.UUID, start *int, limit *int) ([]Comment, uint64, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "query"}, time.Now())
+	return m.list(ctx, m.db.Model(&Comment{}).Where("parent_id = ? AND parent_comment_id IS NULL", parentID), start, limit)
+}
+
+// ListReplies lists the oldest replies to the given comments, at any depth and
+// up to the given limit, from the oldest to the most recent one, along with
+// the total count of the replies. Since a reply is always more recent than the
+// comment it replies to, the listed replies only reply to the given comments
+// or to other listed replies.
+func (m *GormCommentRepository) ListReplies(ctx context.Context, commentIDs []uuid.UUID, limit int) ([]Comment, uint64, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment", "query"}, time.Now())
+	replies := []Comment{}
+	if len(commentIDs) == 0 {
+		return replies, 0, nil
+	}
+	if limit <= 0 {
+		return nil, 0, errors.NewBadParameterError("limit", limit)
+	}
+	db := m.db.Model(&Comment{}).Where("id IN ("+repliesQuery+")", commentIDs)
+	var count uint64
+	if err := db.Count(&count).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"comment_ids": commentIDs,
+			"err":         err,
+		}, "unable to count the replies")
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	if err := db.Order("created_at asc").Limit(limit).Find(&replies).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"comment_ids": commentIDs,
+			"err":         err,
+		}, "unable to list the replies")
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	return replies, count, nil
+}
 
-	db := m.db.Model(&Comment{}).Where("parent_id = ?", parentID)
+// list returns a page of the comments selected by the given query, from the
+// most recent to the oldest one, along with their total count
+func (m *GormCommentRepository) list(ctx context.Context, db *gorm.DB, start *int, limit *int) ([]Comment, uint64, error) {
 	orgDB := db
 	if start != nil {
 		if *start < 0 {
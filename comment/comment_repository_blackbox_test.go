@@ -16,6 +16,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/resource"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -247,3 +248,115 @@ func (s *TestCommentRepository) TestExistsComment() {
 	})
 
 }
+
+func (s *TestCommentRepository) TestCreateReply() {
+	// given
+	parentID := uuid.NewV4()
+	c := newComment(parentID, "Test A", rendering.SystemMarkupMarkdown)
+	s.createComment(c, s.testIdentity.ID)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		reply := newComment(parentID, "Test B", rendering.SystemMarkupMarkdown)
+		reply.ParentCommentID = &c.ID
+		err := s.repo.Create(s.ctx, reply, s.testIdentity.ID)
+		// then
+		require.Nil(t, err)
+		loadedReply, err := s.repo.Load(s.ctx, reply.ID)
+		require.Nil(t, err)
+		require.NotNil(t, loadedReply.ParentCommentID)
+		assert.Equal(t, c.ID, *loadedReply.ParentCommentID)
+	})
+
+	s.T().Run("comment with another parent", func(t *testing.T) {
+		// when
+		reply := newComment(uuid.NewV4(), "Test B", rendering.SystemMarkupMarkdown)
+		reply.ParentCommentID = &c.ID
+		err := s.repo.Create(s.ctx, reply, s.testIdentity.ID)
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("unknown comment", func(t *testing.T) {
+		// when
+		reply := newComment(parentID, "Test B", rendering.SystemMarkupMarkdown)
+		unknownID := uuid.NewV4()
+		reply.ParentCommentID = &unknownID
+		err := s.repo.Create(s.ctx, reply, s.testIdentity.ID)
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+func (s *TestCommentRepository) TestListTopLevelAndReplies() {
+	// given
+	parentID := uuid.NewV4()
+	comment1 := newComment(parentID, "Test A", rendering.SystemMarkupMarkdown)
+	comment2 := newComment(parentID, "Test B", rendering.SystemMarkupMarkdown)
+	s.createComments([]*comment.Comment{comment1, comment2}, s.testIdentity.ID)
+	reply := newComment(parentID, "Reply to A", rendering.SystemMarkupMarkdown)
+	reply.ParentCommentID = &comment1.ID
+	s.createComment(reply, s.testIdentity.ID)
+	replyToReply := newComment(parentID, "Reply to the reply to A", rendering.SystemMarkupMarkdown)
+	replyToReply.ParentCommentID = &reply.ID
+	s.createComment(replyToReply, s.testIdentity.ID)
+	// when
+	topLevel, count, err := s.repo.ListTopLevel(s.ctx, parentID, nil, nil)
+	// then
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(2), count)
+	require.Len(s.T(), topLevel, 2)
+	assert.Equal(s.T(), comment2.ID, topLevel[0].ID)
+	assert.Equal(s.T(), comment1.ID, topLevel[1].ID)
+	// when
+	replies, count, err := s.repo.ListReplies(s.ctx, []uuid.UUID{comment1.ID, comment2.ID}, 10)
+	// then
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(2), count)
+	require.Len(s.T(), replies, 2)
+	assert.Equal(s.T(), reply.ID, replies[0].ID)
+	assert.Equal(s.T(), replyToReply.ID, replies[1].ID)
+	// when
+	replies, count, err = s.repo.ListReplies(s.ctx, []uuid.UUID{comment1.ID, comment2.ID}, 1)
+	// then only the oldest replies are listed
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(2), count)
+	require.Len(s.T(), replies, 1)
+	assert.Equal(s.T(), reply.ID, replies[0].ID)
+	// all the comments are still listed
+	_, count, err = s.repo.List(s.ctx, parentID, nil, nil)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(4), count)
+}
+
+func (s *TestCommentRepository) TestDeleteCommentWithReplies() {
+	// given
+	parentID := uuid.NewV4()
+	c := newComment(parentID, "Test A", rendering.SystemMarkupMarkdown)
+	s.createComment(c, s.testIdentity.ID)
+	reply := newComment(parentID, "Reply to A", rendering.SystemMarkupMarkdown)
+	reply.ParentCommentID = &c.ID
+	s.createComment(reply, s.testIdentity.ID)
+	replyToReply := newComment(parentID, "Reply to the reply to A", rendering.SystemMarkupMarkdown)
+	replyToReply.ParentCommentID = &reply.ID
+	s.createComment(replyToReply, s.testIdentity.ID)
+	other := newComment(parentID, "Test B", rendering.SystemMarkupMarkdown)
+	s.createComment(other, s.testIdentity.ID)
+	// when
+	err := s.repo.Delete(s.ctx, c.ID, s.testIdentity.ID)
+	// then
+	require.Nil(s.T(), err)
+	count, err := s.repo.Count(s.ctx, parentID)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, count)
+	for _, id := range []uuid.UUID{reply.ID, replyToReply.ID} {
+		_, err := s.repo.Load(s.ctx, id)
+		assert.IsType(s.T(), errors.NotFoundError{}, errs.Cause(err))
+		revisions, err := comment.NewRevisionRepository(s.DB).List(s.ctx, id)
+		require.Nil(s.T(), err)
+		require.Len(s.T(), revisions, 2)
+		assert.Equal(s.T(), comment.RevisionTypeDelete, revisions[1].Type)
+	}
+}
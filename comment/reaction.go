@@ -0,0 +1,53 @@
+package comment
+
+import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// APIStringTypeReactions is the JSON API type of the comment reactions
+	APIStringTypeReactions = "reactions"
+	// maxEmojiLength is the maximum number of code points of a reaction, which
+	// is enough for the emoji sequences with modifiers and joiners
+	maxEmojiLength = 16
+	// zeroWidthJoiner joins several emojis into one
+	zeroWidthJoiner = '\u200d'
+)
+
+// Reaction is the reaction of an identity to a comment with an emoji
+type Reaction struct {
+	CreatedAt  time.Time
+	CommentID  uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	IdentityID uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	Emoji      string    `gorm:"primary_key"`
+}
+
+// TableName implements gorm.tabler
+func (r Reaction) TableName() string {
+	return "comment_reactions"
+}
+
+// ReactionSummary lists the identities that reacted to a comment with an emoji
+type ReactionSummary struct {
+	Emoji      string
+	Identities []uuid.UUID
+}
+
+// ValidateEmoji returns an error unless the given string is a single emoji,
+// possibly made of several code points such as skin tone modifiers or joiners
+func ValidateEmoji(emoji string) error {
+	if emoji == "" || utf8.RuneCountInString(emoji) > maxEmojiLength {
+		return errors.NewBadParameterError("emoji", emoji).Expected("an emoji")
+	}
+	for _, r := range emoji {
+		if !unicode.In(r, unicode.So, unicode.Sk, unicode.Mn, unicode.Me) && r != zeroWidthJoiner {
+			return errors.NewBadParameterError("emoji", emoji).Expected("an emoji")
+		}
+	}
+	return nil
+}
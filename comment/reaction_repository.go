@@ -0,0 +1,112 @@
+package comment
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReactionRepository describes interactions with the reactions to comments.
+// The reactions are not part of the comments, hence toggling one does not
+// create a comment revision.
+type ReactionRepository interface {
+	// Toggle adds the reaction of the given identity with the given emoji to
+	// the given comment, or removes it if it already exists. It returns true
+	// if the reaction was added.
+	Toggle(ctx context.Context, commentID uuid.UUID, identityID uuid.UUID, emoji string) (bool, error)
+	// List returns the reactions to the given comments by comment ID, in
+	// the order of their first occurrence.
+	List(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID][]ReactionSummary, error)
+}
+
+// NewReactionRepository creates a new storage type.
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &GormReactionRepository{db: db}
+}
+
+// GormReactionRepository is the implementation of the storage interface for
+// the reactions to comments.
+type GormReactionRepository struct {
+	db *gorm.DB
+}
+
+// Toggle adds the reaction of the given identity with the given emoji to the
+// given comment, or removes it if it already exists. It returns true if the
+// reaction was added.
+func (r *GormReactionRepository) Toggle(ctx context.Context, commentID uuid.UUID, identityID uuid.UUID, emoji string) (bool, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment_reaction", "toggle"}, time.Now())
+	if err := ValidateEmoji(emoji); err != nil {
+		return false, errs.WithStack(err)
+	}
+	var c Comment
+	tx := r.db.Select("id, parent_id").Where("id = ?", commentID).First(&c)
+	if tx.RecordNotFound() {
+		return false, errors.NewNotFoundError("comment", commentID.String())
+	}
+	if tx.Error != nil {
+		return false, errors.NewInternalError(ctx, tx.Error)
+	}
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, c.ParentID); err != nil {
+		return false, err
+	}
+	tx = r.db.Where("comment_id = ? AND identity_id = ? AND emoji = ?", commentID, identityID, emoji).Delete(Reaction{})
+	if tx.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"comment_id":  commentID,
+			"identity_id": identityID,
+			"err":         tx.Error,
+		}, "unable to remove the reaction")
+		return false, errors.NewInternalError(ctx, tx.Error)
+	}
+	if tx.RowsAffected > 0 {
+		return false, nil
+	}
+	reaction := Reaction{
+		CommentID:  commentID,
+		IdentityID: identityID,
+		Emoji:      emoji,
+	}
+	if err := r.db.Create(&reaction).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"comment_id":  commentID,
+			"identity_id": identityID,
+			"err":         err,
+		}, "unable to add the reaction")
+		return false, errors.NewInternalError(ctx, err)
+	}
+	return true, nil
+}
+
+// List returns the reactions to the given comments by comment ID, in the
+// order of their first occurrence.
+func (r *GormReactionRepository) List(ctx context.Context, commentIDs []uuid.UUID) (map[uuid.UUID][]ReactionSummary, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "comment_reaction", "list"}, time.Now())
+	res := make(map[uuid.UUID][]ReactionSummary, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return res, nil
+	}
+	var reactions []Reaction
+	if err := r.db.Where("comment_id IN (?)", commentIDs).Order("created_at, identity_id").Find(&reactions).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	for _, reaction := range reactions {
+		summaries := res[reaction.CommentID]
+		i := 0
+		for i < len(summaries) && summaries[i].Emoji != reaction.Emoji {
+			i++
+		}
+		if i == len(summaries) {
+			summaries = append(summaries, ReactionSummary{Emoji: reaction.Emoji})
+		}
+		summaries[i].Identities = append(summaries[i].Identities, reaction.IdentityID)
+		res[reaction.CommentID] = summaries
+	}
+	return res, nil
+}
@@ -0,0 +1,148 @@
+package comment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestReactionRepository struct {
+	gormtestsupport.DBTestSuite
+	clean         func()
+	testIdentity  account.Identity
+	testIdentity2 account.Identity
+	repo          comment.ReactionRepository
+	commentRepo   comment.Repository
+	ctx           context.Context
+}
+
+func TestRunReactionRepository(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestReactionRepository{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (s *TestReactionRepository) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.ctx = migration.NewMigrationContext(context.Background())
+	s.DBTestSuite.PopulateDBTestSuite(s.ctx)
+}
+
+func (s *TestReactionRepository) SetupTest() {
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+	s.repo = comment.NewReactionRepository(s.DB)
+	s.commentRepo = comment.NewRepository(s.DB)
+	var err error
+	s.testIdentity, err = testsupport.CreateTestIdentity(s.DB, "jdoe", "test")
+	require.Nil(s.T(), err)
+	s.testIdentity2, err = testsupport.CreateTestIdentity(s.DB, "jdoe2", "test")
+	require.Nil(s.T(), err)
+}
+
+func (s *TestReactionRepository) TearDownTest() {
+	s.clean()
+}
+
+func (s *TestReactionRepository) TestToggle() {
+	// given
+	c := newComment(uuid.NewV4(), "Test A", rendering.SystemMarkupMarkdown)
+	require.Nil(s.T(), s.commentRepo.Create(s.ctx, c, s.testIdentity.ID))
+
+	s.T().Run("add and remove", func(t *testing.T) {
+		// when
+		added, err := s.repo.Toggle(s.ctx, c.ID, s.testIdentity.ID, "👍")
+		require.Nil(t, err)
+		assert.True(t, added)
+		added, err = s.repo.Toggle(s.ctx, c.ID, s.testIdentity2.ID, "👍")
+		require.Nil(t, err)
+		assert.True(t, added)
+		added, err = s.repo.Toggle(s.ctx, c.ID, s.testIdentity.ID, "🎉")
+		require.Nil(t, err)
+		assert.True(t, added)
+		// then
+		reactions, err := s.repo.List(s.ctx, []uuid.UUID{c.ID})
+		require.Nil(t, err)
+		assert.Equal(t, []comment.ReactionSummary{
+			{Emoji: "👍", Identities: []uuid.UUID{s.testIdentity.ID, s.testIdentity2.ID}},
+			{Emoji: "🎉", Identities: []uuid.UUID{s.testIdentity.ID}},
+		}, reactions[c.ID])
+		// when
+		added, err = s.repo.Toggle(s.ctx, c.ID, s.testIdentity.ID, "👍")
+		require.Nil(t, err)
+		assert.False(t, added)
+		// then
+		reactions, err = s.repo.List(s.ctx, []uuid.UUID{c.ID})
+		require.Nil(t, err)
+		assert.Equal(t, []comment.ReactionSummary{
+			{Emoji: "👍", Identities: []uuid.UUID{s.testIdentity2.ID}},
+			{Emoji: "🎉", Identities: []uuid.UUID{s.testIdentity.ID}},
+		}, reactions[c.ID])
+		// the comment itself did not change
+		revisions, err := comment.NewRevisionRepository(s.DB).List(s.ctx, c.ID)
+		require.Nil(t, err)
+		assert.Len(t, revisions, 1)
+	})
+
+	s.T().Run("invalid emoji", func(t *testing.T) {
+		for _, emoji := range []string{"", "+1", "👍 ", "👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍"} {
+			_, err := s.repo.Toggle(s.ctx, c.ID, s.testIdentity.ID, emoji)
+			require.NotNil(t, err, emoji)
+			assert.IsType(t, errors.BadParameterError{}, errs.Cause(err), emoji)
+		}
+	})
+
+	s.T().Run("unknown comment", func(t *testing.T) {
+		_, err := s.repo.Toggle(s.ctx, uuid.NewV4(), s.testIdentity.ID, "👍")
+		require.NotNil(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+
+	s.T().Run("comment on a work item of an archived space", func(t *testing.T) {
+		// given
+		spaceRepo := space.NewRepository(s.DB)
+		sp, err := spaceRepo.Create(s.ctx, &space.Space{Name: testsupport.CreateRandomValidTestName("reactions-")})
+		require.Nil(t, err)
+		wi, err := workitem.NewWorkItemRepository(s.DB).Create(s.ctx, sp.ID, workitem.SystemBug, map[string]interface{}{
+			workitem.SystemTitle: "Archived",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity.ID)
+		require.Nil(t, err)
+		archived := newComment(wi.ID, "Test B", rendering.SystemMarkupMarkdown)
+		require.Nil(t, s.commentRepo.Create(s.ctx, archived, s.testIdentity.ID))
+		sp.Archived = true
+		_, err = spaceRepo.Save(s.ctx, sp)
+		require.Nil(t, err)
+		// when
+		_, err = s.repo.Toggle(s.ctx, archived.ID, s.testIdentity.ID, "👍")
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+}
+
+func TestValidateEmoji(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	for _, emoji := range []string{"👍", "👍🏽", "❤️", "👩‍💻", "🇫🇷"} {
+		assert.Nil(t, comment.ValidateEmoji(emoji), emoji)
+	}
+	for _, emoji := range []string{"", "a", ":+1:", "👍 👍"} {
+		assert.NotNil(t, comment.ValidateEmoji(emoji), emoji)
+	}
+}
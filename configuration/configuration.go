@@ -45,6 +45,7 @@ const (
 	varPostgresConnectionMaxIdle        = "postgres.connection.maxidle"
 	varPostgresConnectionMaxOpen        = "postgres.connection.maxopen"
 	varFeatureWorkitemRemote            = "feature.workitem.remote"
+	varFeatureIterationSchedule         = "feature.iteration.schedule"
 	varPopulateCommonTypes              = "populate.commontypes"
 	varHTTPAddress                      = "http.address"
 	varDeveloperModeEnabled             = "developer.mode.enabled"
@@ -91,6 +92,11 @@ const (
 	varLogLevel                         = "log.level"
 	varLogJSON                          = "log.json"
 	varTenantServiceURL                 = "tenant.serviceurl"
+	varSearchKnownURLs                  = "search.knownurls"
+	varIterationPointsField             = "iteration.pointsfield"
+	varIterationHoursField              = "iteration.hoursfield"
+	varIterationSchedule                = "iteration.schedule"
+	varSpaceAdmins                      = "space.admins"
 )
 
 // ConfigurationData encapsulates the Viper configuration object which stores the configuration data in-memory.
@@ -207,6 +213,13 @@ func (c *ConfigurationData) setConfigDefaults() {
 
 	// Features
 	c.v.SetDefault(varFeatureWorkitemRemote, true)
+	c.v.SetDefault(varFeatureIterationSchedule, false)
+
+	// Search
+	c.v.SetDefault(varSearchKnownURLs, defaultSearchKnownURLs)
+
+	// Iterations
+	c.v.SetDefault(varIterationSchedule, defaultIterationSchedule)
 
 	c.v.SetDefault(varKeycloakTesUser2Name, defaultKeycloakTesUser2Name)
 	c.v.SetDefault(varKeycloakTesUser2Secret, defaultKeycloakTesUser2Secret)
@@ -229,6 +242,12 @@ func (c *ConfigurationData) GetFeatureWorkitemRemote() bool {
 	return c.v.GetBool(varFeatureWorkitemRemote)
 }
 
+// GetFeatureIterationSchedule returns true if the iterations are started and
+// closed on schedule when their dates pass
+func (c *ConfigurationData) GetFeatureIterationSchedule() bool {
+	return c.v.GetBool(varFeatureIterationSchedule)
+}
+
 // GetPostgresUser returns the postgres user as set via default, config file, or environment variable
 func (c *ConfigurationData) GetPostgresUser() string {
 	return c.v.GetString(varPostgresUser)
@@ -665,9 +684,58 @@ func (c *ConfigurationData) GetTenantServiceURL() string {
 	return c.v.GetString(varTenantServiceURL)
 }
 
+// GetSearchKnownURLs returns the regular expressions of the URLs that the
+// search understands, by name (as set via default or config file). The
+// expressions use the `domain`, `path` and `id` capture groups to search the
+// URL as text, or the `owner`, `space` and `number` capture groups to look up
+// the work item with the given number in the space with the given owner and name.
+func (c *ConfigurationData) GetSearchKnownURLs() map[string]string {
+	return c.v.GetStringMapString(varSearchKnownURLs)
+}
+
+// GetIterationPointsField returns the name of the numeric work item field
+// from which the points of the iteration burndown and velocity reports are
+// summed when the request does not name one (as set via config file). When
+// empty, the reports only count the work items.
+func (c *ConfigurationData) GetIterationPointsField() string {
+	return c.v.GetString(varIterationPointsField)
+}
+
+// GetIterationHoursField returns the name of the numeric work item field
+// from which the hours committed by the collaborators of an iteration are
+// summed when their capacity is given in hours (as set via config file).
+func (c *ConfigurationData) GetIterationHoursField() string {
+	return c.v.GetString(varIterationHoursField)
+}
+
+// GetIterationSchedule returns the cron schedule on which the iterations
+// are started and closed when their start and end dates pass (as set via
+// default or config file).
+func (c *ConfigurationData) GetIterationSchedule() string {
+	return c.v.GetString(varIterationSchedule)
+}
+
+// GetSpaceAdmins returns the IDs of the identities which are allowed to
+// create the space templates and to transfer the ownership of any space, on
+// top of the space owners (as set via config file).
+func (c *ConfigurationData) GetSpaceAdmins() []string {
+	return c.v.GetStringSlice(varSpaceAdmins)
+}
+
+// defaultSearchKnownURLs are the URLs of the work item pages of the demo
+// deployment. Do not include the protocol nor trailing slashes, they are
+// removed before the URLs are matched.
+var defaultSearchKnownURLs = map[string]string{
+	"test-work-item-list-details":  `(?P<domain>demo.almighty.io)(?P<path>/work-item/list/detail/)(?P<id>\d*)`,
+	"test-work-item-board-details": `(?P<domain>demo.almighty.io)(?P<path>/work-item/board/detail/)(?P<id>\d*)`,
+}
+
 const (
 	defaultHeaderMaxLength = 5000 // bytes
 
+	// defaultIterationSchedule checks the dates of the iterations every minute
+	defaultIterationSchedule = "@every 1m"
+
 	// Auth-related defaults
 
 	// RSAPrivateKey for signing JWT Tokens
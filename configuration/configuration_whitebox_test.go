@@ -197,3 +197,17 @@ func validateRedirectURL(t *testing.T, request string, redirect string) bool {
 	require.Nil(t, err)
 	return matched
 }
+
+func TestGetSearchKnownURLsOK(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	t.Parallel()
+
+	c, err := NewConfigurationData("")
+	require.Nil(t, err)
+	knownURLs := c.GetSearchKnownURLs()
+	assert.Equal(t, defaultSearchKnownURLs, knownURLs)
+	for name, urlRegex := range knownURLs {
+		_, err := regexp.Compile(urlRegex)
+		assert.Nil(t, err, name)
+	}
+}
@@ -10,11 +10,11 @@ import (
 	"github.com/fabric8-services/fabric8-wit/area"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
-	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/path"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"github.com/goadesign/goa"
 	uuid "github.com/satori/go.uuid"
@@ -83,13 +83,8 @@ func (c *AreaController) CreateChild(ctx *app.CreateChildAreaContext) error {
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
 		}
-		if !uuid.Equal(*currentUser, s.OwnerId) {
-			log.Warn(ctx, map[string]interface{}{
-				"space_id":     s.ID,
-				"space_owner":  s.OwnerId,
-				"current_user": *currentUser,
-			}, "user is not the space owner")
-			return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not the space owner"))
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
 		}
 
 		reqArea := ctx.Payload.Data
@@ -117,6 +112,188 @@ func (c *AreaController) CreateChild(ctx *app.CreateChildAreaContext) error {
 	})
 }
 
+// Update runs the update action.
+func (c *AreaController) Update(ctx *app.UpdateAreaContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.ID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs == nil || attrs.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	// the owners and the area are saved in the same transaction, which is
+	// rolled back if any of them fails
+	var updated *area.Area
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		a, err := appl.Areas().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, a.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		a.Version = *attrs.Version
+		if attrs.Name != nil {
+			a.Name = *attrs.Name
+		}
+		if attrs.DefaultAssigneeRule != nil {
+			a.DefaultAssigneeRule = *attrs.DefaultAssigneeRule
+		}
+		if rel := ctx.Payload.Data.Relationships; rel != nil {
+			if rel.DefaultAssignee != nil {
+				a.DefaultAssigneeID = nil
+				if rel.DefaultAssignee.Data != nil && rel.DefaultAssignee.Data.ID != nil {
+					assigneeID, err := validIdentityID(ctx, appl, "data.relationships.default-assignee.data.id", *rel.DefaultAssignee.Data.ID)
+					if err != nil {
+						return err
+					}
+					a.DefaultAssigneeID = &assigneeID
+				}
+			}
+			if rel.Owners != nil {
+				owners := []uuid.UUID{}
+				for _, d := range rel.Owners.Data {
+					if d.ID == nil {
+						return errors.NewBadParameterError("data.relationships.owners.data.id", nil).Expected("not nil")
+					}
+					ownerID, err := validIdentityID(ctx, appl, "data.relationships.owners.data.id", *d.ID)
+					if err != nil {
+						return err
+					}
+					owners = append(owners, ownerID)
+				}
+				if err := appl.Areas().SetOwners(ctx, a.ID, owners); err != nil {
+					return err
+				}
+			}
+		}
+		updated, err = appl.Areas().Save(ctx, *a)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		res := &app.AreaSingle{
+			Data: ConvertArea(appl, ctx.RequestData, *updated, addResolvedPath, addOwners),
+		}
+		return ctx.OK(res)
+	})
+}
+
+// validIdentityID returns the UUID of the given identity, or a
+// BadParameterError for the given parameter if it is not a valid identity.
+func validIdentityID(ctx context.Context, appl application.Application, param string, identityID string) (uuid.UUID, error) {
+	id, err := uuid.FromString(identityID)
+	if err != nil || !appl.Identities().IsValid(ctx, id) {
+		return uuid.Nil, errors.NewBadParameterError(param, identityID).Expected("valid identity ID")
+	}
+	return id, nil
+}
+
+// Delete runs the delete action.
+func (c *AreaController) Delete(ctx *app.DeleteAreaContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.ID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	// the work items are moved and the areas are deleted in the same
+	// transaction, which is rolled back if any of them fails
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		a, err := appl.Areas().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, a.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		if a.Path.IsEmpty() {
+			return errors.NewBadParameterError("id", a.ID).Expected("area which is not the root area of the space")
+		}
+		target, err := appl.Areas().Load(ctx, ctx.Target)
+		if err != nil || !uuid.Equal(target.SpaceID, a.SpaceID) {
+			return errors.NewBadParameterError("target", ctx.Target).Expected("existing area of the space")
+		}
+		children, err := appl.Areas().Query(area.FilterBySpaceID(a.SpaceID), area.FilterByAncestor(*a))
+		if err != nil {
+			return err
+		}
+		ids := []uuid.UUID{a.ID}
+		for _, child := range children {
+			ids = append(ids, child.ID)
+		}
+		for _, deletedID := range ids {
+			if uuid.Equal(deletedID, target.ID) {
+				return errors.NewBadParameterError("target", ctx.Target).Expected("area which is neither the deleted area nor one of its children")
+			}
+		}
+		if err := reassignWorkItems(ctx, appl, a.SpaceID, workitem.SystemArea, ids, target.ID, *currentUser); err != nil {
+			return err
+		}
+		return appl.Areas().Delete(ctx, a.ID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK([]byte{})
+}
+
+// Move runs the move action.
+func (c *AreaController) Move(ctx *app.MoveAreaContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.ID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	// the paths of the area and of its children are rewritten in the same
+	// transaction, which is rolled back if any of them fails
+	var moved *area.Area
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		a, err := appl.Areas().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, a.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		moved, err = appl.Areas().Move(ctx, a.ID, ctx.Parent)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		res := &app.AreaSingle{
+			Data: ConvertArea(appl, ctx.RequestData, *moved, addResolvedPath),
+		}
+		return ctx.OK(res)
+	})
+}
+
 // Show runs the show action.
 func (c *AreaController) Show(ctx *app.ShowAreaContext) error {
 	id, err := uuid.FromString(ctx.ID)
@@ -131,7 +308,7 @@ func (c *AreaController) Show(ctx *app.ShowAreaContext) error {
 		}
 		return ctx.ConditionalRequest(*a, c.config.GetCacheControlAreas, func() error {
 			res := &app.AreaSingle{}
-			res.Data = ConvertArea(appl, ctx.RequestData, *a, addResolvedPath)
+			res.Data = ConvertArea(appl, ctx.RequestData, *a, addResolvedPath, addOwners)
 			return ctx.OK(res)
 		})
 	})
@@ -144,6 +321,22 @@ func addResolvedPath(appl application.Application, req *goa.RequestData, mArea *
 	return error
 }
 
+// addOwners adds the identities owning the area
+func addOwners(appl application.Application, req *goa.RequestData, mArea *area.Area, sArea *app.Area) error {
+	owners, err := appl.Areas().ListOwners(context.Background(), mArea.ID)
+	if err != nil {
+		return err
+	}
+	data := make([]*app.GenericData, len(owners))
+	for i, owner := range owners {
+		data[i] = ConvertUserSimple(req, owner)
+	}
+	sArea.Relationships.Owners = &app.RelationGenericList{
+		Data: data,
+	}
+	return nil
+}
+
 func getResolvePath(appl application.Application, a *area.Area) (*string, error) {
 	parentUuids := a.Path
 	parentAreas, err := appl.Areas().LoadMultiple(context.Background(), parentUuids)
@@ -200,11 +393,12 @@ func ConvertArea(appl application.Application, request *goa.RequestData, ar area
 		Type: areaType,
 		ID:   &ar.ID,
 		Attributes: &app.AreaAttributes{
-			Name:       &ar.Name,
-			CreatedAt:  &ar.CreatedAt,
-			UpdatedAt:  &ar.UpdatedAt,
-			Version:    &ar.Version,
-			ParentPath: &pathToTopMostParent,
+			Name:                &ar.Name,
+			CreatedAt:           &ar.CreatedAt,
+			UpdatedAt:           &ar.UpdatedAt,
+			Version:             &ar.Version,
+			ParentPath:          &pathToTopMostParent,
+			DefaultAssigneeRule: &ar.DefaultAssigneeRule,
 		},
 		Relationships: &app.AreaRelations{
 			Space: &app.RelationGeneric{
@@ -244,6 +438,11 @@ func ConvertArea(appl application.Application, request *goa.RequestData, ar area
 			},
 		}
 	}
+	if ar.DefaultAssigneeID != nil {
+		i.Relationships.DefaultAssignee = &app.RelationGeneric{
+			Data: ConvertUserSimple(request, *ar.DefaultAssigneeID),
+		}
+	}
 	for _, add := range additional {
 		add(appl, request, &ar, i)
 	}
@@ -16,11 +16,14 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/migration"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/space"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
+	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"context"
 	"github.com/goadesign/goa"
@@ -43,6 +46,13 @@ func TestRunAreaREST(t *testing.T) {
 	suite.Run(t, &TestAreaREST{DBTestSuite: gormtestsupport.NewDBTestSuite(pwd + "/../config.yaml")})
 }
 
+// SetupSuite overrides the DBTestSuite's function but calls it before doing anything else
+func (rest *TestAreaREST) SetupSuite() {
+	rest.DBTestSuite.SetupSuite()
+	// the work item types are needed to create work items in the areas
+	rest.DBTestSuite.PopulateDBTestSuite(migration.NewMigrationContext(context.Background()))
+}
+
 func (rest *TestAreaREST) SetupTest() {
 	rest.db = gormapplication.NewGormDB(rest.DB)
 	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
@@ -323,6 +333,213 @@ func (rest *TestAreaREST) TestShowChildrenAreaNotModifiedIfNoneMatchHeader() {
 	assertResponseHeaders(rest.T(), res)
 }
 
+func (rest *TestAreaREST) TestDeleteArea() {
+	// given
+	sp, rootArea := createSpaceAndArea(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	svc, ctrl := rest.SecuredControllerWithIdentity(owner)
+	parent := rest.createChildArea("Backend", rootArea, svc, ctrl)
+	child := rest.createChildArea("Database", convertAreaToModel(*parent), svc, ctrl)
+	wirepo := workitem.NewWorkItemRepository(rest.DB)
+	createWorkItem := func(areaID uuid.UUID) *workitem.WorkItem {
+		wi, err := wirepo.Create(
+			context.Background(), sp.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle: "Issue",
+				workitem.SystemState: workitem.SystemStateNew,
+				workitem.SystemArea:  areaID.String(),
+			}, owner.ID)
+		require.Nil(rest.T(), err)
+		return wi
+	}
+
+	rest.T().Run("not the space owner", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController()
+		test.DeleteAreaForbidden(t, svc.Context, svc, ctrl, parent.Data.ID.String(), rootArea.ID)
+	})
+
+	rest.T().Run("root area", func(t *testing.T) {
+		test.DeleteAreaBadRequest(t, svc.Context, svc, ctrl, rootArea.ID.String(), *parent.Data.ID)
+	})
+
+	rest.T().Run("target is a child area", func(t *testing.T) {
+		test.DeleteAreaBadRequest(t, svc.Context, svc, ctrl, parent.Data.ID.String(), *child.Data.ID)
+	})
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// given
+		wi := createWorkItem(*parent.Data.ID)
+		childWI := createWorkItem(*child.Data.ID)
+		// when
+		test.DeleteAreaOK(t, svc.Context, svc, ctrl, parent.Data.ID.String(), rootArea.ID)
+		// then
+		test.ShowAreaNotFound(t, svc.Context, svc, ctrl, parent.Data.ID.String(), nil, nil)
+		test.ShowAreaNotFound(t, svc.Context, svc, ctrl, child.Data.ID.String(), nil, nil)
+		for _, id := range []uuid.UUID{wi.ID, childWI.ID} {
+			moved, err := wirepo.LoadByID(context.Background(), id)
+			require.Nil(t, err)
+			assert.Equal(t, rootArea.ID.String(), moved.Fields[workitem.SystemArea])
+		}
+	})
+}
+
+func (rest *TestAreaREST) TestMoveArea() {
+	// given
+	sp, rootArea := createSpaceAndArea(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	svc, ctrl := rest.SecuredControllerWithIdentity(owner)
+	backend := rest.createChildArea("Backend", rootArea, svc, ctrl)
+	frontend := rest.createChildArea("Frontend", rootArea, svc, ctrl)
+	database := rest.createChildArea("Database", convertAreaToModel(*backend), svc, ctrl)
+
+	rest.T().Run("not the space owner", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController()
+		test.MoveAreaForbidden(t, svc.Context, svc, ctrl, backend.Data.ID.String(), *frontend.Data.ID)
+	})
+
+	rest.T().Run("root area", func(t *testing.T) {
+		test.MoveAreaBadRequest(t, svc.Context, svc, ctrl, rootArea.ID.String(), *frontend.Data.ID)
+	})
+
+	rest.T().Run("under a child area", func(t *testing.T) {
+		test.MoveAreaBadRequest(t, svc.Context, svc, ctrl, backend.Data.ID.String(), *database.Data.ID)
+	})
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// when
+		_, moved := test.MoveAreaOK(t, svc.Context, svc, ctrl, backend.Data.ID.String(), *frontend.Data.ID)
+		// then
+		assert.Equal(t, frontend.Data.ID.String(), *moved.Data.Relationships.Parent.Data.ID)
+		assert.Equal(t, "/"+rootArea.Name+"/Frontend", *moved.Data.Attributes.ParentPathResolved)
+		_, movedChild := test.ShowAreaOK(t, svc.Context, svc, ctrl, database.Data.ID.String(), nil, nil)
+		assert.Equal(t, "/"+rootArea.Name+"/Frontend/Backend", *movedChild.Data.Attributes.ParentPathResolved)
+	})
+}
+
+func (rest *TestAreaREST) TestUpdateArea() {
+	// given
+	sp, rootArea := createSpaceAndArea(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	svc, ctrl := rest.SecuredControllerWithIdentity(owner)
+	backend := rest.createChildArea("Backend", rootArea, svc, ctrl)
+	var ownerIDs []string
+	for i := 0; i < 2; i++ {
+		identity, err := testsupport.CreateTestIdentity(rest.DB, "area-owner-"+uuid.NewV4().String(), "test")
+		require.Nil(rest.T(), err)
+		ownerIDs = append(ownerIDs, identity.ID.String())
+	}
+	payload := func(version int, rule string, ownerIDs ...string) *app.UpdateAreaPayload {
+		owners := []*app.GenericData{}
+		for i := range ownerIDs {
+			owners = append(owners, &app.GenericData{ID: &ownerIDs[i]})
+		}
+		return &app.UpdateAreaPayload{
+			Data: &app.Area{
+				Type: area.APIStringTypeAreas,
+				Attributes: &app.AreaAttributes{
+					Version:             &version,
+					DefaultAssigneeRule: &rule,
+				},
+				Relationships: &app.AreaRelations{
+					Owners: &app.RelationGenericList{Data: owners},
+				},
+			},
+		}
+	}
+
+	rest.T().Run("not the space owner", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController()
+		test.UpdateAreaForbidden(t, svc.Context, svc, ctrl, backend.Data.ID.String(), payload(*backend.Data.Attributes.Version, area.DefaultAssigneeRuleRoundRobin, ownerIDs...))
+	})
+
+	rest.T().Run("unknown owner", func(t *testing.T) {
+		test.UpdateAreaBadRequest(t, svc.Context, svc, ctrl, backend.Data.ID.String(), payload(*backend.Data.Attributes.Version, area.DefaultAssigneeRuleRoundRobin, uuid.NewV4().String()))
+	})
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// when
+		_, updated := test.UpdateAreaOK(t, svc.Context, svc, ctrl, backend.Data.ID.String(), payload(*backend.Data.Attributes.Version, area.DefaultAssigneeRuleRoundRobin, ownerIDs...))
+		// then
+		assert.Equal(t, area.DefaultAssigneeRuleRoundRobin, *updated.Data.Attributes.DefaultAssigneeRule)
+		_, shown := test.ShowAreaOK(t, svc.Context, svc, ctrl, backend.Data.ID.String(), nil, nil)
+		require.NotNil(t, shown.Data.Relationships.Owners)
+		require.Len(t, shown.Data.Relationships.Owners.Data, 2)
+		for i, ownerID := range ownerIDs {
+			assert.Equal(t, ownerID, *shown.Data.Relationships.Owners.Data[i].ID)
+		}
+		// and an outdated version is rejected
+		test.UpdateAreaConflict(t, svc.Context, svc, ctrl, backend.Data.ID.String(), payload(*backend.Data.Attributes.Version, area.DefaultAssigneeRuleNone))
+	})
+}
+
+func (rest *TestAreaREST) TestCreateWorkItemWithAreaDefaultAssignee() {
+	// given
+	sp, rootArea := createSpaceAndArea(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	rootIteration := iteration.Iteration{Name: sp.Name, SpaceID: sp.ID}
+	require.Nil(rest.T(), rest.db.Iterations().Create(context.Background(), &rootIteration))
+	assignee, err := testsupport.CreateTestIdentity(rest.DB, "area-assignee-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+	fixed := area.Area{
+		Name:    "Fixed",
+		SpaceID: sp.ID,
+		Path:    append(rootArea.Path, rootArea.ID),
+	}
+	require.Nil(rest.T(), rest.db.Areas().Create(context.Background(), &fixed))
+	fixed.DefaultAssigneeRule = area.DefaultAssigneeRuleFixed
+	fixed.DefaultAssigneeID = &assignee.ID
+	_, err = rest.db.Areas().Save(context.Background(), fixed)
+	require.Nil(rest.T(), err)
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsSpaceUser("Area-Service", almtoken.NewManagerWithPrivateKey(priv), *owner, &TestSpaceAuthzService{*owner})
+	ctrl := NewWorkitemController(svc, rest.db, rest.Configuration)
+	payload := func(title string, areaID uuid.UUID) *app.CreateWorkitemPayload {
+		p := newCreateWorkItemPayload(sp.ID, workitem.SystemBug, title)
+		id := areaID.String()
+		p.Data.Relationships.Area = &app.RelationGeneric{
+			Data: &app.GenericData{ID: &id},
+		}
+		return p
+	}
+
+	rest.T().Run("without assignee", func(t *testing.T) {
+		// when
+		_, wi := test.CreateWorkitemCreated(t, svc.Context, svc, ctrl, sp.ID, payload("Unassigned bug", fixed.ID))
+		// then
+		require.NotNil(t, wi.Data.Relationships.Assignees)
+		require.Len(t, wi.Data.Relationships.Assignees.Data, 1)
+		assert.Equal(t, assignee.ID.String(), *wi.Data.Relationships.Assignees.Data[0].ID)
+	})
+
+	rest.T().Run("with assignee", func(t *testing.T) {
+		// given
+		p := payload("Assigned bug", fixed.ID)
+		ownerID := owner.ID.String()
+		p.Data.Relationships.Assignees = &app.RelationGenericList{
+			Data: []*app.GenericData{{ID: &ownerID}},
+		}
+		// when
+		_, wi := test.CreateWorkitemCreated(t, svc.Context, svc, ctrl, sp.ID, p)
+		// then
+		require.NotNil(t, wi.Data.Relationships.Assignees)
+		require.Len(t, wi.Data.Relationships.Assignees.Data, 1)
+		assert.Equal(t, ownerID, *wi.Data.Relationships.Assignees.Data[0].ID)
+	})
+
+	rest.T().Run("area without rule", func(t *testing.T) {
+		// when
+		_, wi := test.CreateWorkitemCreated(t, svc.Context, svc, ctrl, sp.ID, payload("Bug of the root area", rootArea.ID))
+		// then
+		if wi.Data.Relationships.Assignees != nil {
+			assert.Empty(t, wi.Data.Relationships.Assignees.Data)
+		}
+	})
+}
+
 func convertAreaToModel(appArea app.AreaSingle) area.Area {
 	return area.Area{
 		ID:      *appArea.Data.ID,
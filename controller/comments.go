@@ -48,6 +48,9 @@ func (c *CommentsController) Show(ctx *app.ShowCommentsContext) error {
 			jerrors, _ := jsonapi.ErrorToJSONAPIErrors(goa.ErrUnauthorized(err.Error()))
 			return ctx.NotFound(jerrors)
 		}
+		if err := loadCommentReaction(ctx, appl, cmt); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
 		return ctx.ConditionalRequest(*cmt, c.config.GetCacheControlComments, func() error {
 			res := &app.CommentSingle{}
 			// This code should change if others type of parents than WI are allowed
@@ -58,7 +61,8 @@ func (c *CommentsController) Show(ctx *app.ShowCommentsContext) error {
 			res.Data = ConvertComment(
 				ctx.RequestData,
 				*cmt,
-				includeParentWorkItem)
+				includeParentWorkItem,
+				commentIncludeMarkupLinks(appl, ctx, []comment.Comment{*cmt}))
 			return ctx.OK(res)
 		})
 	})
@@ -115,6 +119,16 @@ func (c *CommentsController) performUpdate(ctx *app.UpdateCommentsContext, cm *c
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, err)
 		}
+		wi, err := appl.WorkItems().LoadByID(ctx.Context, cm.ParentID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if err := saveCommentMarkupLinks(ctx, appl, *wi, *cm); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if err := loadCommentReaction(ctx, appl, cm); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
 
 		// This code should change if others type of parents than WI are allowed
 		includeParentWorkItem, err := CommentIncludeParentWorkItem(ctx, appl, cm)
@@ -123,7 +137,7 @@ func (c *CommentsController) performUpdate(ctx *app.UpdateCommentsContext, cm *c
 		}
 
 		res := &app.CommentSingle{
-			Data: ConvertComment(ctx.RequestData, *cm, includeParentWorkItem),
+			Data: ConvertComment(ctx.RequestData, *cm, includeParentWorkItem, commentIncludeMarkupLinks(appl, ctx, []comment.Comment{*cm})),
 		}
 		return ctx.OK(res)
 	})
@@ -182,6 +196,62 @@ func (c *CommentsController) performDelete(ctx *app.DeleteCommentsContext, cm *c
 	})
 }
 
+// React toggles the reaction of the current user to the comment. Any
+// authenticated user can react to a comment, the same way as they can comment.
+func (c *CommentsController) React(ctx *app.ReactCommentsContext) error {
+	identityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		_, err := appl.CommentReactions().Toggle(ctx, ctx.CommentID, *identityID, ctx.Payload.Data.Attributes.Emoji)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		cm, err := appl.Comments().Load(ctx, ctx.CommentID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if err := loadCommentReaction(ctx, appl, cm); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		// This code should change if others type of parents than WI are allowed
+		includeParentWorkItem, err := CommentIncludeParentWorkItem(ctx, appl, cm)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("comment parentID", cm.ParentID.String()))
+		}
+		return ctx.OK(&app.CommentSingle{
+			Data: ConvertComment(ctx.RequestData, *cm, includeParentWorkItem, commentIncludeMarkupLinks(appl, ctx, []comment.Comment{*cm})),
+		})
+	})
+}
+
+// loadCommentReactions loads the reactions to the given comments
+func loadCommentReactions(ctx context.Context, appl application.Application, comments []comment.Comment) error {
+	commentIDs := make([]uuid.UUID, len(comments))
+	for i, c := range comments {
+		commentIDs[i] = c.ID
+	}
+	reactions, err := appl.CommentReactions().List(ctx, commentIDs)
+	if err != nil {
+		return err
+	}
+	for i := range comments {
+		comments[i].Reactions = reactions[comments[i].ID]
+	}
+	return nil
+}
+
+// loadCommentReaction loads the reactions to the given comment
+func loadCommentReaction(ctx context.Context, appl application.Application, c *comment.Comment) error {
+	comments := []comment.Comment{*c}
+	if err := loadCommentReactions(ctx, appl, comments); err != nil {
+		return err
+	}
+	c.Reactions = comments[0].Reactions
+	return nil
+}
+
 // CommentConvertFunc is a open ended function to add additional links/data/relations to a Comment during
 // conversion from internal to API
 type CommentConvertFunc func(*goa.RequestData, *comment.Comment, *app.Comment)
@@ -222,6 +292,14 @@ func ConvertComment(request *goa.RequestData, comment comment.Comment, additiona
 	markup := rendering.NilSafeGetMarkup(&comment.Markup)
 	bodyRendered := rendering.RenderMarkupToHTML(html.EscapeString(comment.Body), comment.Markup)
 	relatedCreatorLink := rest.AbsoluteURL(request, fmt.Sprintf("%s/%s", usersEndpoint, comment.CreatedBy.String()))
+	reactions := make([]*app.CommentReaction, len(comment.Reactions))
+	for i, r := range comment.Reactions {
+		reactions[i] = &app.CommentReaction{
+			Emoji:      r.Emoji,
+			Count:      len(r.Identities),
+			Identities: r.Identities,
+		}
+	}
 	c := &app.Comment{
 		Type: "comments",
 		ID:   &comment.ID,
@@ -231,6 +309,7 @@ func ConvertComment(request *goa.RequestData, comment comment.Comment, additiona
 			Markup:       &markup,
 			CreatedAt:    &comment.CreatedAt,
 			UpdatedAt:    &comment.UpdatedAt,
+			Reactions:    reactions,
 		},
 		Relationships: &app.CommentRelations{
 			CreatedBy: &app.CommentCreatedBy{
@@ -247,12 +326,59 @@ func ConvertComment(request *goa.RequestData, comment comment.Comment, additiona
 			Self: &selfURL,
 		},
 	}
+	if comment.ParentCommentID != nil {
+		parentCommentSelf := rest.AbsoluteURL(request, app.CommentsHref(*comment.ParentCommentID))
+		parentCommentID := comment.ParentCommentID.String()
+		parentCommentType := "comments"
+		c.Relationships.ParentComment = &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: &parentCommentType,
+				ID:   &parentCommentID,
+			},
+			Links: &app.GenericLinks{
+				Self: &parentCommentSelf,
+			},
+		}
+	}
 	for _, add := range additional {
 		add(request, &comment, c)
 	}
 	return c
 }
 
+// CommentIncludeReplies adds the "replies" relationship to the comments, with
+// the direct replies to each of them among the given comments
+func CommentIncludeReplies(comments []comment.Comment) CommentConvertFunc {
+	replies := make(map[uuid.UUID][]uuid.UUID)
+	for _, c := range comments {
+		if c.ParentCommentID != nil {
+			replies[*c.ParentCommentID] = append(replies[*c.ParentCommentID], c.ID)
+		}
+	}
+	return func(request *goa.RequestData, comment *comment.Comment, data *app.Comment) {
+		replyIDs := replies[comment.ID]
+		replyType := "comments"
+		relation := &app.RelationGenericList{
+			Data: make([]*app.GenericData, len(replyIDs)),
+			Meta: map[string]interface{}{
+				"totalCount": len(replyIDs),
+			},
+		}
+		for i, replyID := range replyIDs {
+			id := replyID.String()
+			self := rest.AbsoluteURL(request, app.CommentsHref(replyID))
+			relation.Data[i] = &app.GenericData{
+				Type: &replyType,
+				ID:   &id,
+				Links: &app.GenericLinks{
+					Self: &self,
+				},
+			}
+		}
+		data.Relationships.Replies = relation
+	}
+}
+
 // HrefFunc generic function to greate a relative Href to a resource
 type HrefFunc func(id interface{}) string
 
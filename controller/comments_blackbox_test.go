@@ -19,10 +19,12 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/login/tokencontext"
 	"github.com/fabric8-services/fabric8-wit/migration"
 	"github.com/fabric8-services/fabric8-wit/rendering"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/search"
 	"github.com/fabric8-services/fabric8-wit/space"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
@@ -570,3 +572,198 @@ func (s *CommentsSuite) TestOtherCollaboratorCanUpdate() {
 	_, result = test.UpdateCommentsOK(s.T(), svcWithCollaborator2.Context, svcWithCollaborator2, commentCtrl, *c.Data.ID, updateCommentPayload)
 	assertComment(s.T(), result.Data, collaborator1, updatedBody, markdownMarkup)
 }
+
+func newReactCommentsPayload(emoji string) *app.ReactCommentsPayload {
+	return &app.ReactCommentsPayload{
+		Data: &app.CreateCommentReaction{
+			Type: comment.APIStringTypeReactions,
+			Attributes: &app.CreateCommentReactionAttributes{
+				Emoji: emoji,
+			},
+		},
+	}
+}
+
+func (s *CommentsSuite) TestReactToComment() {
+	// given
+	wiID := s.createWorkItem(s.testIdentity)
+	c := s.createWorkItemComment(s.testIdentity, wiID, "body", &markdownMarkup)
+	userSvc, _, _, commentsCtrl := s.securedControllers(s.testIdentity)
+	userSvc2, _, _, commentsCtrl2 := s.securedControllers(s.testIdentity2)
+
+	s.T().Run("toggle", func(t *testing.T) {
+		// when
+		test.ReactCommentsOK(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, newReactCommentsPayload("👍"))
+		_, result := test.ReactCommentsOK(t, userSvc2.Context, userSvc2, commentsCtrl2, *c.Data.ID, newReactCommentsPayload("👍"))
+		// then
+		require.Len(t, result.Data.Attributes.Reactions, 1)
+		assert.Equal(t, "👍", result.Data.Attributes.Reactions[0].Emoji)
+		assert.Equal(t, 2, result.Data.Attributes.Reactions[0].Count)
+		assert.Equal(t, []uuid.UUID{s.testIdentity.ID, s.testIdentity2.ID}, result.Data.Attributes.Reactions[0].Identities)
+		// the comment itself did not change
+		assert.Equal(t, *c.Data.Attributes.UpdatedAt, *result.Data.Attributes.UpdatedAt)
+		// when
+		_, result = test.ReactCommentsOK(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, newReactCommentsPayload("👍"))
+		// then
+		require.Len(t, result.Data.Attributes.Reactions, 1)
+		assert.Equal(t, 1, result.Data.Attributes.Reactions[0].Count)
+		// the reactions are shown and change the ETag of the comment
+		svc, ctrl := s.unsecuredController()
+		ifNoneMatch := app.GenerateEntityTag(convertCommentToModel(c))
+		_, shown := test.ShowCommentsOK(t, svc.Context, svc, ctrl, *c.Data.ID, nil, &ifNoneMatch)
+		require.Len(t, shown.Data.Attributes.Reactions, 1)
+		assert.Equal(t, []uuid.UUID{s.testIdentity2.ID}, shown.Data.Attributes.Reactions[0].Identities)
+	})
+
+	s.T().Run("invalid emoji", func(t *testing.T) {
+		test.ReactCommentsBadRequest(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, newReactCommentsPayload("+1"))
+	})
+
+	s.T().Run("unknown comment", func(t *testing.T) {
+		test.ReactCommentsNotFound(t, userSvc.Context, userSvc, commentsCtrl, uuid.NewV4(), newReactCommentsPayload("👍"))
+	})
+
+	s.T().Run("without auth", func(t *testing.T) {
+		svc, ctrl := s.unsecuredController()
+		test.ReactCommentsUnauthorized(t, svc.Context, svc, ctrl, *c.Data.ID, newReactCommentsPayload("👍"))
+	})
+}
+
+// collaboratorsService returns the same collaborators for all the spaces
+type collaboratorsService []uuid.UUID
+
+func (s collaboratorsService) Collaborators(ctx context.Context, request *goa.RequestData, spaceID uuid.UUID) ([]uuid.UUID, error) {
+	return s, nil
+}
+
+func (s *CommentsSuite) TestCommentMentions() {
+	// given
+	mentioned, err := testsupport.CreateTestIdentity(s.DB, "comments-mentioned", "test provider")
+	require.Nil(s.T(), err)
+	wiID := s.createWorkItem(s.testIdentity)
+	userSvc, _, workitemCommentsCtrl, commentsCtrl := s.securedControllers(s.testIdentity)
+	userSvc.Context = tokencontext.ContextWithCollaboratorsService(userSvc.Context, collaboratorsService{mentioned.ID})
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	usersSvc := testsupport.ServiceAsUser("Users-service-test", almtoken.NewManagerWithPrivateKey(priv), mentioned)
+	usersCtrl := NewUsersController(usersSvc, s.db, s.Configuration, nil)
+	profileURL := "/api/users/" + mentioned.ID.String()
+
+	s.T().Run("create", func(t *testing.T) {
+		// when
+		payload := newCreateWorkItemCommentsPayload("Hello @Comments-Mentioned and @"+s.testIdentity2.Username+", not `@comments-mentioned`", &markdownMarkup)
+		_, c := test.CreateWorkItemCommentsOK(t, userSvc.Context, userSvc, workitemCommentsCtrl, space.SystemSpace, wiID, payload)
+		// then only the collaborator is linked, out of the code
+		require.NotNil(t, c.Data.Attributes.BodyRendered)
+		assert.Contains(t, *c.Data.Attributes.BodyRendered, profileURL+"\" class=\"mention\"")
+		assert.Equal(t, 1, strings.Count(*c.Data.Attributes.BodyRendered, "class=\"mention\""))
+		require.NotNil(t, c.Data.Relationships.Mentions)
+		require.Len(t, c.Data.Relationships.Mentions.Data, 1)
+		assert.Equal(t, mentioned.ID.String(), *c.Data.Relationships.Mentions.Data[0].ID)
+		// the mention is listed for the user
+		_, mentions := test.MentionsUsersOK(t, usersSvc.Context, usersSvc, usersCtrl, mentioned.ID.String(), nil, nil)
+		require.Len(t, mentions.Data, 1)
+		assert.Equal(t, 1, mentions.Meta.TotalCount)
+		assert.Equal(t, "comments-mentioned", mentions.Data[0].Attributes.Username)
+		require.NotNil(t, mentions.Data[0].Relationships.Comment)
+		assert.Equal(t, c.Data.ID.String(), *mentions.Data[0].Relationships.Comment.Data.ID)
+		assert.Equal(t, wiID.String(), *mentions.Data[0].Relationships.Workitem.Data.ID)
+
+		// when the mention is removed
+		_, updated := test.UpdateCommentsOK(t, userSvc.Context, userSvc, commentsCtrl, *c.Data.ID, newUpdateCommentsPayload("Hello", &markdownMarkup))
+		// then
+		assert.Empty(t, updated.Data.Relationships.Mentions.Data)
+		_, mentions = test.MentionsUsersOK(t, usersSvc.Context, usersSvc, usersCtrl, mentioned.ID.String(), nil, nil)
+		assert.Empty(t, mentions.Data)
+	})
+
+	s.T().Run("not a collaborator of the space", func(t *testing.T) {
+		// given
+		payload := newCreateWorkItemCommentsPayload("Hello @comments-mentioned", &markdownMarkup)
+		test.CreateWorkItemCommentsOK(t, userSvc.Context, userSvc, workitemCommentsCtrl, space.SystemSpace, wiID, payload)
+		otherSvc := testsupport.ServiceAsSpaceUser("Users-service-test", almtoken.NewManagerWithPrivateKey(priv), s.testIdentity2, &spaceAccessAuthzService{})
+		otherCtrl := NewUsersController(otherSvc, s.db, s.Configuration, nil)
+		// when
+		_, mentions := test.MentionsUsersOK(t, otherSvc.Context, otherSvc, otherCtrl, mentioned.ID.String(), nil, nil)
+		// then the mentions in the spaces which the caller cannot access are not listed
+		assert.Empty(t, mentions.Data)
+		assert.Equal(t, 0, mentions.Meta.TotalCount)
+		// while they are for a collaborator
+		_, mentions = test.MentionsUsersOK(t, usersSvc.Context, usersSvc, usersCtrl, mentioned.ID.String(), nil, nil)
+		assert.Len(t, mentions.Data, 1)
+	})
+
+	s.T().Run("without auth", func(t *testing.T) {
+		svc := goa.New("Users-service-test")
+		ctrl := NewUsersController(svc, s.db, s.Configuration, nil)
+		test.MentionsUsersUnauthorized(t, svc.Context, svc, ctrl, mentioned.ID.String(), nil, nil)
+	})
+
+	s.T().Run("unknown user", func(t *testing.T) {
+		test.MentionsUsersNotFound(t, usersSvc.Context, usersSvc, usersCtrl, uuid.NewV4().String(), nil, nil)
+	})
+}
+
+func (s *CommentsSuite) TestCommentWorkItemReferences() {
+	// given
+	referencedID := s.createWorkItem(s.testIdentity)
+	wiID := s.createWorkItem(s.testIdentity)
+	userSvc, workitemCtrl, workitemCommentsCtrl, _ := s.securedControllers(s.testIdentity)
+	_, referenced := test.ShowWorkitemOK(s.T(), userSvc.Context, userSvc, workitemCtrl, space.SystemSpace, referencedID.String(), nil, nil)
+	number := referenced.Data.Attributes[workitem.SystemNumber]
+	require.NotNil(s.T(), number)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		payload := newCreateWorkItemCommentsPayload(fmt.Sprintf("Duplicate of #%v, not #0", number), &markdownMarkup)
+		_, c := test.CreateWorkItemCommentsOK(t, userSvc.Context, userSvc, workitemCommentsCtrl, space.SystemSpace, wiID, payload)
+		// then the reference is linked to the work item
+		require.NotNil(t, c.Data.Attributes.BodyRendered)
+		assert.Contains(t, *c.Data.Attributes.BodyRendered, app.WorkitemHref(space.SystemSpace.String(), referencedID)+"\" class=\"workitem-reference\"")
+		assert.Equal(t, 1, strings.Count(*c.Data.Attributes.BodyRendered, "class=\"workitem-reference\""))
+		// and the referenced work item is mentioned in the comment
+		_, shown := test.ShowWorkitemOK(t, userSvc.Context, userSvc, workitemCtrl, space.SystemSpace, referencedID.String(), nil, nil)
+		require.NotNil(t, shown.Data.Relationships.MentionedIn)
+		require.Len(t, shown.Data.Relationships.MentionedIn.Data, 1)
+		assert.Equal(t, "comments", *shown.Data.Relationships.MentionedIn.Data[0].Type)
+		assert.Equal(t, c.Data.ID.String(), *shown.Data.Relationships.MentionedIn.Data[0].ID)
+	})
+
+	s.T().Run("deleted comment", func(t *testing.T) {
+		// given
+		payload := newCreateWorkItemCommentsPayload(fmt.Sprintf("See #%v", number), &markdownMarkup)
+		_, c := test.CreateWorkItemCommentsOK(t, userSvc.Context, userSvc, workitemCommentsCtrl, space.SystemSpace, wiID, payload)
+		_, shown := test.ShowWorkitemOK(t, userSvc.Context, userSvc, workitemCtrl, space.SystemSpace, referencedID.String(), nil, nil)
+		require.Len(t, shown.Data.Relationships.MentionedIn.Data, 2)
+		// when
+		s.deleteComment(s.testIdentity, *c.Data.ID)
+		// then
+		_, shown = test.ShowWorkitemOK(t, userSvc.Context, userSvc, workitemCtrl, space.SystemSpace, referencedID.String(), nil, nil)
+		assert.Len(t, shown.Data.Relationships.MentionedIn.Data, 1)
+	})
+
+	s.T().Run("work item URL", func(t *testing.T) {
+		// given a work item in a space of the user, whose page URL is known
+		search.RegisterAsKnownURL("comments-test-work-item-url", `(?P<domain>planner.comments.io)(?P<path>/)(?P<owner>[^/]+)/(?P<space>[^/]+)/plan/detail/(?P<number>\d+)`)
+		sp, err := space.NewRepository(s.DB).Create(context.Background(), &space.Space{
+			Name:    "TestCommentWorkItemReferences-" + uuid.NewV4().String(),
+			OwnerId: s.testIdentity.ID,
+		})
+		require.Nil(t, err)
+		other, err := workitem.NewWorkItemRepository(s.DB).Create(context.Background(), sp.ID, workitem.SystemBug, map[string]interface{}{
+			workitem.SystemTitle: "Referenced by URL",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity.ID)
+		require.Nil(t, err)
+		url := fmt.Sprintf("https://planner.comments.io/%s/%s/plan/detail/%d", s.testIdentity.Username, sp.Name, other.Number)
+		// when
+		payload := newCreateWorkItemCommentsPayload("Duplicate of "+url, &markdownMarkup)
+		_, c := test.CreateWorkItemCommentsOK(t, userSvc.Context, userSvc, workitemCommentsCtrl, space.SystemSpace, wiID, payload)
+		// then the URL is linked to the work item
+		require.NotNil(t, c.Data.Attributes.BodyRendered)
+		assert.Contains(t, *c.Data.Attributes.BodyRendered, app.WorkitemHref(sp.ID.String(), other.ID)+"\" class=\"workitem-reference\"")
+		// and the referenced work item is mentioned in the comment
+		_, shown := test.ShowWorkitemOK(t, userSvc.Context, userSvc, workitemCtrl, sp.ID, other.ID.String(), nil, nil)
+		require.Len(t, shown.Data.Relationships.MentionedIn.Data, 1)
+		assert.Equal(t, c.Data.ID.String(), *shown.Data.Relationships.MentionedIn.Data[0].ID)
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
 	"github.com/goadesign/goa"
 )
 
@@ -29,6 +30,9 @@ func NewFilterController(service *goa.Service, config FilterControllerConfigurat
 
 // List runs the list action.
 func (c *FilterController) List(ctx *app.ListFilterContext) error {
+	// the built-in filters are system-defined, the saved filters are listed
+	// per space by the SpaceFiltersController
+	scope := filter.ScopeSystem
 	var arr []*app.Filters
 	arr = append(arr, &app.Filters{
 		Attributes: &app.FilterAttributes{
@@ -36,6 +40,7 @@ func (c *FilterController) List(ctx *app.ListFilterContext) error {
 			Query:       "filter[assignee]={id}",
 			Description: "Filter by assignee",
 			Type:        "users",
+			Scope:       &scope,
 		},
 		Type: "filters",
 	},
@@ -45,6 +50,7 @@ func (c *FilterController) List(ctx *app.ListFilterContext) error {
 				Query:       "filter[area]={id}",
 				Description: "Filter by area",
 				Type:        "areas",
+				Scope:       &scope,
 			},
 			Type: "filters",
 		},
@@ -54,6 +60,7 @@ func (c *FilterController) List(ctx *app.ListFilterContext) error {
 				Query:       "filter[iteration]={id}",
 				Description: "Filter by iteration",
 				Type:        "iterations",
+				Scope:       &scope,
 			},
 			Type: "filters",
 		},
@@ -63,6 +70,7 @@ func (c *FilterController) List(ctx *app.ListFilterContext) error {
 				Query:       "filter[workitemtype]={id}",
 				Description: "Filter by workitemtype",
 				Type:        "workitemtypes",
+				Scope:       &scope,
 			},
 			Type: "filters",
 		},
@@ -72,6 +80,7 @@ func (c *FilterController) List(ctx *app.ListFilterContext) error {
 				Query:       "filter[workitemstate]={id}",
 				Description: "Filter by workitemstate",
 				Type:        "workitemstate",
+				Scope:       &scope,
 			},
 			Type: "filters",
 		},
@@ -1,23 +1,31 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/criteria"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/iteration"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
-	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
 
 	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 )
 
+// APIStringTypeIterationCarryOver is the JSONAPI type of the work items
+// carried over when closing an iteration
+const APIStringTypeIterationCarryOver = "iterationcarryovers"
+
 // IterationController implements the iteration resource.
 type IterationController struct {
 	*goa.Controller
@@ -29,6 +37,8 @@ type IterationController struct {
 
 type IterationControllerConfiguration interface {
 	GetCacheControlIterations() string
+	GetIterationPointsField() string
+	GetIterationHoursField() string
 }
 
 // NewIterationController creates a iteration controller.
@@ -57,13 +67,8 @@ func (c *IterationController) CreateChild(ctx *app.CreateChildIterationContext)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
 		}
-		if !uuid.Equal(*currentUser, s.OwnerId) {
-			log.Warn(ctx, map[string]interface{}{
-				"space_id":     s.ID,
-				"space_owner":  s.OwnerId,
-				"current_user": *currentUser,
-			}, "user is not the space owner")
-			return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not the space owner"))
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
 		}
 
 		reqIter := ctx.Payload.Data
@@ -134,7 +139,11 @@ func (c *IterationController) Show(ctx *app.ShowIterationContext) error {
 			for _, itr := range iterations {
 				itrMap[itr.ID] = itr
 			}
-			responseData = ConvertIteration(ctx.RequestData, *iter, parentPathResolver(itrMap), updateIterationsWithCounts(wiCounts))
+			addCapacities, err := c.capacitiesConverter(ctx, appl, iter)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
+			responseData = ConvertIteration(ctx.RequestData, *iter, parentPathResolver(itrMap), updateIterationsWithCounts(wiCounts), addCapacities)
 			res := &app.IterationSingle{
 				Data: responseData,
 			}
@@ -163,13 +172,8 @@ func (c *IterationController) Update(ctx *app.UpdateIterationContext) error {
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
 		}
-		if !uuid.Equal(*currentUser, s.OwnerId) {
-			log.Warn(ctx, map[string]interface{}{
-				"space_id":     s.ID,
-				"space_owner":  s.OwnerId,
-				"current_user": *currentUser,
-			}, "user is not the space owner")
-			return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not the space owner"))
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
 		}
 		if ctx.Payload.Data.Attributes.Name != nil {
 			itr.Name = *ctx.Payload.Data.Attributes.Name
@@ -184,13 +188,39 @@ func (c *IterationController) Update(ctx *app.UpdateIterationContext) error {
 			itr.Description = ctx.Payload.Data.Attributes.Description
 		}
 		if ctx.Payload.Data.Attributes.State != nil {
-			if *ctx.Payload.Data.Attributes.State == iteration.IterationStateStart {
-				res, err := appl.Iterations().CanStart(ctx, itr)
-				if res == false && err != nil {
-					return jsonapi.JSONErrorResponse(ctx, err)
+			// the state transition is checked when saving the iteration
+			itr.State = *ctx.Payload.Data.Attributes.State
+		}
+		if ctx.Payload.Data.Attributes.Capacities != nil {
+			// the capacity is only recorded for the collaborators of the space
+			collaborators, err := authz.Collaborators(ctx, appl, itr.SpaceID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
+			isCollaborator := make(map[uuid.UUID]bool, len(collaborators))
+			for _, id := range collaborators {
+				isCollaborator[id] = true
+			}
+			// the work committed against a capacity is summed from the
+			// field configured for its unit
+			fields := c.capacityFields()
+			capacities := make([]iteration.Capacity, len(ctx.Payload.Data.Attributes.Capacities))
+			for i, capacity := range ctx.Payload.Data.Attributes.Capacities {
+				if !isCollaborator[capacity.Assignee] {
+					return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.capacities.assignee", capacity.Assignee).Expected("collaborator of the space"))
+				}
+				if fields[capacity.Unit] == "" {
+					return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.capacities.unit", capacity.Unit).Expected("unit whose work item field is configured"))
+				}
+				capacities[i] = iteration.Capacity{
+					IdentityID: capacity.Assignee,
+					Capacity:   capacity.Capacity,
+					Unit:       capacity.Unit,
 				}
 			}
-			itr.State = *ctx.Payload.Data.Attributes.State
+			if err := appl.IterationCapacities().Set(ctx, itr.ID, capacities); err != nil {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
 		}
 		itr, err = appl.Iterations().Save(ctx.Context, *itr)
 		if err != nil {
@@ -210,7 +240,11 @@ func (c *IterationController) Update(ctx *app.UpdateIterationContext) error {
 		for _, itr := range iterations {
 			itrMap[itr.ID] = itr
 		}
-		responseData = ConvertIteration(ctx.RequestData, *itr, parentPathResolver(itrMap), updateIterationsWithCounts(wiCounts))
+		addCapacities, err := c.capacitiesConverter(ctx, appl, itr)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		responseData = ConvertIteration(ctx.RequestData, *itr, parentPathResolver(itrMap), updateIterationsWithCounts(wiCounts), addCapacities)
 		res := &app.IterationSingle{
 			Data: responseData,
 		}
@@ -218,6 +252,509 @@ func (c *IterationController) Update(ctx *app.UpdateIterationContext) error {
 	})
 }
 
+// Delete runs the delete action.
+func (c *IterationController) Delete(ctx *app.DeleteIterationContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	// the work items are moved and the iterations are deleted in the same
+	// transaction, which is rolled back if any of them fails
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		itr, err := appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, itr.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		if itr.Path.IsEmpty() {
+			return errors.NewBadParameterError("iterationID", itr.ID).Expected("iteration which is not the root iteration of the space")
+		}
+		target, err := appl.Iterations().Load(ctx, ctx.Target)
+		if err != nil || !uuid.Equal(target.SpaceID, itr.SpaceID) {
+			return errors.NewBadParameterError("target", ctx.Target).Expected("existing iteration of the space")
+		}
+		children, err := appl.Iterations().LoadChildren(ctx, itr.ID)
+		if err != nil {
+			return err
+		}
+		ids := []uuid.UUID{itr.ID}
+		for _, child := range children {
+			ids = append(ids, child.ID)
+		}
+		for _, deletedID := range ids {
+			if uuid.Equal(deletedID, target.ID) {
+				return errors.NewBadParameterError("target", ctx.Target).Expected("iteration which is neither the deleted iteration nor one of its children")
+			}
+		}
+		if err := reassignWorkItems(ctx, appl, itr.SpaceID, workitem.SystemIteration, ids, target.ID, *currentUser); err != nil {
+			return err
+		}
+		return appl.Iterations().Delete(ctx, itr.ID)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK([]byte{})
+}
+
+// Move runs the move action.
+func (c *IterationController) Move(ctx *app.MoveIterationContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	// the paths of the iteration and of its children are rewritten in the
+	// same transaction, which is rolled back if any of them fails
+	var itr *iteration.Iteration
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		itr, err = appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, itr.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		itr, err = appl.Iterations().Move(ctx, itr.ID, ctx.Parent)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		wiCounts, err := appl.WorkItems().GetCountsForIteration(ctx, itr)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		iterations, err := appl.Iterations().LoadMultiple(ctx, itr.Path)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		itrMap := make(iterationIDMap)
+		for _, i := range iterations {
+			itrMap[i.ID] = i
+		}
+		res := &app.IterationSingle{
+			Data: ConvertIteration(ctx.RequestData, *itr, parentPathResolver(itrMap), updateIterationsWithCounts(wiCounts)),
+		}
+		return ctx.OK(res)
+	})
+}
+
+// Close runs the close action.
+func (c *IterationController) Close(ctx *app.CloseIterationContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	closer := iterationCloser{
+		modifierID: *currentUser,
+		moved:      map[uuid.UUID]bool{},
+		linkTypes:  map[uuid.UUID]*link.WorkItemLinkType{},
+	}
+	var targetID *string
+	if ctx.Payload != nil && ctx.Payload.Data != nil {
+		if attrs := ctx.Payload.Data.Attributes; attrs != nil {
+			closer.includeChildren = attrs.IncludeChildren != nil && *attrs.IncludeChildren
+		}
+		if rel := ctx.Payload.Data.Relationships; rel != nil && rel.Target != nil && rel.Target.Data != nil {
+			targetID = rel.Target.Data.ID
+		}
+	}
+	// the work items are moved and the iteration is closed in the same
+	// transaction, which is rolled back if any of them fails
+	var itr *iteration.Iteration
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		closer.appl = appl
+		itr, err = appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		s, err := appl.Spaces().Load(ctx, itr.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		if itr.Path.IsEmpty() {
+			return errors.NewBadParameterError("iterationID", itr.ID).Expected("iteration which is not the root iteration of the space")
+		}
+		if itr.State == iteration.IterationStateClose {
+			return errors.NewBadParameterError("iterationID", itr.ID).Expected("iteration which is not closed")
+		}
+		// move the work items to the backlog unless another iteration is given
+		var target *iteration.Iteration
+		if targetID == nil {
+			target, err = appl.Iterations().Root(ctx, itr.SpaceID)
+			if err != nil {
+				return err
+			}
+		} else {
+			tid, err := uuid.FromString(*targetID)
+			if err != nil {
+				return errors.NewBadParameterError("data.relationships.target", *targetID).Expected("valid iteration ID")
+			}
+			target, err = appl.Iterations().Load(ctx, tid)
+			if err != nil || !uuid.Equal(target.SpaceID, itr.SpaceID) || uuid.Equal(target.ID, itr.ID) || target.State == iteration.IterationStateClose {
+				return errors.NewBadParameterError("data.relationships.target", tid).Expected("existing iteration of the space which is not closed")
+			}
+		}
+		closer.iteration = *itr
+		closer.targetID = target.ID
+		if err := closer.close(ctx); err != nil {
+			return err
+		}
+		itr.State = iteration.IterationStateClose
+		itr, err = appl.Iterations().Save(ctx, *itr)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.IterationCarryOverList{
+		Data:     ConvertIterationCarryOvers(ctx.RequestData, itr.SpaceID, closer.carryOvers),
+		Meta:     &app.WorkItemListResponseMeta{TotalCount: len(closer.carryOvers)},
+		Included: []interface{}{ConvertIteration(ctx.RequestData, *itr)},
+	}
+	return ctx.OK(res)
+}
+
+// CarryOvers runs the carry-overs action.
+func (c *IterationController) CarryOvers(ctx *app.CarryOversIterationContext) error {
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		itr, err := appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		carryOvers, err := appl.IterationCarryOvers().List(ctx, id)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.IterationCarryOverList{
+			Data: ConvertIterationCarryOvers(ctx.RequestData, itr.SpaceID, carryOvers),
+			Meta: &app.WorkItemListResponseMeta{TotalCount: len(carryOvers)},
+		}
+		return ctx.OK(res)
+	})
+}
+
+// CarryOverEndedIteration moves the not-closed work items of the given
+// iteration, which is being closed by the scheduler because its end date
+// passed, to the backlog of its space on behalf of the space owner, the same
+// way the close action does.
+func CarryOverEndedIteration(ctx context.Context, appl application.Application, itr iteration.Iteration) error {
+	s, err := appl.Spaces().Load(ctx, itr.SpaceID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	root, err := appl.Iterations().Root(ctx, itr.SpaceID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	closer := iterationCloser{
+		appl:       appl,
+		iteration:  itr,
+		targetID:   root.ID,
+		modifierID: s.OwnerId,
+		moved:      map[uuid.UUID]bool{},
+		linkTypes:  map[uuid.UUID]*link.WorkItemLinkType{},
+	}
+	return closer.close(ctx)
+}
+
+// iterationCloser moves the not-closed work items of an iteration, and
+// optionally their not-closed children, to another iteration using the
+// repositories of a single transaction.
+type iterationCloser struct {
+	appl            application.Application
+	iteration       iteration.Iteration
+	targetID        uuid.UUID
+	includeChildren bool
+	modifierID      uuid.UUID
+	// the IDs of the work items already moved
+	moved map[uuid.UUID]bool
+	// the records of the moved work items, in the order in which they were moved
+	carryOvers []iteration.CarryOver
+	// the link types already loaded while walking the children
+	linkTypes map[uuid.UUID]*link.WorkItemLinkType
+}
+
+// close moves the not-closed work items of the iteration to the target
+// iteration and records them.
+func (c *iterationCloser) close(ctx context.Context) error {
+	exp := criteria.And(
+		criteria.Equals(criteria.Field(workitem.SystemIteration), criteria.Literal(c.iteration.ID.String())),
+		criteria.Not(criteria.Field(workitem.SystemState), criteria.Literal(workitem.SystemStateClosed)))
+	wis, _, err := c.appl.WorkItems().List(ctx, c.iteration.SpaceID, exp, nil, nil, nil, nil)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	for _, wi := range wis {
+		if err := c.move(ctx, wi); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// move moves the given work item to the target iteration unless it was
+// already moved and, if requested, moves its not-closed children of the same
+// space (following tree-topology links) as well.
+func (c *iterationCloser) move(ctx context.Context, wi workitem.WorkItem) error {
+	if c.moved[wi.ID] {
+		return nil
+	}
+	c.moved[wi.ID] = true
+	if wi.Fields[workitem.SystemIteration] != c.targetID.String() {
+		wi.Fields[workitem.SystemIteration] = c.targetID.String()
+		if _, err := c.appl.WorkItems().Save(ctx, wi.SpaceID, wi, c.modifierID); err != nil {
+			return errs.WithStack(err)
+		}
+		carryOver := iteration.CarryOver{
+			IterationID:       c.iteration.ID,
+			TargetIterationID: c.targetID,
+			WorkItemID:        wi.ID,
+			ModifierID:        c.modifierID,
+		}
+		if err := c.appl.IterationCarryOvers().Create(ctx, &carryOver); err != nil {
+			return errs.WithStack(err)
+		}
+		c.carryOvers = append(c.carryOvers, carryOver)
+	}
+	if !c.includeChildren {
+		return nil
+	}
+	links, err := c.appl.WorkItemLinks().ListByWorkItem(ctx, wi.ID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	for _, l := range links {
+		if !uuid.Equal(l.SourceID, wi.ID) || c.moved[l.TargetID] {
+			continue
+		}
+		linkType, ok := c.linkTypes[l.LinkTypeID]
+		if !ok {
+			linkType, err = c.appl.WorkItemLinkTypes().Load(ctx, l.LinkTypeID)
+			if err != nil {
+				return errs.WithStack(err)
+			}
+			c.linkTypes[l.LinkTypeID] = linkType
+		}
+		if linkType.Topology != link.TopologyTree {
+			continue
+		}
+		child, err := c.appl.WorkItems().LoadByID(ctx, l.TargetID)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		if !uuid.Equal(child.SpaceID, c.iteration.SpaceID) || child.Fields[workitem.SystemState] == workitem.SystemStateClosed {
+			continue
+		}
+		if err := c.move(ctx, *child); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// reassignWorkItems moves the work items of the given space whose given field
+// (i.e. the iteration or the area) references one of the given IDs to the
+// given target.
+func reassignWorkItems(ctx context.Context, appl application.Application, spaceID uuid.UUID, field string, ids []uuid.UUID, targetID uuid.UUID, modifierID uuid.UUID) error {
+	var exp criteria.Expression
+	for _, id := range ids {
+		e := criteria.Equals(criteria.Field(field), criteria.Literal(id.String()))
+		if exp == nil {
+			exp = e
+		} else {
+			exp = criteria.Or(exp, e)
+		}
+	}
+	wis, _, err := appl.WorkItems().List(ctx, spaceID, exp, nil, nil, nil, nil)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	for _, wi := range wis {
+		wi.Fields[field] = targetID.String()
+		if _, err := appl.WorkItems().Save(ctx, wi.SpaceID, wi, modifierID); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Burndown runs the burndown action.
+func (c *IterationController) Burndown(ctx *app.BurndownIterationContext) error {
+	id, err := uuid.FromString(ctx.IterationID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		itr, err := appl.Iterations().Load(ctx, id)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		pointsField, err := iterationPointsField(ctx, appl, itr.SpaceID, ctx.Field, c.config.GetIterationPointsField())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		points, err := appl.WorkItems().GetBurndownForIteration(ctx, itr, pointsField)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		days := make([]*app.IterationBurndownDay, len(points))
+		for i, p := range points {
+			days[i] = &app.IterationBurndownDay{
+				Date:            p.Date,
+				TotalCount:      p.TotalCount,
+				RemainingCount:  p.RemainingCount,
+				TotalPoints:     p.TotalPoints,
+				RemainingPoints: p.RemainingPoints,
+			}
+		}
+		res := &app.IterationBurndownSingle{
+			Data: &app.IterationBurndown{
+				Type: "burndowns",
+				ID:   itr.ID,
+				Attributes: &app.IterationBurndownAttributes{
+					StartAt: itr.StartAt,
+					EndAt:   itr.EndAt,
+					Days:    days,
+				},
+				Relationships: iterationReportRelationships(ctx.RequestData, itr.ID),
+			},
+		}
+		if pointsField != "" {
+			res.Data.Attributes.PointsField = &pointsField
+		}
+		return ctx.OK(res)
+	})
+}
+
+// ConvertIterationCarryOvers converts between internal and external REST representation
+func ConvertIterationCarryOvers(request *goa.RequestData, spaceID uuid.UUID, carryOvers []iteration.CarryOver) []*app.IterationCarryOver {
+	res := make([]*app.IterationCarryOver, len(carryOvers))
+	for i, c := range carryOvers {
+		res[i] = ConvertIterationCarryOver(request, spaceID, c)
+	}
+	return res
+}
+
+// ConvertIterationCarryOver converts between internal and external REST representation
+func ConvertIterationCarryOver(request *goa.RequestData, spaceID uuid.UUID, c iteration.CarryOver) *app.IterationCarryOver {
+	iterationType := iteration.APIStringTypeIteration
+	workItemType := APIStringTypeWorkItem
+	userType := APIStringTypeUser
+	iterationID := c.IterationID.String()
+	targetID := c.TargetIterationID.String()
+	workItemID := c.WorkItemID.String()
+	modifierID := c.ModifierID.String()
+	iterationSelfURL := rest.AbsoluteURL(request, app.IterationHref(iterationID))
+	targetSelfURL := rest.AbsoluteURL(request, app.IterationHref(targetID))
+	workItemSelfURL := rest.AbsoluteURL(request, app.WorkitemHref(spaceID.String(), workItemID))
+	modifierSelfURL := rest.AbsoluteURL(request, app.UsersHref(modifierID))
+	return &app.IterationCarryOver{
+		Type: APIStringTypeIterationCarryOver,
+		ID:   c.ID,
+		Attributes: &app.IterationCarryOverAttributes{
+			CreatedAt: c.CreatedAt,
+		},
+		Relationships: &app.IterationCarryOverRelationships{
+			Iteration: &app.RelationGeneric{
+				Data:  &app.GenericData{Type: &iterationType, ID: &iterationID},
+				Links: &app.GenericLinks{Self: &iterationSelfURL},
+			},
+			Target: &app.RelationGeneric{
+				Data:  &app.GenericData{Type: &iterationType, ID: &targetID},
+				Links: &app.GenericLinks{Self: &targetSelfURL},
+			},
+			Workitem: &app.RelationGeneric{
+				Data:  &app.GenericData{Type: &workItemType, ID: &workItemID},
+				Links: &app.GenericLinks{Self: &workItemSelfURL},
+			},
+			Modifier: &app.RelationGeneric{
+				Data:  &app.GenericData{Type: &userType, ID: &modifierID},
+				Links: &app.GenericLinks{Self: &modifierSelfURL},
+			},
+		},
+	}
+}
+
+// iterationPointsField returns the name of the work item field from which the
+// points of the iteration reports are summed: the requested field, which must
+// be a numeric field of a work item type of the space, or else the configured one.
+func iterationPointsField(ctx context.Context, appl application.Application, spaceID uuid.UUID, requested *string, configured string) (string, error) {
+	if requested == nil || *requested == "" {
+		return configured, nil
+	}
+	witypes, err := appl.WorkItemTypes().List(ctx, spaceID, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	// the spaces without work item types of their own use the ones of the
+	// system space
+	if len(witypes) == 0 {
+		witypes, err = appl.WorkItemTypes().List(ctx, space.SystemSpace, nil, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, wit := range witypes {
+		if fd, ok := wit.Fields[*requested]; ok {
+			switch fd.Type.GetKind() {
+			case workitem.KindInteger, workitem.KindFloat:
+				return *requested, nil
+			}
+		}
+	}
+	return "", errors.NewBadParameterError("field", *requested).Expected("a numeric field of a work item type of the space")
+}
+
+// iterationReportRelationships returns the relationships of a burndown or a
+// velocity with the reported iteration
+func iterationReportRelationships(request *goa.RequestData, iterationID uuid.UUID) *app.IterationReportRelations {
+	iterationType := iteration.APIStringTypeIteration
+	id := iterationID.String()
+	selfURL := rest.AbsoluteURL(request, app.IterationHref(id))
+	return &app.IterationReportRelations{
+		Iteration: &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: &iterationType,
+				ID:   &id,
+			},
+			Links: &app.GenericLinks{
+				Self: &selfURL,
+			},
+		},
+	}
+}
+
 // IterationConvertFunc is a open ended function to add additional links/data/relations to a Iteration during
 // conversion from internal to API
 type IterationConvertFunc func(*goa.RequestData, *iteration.Iteration, *app.Iteration)
@@ -363,3 +900,54 @@ func updateIterationsWithCounts(wiCounts map[string]workitem.WICountsPerIteratio
 		appIteration.Relationships.Workitems.Meta["closed"] = counts.Closed
 	}
 }
+
+// capacityFields returns the names of the work item fields from which the
+// work committed by the collaborators is summed, keyed by the unit of their
+// capacity. The name is empty if no field is configured for the unit.
+func (c *IterationController) capacityFields() map[string]string {
+	return map[string]string{
+		iteration.CapacityUnitPoints: c.config.GetIterationPointsField(),
+		iteration.CapacityUnitHours:  c.config.GetIterationHoursField(),
+	}
+}
+
+// capacitiesConverter returns an IterationConvertFunc which adds the
+// capacity of each collaborator of the given iteration along with the work
+// assigned to them. The committed work is summed from the configured points
+// or hours field, depending on the unit of the capacity, so that it is
+// flagged as over-allocated when it exceeds the capacity.
+func (c *IterationController) capacitiesConverter(ctx context.Context, appl application.Application, itr *iteration.Iteration) (IterationConvertFunc, error) {
+	capacities, err := appl.IterationCapacities().List(ctx, itr.ID)
+	if err != nil {
+		return nil, err
+	}
+	fields := c.capacityFields()
+	commitments := map[string]map[string]workitem.AssigneeCommitment{}
+	result := make([]*app.IterationCapacity, len(capacities))
+	for i, capacity := range capacities {
+		committed, ok := commitments[capacity.Unit]
+		if !ok {
+			committed, err = appl.WorkItems().GetCommitmentPerAssignee(ctx, itr, fields[capacity.Unit])
+			if err != nil {
+				return nil, err
+			}
+			commitments[capacity.Unit] = committed
+		}
+		commitment := committed[capacity.IdentityID.String()]
+		overAllocated := commitment.Total > capacity.Capacity
+		result[i] = &app.IterationCapacity{
+			Assignee:       capacity.IdentityID,
+			Unit:           capacity.Unit,
+			Capacity:       capacity.Capacity,
+			Committed:      &commitment.Total,
+			CommittedCount: &commitment.Count,
+			OverAllocated:  &overAllocated,
+		}
+	}
+	return func(request *goa.RequestData, itr *iteration.Iteration, appIteration *app.Iteration) {
+		if appIteration.Attributes == nil {
+			appIteration.Attributes = &app.IterationAttributes{}
+		}
+		appIteration.Attributes.Capacities = result
+	}, nil
+}
@@ -13,21 +13,26 @@ import (
 	"github.com/fabric8-services/fabric8-wit/app/test"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/configuration"
 	. "github.com/fabric8-services/fabric8-wit/controller"
 	"github.com/fabric8-services/fabric8-wit/gormapplication"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/login/tokencontext"
+	"github.com/fabric8-services/fabric8-wit/migration"
 	"github.com/fabric8-services/fabric8-wit/space"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
 
 	"context"
 
 	"github.com/fabric8-services/fabric8-wit/path"
 	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +50,16 @@ func TestRunIterationREST(t *testing.T) {
 	suite.Run(t, &TestIterationREST{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
 }
 
+// SetupSuite overrides the DBTestSuite's function but calls it before doing anything else
+func (rest *TestIterationREST) SetupSuite() {
+	rest.DBTestSuite.SetupSuite()
+	ctx := migration.NewMigrationContext(context.Background())
+	rest.DBTestSuite.PopulateDBTestSuite(ctx)
+	// the parent-child link type is used when closing iterations
+	err := migration.BootstrapWorkItemLinking(ctx, link.NewWorkItemLinkCategoryRepository(rest.DB), space.NewRepository(rest.DB), link.NewWorkItemLinkTypeRepository(rest.DB))
+	require.Nil(rest.T(), err)
+}
+
 func (rest *TestIterationREST) SetupTest() {
 	rest.db = gormapplication.NewGormDB(rest.DB)
 	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
@@ -437,6 +452,493 @@ func (rest *TestIterationREST) TestRootIterationCanNotStart() {
 	test.UpdateIterationBadRequest(rest.T(), svc.Context, svc, ctrl, ri.ID.String(), &payload)
 }
 
+func (rest *TestIterationREST) TestCloseIteration() {
+	// given
+	sp, _, rootItr, _, itr := createSpaceAndRootAreaAndIterations(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	createIteration := func(name string) iteration.Iteration {
+		i := iteration.Iteration{
+			Name:    name,
+			SpaceID: sp.ID,
+			Path:    append(rootItr.Path, rootItr.ID),
+		}
+		require.Nil(rest.T(), rest.db.Iterations().Create(context.Background(), &i))
+		return i
+	}
+	wirepo := workitem.NewWorkItemRepository(rest.DB)
+	createWorkItem := func(iterationID uuid.UUID, state string) *workitem.WorkItem {
+		wi, err := wirepo.Create(
+			context.Background(), sp.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Issue",
+				workitem.SystemState:     state,
+				workitem.SystemIteration: iterationID.String(),
+			}, owner.ID)
+		require.Nil(rest.T(), err)
+		return wi
+	}
+	loadIterationID := func(t *testing.T, wiID uuid.UUID) string {
+		wi, err := wirepo.LoadByID(context.Background(), wiID)
+		require.Nil(t, err)
+		return wi.Fields[workitem.SystemIteration].(string)
+	}
+	payload := func(target *iteration.Iteration, includeChildren bool) *app.CloseIterationPayload {
+		p := &app.CloseIterationPayload{
+			Data: &app.IterationClose{
+				Type: "iterationclosings",
+				Attributes: &app.IterationCloseAttributes{
+					IncludeChildren: &includeChildren,
+				},
+			},
+		}
+		if target != nil {
+			targetID := target.ID.String()
+			p.Data.Relationships = &app.IterationCloseRelationships{
+				Target: &app.RelationGeneric{
+					Data: &app.GenericData{ID: &targetID},
+				},
+			}
+		}
+		return p
+	}
+	svc, ctrl := rest.SecuredControllerWithIdentity(owner)
+
+	rest.T().Run("move to the backlog", func(t *testing.T) {
+		// given
+		openWI := createWorkItem(itr.ID, workitem.SystemStateNew)
+		closedWI := createWorkItem(itr.ID, workitem.SystemStateClosed)
+		// when
+		_, result := test.CloseIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), payload(nil, false))
+		// then
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, 1, result.Meta.TotalCount)
+		assert.Equal(t, openWI.ID.String(), *result.Data[0].Relationships.Workitem.Data.ID)
+		assert.Equal(t, rootItr.ID.String(), *result.Data[0].Relationships.Target.Data.ID)
+		assert.Equal(t, rootItr.ID.String(), loadIterationID(t, openWI.ID))
+		assert.Equal(t, itr.ID.String(), loadIterationID(t, closedWI.ID))
+		closed, err := rest.db.Iterations().Load(context.Background(), itr.ID)
+		require.Nil(t, err)
+		assert.Equal(t, iteration.IterationStateClose, closed.State)
+		// the summary is recorded
+		_, carryOvers := test.CarryOversIterationOK(t, svc.Context, svc, ctrl, itr.ID.String())
+		require.Len(t, carryOvers.Data, 1)
+		assert.Equal(t, result.Data[0].ID, carryOvers.Data[0].ID)
+		// and the iteration can not be closed again
+		test.CloseIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), payload(nil, false))
+	})
+
+	rest.T().Run("move to the next iteration with children", func(t *testing.T) {
+		// given
+		current := createIteration("Current Sprint")
+		next := createIteration("Next Sprint")
+		parent := createWorkItem(current.ID, workitem.SystemStateOpen)
+		openChild := createWorkItem(rootItr.ID, workitem.SystemStateNew)
+		closedChild := createWorkItem(rootItr.ID, workitem.SystemStateClosed)
+		err := application.Transactional(rest.db, func(appl application.Application) error {
+			for _, child := range []*workitem.WorkItem{openChild, closedChild} {
+				if _, err := appl.WorkItemLinks().Create(context.Background(), parent.ID, child.ID, link.SystemWorkItemLinkTypeParentChildID, owner.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.Nil(t, err)
+		// when
+		_, result := test.CloseIterationOK(t, svc.Context, svc, ctrl, current.ID.String(), payload(&next, true))
+		// then
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, next.ID.String(), loadIterationID(t, parent.ID))
+		assert.Equal(t, next.ID.String(), loadIterationID(t, openChild.ID))
+		assert.Equal(t, rootItr.ID.String(), loadIterationID(t, closedChild.ID))
+	})
+
+	rest.T().Run("unknown target iteration", func(t *testing.T) {
+		// given
+		current := createIteration("Sprint without target")
+		wi := createWorkItem(current.ID, workitem.SystemStateNew)
+		unknown := iteration.Iteration{ID: uuid.NewV4()}
+		// when
+		test.CloseIterationBadRequest(t, svc.Context, svc, ctrl, current.ID.String(), payload(&unknown, false))
+		// then nothing was moved nor closed
+		assert.Equal(t, current.ID.String(), loadIterationID(t, wi.ID))
+		notClosed, err := rest.db.Iterations().Load(context.Background(), current.ID)
+		require.Nil(t, err)
+		assert.NotEqual(t, iteration.IterationStateClose, notClosed.State)
+	})
+
+	rest.T().Run("root iteration", func(t *testing.T) {
+		test.CloseIterationBadRequest(t, svc.Context, svc, ctrl, rootItr.ID.String(), payload(nil, false))
+	})
+
+	rest.T().Run("not the space owner", func(t *testing.T) {
+		// given
+		current := createIteration("Sprint of someone else")
+		svc, ctrl := rest.SecuredController()
+		// when
+		test.CloseIterationForbidden(t, svc.Context, svc, ctrl, current.ID.String(), payload(nil, false))
+	})
+
+	rest.T().Run("closed by the scheduler", func(t *testing.T) {
+		// given
+		start := time.Now().Add(-48 * time.Hour)
+		end := time.Now().Add(-24 * time.Hour)
+		ended := iteration.Iteration{
+			Name:    "Ended Sprint",
+			SpaceID: sp.ID,
+			Path:    append(rootItr.Path, rootItr.ID),
+			StartAt: &start,
+			EndAt:   &end,
+		}
+		require.Nil(t, rest.db.Iterations().Create(context.Background(), &ended))
+		openWI := createWorkItem(ended.ID, workitem.SystemStateOpen)
+		closedWI := createWorkItem(ended.ID, workitem.SystemStateClosed)
+		scheduler := iteration.NewScheduler(rest.DB, time.Time{}, func(ctx context.Context, tx *gorm.DB, itr iteration.Iteration) error {
+			return CarryOverEndedIteration(ctx, gormapplication.NewGormDB(tx), itr)
+		})
+		// when
+		scheduler.UpdateStates(context.Background(), time.Now())
+		// then
+		assert.Equal(t, rootItr.ID.String(), loadIterationID(t, openWI.ID))
+		assert.Equal(t, ended.ID.String(), loadIterationID(t, closedWI.ID))
+		closed, err := rest.db.Iterations().Load(context.Background(), ended.ID)
+		require.Nil(t, err)
+		assert.Equal(t, iteration.IterationStateClose, closed.State)
+		_, carryOvers := test.CarryOversIterationOK(t, svc.Context, svc, ctrl, ended.ID.String())
+		require.Len(t, carryOvers.Data, 1)
+		assert.Equal(t, openWI.ID.String(), *carryOvers.Data[0].Relationships.Workitem.Data.ID)
+	})
+}
+
+func (rest *TestIterationREST) TestDeleteIteration() {
+	// given
+	sp, _, rootItr, _, itr := createSpaceAndRootAreaAndIterations(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	child := iteration.Iteration{
+		Name:    "Sprint #2.1",
+		SpaceID: sp.ID,
+		Path:    append(itr.Path, itr.ID),
+	}
+	require.Nil(rest.T(), rest.db.Iterations().Create(context.Background(), &child))
+	wirepo := workitem.NewWorkItemRepository(rest.DB)
+	createWorkItem := func(iterationID uuid.UUID) *workitem.WorkItem {
+		wi, err := wirepo.Create(
+			context.Background(), sp.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Issue",
+				workitem.SystemState:     workitem.SystemStateNew,
+				workitem.SystemIteration: iterationID.String(),
+			}, owner.ID)
+		require.Nil(rest.T(), err)
+		return wi
+	}
+	svc, ctrl := rest.SecuredControllerWithIdentity(owner)
+
+	rest.T().Run("not the space owner", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController()
+		test.DeleteIterationForbidden(t, svc.Context, svc, ctrl, itr.ID.String(), rootItr.ID)
+	})
+
+	rest.T().Run("root iteration", func(t *testing.T) {
+		test.DeleteIterationBadRequest(t, svc.Context, svc, ctrl, rootItr.ID.String(), itr.ID)
+	})
+
+	rest.T().Run("target is a child iteration", func(t *testing.T) {
+		test.DeleteIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), child.ID)
+	})
+
+	rest.T().Run("unknown target iteration", func(t *testing.T) {
+		test.DeleteIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), uuid.NewV4())
+	})
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// given
+		wi := createWorkItem(itr.ID)
+		childWI := createWorkItem(child.ID)
+		// when
+		test.DeleteIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), rootItr.ID)
+		// then
+		test.ShowIterationNotFound(t, svc.Context, svc, ctrl, itr.ID.String(), nil, nil)
+		test.ShowIterationNotFound(t, svc.Context, svc, ctrl, child.ID.String(), nil, nil)
+		for _, id := range []uuid.UUID{wi.ID, childWI.ID} {
+			moved, err := wirepo.LoadByID(context.Background(), id)
+			require.Nil(t, err)
+			assert.Equal(t, rootItr.ID.String(), moved.Fields[workitem.SystemIteration])
+		}
+	})
+}
+
+func (rest *TestIterationREST) TestMoveIteration() {
+	// given
+	sp, _, rootItr, _, itr := createSpaceAndRootAreaAndIterations(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	release := iteration.Iteration{
+		Name:    "Release 1",
+		SpaceID: sp.ID,
+		Path:    append(rootItr.Path, rootItr.ID),
+	}
+	require.Nil(rest.T(), rest.db.Iterations().Create(context.Background(), &release))
+	child := iteration.Iteration{
+		Name:    "Sprint #2.1",
+		SpaceID: sp.ID,
+		Path:    append(itr.Path, itr.ID),
+	}
+	require.Nil(rest.T(), rest.db.Iterations().Create(context.Background(), &child))
+	svc, ctrl := rest.SecuredControllerWithIdentity(owner)
+
+	rest.T().Run("not the space owner", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController()
+		test.MoveIterationForbidden(t, svc.Context, svc, ctrl, itr.ID.String(), release.ID)
+	})
+
+	rest.T().Run("root iteration", func(t *testing.T) {
+		test.MoveIterationBadRequest(t, svc.Context, svc, ctrl, rootItr.ID.String(), release.ID)
+	})
+
+	rest.T().Run("under a child iteration", func(t *testing.T) {
+		test.MoveIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), child.ID)
+	})
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// when
+		_, moved := test.MoveIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), release.ID)
+		// then
+		assert.Equal(t, release.ID.String(), *moved.Data.Relationships.Parent.Data.ID)
+		assert.Equal(t, path.Path{rootItr.ID, release.ID}.String(), *moved.Data.Attributes.ParentPath)
+		_, movedChild := test.ShowIterationOK(t, svc.Context, svc, ctrl, child.ID.String(), nil, nil)
+		assert.Equal(t, path.Path{rootItr.ID, release.ID, itr.ID}.String(), *movedChild.Data.Attributes.ParentPath)
+	})
+}
+
+func (rest *TestIterationREST) TestBurndownIteration() {
+	// given
+	sp, _, _, _, itr := createSpaceAndRootAreaAndIterations(rest.T(), rest.db)
+	testIdentity, err := testsupport.CreateTestIdentity(rest.DB, "TestBurndownIteration user", "test provider")
+	require.Nil(rest.T(), err)
+	wirepo := workitem.NewWorkItemRepository(rest.DB)
+	for _, state := range []string{workitem.SystemStateNew, workitem.SystemStateClosed} {
+		_, err := wirepo.Create(
+			context.Background(), sp.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Issue",
+				workitem.SystemState:     state,
+				workitem.SystemIteration: itr.ID.String(),
+			}, testIdentity.ID)
+		require.Nil(rest.T(), err)
+	}
+	svc, ctrl := rest.UnSecuredController()
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// when
+		_, burndown := test.BurndownIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), nil)
+		// then
+		require.NotNil(t, burndown.Data)
+		assert.Equal(t, itr.ID, burndown.Data.ID)
+		assert.Nil(t, burndown.Data.Attributes.PointsField)
+		require.Len(t, burndown.Data.Attributes.Days, 1)
+		assert.Equal(t, 2, burndown.Data.Attributes.Days[0].TotalCount)
+		assert.Equal(t, 1, burndown.Data.Attributes.Days[0].RemainingCount)
+		require.NotNil(t, burndown.Data.Relationships.Iteration)
+		assert.Equal(t, itr.ID.String(), *burndown.Data.Relationships.Iteration.Data.ID)
+	})
+
+	rest.T().Run("unknown points field", func(t *testing.T) {
+		// when
+		field := "unknown"
+		test.BurndownIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), &field)
+	})
+
+	rest.T().Run("non numeric points field", func(t *testing.T) {
+		// when
+		field := workitem.SystemTitle
+		test.BurndownIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), &field)
+	})
+
+	rest.T().Run("iteration without dates", func(t *testing.T) {
+		// given
+		root, err := rest.db.Iterations().Root(context.Background(), sp.ID)
+		require.Nil(t, err)
+		// when
+		test.BurndownIterationBadRequest(t, svc.Context, svc, ctrl, root.ID.String(), nil)
+	})
+
+	rest.T().Run("unknown iteration", func(t *testing.T) {
+		// when
+		test.BurndownIterationNotFound(t, svc.Context, svc, ctrl, uuid.NewV4().String(), nil)
+	})
+
+	rest.T().Run("points field of a system type", func(t *testing.T) {
+		// given a numeric field of a work item type of the system space, since
+		// the space has no work item types of its own
+		extended := workitem.SystemBug
+		wit, err := workitem.NewWorkItemTypeRepository(rest.DB).Create(context.Background(), space.SystemSpace, nil, &extended, "TestBurndownIteration-"+uuid.NewV4().String(), nil, "fa-bomb", map[string]workitem.FieldDefinition{
+			"burndownpoints": {
+				Label: "Points",
+				Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+			},
+		})
+		require.Nil(t, err)
+		for state, points := range map[string]float64{workitem.SystemStateNew: 3, workitem.SystemStateClosed: 5} {
+			_, err := wirepo.Create(
+				context.Background(), sp.ID, wit.ID,
+				map[string]interface{}{
+					workitem.SystemTitle:     "Story",
+					workitem.SystemState:     state,
+					workitem.SystemIteration: itr.ID.String(),
+					"burndownpoints":         points,
+				}, testIdentity.ID)
+			require.Nil(t, err)
+		}
+		field := "burndownpoints"
+		// when
+		_, burndown := test.BurndownIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), &field)
+		// then
+		require.NotNil(t, burndown.Data.Attributes.PointsField)
+		assert.Equal(t, field, *burndown.Data.Attributes.PointsField)
+		require.Len(t, burndown.Data.Attributes.Days, 1)
+		assert.Equal(t, float64(8), burndown.Data.Attributes.Days[0].TotalPoints)
+		assert.Equal(t, float64(3), burndown.Data.Attributes.Days[0].RemainingPoints)
+	})
+}
+
+// capacityConfiguration sums the work committed by the collaborators whose
+// capacity is given in points from the given field
+type capacityConfiguration struct {
+	*configuration.ConfigurationData
+	pointsField string
+}
+
+func (c capacityConfiguration) GetIterationPointsField() string {
+	return c.pointsField
+}
+
+func (rest *TestIterationREST) TestIterationCapacities() {
+	// given
+	sp, _, _, _, itr := createSpaceAndRootAreaAndIterations(rest.T(), rest.db)
+	owner, err := rest.db.Identities().Load(context.Background(), sp.OwnerId)
+	require.Nil(rest.T(), err)
+	collaborator, err := testsupport.CreateTestIdentity(rest.DB, "TestIterationCapacities user", "test provider")
+	require.Nil(rest.T(), err)
+	extended := workitem.SystemBug
+	wit, err := workitem.NewWorkItemTypeRepository(rest.DB).Create(context.Background(), sp.ID, nil, &extended, "story", nil, "fa-bomb", map[string]workitem.FieldDefinition{
+		"storypoints": {
+			Label: "Story Points",
+			Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+		},
+	})
+	require.Nil(rest.T(), err)
+	wirepo := workitem.NewWorkItemRepository(rest.DB)
+	for _, points := range []float64{5, 8} {
+		_, err := wirepo.Create(
+			context.Background(), sp.ID, wit.ID,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Story",
+				workitem.SystemState:     workitem.SystemStateNew,
+				workitem.SystemIteration: itr.ID.String(),
+				workitem.SystemAssignees: []string{collaborator.ID.String()},
+				"storypoints":            points,
+			}, owner.ID)
+		require.Nil(rest.T(), err)
+	}
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsUser("Iteration-Service", almtoken.NewManagerWithPrivateKey(priv), *owner)
+	svc.Context = tokencontext.ContextWithCollaboratorsService(svc.Context, collaboratorsService{collaborator.ID})
+	ctrl := NewIterationController(svc, rest.db, capacityConfiguration{ConfigurationData: rest.Configuration, pointsField: "storypoints"})
+	newPayload := func(capacities ...*app.IterationCapacity) *app.UpdateIterationPayload {
+		if capacities == nil {
+			capacities = []*app.IterationCapacity{}
+		}
+		return &app.UpdateIterationPayload{
+			Data: &app.Iteration{
+				Attributes: &app.IterationAttributes{
+					Capacities: capacities,
+				},
+				ID:   &itr.ID,
+				Type: iteration.APIStringTypeIteration,
+			},
+		}
+	}
+	capacitiesByAssignee := func(t *testing.T, appIteration *app.Iteration) map[uuid.UUID]*app.IterationCapacity {
+		result := map[uuid.UUID]*app.IterationCapacity{}
+		for _, c := range appIteration.Attributes.Capacities {
+			result[c.Assignee] = c
+		}
+		require.Len(t, result, len(appIteration.Attributes.Capacities))
+		return result
+	}
+
+	rest.T().Run("set capacities", func(t *testing.T) {
+		// when
+		payload := newPayload(
+			&app.IterationCapacity{Assignee: owner.ID, Unit: iteration.CapacityUnitPoints, Capacity: 20},
+			&app.IterationCapacity{Assignee: collaborator.ID, Unit: iteration.CapacityUnitPoints, Capacity: 10},
+		)
+		_, updated := test.UpdateIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), payload)
+		// then
+		capacities := capacitiesByAssignee(t, updated.Data)
+		require.Len(t, capacities, 2)
+		assert.Equal(t, 20.0, capacities[owner.ID].Capacity)
+		assert.Equal(t, 0.0, *capacities[owner.ID].Committed)
+		assert.Equal(t, 0, *capacities[owner.ID].CommittedCount)
+		assert.False(t, *capacities[owner.ID].OverAllocated)
+		assert.Equal(t, 13.0, *capacities[collaborator.ID].Committed)
+		assert.Equal(t, 2, *capacities[collaborator.ID].CommittedCount)
+		assert.True(t, *capacities[collaborator.ID].OverAllocated)
+	})
+
+	rest.T().Run("show capacities", func(t *testing.T) {
+		// when
+		_, shown := test.ShowIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), nil, nil)
+		// then
+		capacities := capacitiesByAssignee(t, shown.Data)
+		require.Len(t, capacities, 2)
+		assert.Equal(t, 10.0, capacities[collaborator.ID].Capacity)
+		assert.Equal(t, iteration.CapacityUnitPoints, capacities[collaborator.ID].Unit)
+		assert.True(t, *capacities[collaborator.ID].OverAllocated)
+	})
+
+	rest.T().Run("capacity in hours without hours field", func(t *testing.T) {
+		// when no hours field is configured
+		payload := newPayload(
+			&app.IterationCapacity{Assignee: collaborator.ID, Unit: iteration.CapacityUnitHours, Capacity: 10},
+		)
+		test.UpdateIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), payload)
+		// then the capacities are left as they are
+		_, shown := test.ShowIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), nil, nil)
+		capacities := capacitiesByAssignee(t, shown.Data)
+		require.Len(t, capacities, 2)
+		assert.Equal(t, iteration.CapacityUnitPoints, capacities[collaborator.ID].Unit)
+	})
+
+	rest.T().Run("unknown assignee", func(t *testing.T) {
+		// when
+		payload := newPayload(
+			&app.IterationCapacity{Assignee: uuid.NewV4(), Unit: iteration.CapacityUnitPoints, Capacity: 10},
+		)
+		test.UpdateIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), payload)
+	})
+
+	rest.T().Run("not a collaborator", func(t *testing.T) {
+		// given
+		other, err := testsupport.CreateTestIdentity(rest.DB, "TestIterationCapacities other user", "test provider")
+		require.Nil(t, err)
+		// when
+		payload := newPayload(
+			&app.IterationCapacity{Assignee: other.ID, Unit: iteration.CapacityUnitPoints, Capacity: 10},
+		)
+		test.UpdateIterationBadRequest(t, svc.Context, svc, ctrl, itr.ID.String(), payload)
+	})
+
+	rest.T().Run("clear capacities", func(t *testing.T) {
+		// when
+		_, updated := test.UpdateIterationOK(t, svc.Context, svc, ctrl, itr.ID.String(), newPayload())
+		// then
+		assert.Empty(t, updated.Data.Attributes.Capacities)
+	})
+}
+
 func getChildIterationPayload(name *string) *app.CreateChildIterationPayload {
 	start := time.Now()
 	end := start.Add(time.Hour * (24 * 8 * 3))
@@ -0,0 +1,460 @@
+package controller
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/mention"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/search"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// saveMentions stores the collaborators of the given space mentioned with
+// `@username` in the given content, which is the description of the given work
+// item or the given comment of the work item if not nil. The usernames which
+// do not belong to a collaborator are ignored.
+func saveMentions(ctx context.Context, appl application.Application, spaceID uuid.UUID, workItemID uuid.UUID, commentID *uuid.UUID, content rendering.MarkupContent) error {
+	usernames := rendering.Mentions(content.Content, content.Markup)
+	identities := make(map[string]uuid.UUID, len(usernames))
+	if len(usernames) > 0 {
+		collaborators, err := authz.Collaborators(ctx, appl, spaceID)
+		if err != nil {
+			return errs.Wrapf(err, "unable to list the collaborators of space %s", spaceID)
+		}
+		collaboratorIdentities, err := appl.Identities().Query(account.IdentityFilterByIDs(collaborators))
+		if err != nil {
+			return errs.Wrapf(err, "unable to load the collaborators of space %s", spaceID)
+		}
+		// the usernames of the mentions are in lower case
+		byUsername := make(map[string]uuid.UUID, len(collaboratorIdentities))
+		for _, identity := range collaboratorIdentities {
+			byUsername[strings.ToLower(identity.Username)] = identity.ID
+		}
+		for _, username := range usernames {
+			if id, ok := byUsername[username]; ok {
+				identities[username] = id
+			}
+		}
+	}
+	_, err := appl.Mentions().Replace(ctx, workItemID, commentID, identities)
+	return errs.Wrap(err, "unable to save the mentions")
+}
+
+// saveWorkItemReferences stores the work items referenced with `#number` or
+// `space-name#number`, or with the URL of their page, in the given content,
+// which is the description of the given work item or the given comment of the
+// work item if not nil. The space names of the `space-name#number` references
+// are looked up among the spaces of the owner of the space of the work item,
+// while the URLs are resolved with the known URLs of the search. The
+// references to unknown work items or to the work item itself are ignored.
+func saveWorkItemReferences(ctx context.Context, appl application.Application, wi workitem.WorkItem, commentID *uuid.UUID, content rendering.MarkupContent) error {
+	workItems := make(map[string]uuid.UUID)
+	// addReference records the reference to the work item with the given
+	// number in the given space, if any
+	addReference := func(ref string, spaceID uuid.UUID, number int) error {
+		referenced, err := appl.WorkItems().Load(ctx, spaceID, number)
+		if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+			return nil
+		}
+		if err != nil {
+			return errs.Wrapf(err, "unable to load the work item referenced with %s", ref)
+		}
+		if !uuid.Equal(referenced.ID, wi.ID) {
+			workItems[ref] = referenced.ID
+		}
+		return nil
+	}
+	var owner *uuid.UUID
+	for _, ref := range rendering.WorkItemReferences(content.Content, content.Markup) {
+		spaceName, number, err := rendering.ParseWorkItemReference(ref)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		spaceID := wi.SpaceID
+		if spaceName != "" {
+			if owner == nil {
+				s, err := appl.Spaces().Load(ctx, wi.SpaceID)
+				if err != nil {
+					return errs.Wrapf(err, "unable to load space %s", wi.SpaceID)
+				}
+				owner = &s.OwnerId
+			}
+			s, err := appl.Spaces().LoadByOwnerAndName(ctx, owner, &spaceName)
+			if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+				continue
+			}
+			if err != nil {
+				return errs.Wrapf(err, "unable to load space %s", spaceName)
+			}
+			spaceID = s.ID
+		}
+		if err := addReference(ref, spaceID, number); err != nil {
+			return err
+		}
+	}
+	for _, url := range rendering.WorkItemURLs(content.Content, content.Markup) {
+		ownerName, spaceName, number, ok := search.ParseWorkItemURL(url)
+		if !ok {
+			continue
+		}
+		owners, err := appl.Identities().Query(account.IdentityFilterByUsernameIgnoreCase(ownerName))
+		if err != nil {
+			return errs.Wrapf(err, "unable to load identity %s", ownerName)
+		}
+		if len(owners) == 0 {
+			continue
+		}
+		s, err := appl.Spaces().LoadByOwnerAndName(ctx, &owners[0].ID, &spaceName)
+		if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+			continue
+		}
+		if err != nil {
+			return errs.Wrapf(err, "unable to load space %s", spaceName)
+		}
+		if err := addReference(url, s.ID, number); err != nil {
+			return err
+		}
+	}
+	_, err := appl.WorkItemReferences().Replace(ctx, wi.ID, commentID, workItems)
+	return errs.Wrap(err, "unable to save the work item references")
+}
+
+// saveMarkupLinks stores the mentions and the work item references of the
+// given content, which is the description of the given work item or the given
+// comment of the work item if not nil
+func saveMarkupLinks(ctx context.Context, appl application.Application, wi workitem.WorkItem, commentID *uuid.UUID, content rendering.MarkupContent) error {
+	if err := saveMentions(ctx, appl, wi.SpaceID, wi.ID, commentID, content); err != nil {
+		return err
+	}
+	return saveWorkItemReferences(ctx, appl, wi, commentID, content)
+}
+
+// saveWorkItemMarkupLinks stores the mentions and the work item references of
+// the description of the given work item
+func saveWorkItemMarkupLinks(ctx context.Context, appl application.Application, wi workitem.WorkItem) error {
+	content := rendering.NewMarkupContentFromValue(wi.Fields[workitem.SystemDescription])
+	if content == nil {
+		content = &rendering.MarkupContent{Markup: rendering.SystemMarkupDefault}
+	}
+	return saveMarkupLinks(ctx, appl, wi, nil, *content)
+}
+
+// saveCommentMarkupLinks stores the mentions and the work item references of
+// the given comment of the given work item
+func saveCommentMarkupLinks(ctx context.Context, appl application.Application, wi workitem.WorkItem, c comment.Comment) error {
+	return saveMarkupLinks(ctx, appl, wi, &c.ID, rendering.NewMarkupContent(c.Body, c.Markup))
+}
+
+// markupLinks are the stored mentions and work item references of the
+// description of a work item or of a comment
+type markupLinks struct {
+	mentions []mention.Mention
+	// the URLs of the referenced work items by reference
+	references map[string]string
+}
+
+// markupSource identifies the description of a work item, or one of its
+// comments if the comment ID is not uuid.Nil
+type markupSource struct {
+	workItemID uuid.UUID
+	commentID  uuid.UUID
+}
+
+func newMarkupSource(workItemID uuid.UUID, commentID *uuid.UUID) markupSource {
+	source := markupSource{workItemID: workItemID}
+	if commentID != nil {
+		source.commentID = *commentID
+	}
+	return source
+}
+
+// markupLinksLoader holds the mentions and the work item references of the
+// descriptions and of the comments of a set of work items, which are loaded
+// at once along with the referenced work items
+type markupLinksLoader struct {
+	mentions   map[markupSource][]mention.Mention
+	references map[markupSource][]reference.Reference
+	// the referenced work items by ID
+	workItems map[uuid.UUID]*workitem.WorkItem
+}
+
+// newMarkupLinksLoader loads the mentions and the work item references of the
+// descriptions and of the comments of the given work items
+func newMarkupLinksLoader(ctx context.Context, appl application.Application, workItemIDs []uuid.UUID) (*markupLinksLoader, error) {
+	mentions, err := appl.Mentions().ListByWorkItems(ctx, workItemIDs)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	references, err := appl.WorkItemReferences().ListByWorkItems(ctx, workItemIDs)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	l := markupLinksLoader{
+		mentions:   make(map[markupSource][]mention.Mention),
+		references: make(map[markupSource][]reference.Reference),
+		workItems:  make(map[uuid.UUID]*workitem.WorkItem),
+	}
+	for _, m := range mentions {
+		source := newMarkupSource(m.WorkItemID, m.CommentID)
+		l.mentions[source] = append(l.mentions[source], m)
+	}
+	referencedIDs := make([]uuid.UUID, len(references))
+	for i, ref := range references {
+		source := newMarkupSource(ref.SourceWorkItemID, ref.SourceCommentID)
+		l.references[source] = append(l.references[source], ref)
+		referencedIDs[i] = ref.WorkItemID
+	}
+	// the referenced work items may have been moved to another space
+	workItems, err := appl.WorkItems().LoadBatchByID(ctx, referencedIDs)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, wi := range workItems {
+		l.workItems[wi.ID] = wi
+	}
+	return &l, nil
+}
+
+// load returns the mentions and the work item references of the description
+// of the given work item, or of the given comment of the work item if not nil
+func (l markupLinksLoader) load(request *goa.RequestData, workItemID uuid.UUID, commentID *uuid.UUID) markupLinks {
+	source := newMarkupSource(workItemID, commentID)
+	links := markupLinks{
+		mentions:   l.mentions[source],
+		references: make(map[string]string, len(l.references[source])),
+	}
+	for _, ref := range l.references[source] {
+		if wi, ok := l.workItems[ref.WorkItemID]; ok {
+			links.references[ref.Reference] = rest.AbsoluteURL(request, app.WorkitemHref(wi.SpaceID.String(), wi.ID))
+		}
+	}
+	return links
+}
+
+// render renders the given content to HTML like the converters, with links to
+// the profile of the mentioned identities and to the referenced work items
+func (l markupLinks) render(request *goa.RequestData, content rendering.MarkupContent) string {
+	return rendering.RenderMarkupToHTML(html.EscapeString(content.Content), content.Markup,
+		rendering.WithMentions(func(username string) (string, bool) {
+			username = strings.ToLower(username)
+			for _, m := range l.mentions {
+				if m.Username == username {
+					return rest.AbsoluteURL(request, app.UsersHref(m.IdentityID.String())), true
+				}
+			}
+			return "", false
+		}),
+		rendering.WithWorkItemReferences(func(ref string) (string, bool) {
+			url, ok := l.references[strings.ToLower(ref)]
+			return url, ok
+		}),
+		rendering.WithWorkItemURLs(func(ref string) (string, bool) {
+			url, ok := l.references[strings.ToLower(ref)]
+			return url, ok
+		}))
+}
+
+// createMentionsRelation returns the relationship to the mentioned identities
+func (l markupLinks) createMentionsRelation(request *goa.RequestData) *app.RelationGenericList {
+	data := make([]*app.GenericData, len(l.mentions))
+	for i, m := range l.mentions {
+		data[i] = ConvertUserSimple(request, m.IdentityID.String())
+	}
+	return &app.RelationGenericList{
+		Data: data,
+	}
+}
+
+// workItemIncludeMarkupLinks adds the identities mentioned in the description
+// of the given work items, which are linked in the rendered description along
+// with the referenced work items
+func workItemIncludeMarkupLinks(appl application.Application, ctx context.Context, wis []workitem.WorkItem) WorkItemConvertFunc {
+	workItemIDs := make([]uuid.UUID, len(wis))
+	for i, wi := range wis {
+		workItemIDs[i] = wi.ID
+	}
+	loader, err := newMarkupLinksLoader(ctx, appl, workItemIDs)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_ids": workItemIDs,
+			"err":    err,
+		}, "unable to load the mentions and references of the work items")
+		return func(request *goa.RequestData, wi *workitem.WorkItem, wi2 *app.WorkItem) {}
+	}
+	return func(request *goa.RequestData, wi *workitem.WorkItem, wi2 *app.WorkItem) {
+		links := loader.load(request, wi.ID, nil)
+		wi2.Relationships.Mentions = links.createMentionsRelation(request)
+		description := rendering.NewMarkupContentFromValue(wi.Fields[workitem.SystemDescription])
+		if description != nil && (len(links.mentions) > 0 || len(links.references) > 0) {
+			wi2.Attributes[workitem.SystemDescriptionRendered] = links.render(request, *description)
+		}
+	}
+}
+
+// commentIncludeMarkupLinks adds the identities mentioned in the given
+// comments, which are linked in the rendered body along with the referenced
+// work items
+func commentIncludeMarkupLinks(appl application.Application, ctx context.Context, comments []comment.Comment) CommentConvertFunc {
+	workItemIDs := []uuid.UUID{}
+	loaded := make(map[uuid.UUID]bool)
+	for _, c := range comments {
+		if !loaded[c.ParentID] {
+			loaded[c.ParentID] = true
+			workItemIDs = append(workItemIDs, c.ParentID)
+		}
+	}
+	loader, err := newMarkupLinksLoader(ctx, appl, workItemIDs)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_ids": workItemIDs,
+			"err":    err,
+		}, "unable to load the mentions and references of the comments")
+		return func(request *goa.RequestData, c *comment.Comment, c2 *app.Comment) {}
+	}
+	return func(request *goa.RequestData, c *comment.Comment, c2 *app.Comment) {
+		links := loader.load(request, c.ParentID, &c.ID)
+		c2.Relationships.Mentions = links.createMentionsRelation(request)
+		if len(links.mentions) > 0 || len(links.references) > 0 {
+			bodyRendered := links.render(request, rendering.NewMarkupContent(c.Body, c.Markup))
+			c2.Attributes.BodyRendered = &bodyRendered
+		}
+	}
+}
+
+// workItemIncludeMentionedIn adds the read-only "mentioned-in" relationship
+// to the work items and comments referencing the work item
+func workItemIncludeMentionedIn(appl application.Application, ctx context.Context) WorkItemConvertFunc {
+	return func(request *goa.RequestData, wi *workitem.WorkItem, wi2 *app.WorkItem) {
+		references, err := appl.WorkItemReferences().ListReferencing(ctx, wi.ID)
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"wi_id": wi.ID,
+				"err":   err,
+			}, "unable to list the references to work item: %s", wi.ID)
+			return
+		}
+		sourceIDs := []uuid.UUID{}
+		for _, ref := range references {
+			if ref.SourceCommentID == nil {
+				sourceIDs = append(sourceIDs, ref.SourceWorkItemID)
+			}
+		}
+		sources, err := appl.WorkItems().LoadBatchByID(ctx, sourceIDs)
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"wi_id": wi.ID,
+				"err":   err,
+			}, "unable to load the work items referencing work item: %s", wi.ID)
+			return
+		}
+		sourcesByID := make(map[uuid.UUID]*workitem.WorkItem, len(sources))
+		for _, source := range sources {
+			sourcesByID[source.ID] = source
+		}
+		data := []*app.GenericData{}
+		for _, ref := range references {
+			if ref.SourceCommentID != nil {
+				commentType := "comments"
+				commentID := ref.SourceCommentID.String()
+				commentSelf := rest.AbsoluteURL(request, app.CommentsHref(*ref.SourceCommentID))
+				data = append(data, &app.GenericData{
+					Type:  &commentType,
+					ID:    &commentID,
+					Links: &app.GenericLinks{Self: &commentSelf},
+				})
+				continue
+			}
+			source, ok := sourcesByID[ref.SourceWorkItemID]
+			if !ok {
+				log.Warn(ctx, map[string]interface{}{
+					"wi_id": ref.SourceWorkItemID,
+				}, "unable to find the work item referencing work item: %s", wi.ID)
+				continue
+			}
+			workItemType := APIStringTypeWorkItem
+			workItemID := source.ID.String()
+			workItemSelf := rest.AbsoluteURL(request, app.WorkitemHref(source.SpaceID.String(), source.ID))
+			data = append(data, &app.GenericData{
+				Type:  &workItemType,
+				ID:    &workItemID,
+				Links: &app.GenericLinks{Self: &workItemSelf},
+			})
+		}
+		wi2.Relationships.MentionedIn = &app.RelationGenericList{
+			Data: data,
+		}
+	}
+}
+
+// ConvertMention converts the given mention of an identity in the given work
+// item to the REST representation
+func ConvertMention(request *goa.RequestData, m mention.Mention, wi workitem.WorkItem) *app.MentionData {
+	workItemType := APIStringTypeWorkItem
+	workItemID := wi.ID.String()
+	workItemSelf := rest.AbsoluteURL(request, app.WorkitemHref(wi.SpaceID.String(), wi.ID))
+	spaceType := APIStringTypeSpace
+	spaceID := wi.SpaceID.String()
+	spaceSelf := rest.AbsoluteURL(request, app.SpaceHref(spaceID))
+	res := &app.MentionData{
+		Type: mention.APIStringTypeMentions,
+		ID:   m.ID,
+		Attributes: &app.MentionAttributes{
+			Username:  m.Username,
+			CreatedAt: m.CreatedAt,
+		},
+		Relationships: &app.MentionRelationships{
+			Identity: &app.RelationGeneric{
+				Data:  ConvertUserSimple(request, m.IdentityID.String()),
+				Links: createUserLinks(request, m.IdentityID.String()),
+			},
+			Workitem: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &workItemType,
+					ID:   &workItemID,
+				},
+				Links: &app.GenericLinks{
+					Self: &workItemSelf,
+				},
+			},
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &spaceType,
+					ID:   &spaceID,
+				},
+				Links: &app.GenericLinks{
+					Self: &spaceSelf,
+				},
+			},
+		},
+	}
+	if m.CommentID != nil {
+		commentType := "comments"
+		commentID := m.CommentID.String()
+		commentSelf := rest.AbsoluteURL(request, app.CommentsHref(*m.CommentID))
+		res.Relationships.Comment = &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: &commentType,
+				ID:   &commentID,
+			},
+			Links: &app.GenericLinks{
+				Self: &commentSelf,
+			},
+		}
+	}
+	return res
+}
@@ -51,9 +51,22 @@ func (c *PlannerBacklogController) List(ctx *app.ListPlannerBacklogContext) erro
 	if ctx.FilterArea != nil {
 		exp = criteria.And(exp, criteria.Equals(criteria.Field(workitem.SystemArea), criteria.Literal(string(*ctx.FilterArea))))
 	}
+	// the saved filter narrows down the backlog items, whereas the other
+	// filters are replaced by the backlog criteria
+	var savedExp criteria.Expression
+	var sort []workitem.SortKey
+	if ctx.FilterID != nil {
+		err = application.Transactional(c.db, func(appl application.Application) error {
+			savedExp, sort, err = applySavedFilter(ctx, appl, ctx.SpaceID, *ctx.FilterID, criteria.Literal(true))
+			return err
+		})
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+	}
 
 	// Get the list of work items for the following criteria
-	result, count, err := getBacklogItems(ctx.Context, c.db, ctx.SpaceID, exp, &offset, &limit)
+	result, count, err := getBacklogItems(ctx.Context, c.db, ctx.SpaceID, exp, savedExp, sort, &offset, &limit)
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
@@ -112,7 +125,7 @@ func generateBacklogExpression(ctx context.Context, db application.DB, spaceID u
 	return exp, nil
 }
 
-func getBacklogItems(ctx context.Context, db application.DB, spaceID uuid.UUID, exp criteria.Expression, offset *int, limit *int) ([]workitem.WorkItem, int, error) {
+func getBacklogItems(ctx context.Context, db application.DB, spaceID uuid.UUID, exp criteria.Expression, savedExp criteria.Expression, sort []workitem.SortKey, offset *int, limit *int) ([]workitem.WorkItem, int, error) {
 	result := []workitem.WorkItem{}
 	count := 0
 
@@ -120,10 +133,13 @@ func getBacklogItems(ctx context.Context, db application.DB, spaceID uuid.UUID,
 	if err != nil || backlogExp == nil {
 		return result, count, err
 	}
+	if savedExp != nil {
+		backlogExp = criteria.And(backlogExp, savedExp)
+	}
 
 	err = application.Transactional(db, func(appl application.Application) error {
 		// Get the list of work items for the following criteria
-		result, count, err = appl.WorkItems().List(ctx, spaceID, backlogExp, nil, offset, limit)
+		result, count, err = appl.WorkItems().List(ctx, spaceID, backlogExp, nil, sort, offset, limit)
 		if err != nil {
 			return errs.Wrap(err, "error listing backlog items")
 		}
@@ -21,6 +21,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/space"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
 
 	"github.com/goadesign/goa"
 	uuid "github.com/satori/go.uuid"
@@ -139,12 +140,54 @@ func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsOK()
 	offset := "0"
 	filter := ""
 	limit := -1
-	res, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, &limit, &offset, nil, nil)
+	res, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, nil, &limit, &offset, nil, nil)
 	// then
 	assertPlannerBacklogWorkItems(rest.T(), workitems, testSpace, parentIteration)
 	assertResponseHeaders(rest.T(), res)
 }
 
+func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsWithSavedFilter() {
+	// given a second backlog item in the root iteration
+	testSpace, parentIteration, closedWI := rest.setupPlannerBacklogWorkItems()
+	db := gormapplication.NewGormDB(rest.DB)
+	_, err := db.WorkItems().Create(rest.ctx, testSpace.ID, closedWI.Type, map[string]interface{}{
+		workitem.SystemTitle:     "other backlog item",
+		workitem.SystemState:     workitem.SystemStateOpen,
+		workitem.SystemIteration: parentIteration.ID.String(),
+	}, rest.testIdentity.ID)
+	require.Nil(rest.T(), err)
+	savedFilter, err := db.Filters().Create(rest.ctx, &filter.Filter{
+		SpaceID:   testSpace.ID,
+		CreatorID: rest.testIdentity.ID,
+		Scope:     filter.ScopeSpace,
+		Name:      "new items",
+		Query:     `{"system.state":"new"}`,
+	})
+	require.Nil(rest.T(), err)
+	svc, ctrl := rest.UnSecuredController()
+	offset := "0"
+	limit := -1
+
+	rest.T().Run("without saved filter", func(t *testing.T) {
+		query := ""
+		_, workitems := test.ListPlannerBacklogOK(t, svc.Context, svc, ctrl, testSpace.ID, &query, nil, nil, nil, nil, &limit, &offset, nil, nil)
+		assert.Len(t, workitems.Data, 2)
+	})
+
+	rest.T().Run("with saved filter", func(t *testing.T) {
+		query := ""
+		_, workitems := test.ListPlannerBacklogOK(t, svc.Context, svc, ctrl, testSpace.ID, &query, nil, nil, &savedFilter.ID, nil, &limit, &offset, nil, nil)
+		assertPlannerBacklogWorkItems(t, workitems, testSpace, parentIteration)
+	})
+
+	rest.T().Run("query replaced by the backlog criteria", func(t *testing.T) {
+		// only the saved filter narrows down the backlog items
+		query := `{"system.state":"new"}`
+		_, workitems := test.ListPlannerBacklogOK(t, svc.Context, svc, ctrl, testSpace.ID, &query, nil, nil, nil, nil, &limit, &offset, nil, nil)
+		assert.Len(t, workitems.Data, 2)
+	})
+}
+
 func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsOkUsingExpiredIfModifiedSinceHeader() {
 	// given
 	testSpace, parentIteration, _ := rest.setupPlannerBacklogWorkItems()
@@ -155,7 +198,7 @@ func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsOkUsi
 	filter := ""
 	limit := -1
 	ifModifiedSince := app.ToHTTPTime(parentIteration.UpdatedAt.Add(-1 * time.Hour))
-	res, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, &limit, &offset, &ifModifiedSince, nil)
+	res, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, nil, &limit, &offset, &ifModifiedSince, nil)
 	// then
 	assertPlannerBacklogWorkItems(rest.T(), workitems, testSpace, parentIteration)
 	assertResponseHeaders(rest.T(), res)
@@ -170,7 +213,7 @@ func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsOkUsi
 	filter := ""
 	limit := -1
 	ifNoneMatch := "foo"
-	res, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, &limit, &offset, nil, &ifNoneMatch)
+	res, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, nil, &limit, &offset, nil, &ifNoneMatch)
 	// then
 	assertPlannerBacklogWorkItems(rest.T(), workitems, testSpace, parentIteration)
 	assertResponseHeaders(rest.T(), res)
@@ -185,7 +228,7 @@ func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsNotMo
 	filter := ""
 	limit := -1
 	ifModifiedSince := app.ToHTTPTime(lastWorkItem.Fields[workitem.SystemUpdatedAt].(time.Time))
-	res := test.ListPlannerBacklogNotModified(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, &limit, &offset, &ifModifiedSince, nil)
+	res := test.ListPlannerBacklogNotModified(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, nil, &limit, &offset, &ifModifiedSince, nil)
 	// then
 	assertResponseHeaders(rest.T(), res)
 }
@@ -197,10 +240,10 @@ func (rest *TestPlannerBacklogBlackboxREST) TestListPlannerBacklogWorkItemsNotMo
 	offset := "0"
 	filter := ""
 	limit := -1
-	res, _ := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, &limit, &offset, nil, nil)
+	res, _ := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, nil, &limit, &offset, nil, nil)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	res = test.ListPlannerBacklogNotModified(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, &limit, &offset, nil, &ifNoneMatch)
+	res = test.ListPlannerBacklogNotModified(rest.T(), svc.Context, svc, ctrl, testSpace.ID, &filter, nil, nil, nil, nil, &limit, &offset, nil, &ifNoneMatch)
 	// then
 	assertResponseHeaders(rest.T(), res)
 }
@@ -240,7 +283,7 @@ func (rest *TestPlannerBacklogBlackboxREST) TestSuccessEmptyListPlannerBacklogWo
 	offset := "0"
 	filter := ""
 	limit := -1
-	_, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, spaceID, &filter, nil, nil, nil, &limit, &offset, nil, nil)
+	_, workitems := test.ListPlannerBacklogOK(rest.T(), svc.Context, svc, ctrl, spaceID, &filter, nil, nil, nil, nil, &limit, &offset, nil, nil)
 	// The list has to be empty
 	assert.Len(rest.T(), workitems.Data, 0)
 }
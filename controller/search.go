@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/app"
@@ -51,7 +52,27 @@ func (c *SearchController) Show(ctx *app.ShowSearchContext) error {
 
 	return application.Transactional(c.db, func(appl application.Application) error {
 		//return transaction.Do(c.ts, func() error {
-		result, c, err := appl.SearchItems().SearchFullText(ctx.Context, ctx.Q, &offset, &limit, ctx.SpaceID)
+		filter, additionalQuery, err := workItemFilterExpression(ctx, appl, workItemFilterParams{
+			Filter:        ctx.Filter,
+			Assignee:      ctx.FilterAssignee,
+			Iteration:     ctx.FilterIteration,
+			Workitemtype:  ctx.FilterWorkitemtype,
+			Area:          ctx.FilterArea,
+			Workitemstate: ctx.FilterWorkitemstate,
+		})
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		highlight := search.DefaultHighlightOptions
+		if ctx.HighlightStart != nil {
+			highlight.StartTag = *ctx.HighlightStart
+			additionalQuery = append(additionalQuery, "highlight[start]="+url.QueryEscape(*ctx.HighlightStart))
+		}
+		if ctx.HighlightStop != nil {
+			highlight.StopTag = *ctx.HighlightStop
+			additionalQuery = append(additionalQuery, "highlight[stop]="+url.QueryEscape(*ctx.HighlightStop))
+		}
+		result, c, err := appl.SearchItems().SearchFullText(ctx.Context, ctx.Q, filter, &highlight, &offset, &limit, ctx.SpaceID)
 		count := int(c)
 		if err != nil {
 			cause := errs.Cause(err)
@@ -71,14 +92,32 @@ func (c *SearchController) Show(ctx *app.ShowSearchContext) error {
 		response := app.SearchWorkItemList{
 			Links: &app.PagingLinks{},
 			Meta:  &app.WorkItemListResponseMeta{TotalCount: count},
-			Data:  ConvertWorkItems(ctx.RequestData, result),
+			Data:  ConvertSearchResults(ctx.RequestData, result),
 		}
 
-		setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(result), offset, limit, count, "q="+ctx.Q)
+		setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(result), offset, limit, count, append([]string{"q=" + ctx.Q}, additionalQuery...)...)
 		return ctx.OK(&response)
 	})
 }
 
+// ConvertSearchResults converts the search results into work items whose meta
+// holds the score, the highlighted excerpts and the ID of the matching comment
+// of each result
+func ConvertSearchResults(request *goa.RequestData, results []search.Result) []*app.WorkItem {
+	res := make([]*app.WorkItem, len(results))
+	for i, result := range results {
+		res[i] = ConvertWorkItem(request, result.WorkItem)
+		res[i].Meta = map[string]interface{}{
+			"score":      result.Rank,
+			"highlights": result.Highlights,
+		}
+		if result.CommentID != nil {
+			res[i].Meta["comment"] = result.CommentID.String()
+		}
+	}
+	return res
+}
+
 // Spaces runs the space search action.
 func (c *SearchController) Spaces(ctx *app.SpacesSearchContext) error {
 	q := ctx.Q
@@ -93,10 +132,12 @@ func (c *SearchController) Spaces(ctx *app.SpacesSearchContext) error {
 	var err error
 
 	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
+	// archived spaces are hidden unless they are explicitly asked for
+	archived := ctx.FilterArchived != nil && *ctx.FilterArchived
 
 	return application.Transactional(c.db, func(appl application.Application) error {
 		var resultCount uint64
-		result, resultCount, err = appl.Spaces().Search(ctx, &q, &offset, &limit)
+		result, resultCount, err = appl.Spaces().Search(ctx, &q, &archived, &offset, &limit)
 		count = int(resultCount)
 		if err != nil {
 			cause := errs.Cause(err)
@@ -123,7 +164,11 @@ func (c *SearchController) Spaces(ctx *app.SpacesSearchContext) error {
 			Meta:  &app.SpaceListMeta{TotalCount: count},
 			Data:  spaceData,
 		}
-		setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(result), offset, limit, count, "q="+q)
+		additionalQuery := []string{"q=" + q}
+		if archived {
+			additionalQuery = append(additionalQuery, "filter[archived]=true")
+		}
+		setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(result), offset, limit, count, additionalQuery...)
 
 		return ctx.OK(&response)
 	})
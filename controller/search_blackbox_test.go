@@ -99,7 +99,7 @@ func (s *searchBlackBoxTest) TestSearchWorkItems() {
 	// when
 	q := "specialwordforsearch"
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -123,7 +123,7 @@ func (s *searchBlackBoxTest) TestSearchPagination() {
 	// when
 	q := "specialwordforsearch2"
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	// defaults in paging.go is 'pageSizeDefault = 20'
 	assert.Equal(s.T(), "http:///api/search?page[offset]=0&page[limit]=20&q=specialwordforsearch2", *sr.Links.First)
@@ -149,7 +149,7 @@ func (s *searchBlackBoxTest) TestSearchWithEmptyValue() {
 	// when
 	q := ""
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	require.NotNil(s.T(), sr.Data)
 	assert.Empty(s.T(), sr.Data)
@@ -172,7 +172,7 @@ func (s *searchBlackBoxTest) TestSearchWithDomainPortCombination() {
 	// when
 	q := `"http://localhost:8080/detail/154687364529310"`
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -197,7 +197,7 @@ func (s *searchBlackBoxTest) TestSearchURLWithoutPort() {
 	// when
 	q := `"http://localhost/detail/876394"`
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -222,7 +222,7 @@ func (s *searchBlackBoxTest) TestUnregisteredURLWithPort() {
 	// when
 	q := `http://some-other-domain:8080/different-path/`
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	r := sr.Data[0]
@@ -248,7 +248,7 @@ func (s *searchBlackBoxTest) TestUnwantedCharactersRelatedToSearchLogic() {
 	// add url: in the query, that is not expected by the code hence need to make sure it gives expected result.
 	q := `http://url:some-random-other-domain:8080/different-path/`
 	spaceIDStr := space.SystemSpace.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &spaceIDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
 	// then
 	require.NotNil(s.T(), sr.Data)
 	assert.Empty(s.T(), sr.Data)
@@ -414,7 +414,7 @@ func (s *searchBlackBoxTest) TestSearchWorkItemsSpaceContext() {
 	// when
 	q := "common_word"
 	space1IDStr := space1.ID.String()
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &space1IDStr)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &space1IDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 3)
@@ -423,7 +423,7 @@ func (s *searchBlackBoxTest) TestSearchWorkItemsSpaceContext() {
 		assert.Contains(s.T(), item.Attributes[workitem.SystemTitle], "shutter_island common_word")
 	}
 	space2IDStr := space2.ID.String()
-	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, &space2IDStr)
+	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &space2IDStr)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 5)
@@ -433,7 +433,7 @@ func (s *searchBlackBoxTest) TestSearchWorkItemsSpaceContext() {
 	}
 
 	// when searched without spaceID then it should get all related WI
-	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, nil)
+	_, sr = test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, nil)
 	// then
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 8)
@@ -498,7 +498,165 @@ func (s *searchBlackBoxTest) TestSearchWorkItemsWithoutSpaceContext() {
 	}
 	q := "search_by_me"
 	// search without space context
-	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, q, nil)
+	_, sr := test.ShowSearchOK(s.T(), nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, nil)
 	require.NotEmpty(s.T(), sr.Data)
 	assert.Len(s.T(), sr.Data, 15)
 }
+
+func (s *searchBlackBoxTest) TestSearchWorkItemsWithFilter() {
+	// given
+	var sp *space.Space
+	application.Transactional(s.db, func(app application.Application) error {
+		var err error
+		sp, err = app.Spaces().Create(context.Background(), &space.Space{
+			Name: "Test Space Filter " + uuid.NewV4().String(),
+		})
+		require.Nil(s.T(), err)
+		return nil
+	})
+	for _, state := range []string{workitem.SystemStateOpen, workitem.SystemStateOpen, workitem.SystemStateClosed} {
+		wi, err := s.wiRepo.Create(
+			s.ctx,
+			sp.ID,
+			workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle: "filtered_crash random - " + uuid.NewV4().String(),
+				workitem.SystemState: state,
+			},
+			s.testIdentity.ID)
+		require.Nil(s.T(), err)
+		require.NotNil(s.T(), wi)
+	}
+	q := "filtered_crash"
+	spaceIDStr := sp.ID.String()
+
+	s.T().Run("by state", func(t *testing.T) {
+		state := workitem.SystemStateOpen
+		limit := 1
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, &state, nil, nil, nil, &limit, nil, q, &spaceIDStr)
+		require.Len(t, sr.Data, 1)
+		assert.Equal(t, 2, sr.Meta.TotalCount)
+		assert.Equal(t, workitem.SystemStateOpen, sr.Data[0].Attributes[workitem.SystemState])
+		require.NotNil(t, sr.Links.Next)
+		assert.Contains(t, *sr.Links.Next, "filter[workitemstate]=open")
+	})
+
+	s.T().Run("by query language expression", func(t *testing.T) {
+		filter := fmt.Sprintf(`{"%s":"%s"}`, workitem.SystemState, workitem.SystemStateClosed)
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, &filter, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
+		require.Len(t, sr.Data, 1)
+		assert.Equal(t, workitem.SystemStateClosed, sr.Data[0].Attributes[workitem.SystemState])
+	})
+
+	s.T().Run("invalid iteration", func(t *testing.T) {
+		iteration := "foo"
+		test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, nil, nil, &iteration, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
+	})
+}
+
+func (s *searchBlackBoxTest) TestSearchWorkItemsWithPhraseAndExclusion() {
+	// given
+	for _, title := range []string{"phrase_search memory leak", "phrase_search leak of memory"} {
+		_, err := s.wiRepo.Create(
+			s.ctx,
+			space.SystemSpace,
+			workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle: title,
+				workitem.SystemState: workitem.SystemStateClosed,
+			},
+			s.testIdentity.ID)
+		require.Nil(s.T(), err)
+	}
+	spaceIDStr := space.SystemSpace.String()
+
+	s.T().Run("phrase", func(t *testing.T) {
+		q := `phrase_search "memory leak"`
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
+		require.Len(t, sr.Data, 1)
+		assert.Equal(t, "phrase_search memory leak", sr.Data[0].Attributes[workitem.SystemTitle])
+	})
+
+	s.T().Run("exclusion", func(t *testing.T) {
+		q := `phrase_search -"memory leak"`
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
+		require.Len(t, sr.Data, 1)
+		assert.Equal(t, "phrase_search leak of memory", sr.Data[0].Attributes[workitem.SystemTitle])
+	})
+
+	s.T().Run("malformed", func(t *testing.T) {
+		q := `phrase_search "memory leak`
+		test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
+	})
+}
+
+func (s *searchBlackBoxTest) TestSearchWorkItemsHighlights() {
+	// given
+	_, err := s.wiRepo.Create(
+		s.ctx,
+		space.SystemSpace,
+		workitem.SystemBug,
+		map[string]interface{}{
+			workitem.SystemTitle:       "highlightedword in the title",
+			workitem.SystemDescription: rendering.NewMarkupContentFromLegacy("the description mentions the highlightedword too"),
+			workitem.SystemState:       workitem.SystemStateClosed,
+		},
+		s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	q := "highlightedword"
+	spaceIDStr := space.SystemSpace.String()
+
+	s.T().Run("default tags", func(t *testing.T) {
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, q, &spaceIDStr)
+		require.Len(t, sr.Data, 1)
+		require.NotNil(t, sr.Data[0].Meta)
+		assert.NotZero(t, sr.Data[0].Meta["score"])
+		highlights, ok := sr.Data[0].Meta["highlights"].(map[string]string)
+		require.True(t, ok)
+		assert.Contains(t, highlights[workitem.SystemTitle], "<b>highlightedword</b>")
+		assert.Contains(t, highlights[workitem.SystemDescription], "<b>highlightedword</b>")
+	})
+
+	s.T().Run("custom tags", func(t *testing.T) {
+		startTag, stopTag := "<mark>", "</mark>"
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, &startTag, &stopTag, nil, nil, q, &spaceIDStr)
+		require.Len(t, sr.Data, 1)
+		highlights, ok := sr.Data[0].Meta["highlights"].(map[string]string)
+		require.True(t, ok)
+		assert.Contains(t, highlights[workitem.SystemTitle], "<mark>highlightedword</mark>")
+	})
+
+	s.T().Run("custom tags in the paging links", func(t *testing.T) {
+		startTag, stopTag := "<mark class=a&b #c>", "</mark>"
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, &startTag, &stopTag, nil, nil, q, &spaceIDStr)
+		require.NotNil(t, sr.Links.First)
+		assert.Contains(t, *sr.Links.First, "highlight[start]="+url.QueryEscape(startTag))
+		assert.Contains(t, *sr.Links.First, "highlight[stop]="+url.QueryEscape(stopTag))
+	})
+
+	s.T().Run("escaped user markup", func(t *testing.T) {
+		// given
+		_, err := s.wiRepo.Create(
+			s.ctx,
+			space.SystemSpace,
+			workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle: `escapedword <script>alert("x")</script> & co`,
+				workitem.SystemState: workitem.SystemStateClosed,
+			},
+			s.testIdentity.ID)
+		require.Nil(t, err)
+		// when
+		_, sr := test.ShowSearchOK(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "escapedword", &spaceIDStr)
+		// then
+		require.Len(t, sr.Data, 1)
+		highlights, ok := sr.Data[0].Meta["highlights"].(map[string]string)
+		require.True(t, ok)
+		assert.Equal(t, `<b>escapedword</b> &lt;script&gt;alert(&quot;x&quot;)&lt;/script&gt; &amp; co`, highlights[workitem.SystemTitle])
+	})
+
+	s.T().Run("invalid tag", func(t *testing.T) {
+		startTag := `<span class="hit">`
+		test.ShowSearchBadRequest(t, nil, nil, s.controller, nil, nil, nil, nil, nil, nil, &startTag, nil, nil, nil, q, &spaceIDStr)
+	})
+}
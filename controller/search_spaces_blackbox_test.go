@@ -91,7 +91,7 @@ func (rest *TestSearchSpacesREST) TestSpacesSearchOK() {
 	svc, ctrl := rest.UnSecuredController()
 	// when/then
 	for _, tt := range tests {
-		_, result := test.SpacesSearchOK(rest.T(), svc.Context, svc, ctrl, tt.args.pageLimit, tt.args.pageOffset, tt.args.q)
+		_, result := test.SpacesSearchOK(rest.T(), svc.Context, svc, ctrl, nil, tt.args.pageLimit, tt.args.pageOffset, tt.args.q)
 		for _, expect := range tt.expects {
 			expect(rest.T(), tt, result)
 		}
@@ -70,6 +70,14 @@ func (c *SpaceController) Create(ctx *app.CreateSpaceContext) error {
 	if reqSpace.ID != nil {
 		spaceID = *reqSpace.ID
 	}
+	var templateID *uuid.UUID
+	if rel := reqSpace.Relationships; rel != nil && rel.Template != nil && rel.Template.Data != nil && rel.Template.Data.ID != nil {
+		id, err := uuid.FromString(*rel.Template.Data.ID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.template", *rel.Template.Data.ID))
+		}
+		templateID = &id
+	}
 
 	var rSpace *space.Space
 	err = application.Transactional(c.db, func(appl application.Application) error {
@@ -81,6 +89,9 @@ func (c *SpaceController) Create(ctx *app.CreateSpaceContext) error {
 		if reqSpace.Attributes.Description != nil {
 			newSpace.Description = *reqSpace.Attributes.Description
 		}
+		if reqSpace.Attributes.TextSearchConfig != nil {
+			newSpace.TextSearchConfig = *reqSpace.Attributes.TextSearchConfig
+		}
 
 		rSpace, err = appl.Spaces().Create(ctx, &newSpace)
 		if err != nil {
@@ -116,30 +127,23 @@ func (c *SpaceController) Create(ctx *app.CreateSpaceContext) error {
 		if err != nil {
 			return errs.Wrapf(err, "failed to create iteration for space: %s", rSpace.Name)
 		}
+
+		if templateID != nil {
+			tpl, err := appl.SpaceTemplates().Load(ctx, *templateID)
+			if err != nil {
+				return errors.NewBadParameterError("data.relationships.template", *templateID).Expected("existing space template")
+			}
+			if err := applySpaceTemplate(ctx, appl, *tpl, newArea, newIteration); err != nil {
+				return errs.Wrapf(err, "failed to set up space template: %s", tpl.Name)
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
 
-	// Create keycloak resource for this space
-	resource, err := c.resourceManager.CreateResource(ctx, ctx.RequestData, spaceID.String(), spaceResourceType, &spaceName, &scopes, currentUser.String())
-	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, err)
-	}
-	spaceResource := &space.Resource{
-		ResourceID:   resource.ResourceID,
-		PolicyID:     resource.PolicyID,
-		PermissionID: resource.PermissionID,
-		SpaceID:      spaceID,
-	}
-
-	err = application.Transactional(c.db, func(appl application.Application) error {
-		// Create space resource which will represent the keyclok resource associated with this space
-		_, err = appl.SpaceResources().Create(ctx, spaceResource)
-		return err
-	})
-	if err != nil {
+	if err := c.createSpaceResource(ctx, ctx.RequestData, *rSpace); err != nil {
 		return jsonapi.JSONErrorResponse(ctx, err)
 	}
 
@@ -154,6 +158,26 @@ func (c *SpaceController) Create(ctx *app.CreateSpaceContext) error {
 	return ctx.Created(res)
 }
 
+// createSpaceResource creates the keycloak resource of the given space, owned
+// by the owner of the space, along with the space resource which represents it
+func (c *SpaceController) createSpaceResource(ctx context.Context, request *goa.RequestData, s space.Space) error {
+	resource, err := c.resourceManager.CreateResource(ctx, request, s.ID.String(), spaceResourceType, &s.Name, &scopes, s.OwnerId.String())
+	if err != nil {
+		return err
+	}
+	spaceResource := &space.Resource{
+		ResourceID:   resource.ResourceID,
+		PolicyID:     resource.PolicyID,
+		PermissionID: resource.PermissionID,
+		SpaceID:      s.ID,
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		// Create space resource which will represent the keyclok resource associated with this space
+		_, err := appl.SpaceResources().Create(ctx, spaceResource)
+		return err
+	})
+}
+
 // Delete runs the delete action.
 func (c *SpaceController) Delete(ctx *app.DeleteSpaceContext) error {
 	currentUser, err := login.ContextIdentity(ctx)
@@ -191,6 +215,11 @@ func (c *SpaceController) Delete(ctx *app.DeleteSpaceContext) error {
 		if err != nil {
 			return err
 		}
+		// Remove the links to the work items of other spaces, so that they
+		// don't keep pointing at work items that are no longer reachable.
+		if err := appl.WorkItemLinks().DeleteCrossSpaceLinks(ctx.Context, ctx.SpaceID, *currentUser); err != nil {
+			return err
+		}
 		return appl.Spaces().Delete(ctx.Context, ctx.SpaceID)
 	})
 
@@ -211,10 +240,12 @@ func (c *SpaceController) List(ctx *app.ListSpaceContext) error {
 		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
 	}
 	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
+	// archived spaces are hidden unless they are explicitly asked for
+	archived := ctx.FilterArchived != nil && *ctx.FilterArchived
 
 	var response app.SpaceList
 	txnErr := application.Transactional(c.db, func(appl application.Application) error {
-		spaces, cnt, err := appl.Spaces().List(ctx.Context, &offset, &limit)
+		spaces, cnt, err := appl.Spaces().List(ctx.Context, &archived, &offset, &limit)
 		if err != nil {
 			return err
 		}
@@ -229,7 +260,11 @@ func (c *SpaceController) List(ctx *app.ListSpaceContext) error {
 				Meta:  &app.SpaceListMeta{TotalCount: count},
 				Data:  spaceData,
 			}
-			setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(spaces), offset, limit, count)
+			var additionalQuery []string
+			if archived {
+				additionalQuery = append(additionalQuery, "filter[archived]=true")
+			}
+			setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(spaces), offset, limit, count, additionalQuery...)
 			return nil
 		})
 		if entityErr != nil {
@@ -311,6 +346,16 @@ func (c *SpaceController) Update(ctx *app.UpdateSpaceContext) error {
 		if ctx.Payload.Data.Attributes.Description != nil {
 			s.Description = *ctx.Payload.Data.Attributes.Description
 		}
+		if ctx.Payload.Data.Attributes.TextSearchConfig != nil {
+			s.TextSearchConfig = *ctx.Payload.Data.Attributes.TextSearchConfig
+		}
+		if ctx.Payload.Data.Attributes.Archived != nil && *ctx.Payload.Data.Attributes.Archived != s.Archived {
+			s.Archived = *ctx.Payload.Data.Attributes.Archived
+			log.Info(ctx, map[string]interface{}{
+				"space_id": s.ID,
+				"archived": s.Archived,
+			}, "space archived state changed")
+		}
 
 		s, err = appl.Spaces().Save(ctx.Context, s)
 		if err != nil {
@@ -333,6 +378,32 @@ func (c *SpaceController) Update(ctx *app.UpdateSpaceContext) error {
 	return ctx.OK(&response)
 }
 
+// checkSpaceOwner returns a ForbiddenError if the given user is not the owner
+// of the given space
+func checkSpaceOwner(ctx context.Context, s *space.Space, currentUser uuid.UUID) error {
+	if !uuid.Equal(currentUser, s.OwnerId) {
+		log.Warn(ctx, map[string]interface{}{
+			"space_id":     s.ID,
+			"space_owner":  s.OwnerId,
+			"current_user": currentUser,
+		}, "user is not the space owner")
+		return errors.NewForbiddenError("user is not the space owner")
+	}
+	return nil
+}
+
+// isSpaceAdmin returns true if the given identity is one of the given space
+// admins, which are allowed to create the space templates and to transfer
+// the ownership of any space
+func isSpaceAdmin(admins []string, identityID uuid.UUID) bool {
+	for _, admin := range admins {
+		if id, err := uuid.FromString(admin); err == nil && uuid.Equal(id, identityID) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateCreateSpace(ctx *app.CreateSpaceContext) error {
 	if ctx.Payload.Data == nil {
 		return errors.NewBadParameterError("data", nil).Expected("not nil")
@@ -385,6 +456,12 @@ func ConvertSpaceToModel(appSpace app.Space) space.Space {
 		if appSpace.Attributes.Description != nil {
 			modelSpace.Description = *appSpace.Attributes.Description
 		}
+		if appSpace.Attributes.TextSearchConfig != nil {
+			modelSpace.TextSearchConfig = *appSpace.Attributes.TextSearchConfig
+		}
+		if appSpace.Attributes.Archived != nil {
+			modelSpace.Archived = *appSpace.Attributes.Archived
+		}
 	}
 	if appSpace.Relationships != nil && appSpace.Relationships.OwnedBy != nil &&
 		appSpace.Relationships.OwnedBy.Data != nil && appSpace.Relationships.OwnedBy.Data.ID != nil {
@@ -434,11 +511,13 @@ func ConvertSpaceFromModel(ctx context.Context, db application.DB, request *goa.
 		ID:   &sp.ID,
 		Type: APIStringTypeSpace,
 		Attributes: &app.SpaceAttributes{
-			Name:        &sp.Name,
-			Description: &sp.Description,
-			CreatedAt:   &sp.CreatedAt,
-			UpdatedAt:   &sp.UpdatedAt,
-			Version:     &sp.Version,
+			Name:             &sp.Name,
+			Description:      &sp.Description,
+			TextSearchConfig: &sp.TextSearchConfig,
+			Archived:         &sp.Archived,
+			CreatedAt:        &sp.CreatedAt,
+			UpdatedAt:        &sp.UpdatedAt,
+			Version:          &sp.Version,
 		},
 		Links: &app.GenericLinksForSpace{
 			Self: &selfURL,
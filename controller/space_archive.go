@@ -0,0 +1,990 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/codebase"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/criteria"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/path"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Export runs the export action.
+func (c *SpaceController) Export(ctx *app.ExportSpaceContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	var arc *archive.Archive
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		if err := checkSpaceOwner(ctx, s, *currentUser); err != nil {
+			return err
+		}
+		arc, err = exportSpace(ctx, appl, *s)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	// the archive is wrapped so that it can be posted as is to the import action
+	body, err := json.Marshal(map[string]interface{}{"data": arc})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewInternalError(ctx, err))
+	}
+	ctx.ResponseData.Header().Set("Content-Type", "application/json")
+	ctx.ResponseData.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="space-%s.json"`, ctx.SpaceID))
+	return ctx.OK(body)
+}
+
+// Import runs the import action.
+func (c *SpaceController) Import(ctx *app.ImportSpaceContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	var arc archive.Archive
+	data, err := json.Marshal(ctx.Payload.Data)
+	if err == nil {
+		err = json.Unmarshal(data, &arc)
+	}
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", nil).Expected("space archive: "+err.Error()))
+	}
+	if err := arc.Validate(); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var rSpace *space.Space
+	created := false
+	missingResource := false
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		spaceID, err := appl.SpaceImportMappings().LookupSpace(ctx, arc.Space.ID)
+		if err != nil {
+			return err
+		}
+		if spaceID != nil {
+			rSpace, err = appl.Spaces().Load(ctx, *spaceID)
+			if err != nil {
+				return err
+			}
+			if !uuid.Equal(*currentUser, rSpace.OwnerId) {
+				return errors.NewForbiddenError("user is not the owner of the space imported from the archive")
+			}
+		} else {
+			name, err := freeSpaceName(ctx, appl, *currentUser, arc.Space.Name, "imported")
+			if err != nil {
+				return err
+			}
+			rSpace, err = appl.Spaces().Create(ctx, &space.Space{
+				ID:          uuid.NewV4(),
+				Name:        name,
+				Description: arc.Space.Description,
+				OwnerId:     *currentUser,
+			})
+			if err != nil {
+				return errs.Wrapf(err, "failed to create space: %s", arc.Space.Name)
+			}
+			err = appl.SpaceImportMappings().Create(ctx, &archive.Mapping{
+				SpaceID:  rSpace.ID,
+				Kind:     archive.KindSpace,
+				SourceID: arc.Space.ID,
+				TargetID: rSpace.ID,
+			})
+			if err != nil {
+				return err
+			}
+			created = true
+		}
+		// the space resource is created after the commit, so it is missing if
+		// the space was just created or if creating it failed last time
+		_, err = appl.SpaceResources().LoadBySpace(ctx, &rSpace.ID)
+		if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+			missingResource = true
+		} else if err != nil {
+			return err
+		}
+		imp := spaceImporter{
+			appl:       appl,
+			archive:    arc,
+			spaceID:    rSpace.ID,
+			importerID: *currentUser,
+		}
+		return imp.run(ctx)
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if missingResource {
+		if err := c.createSpaceResource(ctx, ctx.RequestData, *rSpace); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+	}
+	spaceData, err := ConvertSpaceFromModel(ctx.Context, c.db, ctx.RequestData, *rSpace)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.SpaceSingle{
+		Data: spaceData,
+	}
+	if !created {
+		return ctx.OK(res)
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.RequestData, app.SpaceHref(res.Data.ID)))
+	return ctx.Created(res)
+}
+
+// freeSpaceName returns the given space name if the owner has no other space
+// with that name, or else the name suffixed with the given tag (and a number
+// if needed) which doesn't clash with the names of the other spaces of the
+// owner
+func freeSpaceName(ctx context.Context, appl application.Application, ownerID uuid.UUID, name string, tag string) (string, error) {
+	candidate := name
+	for i := 1; ; i++ {
+		_, err := appl.Spaces().LoadByOwnerAndName(ctx, &ownerID, &candidate)
+		if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		suffix := fmt.Sprintf(" (%s)", tag)
+		if i > 1 {
+			suffix = fmt.Sprintf(" (%s %d)", tag, i)
+		}
+		// space names are limited to 62 characters
+		base := []rune(name)
+		if len(base)+len([]rune(suffix)) > 62 {
+			base = base[:62-len([]rune(suffix))]
+		}
+		candidate = string(base) + suffix
+	}
+}
+
+// forEachFieldReference calls the given function for each reference to an
+// identity, an iteration or an area in the given stored fields of a work item
+// of the given type, and replaces the reference with the returned one. The
+// reference is removed when nil is returned.
+func forEachFieldReference(wit workitem.WorkItemType, fields map[string]interface{}, fn func(kind workitem.Kind, ref string) *string) {
+	for name, value := range fields {
+		def, ok := wit.Fields[name]
+		if !ok || value == nil {
+			continue
+		}
+		kind := def.Type.GetKind()
+		if listType, ok := def.Type.(workitem.ListType); ok {
+			kind = listType.ComponentType.GetKind()
+		}
+		if kind != workitem.KindUser && kind != workitem.KindIteration && kind != workitem.KindArea {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if ref := fn(kind, v); ref != nil {
+				fields[name] = *ref
+			} else {
+				delete(fields, name)
+			}
+		case []interface{}:
+			refs := []interface{}{}
+			for _, elem := range v {
+				if s, ok := elem.(string); ok {
+					if ref := fn(kind, s); ref != nil {
+						refs = append(refs, *ref)
+					}
+				}
+			}
+			fields[name] = refs
+		}
+	}
+}
+
+// exportSpace returns the archive of the given space
+func exportSpace(ctx context.Context, appl application.Application, s space.Space) (*archive.Archive, error) {
+	arc := archive.Archive{
+		FormatVersion: archive.FormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Space: archive.Space{
+			ID:          s.ID,
+			Name:        s.Name,
+			Description: s.Description,
+		},
+	}
+	identities := map[uuid.UUID]bool{}
+	addIdentity := func(id uuid.UUID) {
+		if !uuid.Equal(id, uuid.Nil) {
+			identities[id] = true
+		}
+	}
+
+	wits, err := appl.WorkItemTypes().List(ctx, s.ID, nil, nil)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list the work item types of the space")
+	}
+	// the types are created in the order of the archive, so base types first
+	sort.Slice(wits, func(i, j int) bool {
+		di, dj := strings.Count(wits[i].Path, workitem.GetTypePathSeparator()), strings.Count(wits[j].Path, workitem.GetTypePathSeparator())
+		return di < dj || (di == dj && wits[i].Name < wits[j].Name)
+	})
+	for _, wit := range wits {
+		arc.WorkItemTypes = append(arc.WorkItemTypes, archive.WorkItemType{
+			ID:          wit.ID,
+			Name:        wit.Name,
+			Description: wit.Description,
+			Icon:        wit.Icon,
+			Path:        wit.Path,
+			Fields:      wit.Fields,
+		})
+	}
+
+	linkTypes, err := appl.WorkItemLinkTypes().List(ctx, s.ID)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list the work item link types of the space")
+	}
+	categories := map[uuid.UUID]bool{}
+	for _, lt := range linkTypes {
+		if !categories[lt.LinkCategoryID] {
+			categories[lt.LinkCategoryID] = true
+			category, err := appl.WorkItemLinkCategories().Load(ctx, lt.LinkCategoryID)
+			if err != nil {
+				return nil, errs.Wrapf(err, "failed to load the work item link category %s", lt.LinkCategoryID)
+			}
+			arc.LinkCategories = append(arc.LinkCategories, archive.LinkCategory{
+				ID:          category.ID,
+				Name:        category.Name,
+				Description: category.Description,
+			})
+		}
+		// the link types of the system space are available in every space
+		if !uuid.Equal(lt.SpaceID, s.ID) {
+			continue
+		}
+		arc.LinkTypes = append(arc.LinkTypes, archive.LinkType{
+			ID:             lt.ID,
+			Name:           lt.Name,
+			Description:    lt.Description,
+			Topology:       lt.Topology,
+			ForwardName:    lt.ForwardName,
+			ReverseName:    lt.ReverseName,
+			LinkCategoryID: lt.LinkCategoryID,
+		})
+	}
+
+	areas, err := appl.Areas().List(ctx, s.ID)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list the areas of the space")
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		return len(areas[i].Path) < len(areas[j].Path) || (len(areas[i].Path) == len(areas[j].Path) && areas[i].Name < areas[j].Name)
+	})
+	for _, a := range areas {
+		exported := archive.Area{ID: a.ID, Name: a.Name}
+		if !a.Path.IsEmpty() {
+			parentID := a.Path.This()
+			exported.ParentID = &parentID
+		}
+		arc.Areas = append(arc.Areas, exported)
+	}
+
+	iterations, err := appl.Iterations().List(ctx, s.ID)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list the iterations of the space")
+	}
+	sort.Slice(iterations, func(i, j int) bool {
+		return len(iterations[i].Path) < len(iterations[j].Path) || (len(iterations[i].Path) == len(iterations[j].Path) && iterations[i].Name < iterations[j].Name)
+	})
+	for _, itr := range iterations {
+		exported := archive.Iteration{
+			ID:          itr.ID,
+			Name:        itr.Name,
+			Description: itr.Description,
+			StartAt:     itr.StartAt,
+			EndAt:       itr.EndAt,
+			State:       itr.State,
+		}
+		if !itr.Path.IsEmpty() {
+			parentID := itr.Path.This()
+			exported.ParentID = &parentID
+		}
+		arc.Iterations = append(arc.Iterations, exported)
+	}
+
+	wis, _, err := appl.WorkItems().List(ctx, s.ID, criteria.Literal(true), nil, nil, nil, nil)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list the work items of the space")
+	}
+	sort.Slice(wis, func(i, j int) bool { return wis[i].Number < wis[j].Number })
+	workItems := map[uuid.UUID]bool{}
+	for _, wi := range wis {
+		workItems[wi.ID] = true
+	}
+	links := map[uuid.UUID]bool{}
+	for _, wi := range wis {
+		wit, err := appl.WorkItemTypes().LoadByID(ctx, wi.Type)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to load the type of the work item %d", wi.Number)
+		}
+		revisions, err := appl.WorkItemRevisions().List(ctx, wi.ID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to list the revisions of the work item %d", wi.Number)
+		}
+		exported := archive.WorkItem{
+			ID:     wi.ID,
+			Number: wi.Number,
+			Type:   wi.Type,
+		}
+		for _, rev := range revisions {
+			addIdentity(rev.ModifierIdentity)
+			forEachFieldReference(*wit, rev.WorkItemFields, func(kind workitem.Kind, ref string) *string {
+				if id, err := uuid.FromString(ref); err == nil && kind == workitem.KindUser {
+					addIdentity(id)
+				}
+				return &ref
+			})
+			exported.Revisions = append(exported.Revisions, archive.Revision{
+				Time:       rev.Time,
+				Type:       rev.Type,
+				ModifierID: rev.ModifierIdentity,
+				Fields:     rev.WorkItemFields,
+			})
+		}
+		arc.WorkItems = append(arc.WorkItems, exported)
+
+		wiLinks, err := appl.WorkItemLinks().ListByWorkItem(ctx, wi.ID)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to list the links of the work item %d", wi.Number)
+		}
+		for _, l := range wiLinks {
+			// the links to the work items of other spaces are left behind
+			if links[l.ID] || !workItems[l.SourceID] || !workItems[l.TargetID] {
+				continue
+			}
+			links[l.ID] = true
+			arc.Links = append(arc.Links, archive.Link{
+				ID:         l.ID,
+				LinkTypeID: l.LinkTypeID,
+				SourceID:   l.SourceID,
+				TargetID:   l.TargetID,
+			})
+		}
+
+		comments, _, err := appl.Comments().List(ctx, wi.ID, nil, nil)
+		if err != nil {
+			return nil, errs.Wrapf(err, "failed to list the comments of the work item %d", wi.Number)
+		}
+		sort.Slice(comments, func(i, j int) bool { return comments[i].CreatedAt.Before(comments[j].CreatedAt) })
+		for _, cm := range comments {
+			addIdentity(cm.CreatedBy)
+			arc.Comments = append(arc.Comments, archive.Comment{
+				ID:              cm.ID,
+				WorkItemID:      wi.ID,
+				ParentCommentID: cm.ParentCommentID,
+				CreatorID:       cm.CreatedBy,
+				Body:            cm.Body,
+				Markup:          cm.Markup,
+				CreatedAt:       cm.CreatedAt,
+			})
+		}
+	}
+
+	codebases, _, err := appl.Codebases().List(ctx, s.ID, nil, nil)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to list the codebases of the space")
+	}
+	for _, cb := range codebases {
+		arc.Codebases = append(arc.Codebases, archive.Codebase{
+			ID:      cb.ID,
+			Type:    cb.Type,
+			URL:     cb.URL,
+			StackID: cb.StackID,
+		})
+	}
+
+	for id := range identities {
+		identity, err := appl.Identities().Load(ctx, id)
+		if err != nil {
+			// the identity can still be mapped to the importing user
+			log.Warn(ctx, map[string]interface{}{
+				"identity_id": id,
+				"err":         err,
+			}, "unable to load an identity referenced by the space")
+			continue
+		}
+		exported := archive.Identity{ID: id, Username: identity.Username}
+		if identity.UserID.Valid {
+			if user, err := appl.Users().Load(ctx, identity.UserID.UUID); err == nil {
+				exported.Email = user.Email
+			}
+		}
+		arc.Identities = append(arc.Identities, exported)
+	}
+	sort.Slice(arc.Identities, func(i, j int) bool { return arc.Identities[i].Username < arc.Identities[j].Username })
+	return &arc, nil
+}
+
+// spaceImporter imports the entities of an archive which were not imported
+// yet in a space, using the repositories of a single transaction.
+type spaceImporter struct {
+	appl       application.Application
+	archive    archive.Archive
+	spaceID    uuid.UUID
+	importerID uuid.UUID
+	// the IDs of the identities of this installation by the IDs of the
+	// archive
+	identities map[uuid.UUID]uuid.UUID
+	// the numbers of the imported work items by the numbers of the archive
+	numbers map[int]int
+	// the IDs of the imported entities by kind and ID of the archive
+	mappings map[string]map[uuid.UUID]archive.Mapping
+}
+
+// target returns the ID of the entity imported from the entity of the given
+// kind and ID of the archive, if any
+func (i *spaceImporter) target(kind string, sourceID uuid.UUID) (uuid.UUID, bool) {
+	m, ok := i.mappings[kind][sourceID]
+	return m.TargetID, ok
+}
+
+// record records that the entity with the given target ID was imported from
+// the entity of the given kind and ID of the archive
+func (i *spaceImporter) record(ctx context.Context, kind string, sourceID, targetID uuid.UUID, number *int) error {
+	m := archive.Mapping{
+		SpaceID:  i.spaceID,
+		Kind:     kind,
+		SourceID: sourceID,
+		TargetID: targetID,
+		Number:   number,
+	}
+	if err := i.appl.SpaceImportMappings().Create(ctx, &m); err != nil {
+		return err
+	}
+	i.mappings[kind][sourceID] = m
+	return nil
+}
+
+// identity returns the identity of this installation to which the identity
+// with the given ID of the archive is mapped, defaulting to the importer
+func (i *spaceImporter) identity(sourceID uuid.UUID) uuid.UUID {
+	if id, ok := i.identities[sourceID]; ok {
+		return id
+	}
+	return i.importerID
+}
+
+func (i *spaceImporter) run(ctx context.Context) error {
+	i.mappings = map[string]map[uuid.UUID]archive.Mapping{}
+	for _, kind := range []string{archive.KindLinkCategory, archive.KindWorkItemType, archive.KindLinkType, archive.KindArea, archive.KindIteration, archive.KindWorkItem, archive.KindLink, archive.KindComment, archive.KindCodebase} {
+		mappings, err := i.appl.SpaceImportMappings().Lookup(ctx, i.spaceID, kind)
+		if err != nil {
+			return err
+		}
+		i.mappings[kind] = mappings
+	}
+	for _, step := range []func(context.Context) error{
+		i.importIdentities,
+		i.importLinkCategories,
+		i.importWorkItemTypes,
+		i.importLinkTypes,
+		i.importAreas,
+		i.importIterations,
+		i.importWorkItems,
+		i.importLinks,
+		i.importComments,
+		i.importCodebases,
+	} {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_id":        i.spaceID,
+		"source_space_id": i.archive.Space.ID,
+	}, "space archive imported")
+	return nil
+}
+
+// importIdentities maps the identities of the archive to the collaborators of
+// the space with the same username or else with the same email, so that the
+// importer cannot act on behalf of users who are not part of the space
+func (i *spaceImporter) importIdentities(ctx context.Context) error {
+	collaborators, err := authz.Collaborators(ctx, i.appl, i.spaceID)
+	if err != nil {
+		return errs.Wrapf(err, "unable to list the collaborators of space %s", i.spaceID)
+	}
+	allowed := make(map[uuid.UUID]bool, len(collaborators))
+	for _, id := range collaborators {
+		allowed[id] = true
+	}
+	i.identities = map[uuid.UUID]uuid.UUID{}
+	for _, identity := range i.archive.Identities {
+		if identity.Username != "" {
+			matches, err := i.appl.Identities().Query(account.IdentityFilterByUsername(identity.Username))
+			if err != nil {
+				return errors.NewInternalError(ctx, err)
+			}
+			if id, ok := firstAllowed(matches, allowed); ok {
+				i.identities[identity.ID] = id
+				continue
+			}
+		}
+		if identity.Email != "" {
+			users, err := i.appl.Users().Query(account.UserFilterByEmail(identity.Email))
+			if err != nil {
+				return errors.NewInternalError(ctx, err)
+			}
+			if len(users) > 0 {
+				matches, err := i.appl.Identities().Query(account.IdentityFilterByUserID(users[0].ID))
+				if err != nil {
+					return errors.NewInternalError(ctx, err)
+				}
+				if id, ok := firstAllowed(matches, allowed); ok {
+					i.identities[identity.ID] = id
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// firstAllowed returns the ID of the first of the given identities which is
+// allowed, if any
+func firstAllowed(identities []account.Identity, allowed map[uuid.UUID]bool) (uuid.UUID, bool) {
+	for _, identity := range identities {
+		if allowed[identity.ID] {
+			return identity.ID, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+// importLinkCategories maps the link categories of the archive to the
+// categories with the same ID or else with the same name, and rejects the
+// archive if one of them does not exist
+func (i *spaceImporter) importLinkCategories(ctx context.Context) error {
+	var existing []link.WorkItemLinkCategory
+	for _, lc := range i.archive.LinkCategories {
+		if _, ok := i.target(archive.KindLinkCategory, lc.ID); ok {
+			continue
+		}
+		targetID := uuid.Nil
+		if category, err := i.appl.WorkItemLinkCategories().Load(ctx, lc.ID); err == nil {
+			targetID = category.ID
+		} else {
+			if existing == nil {
+				existing, err = i.appl.WorkItemLinkCategories().List(ctx)
+				if err != nil {
+					return errs.Wrap(err, "failed to list the work item link categories")
+				}
+			}
+			for _, category := range existing {
+				if category.Name == lc.Name {
+					targetID = category.ID
+				}
+			}
+		}
+		if uuid.Equal(targetID, uuid.Nil) {
+			// the link categories are shared by all the spaces, so an import
+			// must not be able to create them
+			return errors.NewBadParameterError("data.link_categories", lc.Name).Expected("existing work item link category")
+		}
+		if err := i.record(ctx, archive.KindLinkCategory, lc.ID, targetID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *spaceImporter) importWorkItemTypes(ctx context.Context) error {
+	for _, t := range i.archive.WorkItemTypes {
+		if _, ok := i.target(archive.KindWorkItemType, t.ID); ok {
+			continue
+		}
+		id := uuid.NewV4()
+		// the types extended by the type are either types of the archive or
+		// types of the system space
+		segments := strings.Split(t.Path, workitem.GetTypePathSeparator())
+		for j, segment := range segments[:len(segments)-1] {
+			if sourceID, err := uuid.FromString(strings.Replace(segment, "_", "-", -1)); err == nil {
+				if targetID, ok := i.target(archive.KindWorkItemType, sourceID); ok {
+					segments[j] = workitem.LtreeSafeID(targetID)
+				}
+			}
+		}
+		segments[len(segments)-1] = workitem.LtreeSafeID(id)
+		_, err := i.appl.WorkItemTypes().CreateFromModel(ctx, &workitem.WorkItemType{
+			ID:          id,
+			Name:        t.Name,
+			Description: t.Description,
+			Icon:        t.Icon,
+			Path:        strings.Join(segments, workitem.GetTypePathSeparator()),
+			Fields:      t.Fields,
+			SpaceID:     i.spaceID,
+		})
+		if err != nil {
+			return errs.Wrapf(err, "failed to create work item type: %s", t.Name)
+		}
+		if err := i.record(ctx, archive.KindWorkItemType, t.ID, id, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *spaceImporter) importLinkTypes(ctx context.Context) error {
+	for _, lt := range i.archive.LinkTypes {
+		if _, ok := i.target(archive.KindLinkType, lt.ID); ok {
+			continue
+		}
+		categoryID, ok := i.target(archive.KindLinkCategory, lt.LinkCategoryID)
+		if !ok {
+			return errors.NewBadParameterError("link_types.link_category", lt.LinkCategoryID).Expected("link category of the archive")
+		}
+		created, err := i.appl.WorkItemLinkTypes().Create(ctx, &link.WorkItemLinkType{
+			Name:           lt.Name,
+			Description:    lt.Description,
+			Topology:       lt.Topology,
+			ForwardName:    lt.ForwardName,
+			ReverseName:    lt.ReverseName,
+			LinkCategoryID: categoryID,
+			SpaceID:        i.spaceID,
+		})
+		if err != nil {
+			return errs.Wrapf(err, "failed to create work item link type: %s", lt.Name)
+		}
+		if err := i.record(ctx, archive.KindLinkType, lt.ID, created.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// childPath returns the path of the children of the entity with the given
+// path and ID
+func childPath(parentPath path.Path, parentID uuid.UUID) path.Path {
+	result := append(path.Path{}, parentPath...)
+	return append(result, parentID)
+}
+
+func (i *spaceImporter) importAreas(ctx context.Context) error {
+	// the paths of the areas of the space by the IDs of the archive
+	paths := map[uuid.UUID]path.Path{}
+	for _, a := range i.archive.Areas {
+		if targetID, ok := i.target(archive.KindArea, a.ID); ok {
+			existing, err := i.appl.Areas().Load(ctx, targetID)
+			if err != nil {
+				return errs.Wrapf(err, "failed to load the imported area: %s", a.Name)
+			}
+			paths[a.ID] = childPath(existing.Path, existing.ID)
+			continue
+		}
+		newArea := area.Area{
+			SpaceID: i.spaceID,
+			Name:    a.Name,
+		}
+		if a.ParentID != nil {
+			newArea.Path = paths[*a.ParentID]
+		}
+		if err := i.appl.Areas().Create(ctx, &newArea); err != nil {
+			return errs.Wrapf(err, "failed to create area: %s", a.Name)
+		}
+		paths[a.ID] = childPath(newArea.Path, newArea.ID)
+		if err := i.record(ctx, archive.KindArea, a.ID, newArea.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *spaceImporter) importIterations(ctx context.Context) error {
+	// the paths of the iterations of the space by the IDs of the archive
+	paths := map[uuid.UUID]path.Path{}
+	for _, itr := range i.archive.Iterations {
+		if targetID, ok := i.target(archive.KindIteration, itr.ID); ok {
+			existing, err := i.appl.Iterations().Load(ctx, targetID)
+			if err != nil {
+				return errs.Wrapf(err, "failed to load the imported iteration: %s", itr.Name)
+			}
+			paths[itr.ID] = childPath(existing.Path, existing.ID)
+			continue
+		}
+		newIteration := iteration.Iteration{
+			SpaceID:     i.spaceID,
+			Name:        itr.Name,
+			Description: itr.Description,
+			StartAt:     itr.StartAt,
+			EndAt:       itr.EndAt,
+		}
+		if itr.ParentID != nil {
+			newIteration.Path = paths[*itr.ParentID]
+		}
+		if err := i.appl.Iterations().Create(ctx, &newIteration); err != nil {
+			return errs.Wrapf(err, "failed to create iteration: %s", itr.Name)
+		}
+		// iterations are created as new, then moved to their state
+		if itr.State != "" && itr.State != newIteration.State {
+			newIteration.State = itr.State
+			if _, err := i.appl.Iterations().Save(ctx, newIteration); err != nil {
+				return errs.Wrapf(err, "failed to update the state of iteration: %s", itr.Name)
+			}
+		}
+		paths[itr.ID] = childPath(newIteration.Path, newIteration.ID)
+		if err := i.record(ctx, archive.KindIteration, itr.ID, newIteration.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *spaceImporter) importWorkItems(ctx context.Context) error {
+	rootArea, ok := i.target(archive.KindArea, i.archive.Areas[0].ID)
+	if !ok {
+		return errors.NewInternalError(ctx, errs.New("the root area of the archive was not imported"))
+	}
+	rootIteration, ok := i.target(archive.KindIteration, i.archive.Iterations[0].ID)
+	if !ok {
+		return errors.NewInternalError(ctx, errs.New("the root iteration of the archive was not imported"))
+	}
+	// the numbers of the work items to import are reserved first, so that
+	// the references to the work items can be remapped in all the texts
+	i.numbers = map[int]int{}
+	var pending []archive.WorkItem
+	for _, wi := range i.archive.WorkItems {
+		if m, ok := i.mappings[archive.KindWorkItem][wi.ID]; ok {
+			if m.Number != nil {
+				i.numbers[wi.Number] = *m.Number
+			}
+			continue
+		}
+		pending = append(pending, wi)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	first, err := i.appl.WorkItems().ReserveNumbers(ctx, i.spaceID, len(pending))
+	if err != nil {
+		return errs.Wrap(err, "failed to reserve the numbers of the work items")
+	}
+	for j, wi := range pending {
+		i.numbers[wi.Number] = first + j
+	}
+	importedWorkItems := make([]workitem.WorkItem, 0, len(pending))
+	for _, wi := range pending {
+		// the work items of the types of the system space keep their type
+		typeID, ok := i.target(archive.KindWorkItemType, wi.Type)
+		if !ok {
+			typeID = wi.Type
+		}
+		wit, err := i.appl.WorkItemTypes().LoadByID(ctx, typeID)
+		if err != nil {
+			return errors.NewBadParameterError("work_items.type", wi.Type).Expected("work item type of the archive or of the system space")
+		}
+		revisions := make([]workitem.Revision, len(wi.Revisions))
+		for j, rev := range wi.Revisions {
+			fields := workitem.Fields{}
+			for name, value := range rev.Fields {
+				fields[name] = value
+			}
+			forEachFieldReference(*wit, fields, func(kind workitem.Kind, ref string) *string {
+				sourceID, err := uuid.FromString(ref)
+				if err != nil {
+					return nil
+				}
+				var result string
+				switch kind {
+				case workitem.KindUser:
+					result = i.identity(sourceID).String()
+				case workitem.KindIteration:
+					targetID, ok := i.target(archive.KindIteration, sourceID)
+					if !ok {
+						targetID = rootIteration
+					}
+					result = targetID.String()
+				case workitem.KindArea:
+					targetID, ok := i.target(archive.KindArea, sourceID)
+					if !ok {
+						targetID = rootArea
+					}
+					result = targetID.String()
+				}
+				return &result
+			})
+			for name, value := range fields {
+				if def, ok := wit.Fields[name]; ok && def.Type.GetKind() == workitem.KindMarkup {
+					fields[name] = i.remapMarkupReferences(value)
+				}
+			}
+			revisions[j] = workitem.Revision{
+				Time:             rev.Time,
+				Type:             rev.Type,
+				ModifierIdentity: i.identity(rev.ModifierID),
+				WorkItemFields:   fields,
+			}
+		}
+		imported, err := i.appl.WorkItems().Import(ctx, i.spaceID, typeID, i.numbers[wi.Number], revisions)
+		if err != nil {
+			return errs.Wrapf(err, "failed to import the work item %d", wi.Number)
+		}
+		if err := i.record(ctx, archive.KindWorkItem, wi.ID, imported.ID, &imported.Number); err != nil {
+			return err
+		}
+		importedWorkItems = append(importedWorkItems, *imported)
+	}
+	// the mentions and references are saved once all the work items are
+	// imported, so that the references to the later ones are resolved
+	for _, wi := range importedWorkItems {
+		if err := saveWorkItemMarkupLinks(ctx, i.appl, wi); err != nil {
+			return errs.Wrapf(err, "failed to save the mentions and references of the work item %d", wi.Number)
+		}
+	}
+	return nil
+}
+
+// remapReferences returns the given text in which the `#number` references to
+// the work items of the archive are replaced with references to the imported
+// work items
+func (i *spaceImporter) remapReferences(text string) string {
+	return rendering.ReplaceWorkItemReferences(text, func(number int) (int, bool) {
+		target, ok := i.numbers[number]
+		return target, ok
+	})
+}
+
+// remapMarkupReferences returns the given stored value of a markup field with
+// the references to the work items of the archive remapped
+func (i *spaceImporter) remapMarkupReferences(value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	content, ok := m[rendering.ContentKey].(string)
+	if !ok {
+		return value
+	}
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	result[rendering.ContentKey] = i.remapReferences(content)
+	return result
+}
+
+func (i *spaceImporter) importLinks(ctx context.Context) error {
+	for _, l := range i.archive.Links {
+		if _, ok := i.target(archive.KindLink, l.ID); ok {
+			continue
+		}
+		sourceID, ok := i.target(archive.KindWorkItem, l.SourceID)
+		if !ok {
+			return errors.NewBadParameterError("links.source", l.SourceID).Expected("work item of the archive")
+		}
+		targetID, ok := i.target(archive.KindWorkItem, l.TargetID)
+		if !ok {
+			return errors.NewBadParameterError("links.target", l.TargetID).Expected("work item of the archive")
+		}
+		// the links of the link types of the system space keep their type
+		linkTypeID, ok := i.target(archive.KindLinkType, l.LinkTypeID)
+		if !ok {
+			linkTypeID = l.LinkTypeID
+		}
+		created, err := i.appl.WorkItemLinks().Create(ctx, sourceID, targetID, linkTypeID, i.importerID)
+		if err != nil {
+			return errs.Wrapf(err, "failed to create work item link %s", l.ID)
+		}
+		if err := i.record(ctx, archive.KindLink, l.ID, created.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *spaceImporter) importComments(ctx context.Context) error {
+	parents := map[uuid.UUID]*workitem.WorkItem{}
+	for _, cm := range i.archive.Comments {
+		if _, ok := i.target(archive.KindComment, cm.ID); ok {
+			continue
+		}
+		parentID, ok := i.target(archive.KindWorkItem, cm.WorkItemID)
+		if !ok {
+			return errors.NewBadParameterError("comments.work_item", cm.WorkItemID).Expected("work item of the archive")
+		}
+		newComment := comment.Comment{
+			ParentID: parentID,
+			Body:     i.remapReferences(cm.Body),
+			Markup:   cm.Markup,
+		}
+		if cm.ParentCommentID != nil {
+			parentCommentID, ok := i.target(archive.KindComment, *cm.ParentCommentID)
+			if !ok {
+				return errors.NewBadParameterError("comments.parent_comment", *cm.ParentCommentID).Expected("comment of the archive created before its replies")
+			}
+			newComment.ParentCommentID = &parentCommentID
+		}
+		// gorm keeps the creation time which is already set
+		newComment.CreatedAt = cm.CreatedAt
+		if err := i.appl.Comments().Create(ctx, &newComment, i.identity(cm.CreatorID)); err != nil {
+			return errs.Wrapf(err, "failed to create comment %s", cm.ID)
+		}
+		if err := i.record(ctx, archive.KindComment, cm.ID, newComment.ID, nil); err != nil {
+			return err
+		}
+		parent, ok := parents[parentID]
+		if !ok {
+			var err error
+			parent, err = i.appl.WorkItems().LoadByID(ctx, parentID)
+			if err != nil {
+				return errs.Wrapf(err, "failed to load the work item of comment %s", cm.ID)
+			}
+			parents[parentID] = parent
+		}
+		if err := saveCommentMarkupLinks(ctx, i.appl, *parent, newComment); err != nil {
+			return errs.Wrapf(err, "failed to save the mentions and references of comment %s", cm.ID)
+		}
+	}
+	return nil
+}
+
+func (i *spaceImporter) importCodebases(ctx context.Context) error {
+	for _, cb := range i.archive.Codebases {
+		if _, ok := i.target(archive.KindCodebase, cb.ID); ok {
+			continue
+		}
+		newCodebase := codebase.Codebase{
+			SpaceID: i.spaceID,
+			Type:    cb.Type,
+			URL:     cb.URL,
+			StackID: cb.StackID,
+		}
+		if err := i.appl.Codebases().Create(ctx, &newCodebase); err != nil {
+			return errs.Wrapf(err, "failed to create codebase: %s", cb.URL)
+		}
+		if err := i.record(ctx, archive.KindCodebase, cb.ID, newCodebase.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/criteria"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/mention"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	almtoken "github.com/fabric8-services/fabric8-wit/token"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSpaceArchiveREST struct {
+	gormtestsupport.DBTestSuite
+	db    *gormapplication.GormDB
+	clean func()
+}
+
+func TestRunSpaceArchiveREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestSpaceArchiveREST{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+// SetupSuite overrides the DBTestSuite's function but calls it before doing anything else
+func (rest *TestSpaceArchiveREST) SetupSuite() {
+	rest.DBTestSuite.SetupSuite()
+	ctx := migration.NewMigrationContext(context.Background())
+	rest.DBTestSuite.PopulateDBTestSuite(ctx)
+	err := migration.BootstrapWorkItemLinking(ctx, link.NewWorkItemLinkCategoryRepository(rest.DB), space.NewRepository(rest.DB), link.NewWorkItemLinkTypeRepository(rest.DB))
+	require.Nil(rest.T(), err)
+}
+
+func (rest *TestSpaceArchiveREST) SetupTest() {
+	rest.db = gormapplication.NewGormDB(rest.DB)
+	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
+}
+
+func (rest *TestSpaceArchiveREST) TearDownTest() {
+	rest.clean()
+}
+
+func (rest *TestSpaceArchiveREST) SecuredController(identity account.Identity) (*goa.Service, *SpaceController) {
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsUser("Space-Service", almtoken.NewManagerWithPrivateKey(priv), identity)
+	return svc, NewSpaceController(svc, rest.db, spaceConfiguration, &DummyResourceManager{})
+}
+
+// createPopulatedSpace creates a scrum space owned by the test identity, with
+// two linked work items and a comment
+func (rest *TestSpaceArchiveREST) createPopulatedSpace() uuid.UUID {
+	ctx := context.Background()
+	templateID := spacetemplate.SystemScrum.String()
+	p := CreateSpacePayload(testsupport.CreateRandomValidTestName("TestSpaceArchive-"), "")
+	p.Data.Relationships = &app.SpaceRelationships{
+		Template: &app.RelationGeneric{
+			Data: &app.GenericData{ID: &templateID},
+		},
+	}
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	_, created := test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
+	spaceID := *created.Data.ID
+
+	wits, err := workitem.NewWorkItemTypeRepository(rest.DB).List(ctx, spaceID, nil, nil)
+	require.Nil(rest.T(), err)
+	var storyType uuid.UUID
+	for _, wit := range wits {
+		if wit.Name == "User Story" {
+			storyType = wit.ID
+		}
+	}
+	wiRepo := workitem.NewWorkItemRepository(rest.DB)
+	var wis []*workitem.WorkItem
+	for _, title := range []string{"Login", "Logout"} {
+		wi, err := wiRepo.Create(ctx, spaceID, storyType, map[string]interface{}{
+			workitem.SystemTitle:     title,
+			workitem.SystemState:     workitem.SystemStateNew,
+			workitem.SystemAssignees: []string{testsupport.TestIdentity.ID.String()},
+		}, testsupport.TestIdentity.ID)
+		require.Nil(rest.T(), err)
+		wis = append(wis, wi)
+	}
+	_, err = link.NewWorkItemLinkRepository(rest.DB).Create(ctx, wis[0].ID, wis[1].ID, link.SystemWorkItemLinkTypeBugBlockerID, testsupport.TestIdentity.ID)
+	require.Nil(rest.T(), err)
+	err = comment.NewRepository(rest.DB).Create(ctx, &comment.Comment{
+		ParentID: wis[0].ID,
+		Body:     "Needs a design review",
+		Markup:   rendering.SystemMarkupPlainText,
+	}, testsupport.TestIdentity.ID)
+	require.Nil(rest.T(), err)
+	return spaceID
+}
+
+// export returns the body of the export of the given space, which can be
+// posted as is to the import action
+func (rest *TestSpaceArchiveREST) export(t *testing.T, spaceID uuid.UUID) *app.ImportSpacePayload {
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	rw := test.ExportSpaceOK(t, svc.Context, svc, ctrl, spaceID)
+	var payload app.ImportSpacePayload
+	require.Nil(t, json.Unmarshal(rw.(*httptest.ResponseRecorder).Body.Bytes(), &payload))
+	return &payload
+}
+
+func (rest *TestSpaceArchiveREST) TestExportAndImport() {
+	// given
+	ctx := context.Background()
+	sourceID := rest.createPopulatedSpace()
+	payload := rest.export(rest.T(), sourceID)
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when
+	_, imported := test.ImportSpaceCreated(rest.T(), svc.Context, svc, ctrl, payload)
+	// then
+	spaceID := *imported.Data.ID
+	assert.NotEqual(rest.T(), sourceID, spaceID)
+	wiRepo := workitem.NewWorkItemRepository(rest.DB)
+	wis, count, err := wiRepo.List(ctx, spaceID, criteria.Literal(true), nil, nil, nil, nil)
+	require.Nil(rest.T(), err)
+	require.Equal(rest.T(), 2, count)
+	titles := map[int]interface{}{}
+	for _, wi := range wis {
+		titles[wi.Number] = wi.Fields[workitem.SystemTitle]
+		assert.Equal(rest.T(), []interface{}{testsupport.TestIdentity.ID.String()}, wi.Fields[workitem.SystemAssignees])
+		wit, err := workitem.NewWorkItemTypeRepository(rest.DB).LoadByID(ctx, wi.Type)
+		require.Nil(rest.T(), err)
+		assert.Equal(rest.T(), spaceID, wit.SpaceID)
+	}
+	assert.Equal(rest.T(), map[int]interface{}{1: "Login", 2: "Logout"}, titles)
+	iterations, err := iteration.NewIterationRepository(rest.DB).List(ctx, spaceID)
+	require.Nil(rest.T(), err)
+	assert.Len(rest.T(), iterations, 5)
+	links, err := link.NewWorkItemLinkRepository(rest.DB).ListByWorkItem(ctx, wis[0].ID)
+	require.Nil(rest.T(), err)
+	assert.Len(rest.T(), links, 1)
+
+	rest.T().Run("import again", func(t *testing.T) {
+		// when
+		_, reimported := test.ImportSpaceOK(t, svc.Context, svc, ctrl, payload)
+		// then
+		assert.Equal(t, spaceID, *reimported.Data.ID)
+		_, count, err := wiRepo.List(ctx, spaceID, criteria.Literal(true), nil, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	rest.T().Run("import again with a missing space resource", func(t *testing.T) {
+		// given
+		resourceRepo := space.NewResourceRepository(rest.DB)
+		existing, err := resourceRepo.LoadBySpace(ctx, &spaceID)
+		require.Nil(t, err)
+		require.Nil(t, resourceRepo.Delete(ctx, existing.ID))
+		// when
+		test.ImportSpaceOK(t, svc.Context, svc, ctrl, payload)
+		// then
+		_, err = resourceRepo.LoadBySpace(ctx, &spaceID)
+		assert.Nil(t, err)
+	})
+
+	rest.T().Run("import again as another user", func(t *testing.T) {
+		identity, err := testsupport.CreateTestIdentity(rest.DB, "space-archive-"+uuid.NewV4().String(), "test")
+		require.Nil(t, err)
+		svc, ctrl := rest.SecuredController(identity)
+		test.ImportSpaceForbidden(t, svc.Context, svc, ctrl, payload)
+	})
+}
+
+func (rest *TestSpaceArchiveREST) TestExportNotOwner() {
+	// given
+	spaceID := rest.createPopulatedSpace()
+	identity, err := testsupport.CreateTestIdentity(rest.DB, "space-archive-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+	svc, ctrl := rest.SecuredController(identity)
+	// when/then
+	test.ExportSpaceForbidden(rest.T(), svc.Context, svc, ctrl, spaceID)
+}
+
+func (rest *TestSpaceArchiveREST) TestImportInvalidArchive() {
+	// given
+	payload := rest.export(rest.T(), rest.createPopulatedSpace())
+	payload.Data["format_version"] = 1000
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when/then
+	test.ImportSpaceBadRequest(rest.T(), svc.Context, svc, ctrl, payload)
+}
+
+func (rest *TestSpaceArchiveREST) TestImportRemapsWorkItemReferences() {
+	// given the work items of the archive are numbered 11 and 12
+	ctx := context.Background()
+	payload := rest.export(rest.T(), rest.createPopulatedSpace())
+	for i, wi := range payload.Data["work_items"].([]interface{}) {
+		wi.(map[string]interface{})["number"] = 11 + i
+	}
+	cm := payload.Data["comments"].([]interface{})[0].(map[string]interface{})
+	cm["body"] = "Blocked by #12, see other-space#12"
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when
+	_, imported := test.ImportSpaceCreated(rest.T(), svc.Context, svc, ctrl, payload)
+	// then
+	wi, err := workitem.NewWorkItemRepository(rest.DB).Load(ctx, *imported.Data.ID, 1)
+	require.Nil(rest.T(), err)
+	comments, _, err := comment.NewRepository(rest.DB).List(ctx, wi.ID, nil, nil)
+	require.Nil(rest.T(), err)
+	require.Len(rest.T(), comments, 1)
+	assert.Equal(rest.T(), "Blocked by #2, see other-space#12", comments[0].Body)
+}
+
+func (rest *TestSpaceArchiveREST) TestImportSavesMentionsAndReferences() {
+	// given the description of the first work item and its comment mention
+	// the test identity and reference the second work item
+	ctx := context.Background()
+	payload := rest.export(rest.T(), rest.createPopulatedSpace())
+	wi := payload.Data["work_items"].([]interface{})[0].(map[string]interface{})
+	revisions := wi["revisions"].([]interface{})
+	revisions[len(revisions)-1].(map[string]interface{})["fields"].(map[string]interface{})[workitem.SystemDescription] = map[string]interface{}{
+		rendering.ContentKey: "Blocked by #2, cc @" + testsupport.TestIdentity.Username,
+		rendering.MarkupKey:  rendering.SystemMarkupMarkdown,
+	}
+	cm := payload.Data["comments"].([]interface{})[0].(map[string]interface{})
+	cm["body"] = "Discussed in #2 with @" + testsupport.TestIdentity.Username
+	cm["markup"] = rendering.SystemMarkupMarkdown
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when
+	_, imported := test.ImportSpaceCreated(rest.T(), svc.Context, svc, ctrl, payload)
+	// then
+	wiRepo := workitem.NewWorkItemRepository(rest.DB)
+	first, err := wiRepo.Load(ctx, *imported.Data.ID, 1)
+	require.Nil(rest.T(), err)
+	second, err := wiRepo.Load(ctx, *imported.Data.ID, 2)
+	require.Nil(rest.T(), err)
+	comments, _, err := comment.NewRepository(rest.DB).List(ctx, first.ID, nil, nil)
+	require.Nil(rest.T(), err)
+	require.Len(rest.T(), comments, 1)
+	for _, commentID := range []*uuid.UUID{nil, &comments[0].ID} {
+		references, err := reference.NewRepository(rest.DB).List(ctx, first.ID, commentID)
+		require.Nil(rest.T(), err)
+		require.Len(rest.T(), references, 1)
+		assert.Equal(rest.T(), second.ID, references[0].WorkItemID)
+		mentions, err := mention.NewRepository(rest.DB).List(ctx, first.ID, commentID)
+		require.Nil(rest.T(), err)
+		require.Len(rest.T(), mentions, 1)
+		assert.Equal(rest.T(), testsupport.TestIdentity.ID, mentions[0].IdentityID)
+	}
+}
+
+func (rest *TestSpaceArchiveREST) TestImportUnknownLinkCategory() {
+	// given a link category of the archive does not exist
+	payload := rest.export(rest.T(), rest.createPopulatedSpace())
+	categories, _ := payload.Data["link_categories"].([]interface{})
+	payload.Data["link_categories"] = append(categories, map[string]interface{}{
+		"id":   uuid.NewV4().String(),
+		"name": "TestSpaceArchive-" + uuid.NewV4().String(),
+	})
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when/then
+	test.ImportSpaceBadRequest(rest.T(), svc.Context, svc, ctrl, payload)
+}
+
+func (rest *TestSpaceArchiveREST) TestImportInvalidWorkItemFields() {
+	// given the latest revision of a work item has no title
+	payload := rest.export(rest.T(), rest.createPopulatedSpace())
+	wi := payload.Data["work_items"].([]interface{})[0].(map[string]interface{})
+	revisions := wi["revisions"].([]interface{})
+	delete(revisions[len(revisions)-1].(map[string]interface{})["fields"].(map[string]interface{}), workitem.SystemTitle)
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when/then
+	test.ImportSpaceBadRequest(rest.T(), svc.Context, svc, ctrl, payload)
+}
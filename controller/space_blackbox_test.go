@@ -13,6 +13,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/auth"
 	"github.com/fabric8-services/fabric8-wit/configuration"
 	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormapplication"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
@@ -21,6 +22,7 @@ import (
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
 	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -267,6 +269,68 @@ func (rest *TestSpaceREST) TestUpdateSpaceConflict() {
 	test.UpdateSpaceConflict(rest.T(), svc.Context, svc, ctrl, *created.Data.ID, u)
 }
 
+func (rest *TestSpaceREST) TestArchiveSpace() {
+	// given
+	name := testsupport.CreateRandomValidTestName("TestArchiveSpace-")
+	p := minimumRequiredCreateSpace()
+	p.Data.Attributes.Name = &name
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	_, created := test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
+	spaceID := *created.Data.ID
+	archived := true
+	u := minimumRequiredUpdateSpace()
+	u.Data.ID = created.Data.ID
+	u.Data.Attributes.Version = created.Data.Attributes.Version
+	u.Data.Attributes.Name = &name
+	u.Data.Attributes.Archived = &archived
+	// when
+	_, updated := test.UpdateSpaceOK(rest.T(), svc.Context, svc, ctrl, spaceID, u)
+	// then
+	require.True(rest.T(), *updated.Data.Attributes.Archived)
+	listed := func(filterArchived *bool) bool {
+		limit := 1000
+		_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, filterArchived, &limit, nil, nil, nil)
+		for _, s := range list.Data {
+			if *s.ID == spaceID {
+				return true
+			}
+		}
+		return false
+	}
+	assert.False(rest.T(), listed(nil))
+	assert.True(rest.T(), listed(&archived))
+
+	rest.T().Run("read-only", func(t *testing.T) {
+		itr := iteration.Iteration{
+			Name:    "Sprint 1",
+			SpaceID: spaceID,
+		}
+		err := rest.iterationRepo.Create(context.Background(), &itr)
+		require.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+
+	rest.T().Run("unarchive by another user", func(t *testing.T) {
+		notArchived := false
+		u.Data.Attributes.Version = updated.Data.Attributes.Version
+		u.Data.Attributes.Archived = &notArchived
+		svc2, ctrl2 := rest.SecuredController(testsupport.TestIdentity2)
+		test.UpdateSpaceForbidden(t, svc2.Context, svc2, ctrl2, spaceID, u)
+	})
+
+	rest.T().Run("unarchive by the owner", func(t *testing.T) {
+		notArchived := false
+		u.Data.Attributes.Version = updated.Data.Attributes.Version
+		u.Data.Attributes.Archived = &notArchived
+		_, unarchived := test.UpdateSpaceOK(t, svc.Context, svc, ctrl, spaceID, u)
+		assert.False(t, *unarchived.Data.Attributes.Archived)
+		itr := iteration.Iteration{
+			Name:    "Sprint 1",
+			SpaceID: spaceID,
+		}
+		require.Nil(t, rest.iterationRepo.Create(context.Background(), &itr))
+	})
+}
+
 func (rest *TestSpaceREST) TestFailUpdateSpaceNameLength() {
 	// given
 	name := testsupport.CreateRandomValidTestName("TestFailUpdateSpaceNameLength-")
@@ -523,7 +587,7 @@ func (rest *TestSpaceREST) TestListSpacesOK() {
 	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
 	test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
 	// when
-	_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil)
+	_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(rest.T(), list)
 	require.NotEmpty(rest.T(), list.Data)
@@ -533,7 +597,7 @@ func (rest *TestSpaceREST) TestListSpacesUnauthorized() {
 	// given
 	svc, ctrl := rest.UnSecuredController()
 	// then
-	test.ListSpaceUnauthorized(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil)
+	test.ListSpaceUnauthorized(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil, nil)
 }
 
 func (rest *TestSpaceREST) TestListSpacesOKUsingExpiredIfModifiedSinceHeader() {
@@ -545,7 +609,7 @@ func (rest *TestSpaceREST) TestListSpacesOKUsingExpiredIfModifiedSinceHeader() {
 	test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
 	// when
 	ifModifiedSince := app.ToHTTPTime(time.Now().Add(-1 * time.Hour))
-	_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, &ifModifiedSince, nil)
+	_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(rest.T(), list)
 	require.NotEmpty(rest.T(), list.Data)
@@ -560,7 +624,7 @@ func (rest *TestSpaceREST) TestListSpacesOKUsingExpiredIfNoneMatchHeader() {
 	test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
 	// when
 	ifNoneMatch := "fooo-spaces"
-	_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, &ifNoneMatch)
+	_, list := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(rest.T(), list)
 	require.NotEmpty(rest.T(), list.Data)
@@ -575,7 +639,7 @@ func (rest *TestSpaceREST) TestListSpacesNotModifiedUsingIfModifiedSinceHeader()
 	_, createdSpace := test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
 	// when/then
 	ifModifiedSince := app.ToHTTPTime(*createdSpace.Data.Attributes.UpdatedAt)
-	test.ListSpaceNotModified(rest.T(), svc.Context, svc, ctrl, nil, nil, &ifModifiedSince, nil)
+	test.ListSpaceNotModified(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, &ifModifiedSince, nil)
 }
 
 func (rest *TestSpaceREST) TestListSpacesNotModifiedUsingIfNoneMatchHeader() {
@@ -585,10 +649,10 @@ func (rest *TestSpaceREST) TestListSpacesNotModifiedUsingIfNoneMatchHeader() {
 	p.Data.Attributes.Name = &name
 	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
 	test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
-	_, spaceList := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil)
+	_, spaceList := test.ListSpaceOK(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil, nil)
 	// when/then
 	ifNoneMatch := generateSpacesTag(*spaceList)
-	test.ListSpaceNotModified(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, &ifNoneMatch)
+	test.ListSpaceNotModified(rest.T(), svc.Context, svc, ctrl, nil, nil, nil, nil, &ifNoneMatch)
 }
 
 func (rest *TestSpaceREST) TestSuccessCreateSameSpaceNameDifferentOwners() {
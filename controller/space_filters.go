@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/criteria"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SpaceFiltersController implements the space_filters resource.
+type SpaceFiltersController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewSpaceFiltersController creates a space_filters controller.
+func NewSpaceFiltersController(service *goa.Service, db application.DB) *SpaceFiltersController {
+	return &SpaceFiltersController{
+		Controller: service.NewController("SpaceFiltersController"),
+		db:         db,
+	}
+}
+
+// authorizeFilterEditor returns an error unless the given identity is allowed
+// to save, change or delete a filter with the given scope and creator in the
+// given space: the user filters belong to their creator, while the space
+// filters are managed by the collaborators of the space.
+func authorizeFilterEditor(ctx context.Context, spaceID uuid.UUID, identityID uuid.UUID, scope string, creatorID uuid.UUID) error {
+	if scope == filter.ScopeUser {
+		if !uuid.Equal(identityID, creatorID) {
+			return errors.NewForbiddenError("user filters can only be managed by their creator")
+		}
+		return nil
+	}
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return errors.NewForbiddenError("user is not authorized to access the space")
+	}
+	return nil
+}
+
+// List runs the list action.
+func (c *SpaceFiltersController) List(ctx *app.ListSpaceFiltersContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID.String()); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		filters, err := appl.Filters().List(ctx, ctx.SpaceID, currentUserIdentityID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.SavedFilterList{
+			Data: make([]*app.SavedFilterData, len(filters)),
+			Meta: &app.SavedFilterListMeta{TotalCount: len(filters)},
+		}
+		for i, f := range filters {
+			res.Data[i] = ConvertSavedFilter(ctx.RequestData, f)
+		}
+		return ctx.OK(res)
+	})
+}
+
+// Show runs the show action.
+func (c *SpaceFiltersController) Show(ctx *app.ShowSpaceFiltersContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		f, err := appl.Filters().Load(ctx, ctx.SpaceID, ctx.FilterID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if !f.VisibleTo(currentUserIdentityID) {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("filter", ctx.FilterID.String()))
+		}
+		return ctx.OK(&app.SavedFilterSingle{
+			Data: ConvertSavedFilter(ctx.RequestData, *f),
+		})
+	})
+}
+
+// Create runs the create action.
+func (c *SpaceFiltersController) Create(ctx *app.CreateSpaceFiltersContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.Title == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.title", nil).Expected("not nil"))
+	}
+	newFilter := filter.Filter{
+		SpaceID:     ctx.SpaceID,
+		CreatorID:   *currentUserIdentityID,
+		Scope:       filter.ScopeUser,
+		Name:        *attrs.Title,
+		Description: attrs.Description,
+	}
+	if attrs.Scope != nil {
+		newFilter.Scope = *attrs.Scope
+	}
+	if attrs.Query != nil {
+		newFilter.Query = *attrs.Query
+	}
+	if attrs.Sort != nil {
+		newFilter.Sort = *attrs.Sort
+	}
+	if err := authorizeFilterEditor(ctx, ctx.SpaceID, *currentUserIdentityID, newFilter.Scope, newFilter.CreatorID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID.String()); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		f, err := appl.Filters().Create(ctx, &newFilter)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.SavedFilterSingle{
+			Data: ConvertSavedFilter(ctx.RequestData, *f),
+		}
+		ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.RequestData, app.SpaceFiltersHref(ctx.SpaceID, f.ID)))
+		return ctx.Created(res)
+	})
+}
+
+// Update runs the update action.
+func (c *SpaceFiltersController) Update(ctx *app.UpdateSpaceFiltersContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		f, err := appl.Filters().Load(ctx, ctx.SpaceID, ctx.FilterID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if !f.VisibleTo(currentUserIdentityID) {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("filter", ctx.FilterID.String()))
+		}
+		if err := authorizeFilterEditor(ctx, ctx.SpaceID, *currentUserIdentityID, f.Scope, f.CreatorID); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		f.Version = *attrs.Version
+		if attrs.Title != nil {
+			f.Name = *attrs.Title
+		}
+		if attrs.Description != nil {
+			f.Description = attrs.Description
+		}
+		if attrs.Query != nil {
+			f.Query = *attrs.Query
+		}
+		if attrs.Sort != nil {
+			f.Sort = *attrs.Sort
+		}
+		if attrs.Scope != nil && *attrs.Scope != f.Scope {
+			// sharing a filter with the space or taking it back requires the
+			// permissions of both scopes
+			f.Scope = *attrs.Scope
+			if err := authorizeFilterEditor(ctx, ctx.SpaceID, *currentUserIdentityID, f.Scope, f.CreatorID); err != nil {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
+		}
+		f, err = appl.Filters().Save(ctx, *f)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.OK(&app.SavedFilterSingle{
+			Data: ConvertSavedFilter(ctx.RequestData, *f),
+		})
+	})
+}
+
+// Delete runs the delete action.
+func (c *SpaceFiltersController) Delete(ctx *app.DeleteSpaceFiltersContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		f, err := appl.Filters().Load(ctx, ctx.SpaceID, ctx.FilterID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if !f.VisibleTo(currentUserIdentityID) {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("filter", ctx.FilterID.String()))
+		}
+		if err := authorizeFilterEditor(ctx, ctx.SpaceID, *currentUserIdentityID, f.Scope, f.CreatorID); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if err := appl.Filters().Delete(ctx, ctx.SpaceID, ctx.FilterID); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.OK([]byte{})
+	})
+}
+
+// loadSavedFilter returns the saved filter with the given ID of the given
+// space, provided it is visible to the current user, if any.
+func loadSavedFilter(ctx context.Context, appl application.Application, spaceID uuid.UUID, filterID uuid.UUID) (*filter.Filter, error) {
+	f, err := appl.Filters().Load(ctx, spaceID, filterID)
+	if err != nil {
+		return nil, err
+	}
+	// the work item lists do not require a token, so anonymous users can
+	// only execute the filters shared with the space
+	currentUserIdentityID, _ := login.ContextIdentity(ctx)
+	if !f.VisibleTo(currentUserIdentityID) {
+		return nil, errors.NewNotFoundError("filter", filterID.String())
+	}
+	return f, nil
+}
+
+// applySavedFilter restricts the given expression with the query of the saved
+// filter with the given ID and returns the sort keys of the saved filter
+func applySavedFilter(ctx context.Context, appl application.Application, spaceID uuid.UUID, filterID uuid.UUID, exp criteria.Expression) (criteria.Expression, []workitem.SortKey, error) {
+	f, err := loadSavedFilter(ctx, appl, spaceID, filterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	savedExp, err := f.Expression()
+	if err != nil {
+		return nil, nil, err
+	}
+	sort, err := f.SortKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	return criteria.And(exp, savedExp), sort, nil
+}
+
+// ConvertSavedFilter converts a saved filter from the model to the app representation
+func ConvertSavedFilter(request *goa.RequestData, f filter.Filter) *app.SavedFilterData {
+	selfURL := rest.AbsoluteURL(request, app.SpaceFiltersHref(f.SpaceID, f.ID))
+	spaceSelfURL := rest.AbsoluteURL(request, app.SpaceHref(f.SpaceID.String()))
+	creatorID := f.CreatorID.String()
+	creatorType := APIStringTypeUser
+	creatorSelfURL := rest.AbsoluteURL(request, app.UsersHref(creatorID))
+	return &app.SavedFilterData{
+		Type: filter.APIStringTypeFilters,
+		ID:   &f.ID,
+		Attributes: &app.SavedFilterAttributes{
+			Title:       &f.Name,
+			Description: f.Description,
+			Query:       &f.Query,
+			Sort:        &f.Sort,
+			Scope:       &f.Scope,
+			Version:     &f.Version,
+			CreatedAt:   &f.CreatedAt,
+			UpdatedAt:   &f.UpdatedAt,
+		},
+		Relationships: &app.SavedFilterRelationships{
+			Space: app.NewSpaceRelation(f.SpaceID, spaceSelfURL),
+			Creator: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &creatorType,
+					ID:   &creatorID,
+				},
+				Links: &app.GenericLinks{
+					Related: &creatorSelfURL,
+				},
+			},
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	almtoken "github.com/fabric8-services/fabric8-wit/token"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
+
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRunSpaceFiltersREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestSpaceFiltersREST{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+type TestSpaceFiltersREST struct {
+	gormtestsupport.DBTestSuite
+	clean        func()
+	testIdentity account.Identity
+	svc          *goa.Service
+	filtersCtrl  *SpaceFiltersController
+	workitemCtrl *WorkitemController
+	spaceID      uuid.UUID
+}
+
+func (rest *TestSpaceFiltersREST) SetupSuite() {
+	rest.DBTestSuite.SetupSuite()
+	ctx := migration.NewMigrationContext(context.Background())
+	rest.DBTestSuite.PopulateDBTestSuite(ctx)
+}
+
+func (rest *TestSpaceFiltersREST) SetupTest() {
+	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
+	testIdentity, err := testsupport.CreateTestIdentity(rest.DB, "TestSpaceFiltersREST-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+	rest.testIdentity = testIdentity
+	priKey, err := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	require.Nil(rest.T(), err)
+	rest.svc = testsupport.ServiceAsUser("SpaceFilters-Service", almtoken.NewManagerWithPrivateKey(priKey), testIdentity)
+	rest.filtersCtrl = NewSpaceFiltersController(rest.svc, gormapplication.NewGormDB(rest.DB))
+	rest.workitemCtrl = NewWorkitemController(rest.svc, gormapplication.NewGormDB(rest.DB), rest.Configuration)
+
+	sp, err := space.NewRepository(rest.DB).Create(context.Background(), &space.Space{
+		Name:    "TestSpaceFiltersREST-" + uuid.NewV4().String(),
+		OwnerId: testIdentity.ID,
+	})
+	require.Nil(rest.T(), err)
+	rest.spaceID = sp.ID
+	wiRepo := workitem.NewWorkItemRepository(rest.DB)
+	for title, state := range map[string]string{"beta": workitem.SystemStateOpen, "alpha": workitem.SystemStateOpen, "gamma": workitem.SystemStateClosed} {
+		_, err := wiRepo.Create(context.Background(), sp.ID, workitem.SystemBug, map[string]interface{}{
+			workitem.SystemTitle: title,
+			workitem.SystemState: state,
+		}, testIdentity.ID)
+		require.Nil(rest.T(), err)
+	}
+}
+
+func (rest *TestSpaceFiltersREST) TearDownTest() {
+	rest.clean()
+}
+
+func (rest *TestSpaceFiltersREST) createFilter(title string, scope string) *app.SavedFilterData {
+	query := `{"system.state":"open"}`
+	sort := "system.title"
+	payload := app.CreateSpaceFiltersPayload{
+		Data: &app.SavedFilterData{
+			Type: filter.APIStringTypeFilters,
+			Attributes: &app.SavedFilterAttributes{
+				Title: &title,
+				Query: &query,
+				Sort:  &sort,
+				Scope: &scope,
+			},
+		},
+	}
+	_, created := test.CreateSpaceFiltersCreated(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, &payload)
+	require.NotNil(rest.T(), created.Data.ID)
+	return created.Data
+}
+
+func (rest *TestSpaceFiltersREST) TestCreateAndList() {
+	shared := rest.createFilter("shared", filter.ScopeSpace)
+	private := rest.createFilter("private", filter.ScopeUser)
+
+	_, list := test.ListSpaceFiltersOK(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID)
+	require.Len(rest.T(), list.Data, 2)
+	assert.Equal(rest.T(), *private.ID, *list.Data[0].ID)
+	assert.Equal(rest.T(), *shared.ID, *list.Data[1].ID)
+	assert.Equal(rest.T(), rest.testIdentity.ID.String(), *list.Data[0].Relationships.Creator.Data.ID)
+
+	_, shown := test.ShowSpaceFiltersOK(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, *shared.ID)
+	assert.Equal(rest.T(), "shared", *shown.Data.Attributes.Title)
+	assert.Equal(rest.T(), filter.ScopeSpace, *shown.Data.Attributes.Scope)
+}
+
+func (rest *TestSpaceFiltersREST) TestCreateInvalidQuery() {
+	title := "invalid"
+	query := "system.state=open"
+	payload := app.CreateSpaceFiltersPayload{
+		Data: &app.SavedFilterData{
+			Type: filter.APIStringTypeFilters,
+			Attributes: &app.SavedFilterAttributes{
+				Title: &title,
+				Query: &query,
+			},
+		},
+	}
+	test.CreateSpaceFiltersBadRequest(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, &payload)
+}
+
+func (rest *TestSpaceFiltersREST) TestUpdateAndDelete() {
+	f := rest.createFilter("before", filter.ScopeUser)
+	title := "after"
+	payload := app.UpdateSpaceFiltersPayload{
+		Data: &app.SavedFilterData{
+			Type: filter.APIStringTypeFilters,
+			Attributes: &app.SavedFilterAttributes{
+				Title:   &title,
+				Version: f.Attributes.Version,
+			},
+		},
+	}
+	_, updated := test.UpdateSpaceFiltersOK(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, *f.ID, &payload)
+	assert.Equal(rest.T(), "after", *updated.Data.Attributes.Title)
+	assert.Equal(rest.T(), *f.Attributes.Query, *updated.Data.Attributes.Query)
+	// stale version
+	test.UpdateSpaceFiltersConflict(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, *f.ID, &payload)
+
+	test.DeleteSpaceFiltersOK(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, *f.ID)
+	test.ShowSpaceFiltersNotFound(rest.T(), rest.svc.Context, rest.svc, rest.filtersCtrl, rest.spaceID, *f.ID)
+}
+
+func (rest *TestSpaceFiltersREST) TestListWorkItemsWithSavedFilter() {
+	shared := rest.createFilter("shared", filter.ScopeSpace)
+	private := rest.createFilter("private", filter.ScopeUser)
+
+	rest.T().Run("query and sort of the filter", func(t *testing.T) {
+		_, result := test.ListWorkitemOK(t, rest.svc.Context, rest.svc, rest.workitemCtrl, rest.spaceID, nil, nil, nil, shared.ID, nil, nil, nil, nil, nil, nil, nil, nil)
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, "alpha", result.Data[0].Attributes[workitem.SystemTitle])
+		assert.Equal(t, "beta", result.Data[1].Attributes[workitem.SystemTitle])
+		assert.Contains(t, *result.Links.First, "filter[id]="+shared.ID.String())
+	})
+
+	rest.T().Run("anonymous user", func(t *testing.T) {
+		_, result := test.ListWorkitemOK(t, context.Background(), nil, rest.workitemCtrl, rest.spaceID, nil, nil, nil, shared.ID, nil, nil, nil, nil, nil, nil, nil, nil)
+		require.Len(t, result.Data, 2)
+		// the user filters are not visible to the other users
+		test.ListWorkitemNotFound(t, context.Background(), nil, rest.workitemCtrl, rest.spaceID, nil, nil, nil, private.ID, nil, nil, nil, nil, nil, nil, nil, nil)
+	})
+
+	rest.T().Run("unknown filter", func(t *testing.T) {
+		unknownID := uuid.NewV4()
+		test.ListWorkitemNotFound(t, rest.svc.Context, rest.svc, rest.workitemCtrl, rest.spaceID, nil, nil, nil, &unknownID, nil, nil, nil, nil, nil, nil, nil, nil)
+	})
+}
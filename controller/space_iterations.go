@@ -19,6 +19,7 @@ import (
 
 type SpaceIterationsControllerConfiguration interface {
 	GetCacheControlIterations() string
+	GetIterationPointsField() string
 }
 
 // SpaceIterationsController implements the space-iterations resource.
@@ -144,3 +145,41 @@ func (c *SpaceIterationsController) List(ctx *app.ListSpaceIterationsContext) er
 		})
 	})
 }
+
+// Velocity runs the velocity action.
+func (c *SpaceIterationsController) Velocity(ctx *app.VelocitySpaceIterationsContext) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		err := appl.Spaces().CheckExists(ctx, ctx.SpaceID.String())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		pointsField, err := iterationPointsField(ctx, appl, ctx.SpaceID, ctx.Field, c.config.GetIterationPointsField())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		velocities, err := appl.WorkItems().GetVelocityPerIteration(ctx, ctx.SpaceID, pointsField)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.IterationVelocityList{
+			Data: make([]*app.IterationVelocity, len(velocities)),
+		}
+		for i, v := range velocities {
+			res.Data[i] = &app.IterationVelocity{
+				Type: "velocities",
+				ID:   v.Iteration.ID,
+				Attributes: &app.IterationVelocityAttributes{
+					Name:            v.Iteration.Name,
+					StartAt:         v.Iteration.StartAt,
+					EndAt:           v.Iteration.EndAt,
+					CommittedCount:  v.CommittedCount,
+					CommittedPoints: v.CommittedPoints,
+					CompletedCount:  v.CompletedCount,
+					CompletedPoints: v.CompletedPoints,
+				},
+				Relationships: iterationReportRelationships(ctx.RequestData, v.Iteration.ID),
+			}
+		}
+		return ctx.OK(res)
+	})
+}
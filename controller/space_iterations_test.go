@@ -167,6 +167,11 @@ func (rest *TestSpaceIterationREST) TestSuccessCreateIterationWithOptionalValues
 	// create another Iteration with nil description
 	iterationName2 := "Sprint #23"
 	ci = createSpaceIteration(iterationName2, nil)
+	// sibling iterations can not overlap
+	nextStart := ci.Data.Attributes.EndAt.Add(time.Hour)
+	nextEnd := nextStart.Add(time.Hour * (24 * 8 * 3))
+	ci.Data.Attributes.StartAt = &nextStart
+	ci.Data.Attributes.EndAt = &nextEnd
 	_, c = test.CreateSpaceIterationsCreated(rest.T(), svc.Context, svc, ctrl, p.ID, ci)
 	assert.Equal(rest.T(), *c.Data.Attributes.Name, iterationName2)
 	assert.Nil(rest.T(), c.Data.Attributes.Description)
@@ -247,6 +252,89 @@ func (rest *TestSpaceIterationREST) TestFailListIterationsByMissingSpace() {
 	test.ListSpaceIterationsNotFound(rest.T(), svc.Context, svc, ctrl, uuid.NewV4(), nil, nil)
 }
 
+func (rest *TestSpaceIterationREST) TestVelocity() {
+	// given
+	sp, _, rootItr, _, _ := createSpaceAndRootAreaAndIterations(rest.T(), rest.db)
+	start := time.Now().Add(-96 * time.Hour)
+	end := time.Now().Add(-48 * time.Hour)
+	pastItr := iteration.Iteration{
+		Name:    "Past Sprint",
+		SpaceID: sp.ID,
+		StartAt: &start,
+		EndAt:   &end,
+		Path:    append(rootItr.Path, rootItr.ID),
+	}
+	require.Nil(rest.T(), rest.db.Iterations().Create(rest.ctx, &pastItr))
+	wirepo := workitem.NewWorkItemRepository(rest.DB)
+	for _, state := range []string{workitem.SystemStateNew, workitem.SystemStateClosed} {
+		wi, err := wirepo.Create(
+			rest.ctx, sp.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Issue",
+				workitem.SystemState:     state,
+				workitem.SystemIteration: pastItr.ID.String(),
+			}, rest.testIdentity.ID)
+		require.Nil(rest.T(), err)
+		err = rest.DB.Model(&workitem.Revision{}).Where("work_item_id = ?", wi.ID).Update("revision_time", start).Error
+		require.Nil(rest.T(), err)
+	}
+	svc, ctrl := rest.UnSecuredController()
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// when
+		_, velocities := test.VelocitySpaceIterationsOK(t, svc.Context, svc, ctrl, sp.ID, nil)
+		// then
+		require.Len(t, velocities.Data, 1)
+		assert.Equal(t, pastItr.ID, velocities.Data[0].ID)
+		assert.Equal(t, pastItr.Name, velocities.Data[0].Attributes.Name)
+		assert.Equal(t, 2, velocities.Data[0].Attributes.CommittedCount)
+		assert.Equal(t, 1, velocities.Data[0].Attributes.CompletedCount)
+	})
+
+	rest.T().Run("unknown points field", func(t *testing.T) {
+		// when
+		field := "unknown"
+		test.VelocitySpaceIterationsBadRequest(t, svc.Context, svc, ctrl, sp.ID, &field)
+	})
+
+	rest.T().Run("unknown space", func(t *testing.T) {
+		// when
+		test.VelocitySpaceIterationsNotFound(t, svc.Context, svc, ctrl, uuid.NewV4(), nil)
+	})
+
+	rest.T().Run("points field", func(t *testing.T) {
+		// given
+		extended := workitem.SystemBug
+		wit, err := workitem.NewWorkItemTypeRepository(rest.DB).Create(rest.ctx, sp.ID, nil, &extended, "story", nil, "fa-bomb", map[string]workitem.FieldDefinition{
+			"velocitypoints": {
+				Label: "Points",
+				Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+			},
+		})
+		require.Nil(t, err)
+		for state, points := range map[string]float64{workitem.SystemStateNew: 3, workitem.SystemStateClosed: 5} {
+			wi, err := wirepo.Create(
+				rest.ctx, sp.ID, wit.ID,
+				map[string]interface{}{
+					workitem.SystemTitle:     "Story",
+					workitem.SystemState:     state,
+					workitem.SystemIteration: pastItr.ID.String(),
+					"velocitypoints":         points,
+				}, rest.testIdentity.ID)
+			require.Nil(t, err)
+			err = rest.DB.Model(&workitem.Revision{}).Where("work_item_id = ?", wi.ID).Update("revision_time", start).Error
+			require.Nil(t, err)
+		}
+		field := "velocitypoints"
+		// when
+		_, velocities := test.VelocitySpaceIterationsOK(t, svc.Context, svc, ctrl, sp.ID, &field)
+		// then
+		require.Len(t, velocities.Data, 1)
+		assert.Equal(t, float64(8), velocities.Data[0].Attributes.CommittedPoints)
+		assert.Equal(t, float64(5), velocities.Data[0].Attributes.CompletedPoints)
+	})
+}
+
 // Following is behaviour of the test that verifies the WI Count in an iteration
 // Consider, iteration i1 has 2 children c1 & c2
 // Total WI for i1 = WI assigned to i1 + WI assigned to c1 + WI assigned to c2
@@ -521,7 +609,8 @@ func (rest *TestSpaceIterationREST) createIterations() (spaceID uuid.UUID, fathe
 		}
 		spaceID = p.ID
 		for i := 0; i < 3; i++ {
-			start := time.Now()
+			// sibling iterations can not overlap
+			start := time.Now().Add(time.Duration(i) * time.Hour * (24 * 8 * 3))
 			end := start.Add(time.Hour * (24 * 8 * 3))
 			name := "Sprint Test #" + strconv.Itoa(i)
 			i := iteration.Iteration{
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/auth"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SpaceOwnershipController implements the space_ownership resource.
+type SpaceOwnershipController struct {
+	*goa.Controller
+	db            application.DB
+	config        SpaceOwnershipConfiguration
+	policyManager auth.AuthzPolicyManager
+}
+
+// SpaceOwnershipConfiguration represents the configuration of the space
+// ownership transfers
+type SpaceOwnershipConfiguration interface {
+	GetSpaceAdmins() []string
+}
+
+// NewSpaceOwnershipController creates a space_ownership controller.
+func NewSpaceOwnershipController(service *goa.Service, db application.DB, config SpaceOwnershipConfiguration, policyManager auth.AuthzPolicyManager) *SpaceOwnershipController {
+	return &SpaceOwnershipController{Controller: service.NewController("SpaceOwnershipController"), db: db, config: config, policyManager: policyManager}
+}
+
+// Transfer runs the transfer action.
+func (c *SpaceOwnershipController) Transfer(ctx *app.TransferSpaceOwnershipContext) error {
+	currentUser, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	if ctx.Payload.Data == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data", nil).Expected("not nil"))
+	}
+	newOwnerID, err := uuid.FromString(ctx.Payload.Data.ID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.id", ctx.Payload.Data.ID).Expected("identity ID"))
+	}
+
+	var s *space.Space
+	nameClash := false
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		var err error
+		s, err = appl.Spaces().Load(ctx, ctx.SpaceID)
+		if err != nil {
+			return err
+		}
+		if !uuid.Equal(*currentUser, s.OwnerId) && !isSpaceAdmin(c.config.GetSpaceAdmins(), *currentUser) {
+			log.Error(ctx, map[string]interface{}{"currentUser": *currentUser, "owner": s.OwnerId}, "Current user is neither the space owner nor a space admin")
+			return errors.NewForbiddenError("user is not the space owner")
+		}
+		if err := appl.Identities().CheckExists(ctx, newOwnerID.String()); err != nil {
+			if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+				return errors.NewBadParameterError("data.id", newOwnerID).Expected("existing identity ID")
+			}
+			return err
+		}
+		if uuid.Equal(newOwnerID, s.OwnerId) {
+			return nil
+		}
+		// the named spaces are looked up by owner and name, so the space
+		// must not clash with the spaces of the new owner
+		_, err = appl.Spaces().LoadByOwnerAndName(ctx, &newOwnerID, &s.Name)
+		if err == nil {
+			nameClash = true
+			return nil
+		}
+		if _, ok := errs.Cause(err).(errors.NotFoundError); !ok {
+			return err
+		}
+		log.Info(ctx, map[string]interface{}{
+			"space_id":       s.ID,
+			"previous_owner": s.OwnerId,
+			"owner":          newOwnerID,
+		}, "transferring space ownership")
+		s.OwnerId = newOwnerID
+		s, err = appl.Spaces().Save(ctx, s)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	if nameClash {
+		jerrors, _ := jsonapi.ErrorToJSONAPIErrors(goa.ErrInvalidRequest(fmt.Sprintf("the new owner already has a space named %s, which must be renamed before the transfer", s.Name)))
+		return ctx.Conflict(jerrors)
+	}
+	// the policy is updated once the new owner is committed, and even if the
+	// owner did not change, so that transferring the space again to the same
+	// owner repairs a failed update
+	if err := c.addCollaborator(ctx, s.ID, newOwnerID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	spaceData, err := ConvertSpaceFromModel(ctx.Context, c.db, ctx.RequestData, *s)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return ctx.OK(&app.SpaceSingle{Data: spaceData})
+}
+
+// addCollaborator adds the given identity to the collaborators policy of the
+// space, so that the new owner can manage the space collaborators while the
+// previous owner stays a collaborator until removed, and updates the space
+// resource to trigger the refreshing of the RPT tokens
+func (c *SpaceOwnershipController) addCollaborator(ctx *app.TransferSpaceOwnershipContext, spaceID uuid.UUID, identityID uuid.UUID) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		resource, err := appl.SpaceResources().LoadBySpace(ctx, &spaceID)
+		if err != nil {
+			return err
+		}
+		policy, pat, err := c.policyManager.GetPolicy(ctx, ctx.RequestData, resource.PolicyID)
+		if err != nil {
+			return errors.NewInternalError(ctx, err)
+		}
+		if !c.policyManager.AddUserToPolicy(policy, identityID.String()) {
+			// already a collaborator
+			return nil
+		}
+		if err := c.policyManager.UpdatePolicy(ctx, ctx.RequestData, *policy, *pat); err != nil {
+			return errors.NewInternalError(ctx, err)
+		}
+		_, err = appl.SpaceResources().Save(ctx, resource)
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"resource":   resource,
+				"space_uuid": spaceID.String(),
+				"err":        err,
+			}, "unable to update the space resource")
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,206 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	"github.com/fabric8-services/fabric8-wit/auth"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	almtoken "github.com/fabric8-services/fabric8-wit/token"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ownershipPolicyManager keeps the collaborators policy of the spaces in
+// memory, and fails to update it while failUpdate is set
+type ownershipPolicyManager struct {
+	policy     *auth.KeycloakPolicy
+	failUpdate bool
+}
+
+func (m *ownershipPolicyManager) GetPolicy(ctx context.Context, request *goa.RequestData, policyID string) (*auth.KeycloakPolicy, *string, error) {
+	pat := ""
+	policy := *m.policy
+	return &policy, &pat, nil
+}
+
+func (m *ownershipPolicyManager) UpdatePolicy(ctx context.Context, request *goa.RequestData, policy auth.KeycloakPolicy, pat string) error {
+	if m.failUpdate {
+		return errs.New("unable to update the policy")
+	}
+	m.policy = &policy
+	return nil
+}
+
+func (m *ownershipPolicyManager) AddUserToPolicy(p *auth.KeycloakPolicy, userID string) bool {
+	return p.AddUserToPolicy(userID)
+}
+
+func (m *ownershipPolicyManager) RemoveUserFromPolicy(p *auth.KeycloakPolicy, userID string) bool {
+	return p.RemoveUserFromPolicy(userID)
+}
+
+type TestSpaceOwnershipREST struct {
+	gormtestsupport.DBTestSuite
+	db            *gormapplication.GormDB
+	clean         func()
+	policyManager *ownershipPolicyManager
+	owner         account.Identity
+	newOwner      account.Identity
+}
+
+func TestRunSpaceOwnershipREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestSpaceOwnershipREST{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (rest *TestSpaceOwnershipREST) SetupTest() {
+	rest.db = gormapplication.NewGormDB(rest.DB)
+	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
+	rest.policyManager = &ownershipPolicyManager{policy: &auth.KeycloakPolicy{
+		Name:             "TestSpaceOwnership-" + uuid.NewV4().String(),
+		Type:             auth.PolicyTypeUser,
+		Logic:            auth.PolicyLogicPossitive,
+		DecisionStrategy: auth.PolicyDecisionStrategyUnanimous,
+	}}
+	var err error
+	rest.owner, err = testsupport.CreateTestIdentity(rest.DB, "TestSpaceOwnership-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+	rest.newOwner, err = testsupport.CreateTestIdentity(rest.DB, "TestSpaceOwnership-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+}
+
+func (rest *TestSpaceOwnershipREST) TearDownTest() {
+	rest.clean()
+}
+
+func (rest *TestSpaceOwnershipREST) SecuredController(identity account.Identity, admins ...string) (*goa.Service, *SpaceOwnershipController) {
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsUser("SpaceOwnership-Service", almtoken.NewManagerWithPrivateKey(priv), identity)
+	return svc, NewSpaceOwnershipController(svc, rest.db, spaceAdmins(admins), rest.policyManager)
+}
+
+// createSpace creates a space with the given name owned by the given identity
+func (rest *TestSpaceOwnershipREST) createSpace(identity account.Identity, name string) *app.SpaceSingle {
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsUser("Space-Service", almtoken.NewManagerWithPrivateKey(priv), identity)
+	ctrl := NewSpaceController(svc, rest.db, spaceConfiguration, &DummyResourceManager{})
+	_, created := test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, CreateSpacePayload(name, ""))
+	return created
+}
+
+func transferSpacePayload(identity account.Identity) *app.TransferSpacePayload {
+	return &app.TransferSpacePayload{
+		Data: &app.UpdateUserID{
+			ID:   identity.ID.String(),
+			Type: "identities",
+		},
+	}
+}
+
+func (rest *TestSpaceOwnershipREST) TestTransferByOwner() {
+	// given
+	name := testsupport.CreateRandomValidTestName("TestSpaceOwnership-")
+	sp := rest.createSpace(rest.owner, name)
+	svc, ctrl := rest.SecuredController(rest.owner)
+	// when
+	_, transferred := test.TransferSpaceOwnershipOK(rest.T(), svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.newOwner))
+	// then
+	assert.Equal(rest.T(), rest.newOwner.ID, *transferred.Data.Relationships.OwnedBy.Data.ID)
+	assert.Equal(rest.T(), name, *transferred.Data.Attributes.Name)
+	assert.Contains(rest.T(), rest.policyManager.policy.Config.UserIDs, rest.newOwner.ID.String())
+	s, err := rest.db.Spaces().LoadByOwnerAndName(context.Background(), &rest.newOwner.ID, &name)
+	require.Nil(rest.T(), err)
+	assert.Equal(rest.T(), *sp.Data.ID, s.ID)
+
+	rest.T().Run("previous owner can't transfer back", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController(rest.owner)
+		test.TransferSpaceOwnershipForbidden(t, svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.owner))
+	})
+}
+
+func (rest *TestSpaceOwnershipREST) TestTransferByAdmin() {
+	// given
+	sp := rest.createSpace(rest.owner, testsupport.CreateRandomValidTestName("TestSpaceOwnership-"))
+	admin, err := testsupport.CreateTestIdentity(rest.DB, "TestSpaceOwnership-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+
+	rest.T().Run("not an admin", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController(admin)
+		test.TransferSpaceOwnershipForbidden(t, svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.newOwner))
+	})
+
+	rest.T().Run("admin", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController(admin, admin.ID.String())
+		_, transferred := test.TransferSpaceOwnershipOK(t, svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.newOwner))
+		assert.Equal(t, rest.newOwner.ID, *transferred.Data.Relationships.OwnedBy.Data.ID)
+	})
+}
+
+func (rest *TestSpaceOwnershipREST) TestTransferNameClash() {
+	// given
+	name := testsupport.CreateRandomValidTestName("TestSpaceOwnership-")
+	sp := rest.createSpace(rest.owner, name)
+	rest.createSpace(rest.newOwner, name)
+	svc, ctrl := rest.SecuredController(rest.owner)
+	// when
+	test.TransferSpaceOwnershipConflict(rest.T(), svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.newOwner))
+	// then
+	s, err := rest.db.Spaces().Load(context.Background(), *sp.Data.ID)
+	require.Nil(rest.T(), err)
+	assert.Equal(rest.T(), rest.owner.ID, s.OwnerId)
+	assert.Equal(rest.T(), name, s.Name)
+	assert.NotContains(rest.T(), rest.policyManager.policy.Config.UserIDs, rest.newOwner.ID.String())
+}
+
+func (rest *TestSpaceOwnershipREST) TestTransferPolicyUpdateFailure() {
+	// given
+	sp := rest.createSpace(rest.owner, testsupport.CreateRandomValidTestName("TestSpaceOwnership-"))
+	svc, ctrl := rest.SecuredController(rest.owner)
+	rest.policyManager.failUpdate = true
+	// when
+	test.TransferSpaceOwnershipInternalServerError(rest.T(), svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.newOwner))
+	// then the new owner is committed before the policy is updated
+	s, err := rest.db.Spaces().Load(context.Background(), *sp.Data.ID)
+	require.Nil(rest.T(), err)
+	assert.Equal(rest.T(), rest.newOwner.ID, s.OwnerId)
+	assert.NotContains(rest.T(), rest.policyManager.policy.Config.UserIDs, rest.newOwner.ID.String())
+
+	rest.T().Run("transfer again", func(t *testing.T) {
+		// given
+		rest.policyManager.failUpdate = false
+		svc, ctrl := rest.SecuredController(rest.newOwner)
+		// when
+		_, transferred := test.TransferSpaceOwnershipOK(t, svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(rest.newOwner))
+		// then the policy is repaired
+		assert.Equal(t, rest.newOwner.ID, *transferred.Data.Relationships.OwnedBy.Data.ID)
+		assert.Contains(t, rest.policyManager.policy.Config.UserIDs, rest.newOwner.ID.String())
+	})
+}
+
+func (rest *TestSpaceOwnershipREST) TestTransferUnknownIdentity() {
+	// given
+	sp := rest.createSpace(rest.owner, testsupport.CreateRandomValidTestName("TestSpaceOwnership-"))
+	svc, ctrl := rest.SecuredController(rest.owner)
+	// when/then
+	test.TransferSpaceOwnershipBadRequest(rest.T(), svc.Context, svc, ctrl, *sp.Data.ID, transferSpacePayload(account.Identity{ID: uuid.NewV4()}))
+}
+
+func (rest *TestSpaceOwnershipREST) TestTransferUnknownSpace() {
+	// given
+	svc, ctrl := rest.SecuredController(rest.owner)
+	// when/then
+	test.TransferSpaceOwnershipNotFound(rest.T(), svc.Context, svc, ctrl, uuid.NewV4(), transferSpacePayload(rest.newOwner))
+}
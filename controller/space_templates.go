@@ -0,0 +1,564 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/area"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/path"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SpaceTemplatesController implements the space_templates resource.
+type SpaceTemplatesController struct {
+	*goa.Controller
+	db     application.DB
+	config SpaceTemplatesConfiguration
+}
+
+// SpaceTemplatesConfiguration represents the configuration of the space
+// templates
+type SpaceTemplatesConfiguration interface {
+	GetSpaceAdmins() []string
+}
+
+// NewSpaceTemplatesController creates a space_templates controller.
+func NewSpaceTemplatesController(service *goa.Service, db application.DB, config SpaceTemplatesConfiguration) *SpaceTemplatesController {
+	return &SpaceTemplatesController{
+		Controller: service.NewController("SpaceTemplatesController"),
+		db:         db,
+		config:     config,
+	}
+}
+
+// List runs the list action.
+func (c *SpaceTemplatesController) List(ctx *app.ListSpaceTemplatesContext) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		templates, err := appl.SpaceTemplates().List(ctx)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.SpaceTemplateList{
+			Data: make([]*app.SpaceTemplateData, len(templates)),
+			Meta: &app.SpaceTemplateListMeta{TotalCount: len(templates)},
+		}
+		for i, tpl := range templates {
+			res.Data[i] = ConvertSpaceTemplate(ctx.RequestData, tpl)
+		}
+		return ctx.OK(res)
+	})
+}
+
+// Show runs the show action.
+func (c *SpaceTemplatesController) Show(ctx *app.ShowSpaceTemplatesContext) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		tpl, err := appl.SpaceTemplates().Load(ctx, ctx.TemplateID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.OK(&app.SpaceTemplateSingle{
+			Data: ConvertSpaceTemplate(ctx.RequestData, *tpl),
+		})
+	})
+}
+
+// Create runs the create action.
+func (c *SpaceTemplatesController) Create(ctx *app.CreateSpaceTemplatesContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	reqTemplate := ctx.Payload.Data
+	if reqTemplate.Attributes.Name == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.name", nil).Expected("not nil"))
+	}
+	var spaceID *uuid.UUID
+	if rel := reqTemplate.Relationships; rel != nil && rel.Space != nil && rel.Space.Data != nil && rel.Space.Data.ID != nil {
+		id, err := uuid.FromString(*rel.Space.Data.ID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.space", *rel.Space.Data.ID))
+		}
+		spaceID = &id
+	}
+	// the templates are listed to every user, so they are only created by
+	// the space admins
+	if !isSpaceAdmin(c.config.GetSpaceAdmins(), *currentUserIdentityID) {
+		log.Warn(ctx, map[string]interface{}{
+			"current_user": *currentUserIdentityID,
+		}, "user is not a space admin")
+		return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not a space admin"))
+	}
+	var tpl *spacetemplate.Template
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		newTemplate := spacetemplate.Template{
+			Name:        *reqTemplate.Attributes.Name,
+			Description: reqTemplate.Attributes.Description,
+		}
+		if spaceID != nil {
+			s, err := appl.Spaces().Load(ctx, *spaceID)
+			if err != nil {
+				return err
+			}
+			if err := checkSpaceOwner(ctx, s, *currentUserIdentityID); err != nil {
+				return err
+			}
+			newTemplate.Definition, err = exportSpaceTemplate(ctx, appl, s.ID)
+			if err != nil {
+				return err
+			}
+		} else {
+			newTemplate.Definition, err = ConvertSpaceTemplateDefinitionToModel(reqTemplate.Attributes)
+			if err != nil {
+				return err
+			}
+			if err := validateSpaceTemplate(ctx, appl, newTemplate.Definition); err != nil {
+				return err
+			}
+		}
+		tpl, err = appl.SpaceTemplates().Create(ctx, &newTemplate)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	res := &app.SpaceTemplateSingle{
+		Data: ConvertSpaceTemplate(ctx.RequestData, *tpl),
+	}
+	ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.RequestData, app.SpaceTemplatesHref(tpl.ID)))
+	return ctx.Created(res)
+}
+
+// loadExtendedType returns the work item type of the system space extended
+// by a work item type of a space template
+func loadExtendedType(ctx context.Context, appl application.Application, id *uuid.UUID) (*workitem.WorkItemType, error) {
+	extendedTypeID := workitem.SystemPlannerItem
+	if id != nil {
+		extendedTypeID = *id
+	}
+	wit, err := appl.WorkItemTypes().Load(ctx, space.SystemSpace, extendedTypeID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("work_item_types.extended_type", extendedTypeID).Expected("work item type of the system space")
+	}
+	return wit, nil
+}
+
+// validateSpaceTemplate checks that the types extended by the work item types
+// of the given definition, and the categories of its link types, exist.
+func validateSpaceTemplate(ctx context.Context, appl application.Application, def spacetemplate.Definition) error {
+	for _, t := range def.WorkItemTypes {
+		if _, err := loadExtendedType(ctx, appl, t.ExtendedTypeID); err != nil {
+			return err
+		}
+	}
+	for _, lt := range def.LinkTypes {
+		if _, err := appl.WorkItemLinkCategories().Load(ctx, lt.LinkCategoryID); err != nil {
+			return errors.NewBadParameterError("link_types.link_category", lt.LinkCategoryID).Expected("existing work item link category")
+		}
+	}
+	return nil
+}
+
+// applySpaceTemplate creates the work item types, link types, areas and
+// iterations of the given template in the space of the given root area and
+// root iteration.
+func applySpaceTemplate(ctx context.Context, appl application.Application, tpl spacetemplate.Template, rootArea area.Area, rootIteration iteration.Iteration) error {
+	spaceID := rootArea.SpaceID
+	for _, t := range tpl.Definition.WorkItemTypes {
+		extendedType, err := loadExtendedType(ctx, appl, t.ExtendedTypeID)
+		if err != nil {
+			return err
+		}
+		fields := workitem.FieldDefinitions{}
+		for name, field := range extendedType.Fields {
+			fields[name] = field
+		}
+		for name, field := range t.Fields {
+			fields[name] = field
+		}
+		if len(t.States) > 0 {
+			values := make([]interface{}, len(t.States))
+			for i, state := range t.States {
+				values[i] = state
+			}
+			state := fields[workitem.SystemState]
+			if state.Label == "" {
+				state.Label = "State"
+			}
+			state.Required = true
+			state.Type = workitem.EnumType{
+				SimpleType: workitem.SimpleType{Kind: workitem.KindEnum},
+				BaseType:   workitem.SimpleType{Kind: workitem.KindString},
+				Values:     values,
+			}
+			fields[workitem.SystemState] = state
+		}
+		// the states may not be compatible with the ones of the extended type,
+		// so the type is created as is rather than through the checks of Create
+		id := uuid.NewV4()
+		_, err = appl.WorkItemTypes().CreateFromModel(ctx, &workitem.WorkItemType{
+			ID:          id,
+			Name:        t.Name,
+			Description: t.Description,
+			Icon:        t.Icon,
+			Path:        extendedType.Path + workitem.GetTypePathSeparator() + workitem.LtreeSafeID(id),
+			Fields:      fields,
+			SpaceID:     spaceID,
+		})
+		if err != nil {
+			return errs.Wrapf(err, "failed to create work item type: %s", t.Name)
+		}
+	}
+	for _, lt := range tpl.Definition.LinkTypes {
+		_, err := appl.WorkItemLinkTypes().Create(ctx, &link.WorkItemLinkType{
+			Name:           lt.Name,
+			Description:    lt.Description,
+			Topology:       lt.Topology,
+			ForwardName:    lt.ForwardName,
+			ReverseName:    lt.ReverseName,
+			LinkCategoryID: lt.LinkCategoryID,
+			SpaceID:        spaceID,
+		})
+		if err != nil {
+			return errs.Wrapf(err, "failed to create work item link type: %s", lt.Name)
+		}
+	}
+	if err := createTemplateAreas(ctx, appl, rootArea, tpl.Definition.Areas); err != nil {
+		return err
+	}
+	if cadence := tpl.Definition.Cadence; cadence != nil {
+		length := time.Duration(cadence.Days) * 24 * time.Hour
+		start := time.Now().UTC().Truncate(24 * time.Hour)
+		for i := 1; i <= cadence.Count; i++ {
+			startAt := start.Add(time.Duration(i-1) * length)
+			endAt := startAt.Add(length)
+			itr := iteration.Iteration{
+				SpaceID: spaceID,
+				Path:    append(path.Path{}, rootIteration.ID),
+				Name:    fmt.Sprintf("%s %d", cadence.Name, i),
+				StartAt: &startAt,
+				EndAt:   &endAt,
+			}
+			if err := appl.Iterations().Create(ctx, &itr); err != nil {
+				return errs.Wrapf(err, "failed to create iteration: %s", itr.Name)
+			}
+		}
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_id":          spaceID,
+		"space_template_id": tpl.ID,
+	}, "space template set up in the space")
+	return nil
+}
+
+// createTemplateAreas creates the given areas below the given parent area,
+// along with their children.
+func createTemplateAreas(ctx context.Context, appl application.Application, parent area.Area, areas spacetemplate.Areas) error {
+	for _, a := range areas {
+		childPath := append(path.Path{}, parent.Path...)
+		newArea := area.Area{
+			SpaceID: parent.SpaceID,
+			Path:    append(childPath, parent.ID),
+			Name:    a.Name,
+		}
+		if err := appl.Areas().Create(ctx, &newArea); err != nil {
+			return errs.Wrapf(err, "failed to create area: %s", a.Name)
+		}
+		if err := createTemplateAreas(ctx, appl, newArea, a.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterationNumberSuffix matches the number at the end of the name of an
+// iteration created for a cadence
+var iterationNumberSuffix = regexp.MustCompile(`\s*\d+$`)
+
+// exportSpaceTemplate returns the definition of a space template which sets
+// up the work item types, link types, areas and iterations of the given space.
+func exportSpaceTemplate(ctx context.Context, appl application.Application, spaceID uuid.UUID) (spacetemplate.Definition, error) {
+	def := spacetemplate.Definition{}
+	wits, err := appl.WorkItemTypes().List(ctx, spaceID, nil, nil)
+	if err != nil {
+		return def, errs.Wrap(err, "failed to list the work item types of the space")
+	}
+	sort.Slice(wits, func(i, j int) bool { return wits[i].Name < wits[j].Name })
+	for _, wit := range wits {
+		// the extended type is the parent of the type in its path
+		segments := strings.Split(wit.Path, workitem.GetTypePathSeparator())
+		if len(segments) < 2 {
+			return def, errors.NewBadParameterError("data.relationships.space", spaceID).Expected("space whose work item types extend the ones of the system space")
+		}
+		extendedTypeID, err := uuid.FromString(strings.Replace(segments[len(segments)-2], "_", "-", -1))
+		if err != nil {
+			return def, errs.Wrapf(err, "invalid path of work item type: %s", wit.Name)
+		}
+		extendedType, err := appl.WorkItemTypes().Load(ctx, space.SystemSpace, extendedTypeID)
+		if err != nil {
+			return def, errors.NewBadParameterError("data.relationships.space", spaceID).Expected("space whose work item types extend the ones of the system space")
+		}
+		t := spacetemplate.WorkItemType{
+			Name:        wit.Name,
+			Description: wit.Description,
+			Icon:        wit.Icon,
+		}
+		if !uuid.Equal(extendedTypeID, workitem.SystemPlannerItem) {
+			t.ExtendedTypeID = &extendedTypeID
+		}
+		for name, field := range wit.Fields {
+			if existing, ok := extendedType.Fields[name]; ok && existing.Equal(field) {
+				continue
+			}
+			if enum, ok := field.Type.(workitem.EnumType); ok && name == workitem.SystemState {
+				for _, value := range enum.Values {
+					t.States = append(t.States, fmt.Sprint(value))
+				}
+				continue
+			}
+			if t.Fields == nil {
+				t.Fields = workitem.FieldDefinitions{}
+			}
+			t.Fields[name] = field
+		}
+		def.WorkItemTypes = append(def.WorkItemTypes, t)
+	}
+	linkTypes, err := appl.WorkItemLinkTypes().List(ctx, spaceID)
+	if err != nil {
+		return def, errs.Wrap(err, "failed to list the work item link types of the space")
+	}
+	for _, lt := range linkTypes {
+		// the link types of the system space are available in every space
+		if !uuid.Equal(lt.SpaceID, spaceID) {
+			continue
+		}
+		def.LinkTypes = append(def.LinkTypes, spacetemplate.LinkType{
+			Name:           lt.Name,
+			Description:    lt.Description,
+			Topology:       lt.Topology,
+			ForwardName:    lt.ForwardName,
+			ReverseName:    lt.ReverseName,
+			LinkCategoryID: lt.LinkCategoryID,
+		})
+	}
+	areas, err := appl.Areas().List(ctx, spaceID)
+	if err != nil {
+		return def, errs.Wrap(err, "failed to list the areas of the space")
+	}
+	children := map[uuid.UUID][]area.Area{}
+	var rootArea *area.Area
+	for i, a := range areas {
+		if a.Path.IsEmpty() {
+			rootArea = &areas[i]
+			continue
+		}
+		children[a.Path.This()] = append(children[a.Path.This()], a)
+	}
+	if rootArea != nil {
+		def.Areas = exportTemplateAreas(children, rootArea.ID)
+	}
+	rootIteration, err := appl.Iterations().Root(ctx, spaceID)
+	if err != nil {
+		return def, errs.Wrap(err, "failed to load the root iteration of the space")
+	}
+	iterations, err := appl.Iterations().LoadChildren(ctx, rootIteration.ID)
+	if err != nil {
+		return def, errs.Wrap(err, "failed to list the iterations of the space")
+	}
+	var scheduled []iteration.Iteration
+	for _, itr := range iterations {
+		if len(itr.Path) == 1 && itr.StartAt != nil && itr.EndAt != nil && itr.EndAt.After(*itr.StartAt) {
+			scheduled = append(scheduled, itr)
+		}
+	}
+	if len(scheduled) > 0 {
+		sort.Slice(scheduled, func(i, j int) bool { return scheduled[i].StartAt.Before(*scheduled[j].StartAt) })
+		days := int(scheduled[0].EndAt.Sub(*scheduled[0].StartAt).Hours() / 24)
+		if days < 1 {
+			days = 1
+		}
+		name := iterationNumberSuffix.ReplaceAllString(scheduled[0].Name, "")
+		if name == "" {
+			name = "Iteration"
+		}
+		def.Cadence = &spacetemplate.Cadence{
+			Name:  name,
+			Days:  days,
+			Count: len(scheduled),
+		}
+	}
+	return def, nil
+}
+
+// exportTemplateAreas returns the areas below the area with the given ID,
+// along with their children, ordered by name.
+func exportTemplateAreas(children map[uuid.UUID][]area.Area, parentID uuid.UUID) spacetemplate.Areas {
+	areas := children[parentID]
+	if len(areas) == 0 {
+		return nil
+	}
+	sort.Slice(areas, func(i, j int) bool { return areas[i].Name < areas[j].Name })
+	res := make(spacetemplate.Areas, len(areas))
+	for i, a := range areas {
+		res[i] = spacetemplate.Area{
+			Name:     a.Name,
+			Children: exportTemplateAreas(children, a.ID),
+		}
+	}
+	return res
+}
+
+// ConvertSpaceTemplateDefinitionToModel converts the definition of a space
+// template from the REST representation to the model
+func ConvertSpaceTemplateDefinitionToModel(attrs *app.SpaceTemplateAttributes) (spacetemplate.Definition, error) {
+	def := spacetemplate.Definition{}
+	for _, t := range attrs.WorkItemTypes {
+		fields := map[string]app.FieldDefinition{}
+		for name, field := range t.Fields {
+			if field == nil || field.Type == nil {
+				return def, errors.NewBadParameterError(fmt.Sprintf("data.attributes.work-item-types.fields[%s]", name), nil).Expected("field type")
+			}
+			fields[name] = *field
+		}
+		modelFields, err := ConvertFieldDefinitionsToModel(fields)
+		if err != nil {
+			return def, errors.NewBadParameterError("data.attributes.work-item-types.fields", t.Fields).Expected(err.Error())
+		}
+		converted := spacetemplate.WorkItemType{
+			Name:           t.Name,
+			Description:    t.Description,
+			Icon:           t.Icon,
+			ExtendedTypeID: t.ExtendedType,
+			States:         t.States,
+		}
+		if len(modelFields) > 0 {
+			converted.Fields = modelFields
+		}
+		def.WorkItemTypes = append(def.WorkItemTypes, converted)
+	}
+	for _, lt := range attrs.LinkTypes {
+		def.LinkTypes = append(def.LinkTypes, spacetemplate.LinkType{
+			Name:           lt.Name,
+			Description:    lt.Description,
+			Topology:       lt.Topology,
+			ForwardName:    lt.ForwardName,
+			ReverseName:    lt.ReverseName,
+			LinkCategoryID: lt.LinkCategory,
+		})
+	}
+	def.Areas = convertSpaceTemplateAreasToModel(attrs.Areas)
+	if attrs.Cadence != nil {
+		def.Cadence = &spacetemplate.Cadence{
+			Name:  attrs.Cadence.Name,
+			Days:  attrs.Cadence.Days,
+			Count: attrs.Cadence.Count,
+		}
+	}
+	return def, nil
+}
+
+func convertSpaceTemplateAreasToModel(areas []*app.SpaceTemplateArea) spacetemplate.Areas {
+	if len(areas) == 0 {
+		return nil
+	}
+	res := make(spacetemplate.Areas, len(areas))
+	for i, a := range areas {
+		res[i] = spacetemplate.Area{
+			Name:     a.Name,
+			Children: convertSpaceTemplateAreasToModel(a.Children),
+		}
+	}
+	return res
+}
+
+func convertSpaceTemplateAreas(areas spacetemplate.Areas) []*app.SpaceTemplateArea {
+	res := make([]*app.SpaceTemplateArea, len(areas))
+	for i, a := range areas {
+		res[i] = &app.SpaceTemplateArea{
+			Name: a.Name,
+		}
+		if len(a.Children) > 0 {
+			res[i].Children = convertSpaceTemplateAreas(a.Children)
+		}
+	}
+	return res
+}
+
+// ConvertSpaceTemplate converts a space template from the model to the REST
+// representation
+func ConvertSpaceTemplate(request *goa.RequestData, tpl spacetemplate.Template) *app.SpaceTemplateData {
+	selfURL := rest.AbsoluteURL(request, app.SpaceTemplatesHref(tpl.ID))
+	createdAt := tpl.CreatedAt.UTC()
+	updatedAt := tpl.UpdatedAt.UTC()
+	attrs := &app.SpaceTemplateAttributes{
+		Name:          &tpl.Name,
+		Description:   tpl.Description,
+		Version:       &tpl.Version,
+		CreatedAt:     &createdAt,
+		UpdatedAt:     &updatedAt,
+		WorkItemTypes: make([]*app.SpaceTemplateWorkItemType, len(tpl.Definition.WorkItemTypes)),
+		LinkTypes:     make([]*app.SpaceTemplateLinkType, len(tpl.Definition.LinkTypes)),
+		Areas:         convertSpaceTemplateAreas(tpl.Definition.Areas),
+	}
+	for i, t := range tpl.Definition.WorkItemTypes {
+		attrs.WorkItemTypes[i] = &app.SpaceTemplateWorkItemType{
+			Name:         t.Name,
+			Description:  t.Description,
+			Icon:         t.Icon,
+			ExtendedType: t.ExtendedTypeID,
+			States:       t.States,
+			Fields:       map[string]*app.FieldDefinition{},
+		}
+		for name, def := range t.Fields {
+			ct := convertFieldTypeFromModel(def.Type)
+			attrs.WorkItemTypes[i].Fields[name] = &app.FieldDefinition{
+				Required:    def.Required,
+				Label:       def.Label,
+				Description: def.Description,
+				Type:        &ct,
+			}
+		}
+	}
+	for i, lt := range tpl.Definition.LinkTypes {
+		attrs.LinkTypes[i] = &app.SpaceTemplateLinkType{
+			Name:         lt.Name,
+			Description:  lt.Description,
+			Topology:     lt.Topology,
+			ForwardName:  lt.ForwardName,
+			ReverseName:  lt.ReverseName,
+			LinkCategory: lt.LinkCategoryID,
+		}
+	}
+	if c := tpl.Definition.Cadence; c != nil {
+		attrs.Cadence = &app.SpaceTemplateCadence{
+			Name:  c.Name,
+			Days:  c.Days,
+			Count: c.Count,
+		}
+	}
+	return &app.SpaceTemplateData{
+		Type:       spacetemplate.APIStringTypeSpaceTemplates,
+		ID:         &tpl.ID,
+		Attributes: attrs,
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+}
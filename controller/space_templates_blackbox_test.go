@@ -0,0 +1,292 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	almtoken "github.com/fabric8-services/fabric8-wit/token"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// spaceAdmins is a space templates and ownership configuration with the
+// given space admins
+type spaceAdmins []string
+
+func (a spaceAdmins) GetSpaceAdmins() []string {
+	return a
+}
+
+type TestSpaceTemplatesREST struct {
+	gormtestsupport.DBTestSuite
+	db    *gormapplication.GormDB
+	clean func()
+}
+
+func TestRunSpaceTemplatesREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestSpaceTemplatesREST{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+// SetupSuite overrides the DBTestSuite's function but calls it before doing anything else
+func (rest *TestSpaceTemplatesREST) SetupSuite() {
+	rest.DBTestSuite.SetupSuite()
+	ctx := migration.NewMigrationContext(context.Background())
+	rest.DBTestSuite.PopulateDBTestSuite(ctx)
+	// the link types of the templates belong to the user link category
+	err := migration.BootstrapWorkItemLinking(ctx, link.NewWorkItemLinkCategoryRepository(rest.DB), space.NewRepository(rest.DB), link.NewWorkItemLinkTypeRepository(rest.DB))
+	require.Nil(rest.T(), err)
+}
+
+func (rest *TestSpaceTemplatesREST) SetupTest() {
+	rest.db = gormapplication.NewGormDB(rest.DB)
+	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
+}
+
+func (rest *TestSpaceTemplatesREST) TearDownTest() {
+	rest.clean()
+}
+
+func (rest *TestSpaceTemplatesREST) SecuredController(identity account.Identity) (*goa.Service, *SpaceTemplatesController) {
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsUser("SpaceTemplates-Service", almtoken.NewManagerWithPrivateKey(priv), identity)
+	// the test identity is the space admin
+	return svc, NewSpaceTemplatesController(svc, rest.db, spaceAdmins{testsupport.TestIdentity.ID.String()})
+}
+
+func (rest *TestSpaceTemplatesREST) SecuredSpaceController(identity account.Identity) (*goa.Service, *SpaceController) {
+	priv, _ := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	svc := testsupport.ServiceAsUser("Space-Service", almtoken.NewManagerWithPrivateKey(priv), identity)
+	return svc, NewSpaceController(svc, rest.db, spaceConfiguration, &DummyResourceManager{})
+}
+
+// createSpace creates a space owned by the given identity, set up with the
+// given space template if any
+func (rest *TestSpaceTemplatesREST) createSpace(identity account.Identity, templateID *uuid.UUID) uuid.UUID {
+	p := CreateSpacePayload(testsupport.CreateRandomValidTestName("TestSpaceTemplates-"), "")
+	if templateID != nil {
+		id := templateID.String()
+		p.Data.Relationships = &app.SpaceRelationships{
+			Template: &app.RelationGeneric{
+				Data: &app.GenericData{ID: &id},
+			},
+		}
+	}
+	svc, ctrl := rest.SecuredSpaceController(identity)
+	_, created := test.CreateSpaceCreated(rest.T(), svc.Context, svc, ctrl, p)
+	return *created.Data.ID
+}
+
+func newCreateSpaceTemplatePayload(name string) *app.CreateSpaceTemplatePayload {
+	return &app.CreateSpaceTemplatePayload{
+		Data: &app.SpaceTemplateData{
+			Type: spacetemplate.APIStringTypeSpaceTemplates,
+			Attributes: &app.SpaceTemplateAttributes{
+				Name: &name,
+			},
+		},
+	}
+}
+
+func (rest *TestSpaceTemplatesREST) TestListBuiltIn() {
+	// given
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when
+	_, templates := test.ListSpaceTemplatesOK(rest.T(), svc.Context, svc, ctrl)
+	// then
+	names := map[string]bool{}
+	for _, tpl := range templates.Data {
+		names[*tpl.Attributes.Name] = true
+	}
+	assert.True(rest.T(), names["Scrum"])
+	assert.True(rest.T(), names["Kanban"])
+	assert.True(rest.T(), names["Issue Tracking"])
+	_, scrum := test.ShowSpaceTemplatesOK(rest.T(), svc.Context, svc, ctrl, spacetemplate.SystemScrum)
+	require.NotNil(rest.T(), scrum.Data.Attributes.Cadence)
+	assert.Equal(rest.T(), "Sprint", scrum.Data.Attributes.Cadence.Name)
+}
+
+func (rest *TestSpaceTemplatesREST) TestCreateSpaceWithTemplate() {
+	// given
+	ctx := context.Background()
+	templateID := spacetemplate.SystemScrum
+	// when
+	spaceID := rest.createSpace(testsupport.TestIdentity, &templateID)
+	// then
+	wits, err := workitem.NewWorkItemTypeRepository(rest.DB).List(ctx, spaceID, nil, nil)
+	require.Nil(rest.T(), err)
+	require.Len(rest.T(), wits, 4)
+	for _, wit := range wits {
+		assert.True(rest.T(), wit.IsTypeOrSubtypeOf(workitem.SystemPlannerItem), wit.Name)
+		if wit.Name == "User Story" {
+			assert.Contains(rest.T(), wit.Fields, "storypoints")
+			assert.Equal(rest.T(), []interface{}{"new", "ready", "in progress", "in review", "closed"}, wit.Fields[workitem.SystemState].Type.(workitem.EnumType).Values)
+		}
+	}
+	linkTypes, err := link.NewWorkItemLinkTypeRepository(rest.DB).List(ctx, spaceID)
+	require.Nil(rest.T(), err)
+	var duplicate *link.WorkItemLinkType
+	for i, lt := range linkTypes {
+		if uuid.Equal(lt.SpaceID, spaceID) {
+			duplicate = &linkTypes[i]
+		}
+	}
+	require.NotNil(rest.T(), duplicate)
+	assert.Equal(rest.T(), "Duplicate", duplicate.Name)
+	iterations, err := iteration.NewIterationRepository(rest.DB).List(ctx, spaceID)
+	require.Nil(rest.T(), err)
+	// the root iteration and the 4 sprints
+	assert.Len(rest.T(), iterations, 5)
+
+	rest.T().Run("unknown template", func(t *testing.T) {
+		id := uuid.NewV4().String()
+		p := CreateSpacePayload(testsupport.CreateRandomValidTestName("TestSpaceTemplates-"), "")
+		p.Data.Relationships = &app.SpaceRelationships{
+			Template: &app.RelationGeneric{
+				Data: &app.GenericData{ID: &id},
+			},
+		}
+		svc, ctrl := rest.SecuredSpaceController(testsupport.TestIdentity)
+		test.CreateSpaceBadRequest(t, svc.Context, svc, ctrl, p)
+	})
+}
+
+func (rest *TestSpaceTemplatesREST) TestCreateFromAttributes() {
+	// given
+	p := newCreateSpaceTemplatePayload(testsupport.CreateRandomValidTestName("Process-"))
+	p.Data.Attributes.WorkItemTypes = []*app.SpaceTemplateWorkItemType{
+		{
+			Name:   "Story",
+			Icon:   "fa fa-bookmark",
+			States: []string{"new", "done", "closed"},
+		},
+	}
+	p.Data.Attributes.Areas = []*app.SpaceTemplateArea{
+		{Name: "UI", Children: []*app.SpaceTemplateArea{{Name: "Web"}}},
+	}
+	svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+	// when
+	_, created := test.CreateSpaceTemplatesCreated(rest.T(), svc.Context, svc, ctrl, p)
+	// then
+	require.NotNil(rest.T(), created.Data.ID)
+	require.Len(rest.T(), created.Data.Attributes.WorkItemTypes, 1)
+	assert.Equal(rest.T(), []string{"new", "done", "closed"}, created.Data.Attributes.WorkItemTypes[0].States)
+	require.Len(rest.T(), created.Data.Attributes.Areas, 1)
+	assert.Equal(rest.T(), "Web", created.Data.Attributes.Areas[0].Children[0].Name)
+
+	rest.T().Run("states without closed", func(t *testing.T) {
+		p := newCreateSpaceTemplatePayload(testsupport.CreateRandomValidTestName("Process-"))
+		p.Data.Attributes.WorkItemTypes = []*app.SpaceTemplateWorkItemType{
+			{Name: "Story", Icon: "fa fa-bookmark", States: []string{"todo", "done"}},
+		}
+		test.CreateSpaceTemplatesBadRequest(t, svc.Context, svc, ctrl, p)
+	})
+
+	rest.T().Run("not a space admin", func(t *testing.T) {
+		identity, err := testsupport.CreateTestIdentity(rest.DB, "space-template-"+uuid.NewV4().String(), "test")
+		require.Nil(t, err)
+		svc, ctrl := rest.SecuredController(identity)
+		p := newCreateSpaceTemplatePayload(testsupport.CreateRandomValidTestName("Process-"))
+		// when/then
+		test.CreateSpaceTemplatesForbidden(t, svc.Context, svc, ctrl, p)
+	})
+
+	rest.T().Run("unknown extended type", func(t *testing.T) {
+		extendedType := uuid.NewV4()
+		p := newCreateSpaceTemplatePayload(testsupport.CreateRandomValidTestName("Process-"))
+		p.Data.Attributes.WorkItemTypes = []*app.SpaceTemplateWorkItemType{
+			{Name: "Story", Icon: "fa fa-bookmark", ExtendedType: &extendedType},
+		}
+		test.CreateSpaceTemplatesBadRequest(t, svc.Context, svc, ctrl, p)
+	})
+}
+
+func (rest *TestSpaceTemplatesREST) TestCreateFromSpace() {
+	// given
+	templateID := spacetemplate.SystemScrum
+	spaceID := rest.createSpace(testsupport.TestIdentity, &templateID)
+	p := newCreateSpaceTemplatePayload(testsupport.CreateRandomValidTestName("Exported-"))
+	id := spaceID.String()
+	p.Data.Relationships = &app.SpaceTemplateRelationships{
+		Space: &app.RelationGeneric{
+			Data: &app.GenericData{ID: &id},
+		},
+	}
+
+	rest.T().Run("ok", func(t *testing.T) {
+		svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+		// when
+		_, created := test.CreateSpaceTemplatesCreated(t, svc.Context, svc, ctrl, p)
+		// then
+		attrs := created.Data.Attributes
+		require.Len(t, attrs.WorkItemTypes, 4)
+		for _, wit := range attrs.WorkItemTypes {
+			if wit.Name == "User Story" {
+				assert.Equal(t, []string{"new", "ready", "in progress", "in review", "closed"}, wit.States)
+				assert.Contains(t, wit.Fields, "storypoints")
+			}
+		}
+		require.Len(t, attrs.LinkTypes, 1)
+		assert.Equal(t, "Duplicate", attrs.LinkTypes[0].Name)
+		require.NotNil(t, attrs.Cadence)
+		assert.Equal(t, app.SpaceTemplateCadence{Name: "Sprint", Days: 14, Count: 4}, *attrs.Cadence)
+	})
+
+	rest.T().Run("space admin who is not the space owner", func(t *testing.T) {
+		// given
+		identity, err := testsupport.CreateTestIdentity(rest.DB, "space-template-"+uuid.NewV4().String(), "test")
+		require.Nil(t, err)
+		otherSpaceID := rest.createSpace(identity, &templateID)
+		otherID := otherSpaceID.String()
+		other := newCreateSpaceTemplatePayload(testsupport.CreateRandomValidTestName("Exported-"))
+		other.Data.Relationships = &app.SpaceTemplateRelationships{
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{ID: &otherID},
+			},
+		}
+		svc, ctrl := rest.SecuredController(testsupport.TestIdentity)
+		// when/then
+		test.CreateSpaceTemplatesForbidden(t, svc.Context, svc, ctrl, other)
+	})
+
+	rest.T().Run("space owner who is not a space admin", func(t *testing.T) {
+		// given
+		identity, err := testsupport.CreateTestIdentity(rest.DB, "space-template-"+uuid.NewV4().String(), "test")
+		require.Nil(t, err)
+		ownSpaceID := rest.createSpace(identity, &templateID)
+		ownID := ownSpaceID.String()
+		name := testsupport.CreateRandomValidTestName("Exported-")
+		own := newCreateSpaceTemplatePayload(name)
+		own.Data.Relationships = &app.SpaceTemplateRelationships{
+			Space: &app.RelationGeneric{
+				Data: &app.GenericData{ID: &ownID},
+			},
+		}
+		svc, ctrl := rest.SecuredController(identity)
+		// when
+		test.CreateSpaceTemplatesForbidden(t, svc.Context, svc, ctrl, own)
+		// then the template is not listed to the other users
+		_, templates := test.ListSpaceTemplatesOK(t, svc.Context, svc, ctrl)
+		for _, tpl := range templates.Data {
+			assert.NotEqual(t, name, *tpl.Attributes.Name)
+		}
+	})
+}
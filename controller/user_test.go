@@ -22,11 +22,18 @@ import (
 	. "github.com/fabric8-services/fabric8-wit/controller"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/mention"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
 	"github.com/goadesign/goa"
 	"github.com/goadesign/goa/middleware/security/jwt"
 	"github.com/jinzhu/gorm"
@@ -371,6 +378,66 @@ func (g *GormTestBase) Codebases() codebase.Repository {
 	return nil
 }
 
+// WorkItemLinkRevisions returns a work item link revision repository
+func (g *GormTestBase) WorkItemLinkRevisions() link.RevisionRepository {
+	return nil
+}
+
+// WorkItemEvents returns a work item event repository
+func (g *GormTestBase) WorkItemEvents() event.Repository {
+	return nil
+}
+
+// WorkItemTemplates returns a work item template repository
+func (g *GormTestBase) WorkItemTemplates() template.Repository {
+	return nil
+}
+
+// Filters returns a saved filter repository
+func (g *GormTestBase) Filters() filter.Repository {
+	return nil
+}
+
+// CommentReactions returns a comment reactions repository
+func (g *GormTestBase) CommentReactions() comment.ReactionRepository {
+	return nil
+}
+
+// Mentions returns the mentions of the identities
+func (g *GormTestBase) Mentions() mention.Repository {
+	return nil
+}
+
+// WorkItemReferences returns the references to the work items
+func (g *GormTestBase) WorkItemReferences() reference.Repository {
+	return nil
+}
+
+// IterationCarryOvers returns the work items carried over when closing iterations
+func (g *GormTestBase) IterationCarryOvers() iteration.CarryOverRepository {
+	return nil
+}
+
+// IterationCapacities returns the capacities of the collaborators during iterations
+func (g *GormTestBase) IterationCapacities() iteration.CapacityRepository {
+	return nil
+}
+
+// SpaceTemplates returns a space template repository
+func (g *GormTestBase) SpaceTemplates() spacetemplate.Repository {
+	return nil
+}
+
+// SpaceImportMappings returns a space import mapping repository
+func (g *GormTestBase) SpaceImportMappings() archive.MappingRepository {
+	return nil
+}
+
+// WorkItemRevisions returns a work item revision repository
+func (g *GormTestBase) WorkItemRevisions() workitem.RevisionRepository {
+	return nil
+}
+
 func (g *GormTestBase) DB() *gorm.DB {
 	return nil
 }
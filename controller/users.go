@@ -14,6 +14,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/goadesign/goa"
 	goajwt "github.com/goadesign/goa/middleware/security/jwt"
@@ -431,6 +432,73 @@ func (c *UsersController) List(ctx *app.ListUsersContext) error {
 	})
 }
 
+// Mentions runs the mentions action. Only the mentions in the spaces of which
+// the current user is a collaborator are listed.
+func (c *UsersController) Mentions(ctx *app.MentionsUsersContext) error {
+	if _, err := login.ContextIdentity(ctx); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, goa.ErrUnauthorized(err.Error()))
+	}
+	identityID, err := uuid.FromString(ctx.ID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errs.Wrap(errors.NewBadParameterError("identity_id", ctx.ID), err.Error()))
+	}
+	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
+	var spaceIDs []uuid.UUID
+	err = application.Transactional(c.db, func(appl application.Application) error {
+		if _, err := appl.Identities().Load(ctx, identityID); err != nil {
+			return err
+		}
+		spaceIDs, err = appl.Mentions().ListSpaces(ctx, identityID)
+		return err
+	})
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	visibleSpaceIDs := []uuid.UUID{}
+	for _, spaceID := range spaceIDs {
+		authorized, err := authz.Authorize(ctx, spaceID.String())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+		}
+		if authorized {
+			visibleSpaceIDs = append(visibleSpaceIDs, spaceID)
+		}
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		mentions, count, err := appl.Mentions().ListByIdentity(ctx, identityID, visibleSpaceIDs, &offset, &limit)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		workItemIDs := make([]uuid.UUID, len(mentions))
+		for i, m := range mentions {
+			workItemIDs[i] = m.WorkItemID
+		}
+		workItems, err := appl.WorkItems().LoadBatchByID(ctx, workItemIDs)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "unable to load the work items of the mentions"))
+		}
+		workItemsByID := make(map[uuid.UUID]*workitem.WorkItem, len(workItems))
+		for _, wi := range workItems {
+			workItemsByID[wi.ID] = wi
+		}
+		data := make([]*app.MentionData, len(mentions))
+		for i, m := range mentions {
+			wi, ok := workItemsByID[m.WorkItemID]
+			if !ok {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("work item", m.WorkItemID.String()))
+			}
+			data[i] = ConvertMention(ctx.RequestData, m, *wi)
+		}
+		response := app.MentionList{
+			Links: &app.PagingLinks{},
+			Meta:  &app.MentionListMeta{TotalCount: count},
+			Data:  data,
+		}
+		setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(mentions), offset, limit, count)
+		return ctx.OK(&response)
+	})
+}
+
 func filterUsers(appl application.Application, ctx *app.ListUsersContext) ([]account.User, []account.Identity, error) {
 	var err error
 	var resultUsers []account.User
@@ -10,6 +10,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/app/test"
+	"github.com/fabric8-services/fabric8-wit/comment"
 	. "github.com/fabric8-services/fabric8-wit/controller"
 	"github.com/fabric8-services/fabric8-wit/gormapplication"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
@@ -266,11 +267,11 @@ func (s *workItemChildSuite) TestChildren() {
 	s.linkWorkItems(s.bug1, s.bug3)
 
 	s.T().Run("show action has children", func(t *testing.T) {
-		_, workItem := test.ShowWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+		_, workItem := test.ShowWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 		checkChildrenRelationship(t, workItem.Data, hasChildren)
 	})
 	s.T().Run("show action has no children", func(t *testing.T) {
-		_, workItem := test.ShowWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug3.Data.ID, nil, nil)
+		_, workItem := test.ShowWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug3.Data.ID.String(), nil, nil)
 		checkChildrenRelationship(t, workItem.Data, hasNoChildren)
 	})
 	s.T().Run("list ok", func(t *testing.T) {
@@ -321,7 +322,7 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 		// given
 		var pe *bool
 		// when
-		_, result := test.ListWorkitemOK(t, nil, nil, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, pe, nil, nil, nil, nil, nil, nil)
+		_, result := test.ListWorkitemOK(t, nil, nil, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, pe, nil, nil, nil, nil, nil, nil)
 		// then
 		assert.Len(t, result.Data, 3)
 	})
@@ -330,7 +331,7 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 		// given
 		pe := false
 		// when
-		_, result2 := test.ListWorkitemOK(t, nil, nil, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil)
+		_, result2 := test.ListWorkitemOK(t, nil, nil, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil)
 		// then
 		assert.Len(t, result2.Data, 1)
 	})
@@ -339,7 +340,7 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 		// given
 		pe := true
 		// when
-		_, result2 := test.ListWorkitemOK(t, nil, nil, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil)
+		_, result2 := test.ListWorkitemOK(t, nil, nil, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, &pe, nil, nil, nil, nil, nil, nil)
 		// then
 		assert.Len(t, result2.Data, 3)
 	})
@@ -354,11 +355,11 @@ func (s *workItemChildSuite) TestWorkItemListFilterByNoParents() {
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOK() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -366,13 +367,13 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOK() {
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingExpiredIfModifiedSinceHeader() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &ifModifiedSince, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -380,7 +381,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingExpiredIfMod
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingIfModifiedSinceHeader() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug2)
@@ -388,7 +389,7 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingIfModifiedSi
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
 	log.Warn(nil, map[string]interface{}{"wi_id": *s.bug1.Data.ID}, "Using ifModifiedSince=%v", ifModifiedSince)
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &ifModifiedSince, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -396,12 +397,12 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingIfModifiedSi
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingExpiredIfNoneMatchHeader() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, &ifNoneMatch)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -409,12 +410,12 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingExpiredIfNon
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenShowOKUsingIfNoneMatchHeader() {
 	// given
-	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, &ifNoneMatch)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -424,11 +425,11 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenShowOK() {
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
@@ -438,13 +439,13 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenShowOKUsingExp
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &ifModifiedSince, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
@@ -454,14 +455,14 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenShowOKUsingIfM
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	time.Sleep(1 * time.Second)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &ifModifiedSince, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
@@ -471,12 +472,12 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenShowOKUsingExp
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, &ifNoneMatch)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
@@ -486,12 +487,12 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenShowOKUsingIfN
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, &ifNoneMatch)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
@@ -501,11 +502,11 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOK() {
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -515,7 +516,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingExp
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	// add another link
 	time.Sleep(1 * time.Second)
@@ -523,7 +524,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingExp
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &ifModifiedSince, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -533,7 +534,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingIfM
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	// add another link
 	time.Sleep(1 * time.Second)
@@ -541,7 +542,7 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingIfM
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &ifModifiedSince, nil)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -551,14 +552,14 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingExp
 	// given
 	// create a link
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	// add another link
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, &ifNoneMatch)
+	_, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -568,14 +569,14 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingIfN
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	// add another link
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	res, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, &ifNoneMatch)
+	res, workitemSingle = test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemSingle)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
@@ -584,11 +585,11 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenShowOKUsingIfN
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOK() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -596,13 +597,13 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOK() {
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingExpiredIfModifiedSinceHeader() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -610,14 +611,14 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingExpiredIfMod
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenOKThenListUsingIfModifiedSinceHeader() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -625,12 +626,12 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenOKThenListUsingIfModifiedSi
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingExpiredIfNoneMatchHeader() {
 	// given
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -638,13 +639,13 @@ func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingExpiredIfNon
 
 func (s *workItemChildSuite) TestCreateLinkToChildrenThenListOKUsingIfNoneMatchHeader() {
 	// given
-	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasNoChildren)
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug2)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -654,11 +655,11 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkThenListToChildrenOK() {
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasNoChildren)
@@ -668,13 +669,13 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingExp
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasNoChildren)
@@ -684,14 +685,14 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingIfM
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	time.Sleep(1 * time.Second)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasNoChildren)
@@ -701,12 +702,12 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingExp
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasNoChildren)
@@ -716,13 +717,13 @@ func (s *workItemChildSuite) TestCreateAndDeleteLinkToChildrenThenListOKUsingIfN
 	// given
 	// create a link then remove it
 	workitemLink12 := s.linkWorkItems(s.bug1, s.bug2)
-	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	time.Sleep(1 * time.Second)
 	test.DeleteWorkItemLinkOK(s.T(), s.svc.Context, s.svc, s.workitemLinkCtrl, *workitemLink12.Data.ID)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasNoChildren)
@@ -732,11 +733,11 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOK() {
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -746,13 +747,13 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingExp
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt.Add(-1 * time.Hour))
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -762,14 +763,14 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingIfM
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when/then
 	updatedAt := workitemSingle.Data.Attributes[workitem.SystemUpdatedAt].(time.Time)
 	ifModifiedSince := app.ToHTTPTime(updatedAt)
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifModifiedSince, nil)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -779,12 +780,12 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingExp
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	_, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
 	ifNoneMatch := "foo"
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -794,13 +795,13 @@ func (s *workItemChildSuite) TestCreateAndUpdateLinkToChildrenThenListOKUsingIfN
 	// given
 	// create a link then add another one
 	s.linkWorkItems(s.bug1, s.bug2)
-	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, nil, nil)
+	res, workitemSingle := test.ShowWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, s.bug1.Data.ID.String(), nil, nil)
 	checkChildrenRelationship(s.T(), workitemSingle.Data, hasChildren)
 	time.Sleep(1 * time.Second)
 	s.linkWorkItems(s.bug1, s.bug3)
 	// when
 	ifNoneMatch := res.Header()[app.ETag][0]
-	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
+	_, workitemList := test.ListWorkitemOK(s.T(), s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &ifNoneMatch)
 	// then
 	require.NotNil(s.T(), workitemList)
 	checkChildrenRelationship(s.T(), lookupWorkitem(s.T(), *workitemList, *s.bug1.Data.ID), hasChildren)
@@ -815,3 +816,127 @@ func lookupWorkitem(t *testing.T, wiList app.WorkItemList, wiID uuid.UUID) *app.
 	t.Error(fmt.Sprintf("Failed to look-up work item with id='%s'", wiID))
 	return nil
 }
+
+func (s *workItemChildSuite) TestCopy() {
+	// given bug1 as the parent of bug2 and bug3
+	s.linkWorkItems(s.bug1, s.bug2)
+	s.linkWorkItems(s.bug1, s.bug3)
+
+	s.T().Run("copy single work item", func(t *testing.T) {
+		// given
+		payload := app.CopyWorkitemPayload{
+			Data: &app.WorkItemCopy{
+				Type: APIStringTypeWorkItemCopies,
+			},
+		}
+		// when
+		_, result := test.CopyWorkitemCreated(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &payload)
+		// then
+		require.NotNil(t, result.Data.Attributes)
+		require.Len(t, result.Data.Attributes.Mapping, 1)
+		copyID := result.Data.Attributes.Mapping[s.bug1.Data.ID.String()]
+		_, copiedWI := test.ShowWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, copyID.String(), nil, nil)
+		assert.Equal(t, "bug1", copiedWI.Data.Attributes[workitem.SystemTitle])
+		checkChildrenRelationship(t, copiedWI.Data, hasNoChildren)
+	})
+
+	s.T().Run("copy subtree", func(t *testing.T) {
+		// given
+		subtree := true
+		resetState := true
+		payload := app.CopyWorkitemPayload{
+			Data: &app.WorkItemCopy{
+				Type: APIStringTypeWorkItemCopies,
+				Attributes: &app.WorkItemCopyAttributes{
+					Subtree:    &subtree,
+					ResetState: &resetState,
+				},
+			},
+		}
+		// when
+		_, result := test.CopyWorkitemCreated(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &payload)
+		// then
+		require.NotNil(t, result.Data.Attributes)
+		require.Len(t, result.Data.Attributes.Mapping, 3)
+		copyID := result.Data.Attributes.Mapping[s.bug1.Data.ID.String()]
+		_, children := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, copyID, nil, nil, nil, nil)
+		require.Len(t, children.Data, 2)
+		for _, child := range children.Data {
+			assert.Equal(t, workitem.SystemStateNew, child.Attributes[workitem.SystemState])
+			assert.NotEqual(t, *s.bug2.Data.ID, *child.ID)
+			assert.NotEqual(t, *s.bug3.Data.ID, *child.ID)
+		}
+	})
+
+	s.T().Run("copy comments", func(t *testing.T) {
+		// given a comment by another user
+		otherIdentity, err := testsupport.CreateTestIdentity(s.DB, "TestCopy-"+uuid.NewV4().String(), "test provider")
+		require.Nil(t, err)
+		c := comment.Comment{
+			ParentID:  *s.bug1.Data.ID,
+			Body:      "a comment",
+			CreatedBy: otherIdentity.ID,
+		}
+		require.Nil(t, s.db.Comments().Create(context.Background(), &c, otherIdentity.ID))
+		copyComments := true
+		payload := app.CopyWorkitemPayload{
+			Data: &app.WorkItemCopy{
+				Type: APIStringTypeWorkItemCopies,
+				Attributes: &app.WorkItemCopyAttributes{
+					Comments: &copyComments,
+				},
+			},
+		}
+		// when
+		_, result := test.CopyWorkitemCreated(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &payload)
+		// then the copied comment is attributed to the copying user
+		copyID := result.Data.Attributes.Mapping[s.bug1.Data.ID.String()]
+		comments, _, err := s.db.Comments().List(context.Background(), copyID, nil, nil)
+		require.Nil(t, err)
+		require.Len(t, comments, 1)
+		assert.Equal(t, "a comment", comments[0].Body)
+		assert.Equal(t, *s.bug1.Data.Relationships.Creator.Data.ID, comments[0].CreatedBy.String())
+	})
+
+	s.T().Run("copy subtree to another space", func(t *testing.T) {
+		// given
+		_, targetSpace := test.CreateSpaceCreated(t, s.svc.Context, s.svc, s.spaceCtrl, CreateSpacePayload("TestCopy-"+uuid.NewV4().String(), ""))
+		targetSpaceID := *targetSpace.Data.ID
+		subtree := true
+		payload := app.CopyWorkitemPayload{
+			Data: &app.WorkItemCopy{
+				Type: APIStringTypeWorkItemCopies,
+				Attributes: &app.WorkItemCopyAttributes{
+					Subtree: &subtree,
+				},
+				Relationships: &app.WorkItemCopyRelationships{
+					Space: app.NewSpaceRelation(targetSpaceID, ""),
+				},
+			},
+		}
+		t.Run("fail - link type not available in the target space", func(t *testing.T) {
+			test.CopyWorkitemBadRequest(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &payload)
+		})
+		t.Run("ok - link type with the same name in the target space", func(t *testing.T) {
+			// given
+			_, linkCategory := test.CreateWorkItemLinkCategoryCreated(t, s.svc.Context, s.svc, s.workitemLinkCategoryCtrl, newCreateWorkItemLinkCategoryPayload("TestCopy-"+uuid.NewV4().String()))
+			_, targetLinkType := test.CreateWorkItemLinkTypeCreated(t, s.svc.Context, s.svc, s.workitemLinkTypeCtrl, targetSpaceID, createParentChildWorkItemLinkType("test-bug-blocker", *linkCategory.Data.ID, targetSpaceID))
+			// when
+			_, result := test.CopyWorkitemCreated(t, s.svc.Context, s.svc, s.workItemCtrl, s.userSpaceID, *s.bug1.Data.ID, &payload)
+			// then
+			require.Len(t, result.Data.Attributes.Mapping, 3)
+			copyID := result.Data.Attributes.Mapping[s.bug1.Data.ID.String()]
+			_, children := test.ListChildrenWorkitemOK(t, s.svc.Context, s.svc, s.workItemCtrl, targetSpaceID, copyID, nil, nil, nil, nil)
+			require.Len(t, children.Data, 2)
+			for _, child := range children.Data {
+				assert.Equal(t, targetSpaceID, *child.Relationships.Space.Data.ID)
+			}
+			links, err := s.db.WorkItemLinks().ListByWorkItem(context.Background(), copyID)
+			require.Nil(t, err)
+			require.Len(t, links, 2)
+			for _, l := range links {
+				assert.Equal(t, *targetLinkType.Data.ID, l.LinkTypeID)
+			}
+		})
+	})
+}
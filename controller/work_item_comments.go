@@ -6,6 +6,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/rendering"
@@ -40,7 +41,7 @@ func NewWorkItemCommentsController(service *goa.Service, db application.DB, conf
 // Create runs the create action.
 func (c *WorkItemCommentsController) Create(ctx *app.CreateWorkItemCommentsContext) error {
 	return application.Transactional(c.db, func(appl application.Application) error {
-		_, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		wi, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
 		}
@@ -57,14 +58,31 @@ func (c *WorkItemCommentsController) Create(ctx *app.CreateWorkItemCommentsConte
 			Markup:    markup,
 			CreatedBy: *currentUserIdentityID,
 		}
+		if reqComment.Relationships != nil && reqComment.Relationships.ParentComment != nil {
+			parentComment := reqComment.Relationships.ParentComment
+			if parentComment.Data == nil || parentComment.Data.ID == nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.parent-comment.data.id", nil).Expected("not nil"))
+			}
+			parentCommentID, err := uuid.FromString(*parentComment.Data.ID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.parent-comment.data.id", *parentComment.Data.ID).Expected("UUID"))
+			}
+			newComment.ParentCommentID = &parentCommentID
+		}
 
 		err = appl.Comments().Create(ctx, &newComment, *currentUserIdentityID)
 		if err != nil {
+			if _, ok := errs.Cause(err).(errors.BadParameterError); ok {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrInternal(err.Error()))
 		}
+		if err := saveCommentMarkupLinks(ctx, appl, *wi, newComment); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
 
 		res := &app.CommentSingle{
-			Data: ConvertComment(ctx.RequestData, newComment),
+			Data: ConvertComment(ctx.RequestData, newComment, commentIncludeMarkupLinks(appl, ctx, []comment.Comment{newComment})),
 		}
 		return ctx.OK(res)
 	})
@@ -78,16 +96,37 @@ func (c *WorkItemCommentsController) List(ctx *app.ListWorkItemCommentsContext)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
 		}
-		comments, tc, err := appl.Comments().List(ctx, wi.ID, &offset, &limit)
+		comments, tc, err := appl.Comments().ListTopLevel(ctx, wi.ID, &offset, &limit)
 		count := int(tc)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, goa.ErrInternal(err.Error()))
 		}
-		return ctx.ConditionalEntities(comments, c.config.GetCacheControlComments, func() error {
+		// the replies to the listed comments are included, whatever their
+		// depth, up to the maximum page size
+		commentIDs := make([]uuid.UUID, len(comments))
+		for i, cm := range comments {
+			commentIDs[i] = cm.ID
+		}
+		replies, totalReplies, err := appl.Comments().ListReplies(ctx, commentIDs, pageSizeMax)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		threads := append(append([]comment.Comment{}, comments...), replies...)
+		if err := loadCommentReactions(ctx, appl, threads); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.ConditionalEntities(threads, c.config.GetCacheControlComments, func() error {
+			includeReplies := CommentIncludeReplies(threads)
+			includeMarkupLinks := commentIncludeMarkupLinks(appl, ctx, threads)
 			res := &app.CommentList{}
 			res.Data = []*app.Comment{}
-			res.Meta = &app.CommentListMeta{TotalCount: count}
-			res.Data = ConvertComments(ctx.RequestData, comments)
+			repliesCount := int(totalReplies)
+			res.Meta = &app.CommentListMeta{TotalCount: count, TotalReplies: &repliesCount}
+			res.Data = ConvertComments(ctx.RequestData, threads[:len(comments)], includeReplies, includeMarkupLinks)
+			res.Included = make([]interface{}, len(replies))
+			for i, reply := range ConvertComments(ctx.RequestData, threads[len(comments):], includeReplies, includeMarkupLinks) {
+				res.Included[i] = reply
+			}
 			res.Links = &app.PagingLinks{}
 			setPagingLinks(res.Links, buildAbsoluteURL(ctx.RequestData), len(comments), offset, limit, count)
 			return ctx.OK(res)
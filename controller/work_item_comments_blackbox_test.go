@@ -290,3 +290,61 @@ func (rest *TestCommentREST) TestListCommentsByMissingParentWorkItem() {
 	limit := 1
 	test.ListWorkItemCommentsNotFound(rest.T(), svc.Context, svc, ctrl, uuid.NewV4(), uuid.NewV4(), &limit, &offset, nil, nil)
 }
+
+func (rest *TestCommentREST) newCreateWorkItemCommentReplyPayload(body string, parentCommentID string) *app.CreateWorkItemCommentsPayload {
+	p := rest.newCreateWorkItemCommentsPayload(body, nil)
+	commentsType := "comments"
+	p.Data.Relationships = &app.CreateCommentRelations{
+		ParentComment: &app.RelationGeneric{
+			Data: &app.GenericData{
+				Type: &commentsType,
+				ID:   &parentCommentID,
+			},
+		},
+	}
+	return p
+}
+
+func (rest *TestCommentREST) TestCreateAndListReplies() {
+	// given
+	wi := rest.createDefaultWorkItem()
+	svc, ctrl := rest.SecuredController()
+	_, c1 := test.CreateWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.SpaceID, wi.ID, rest.newCreateWorkItemCommentsPayload("Test 1", nil))
+	_, c2 := test.CreateWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.SpaceID, wi.ID, rest.newCreateWorkItemCommentsPayload("Test 2", nil))
+	// when
+	_, reply := test.CreateWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.SpaceID, wi.ID, rest.newCreateWorkItemCommentReplyPayload("Reply 1", c1.Data.ID.String()))
+	_, replyToReply := test.CreateWorkItemCommentsOK(rest.T(), svc.Context, svc, ctrl, wi.SpaceID, wi.ID, rest.newCreateWorkItemCommentReplyPayload("Reply 1.1", reply.Data.ID.String()))
+	// then
+	require.NotNil(rest.T(), reply.Data.Relationships.ParentComment)
+	assert.Equal(rest.T(), c1.Data.ID.String(), *reply.Data.Relationships.ParentComment.Data.ID)
+
+	rest.T().Run("nested list", func(t *testing.T) {
+		_, cs := test.ListWorkItemCommentsOK(t, svc.Context, svc, ctrl, wi.SpaceID, wi.ID, nil, nil, nil, nil)
+		// only the top-level comments are listed, with their replies included
+		require.Len(t, cs.Data, 2)
+		assert.Equal(t, 2, cs.Meta.TotalCount)
+		require.NotNil(t, cs.Meta.TotalReplies)
+		assert.Equal(t, 2, *cs.Meta.TotalReplies)
+		assert.Equal(t, *c2.Data.ID, *cs.Data[0].ID)
+		assert.Equal(t, *c1.Data.ID, *cs.Data[1].ID)
+		require.NotNil(t, cs.Data[1].Relationships.Replies)
+		require.Len(t, cs.Data[1].Relationships.Replies.Data, 1)
+		assert.Equal(t, reply.Data.ID.String(), *cs.Data[1].Relationships.Replies.Data[0].ID)
+		assert.Empty(t, cs.Data[0].Relationships.Replies.Data)
+		require.Len(t, cs.Included, 2)
+		includedReply, ok := cs.Included[0].(*app.Comment)
+		require.True(t, ok)
+		assert.Equal(t, *reply.Data.ID, *includedReply.ID)
+		require.Len(t, includedReply.Relationships.Replies.Data, 1)
+		assert.Equal(t, replyToReply.Data.ID.String(), *includedReply.Relationships.Replies.Data[0].ID)
+	})
+
+	rest.T().Run("reply to a comment of another work item", func(t *testing.T) {
+		otherWI := rest.createDefaultWorkItem()
+		test.CreateWorkItemCommentsBadRequest(t, svc.Context, svc, ctrl, otherWI.SpaceID, otherWI.ID, rest.newCreateWorkItemCommentReplyPayload("Reply", c1.Data.ID.String()))
+	})
+
+	rest.T().Run("invalid parent comment ID", func(t *testing.T) {
+		test.CreateWorkItemCommentsBadRequest(t, svc.Context, svc, ctrl, wi.SpaceID, wi.ID, rest.newCreateWorkItemCommentReplyPayload("Reply", "foo"))
+	})
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeEvents contains the JSON API type for events
+const APIStringTypeEvents = "events"
+
+// WorkItemEventsController implements the work_item_events resource.
+type WorkItemEventsController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewWorkItemEventsController creates a work_item_events controller.
+func NewWorkItemEventsController(service *goa.Service, db application.DB) *WorkItemEventsController {
+	return &WorkItemEventsController{
+		Controller: service.NewController("WorkItemEventsController"),
+		db:         db,
+	}
+}
+
+// List runs the list action.
+func (c *WorkItemEventsController) List(ctx *app.ListWorkItemEventsContext) error {
+	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
+	return application.Transactional(c.db, func(appl application.Application) error {
+		wi, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, goa.ErrNotFound(err.Error()))
+		}
+		events, tc, err := appl.WorkItemEvents().List(ctx, wi.ID, &offset, &limit)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		count := int(tc)
+		modifierIDs := make([]uuid.UUID, len(events))
+		for i, e := range events {
+			modifierIDs[i] = e.ModifierIdentity
+		}
+		included, err := loadIdentitiesForInclusion(ctx, appl, ctx.RequestData, modifierIDs)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.WorkItemEventList{
+			Data:     ConvertEvents(ctx.RequestData, wi.SpaceID, events),
+			Included: included,
+			Meta:     &app.WorkItemListResponseMeta{TotalCount: count},
+			Links:    &app.PagingLinks{},
+		}
+		setPagingLinks(res.Links, buildAbsoluteURL(ctx.RequestData), len(events), offset, limit, count)
+		return ctx.OK(res)
+	})
+}
+
+// ConvertEvents converts between internal and external REST representation
+func ConvertEvents(request *goa.RequestData, spaceID uuid.UUID, events []event.Event) []*app.WorkItemEvent {
+	res := make([]*app.WorkItemEvent, len(events))
+	for i, e := range events {
+		res[i] = ConvertEvent(request, spaceID, e)
+	}
+	return res
+}
+
+// ConvertEvent converts between internal and external REST representation
+func ConvertEvent(request *goa.RequestData, spaceID uuid.UUID, e event.Event) *app.WorkItemEvent {
+	var subjectSelf string
+	switch e.Kind {
+	case event.KindWorkItem:
+		subjectSelf = rest.AbsoluteURL(request, app.WorkitemHref(spaceID, e.SubjectID))
+	case event.KindWorkItemLink:
+		subjectSelf = rest.AbsoluteURL(request, app.WorkItemLinkHref(e.SubjectID))
+	case event.KindComment:
+		subjectSelf = rest.AbsoluteURL(request, app.CommentsHref(e.SubjectID))
+	}
+	subjectType := string(e.Kind)
+	subjectID := e.SubjectID.String()
+	return &app.WorkItemEvent{
+		Type: APIStringTypeEvents,
+		ID:   e.ID,
+		Attributes: &app.WorkItemEventAttributes{
+			Kind:         string(e.Kind),
+			RevisionType: e.Type.String(),
+			Timestamp:    e.Time,
+		},
+		Relationships: &app.WorkItemEventRelationships{
+			Modifier: &app.RelationGeneric{
+				Data: ConvertUserSimple(request, e.ModifierIdentity),
+			},
+			Subject: &app.RelationGeneric{
+				Data: &app.GenericData{
+					Type: &subjectType,
+					ID:   &subjectID,
+				},
+				Links: &app.GenericLinks{
+					Self: &subjectSelf,
+				},
+			},
+		},
+	}
+}
+
+// loadIdentitiesForInclusion loads the distinct identities (along with their
+// user) among the given IDs and converts them so that they can be added in the
+// "included" array of a response.
+func loadIdentitiesForInclusion(ctx context.Context, appl application.Application, request *goa.RequestData, identityIDs []uuid.UUID) ([]interface{}, error) {
+	included := []interface{}{}
+	var distinctIDs []uuid.UUID
+	visited := map[uuid.UUID]bool{}
+	for _, identityID := range identityIDs {
+		if !visited[identityID] {
+			visited[identityID] = true
+			distinctIDs = append(distinctIDs, identityID)
+		}
+	}
+	if len(distinctIDs) == 0 {
+		return included, nil
+	}
+	identities, err := appl.Identities().Query(account.IdentityFilterByIDs(distinctIDs), account.IdentityWithUser())
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to load identities")
+	}
+	byID := make(map[uuid.UUID]*account.Identity, len(identities))
+	for i := range identities {
+		byID[identities[i].ID] = &identities[i]
+	}
+	for _, identityID := range distinctIDs {
+		identity, ok := byID[identityID]
+		if !ok {
+			// the identity may have been removed in the meantime, the
+			// relationship still refers to it though.
+			continue
+		}
+		included = append(included, ConvertToAppUser(request, &identity.User, identity).Data)
+	}
+	return included, nil
+}
@@ -8,8 +8,12 @@ import (
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 	"github.com/goadesign/goa"
 	errs "github.com/pkg/errors"
@@ -53,6 +57,87 @@ type workItemLinkContext struct {
 	CurrentUserIdentityID *uuid.UUID
 	DB                    application.DB
 	LinkFunc              hrefLinkFunc
+	// spaceAccess caches the result of the space authorization checks
+	// performed while handling a single request
+	spaceAccess map[uuid.UUID]bool
+}
+
+// canAccessSpace returns true if the current user is authorized to access
+// the space with the given ID. Any error during the authorization check is
+// treated as a denied access.
+func (ctx *workItemLinkContext) canAccessSpace(spaceID uuid.UUID) bool {
+	if ctx.spaceAccess == nil {
+		ctx.spaceAccess = map[uuid.UUID]bool{}
+	}
+	if authorized, ok := ctx.spaceAccess[spaceID]; ok {
+		return authorized
+	}
+	authorized, err := authz.Authorize(ctx.Context, spaceID.String())
+	if err != nil {
+		log.Info(ctx.Context, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      err,
+		}, "unable to check the authorization on the space")
+		authorized = false
+	}
+	ctx.spaceAccess[spaceID] = authorized
+	return authorized
+}
+
+// authorizeLinkEnds verifies that the current user is authorized to access
+// the spaces of both the source and the target work item when they belong to
+// different spaces. Links within a single space are subject to the regular
+// work item authorization rules.
+func authorizeLinkEnds(ctx *workItemLinkContext, sourceID, targetID uuid.UUID) error {
+	source, err := ctx.Application.WorkItems().LoadByID(ctx.Context, sourceID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	target, err := ctx.Application.WorkItems().LoadByID(ctx.Context, targetID)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	if uuid.Equal(source.SpaceID, target.SpaceID) {
+		return nil
+	}
+	for _, spaceID := range []uuid.UUID{source.SpaceID, target.SpaceID} {
+		if !ctx.canAccessSpace(spaceID) {
+			return errors.NewForbiddenError("user is not authorized to access the space")
+		}
+	}
+	return nil
+}
+
+// convertLinkedWorkItem converts the given work item that appears as an end
+// of a link whose other end lives in the space with the given ID. When the
+// link crosses spaces and the current user is not authorized to access the
+// space of the work item, only a redacted representation is returned.
+func convertLinkedWorkItem(ctx *workItemLinkContext, wi workitem.WorkItem, otherSpaceID uuid.UUID) *app.WorkItem {
+	if uuid.Equal(wi.SpaceID, otherSpaceID) || ctx.canAccessSpace(wi.SpaceID) {
+		return ConvertWorkItem(ctx.RequestData, wi)
+	}
+	return convertRedactedWorkItem(ctx.RequestData, wi)
+}
+
+// convertRedactedWorkItem returns a representation of the given work item
+// that only reveals its identity and space, but none of its attributes.
+func convertRedactedWorkItem(request *goa.RequestData, wi workitem.WorkItem) *app.WorkItem {
+	selfURL := rest.AbsoluteURL(request, app.WorkitemHref(wi.SpaceID.String(), wi.ID.String()))
+	spaceSelfURL := rest.AbsoluteURL(request, app.SpaceHref(wi.SpaceID.String()))
+	return &app.WorkItem{
+		ID:         &wi.ID,
+		Type:       APIStringTypeWorkItem,
+		Attributes: map[string]interface{}{},
+		Relationships: &app.WorkItemRelationships{
+			Space: app.NewSpaceRelation(wi.SpaceID, spaceSelfURL),
+		},
+		Links: &app.GenericLinksForWorkItem{
+			Self: &selfURL,
+			Meta: map[string]interface{}{
+				"redacted": true,
+			},
+		},
+	}
 }
 
 // newWorkItemLinkContext returns a new workItemLinkContext
@@ -101,14 +186,37 @@ func getWorkItemsOfLinks(ctx *workItemLinkContext, linksDataArr []*app.WorkItemL
 		workItemIDMap[linkData.Relationships.Source.Data.ID] = true
 		workItemIDMap[linkData.Relationships.Target.Data.ID] = true
 	}
-	// Now include the optional work item data in the work item link "included" array
-	workItemArr := []*app.WorkItem{}
+	workItems := map[uuid.UUID]workitem.WorkItem{}
 	for workItemID := range workItemIDMap {
 		wi, err := ctx.Application.WorkItems().LoadByID(ctx.Context, workItemID)
 		if err != nil {
 			return nil, errs.WithStack(err)
 		}
-		workItemArr = append(workItemArr, ConvertWorkItem(ctx.RequestData, *wi))
+		workItems[workItemID] = *wi
+	}
+	// A work item is only redacted if it appears at the end of a link whose
+	// other end lives in another space the user is not authorized to access.
+	redacted := map[uuid.UUID]bool{}
+	for _, linkData := range linksDataArr {
+		source := workItems[linkData.Relationships.Source.Data.ID]
+		target := workItems[linkData.Relationships.Target.Data.ID]
+		if uuid.Equal(source.SpaceID, target.SpaceID) {
+			continue
+		}
+		for _, wi := range []workitem.WorkItem{source, target} {
+			if !ctx.canAccessSpace(wi.SpaceID) {
+				redacted[wi.ID] = true
+			}
+		}
+	}
+	// Now include the optional work item data in the work item link "included" array
+	workItemArr := []*app.WorkItem{}
+	for workItemID, wi := range workItems {
+		if redacted[workItemID] {
+			workItemArr = append(workItemArr, convertRedactedWorkItem(ctx.RequestData, wi))
+			continue
+		}
+		workItemArr = append(workItemArr, ConvertWorkItem(ctx.RequestData, wi))
 	}
 	return workItemArr, nil
 }
@@ -171,14 +279,15 @@ func enrichLinkSingle(ctx *workItemLinkContext, appLinks *app.WorkItemLinkSingle
 	if err != nil {
 		return errs.WithStack(err)
 	}
-	appLinks.Included = append(appLinks.Included, ConvertWorkItem(ctx.RequestData, *sourceWi))
 
 	// TODO(kwk): include target work item
 	targetWi, err := ctx.Application.WorkItems().LoadByID(ctx.Context, appLinks.Data.Relationships.Target.Data.ID)
 	if err != nil {
 		return errs.WithStack(err)
 	}
-	appLinks.Included = append(appLinks.Included, ConvertWorkItem(ctx.RequestData, *targetWi))
+	appLinks.Included = append(appLinks.Included,
+		convertLinkedWorkItem(ctx, *sourceWi, targetWi.SpaceID),
+		convertLinkedWorkItem(ctx, *targetWi, sourceWi.SpaceID))
 
 	// Add links to individual link data element
 	selfURL := rest.AbsoluteURL(ctx.RequestData, ctx.LinkFunc(*appLinks.Data.ID))
@@ -245,6 +354,7 @@ type createWorkItemLinkFuncs interface {
 	Created(r *app.WorkItemLinkSingle) error
 	InternalServerError(r *app.JSONAPIErrors) error
 	Unauthorized(r *app.JSONAPIErrors) error
+	Forbidden(r *app.JSONAPIErrors) error
 }
 
 func createWorkItemLink(ctx *workItemLinkContext, httpFuncs createWorkItemLinkFuncs, payload *app.CreateWorkItemLinkPayload) error {
@@ -256,6 +366,9 @@ func createWorkItemLink(ctx *workItemLinkContext, httpFuncs createWorkItemLinkFu
 	if err != nil {
 		return jsonapi.JSONErrorResponse(httpFuncs, err)
 	}
+	if err := authorizeLinkEnds(ctx, modelLink.SourceID, modelLink.TargetID); err != nil {
+		return jsonapi.JSONErrorResponse(httpFuncs, err)
+	}
 	createdModelLink, err := ctx.Application.WorkItemLinks().Create(ctx.Context, modelLink.SourceID, modelLink.TargetID, modelLink.LinkTypeID, *ctx.CurrentUserIdentityID)
 	if err != nil {
 		switch reflect.TypeOf(err) {
@@ -290,10 +403,18 @@ type deleteWorkItemLinkFuncs interface {
 	NotFound(r *app.JSONAPIErrors) error
 	Unauthorized(r *app.JSONAPIErrors) error
 	InternalServerError(r *app.JSONAPIErrors) error
+	Forbidden(r *app.JSONAPIErrors) error
 }
 
 func deleteWorkItemLink(ctx *workItemLinkContext, httpFuncs deleteWorkItemLinkFuncs, linkID uuid.UUID) error {
-	err := ctx.Application.WorkItemLinks().Delete(ctx.Context, linkID, *ctx.CurrentUserIdentityID)
+	modelLink, err := ctx.Application.WorkItemLinks().Load(ctx.Context, linkID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(httpFuncs, err)
+	}
+	if err := authorizeLinkEnds(ctx, modelLink.SourceID, modelLink.TargetID); err != nil {
+		return jsonapi.JSONErrorResponse(httpFuncs, err)
+	}
+	err = ctx.Application.WorkItemLinks().Delete(ctx.Context, linkID, *ctx.CurrentUserIdentityID)
 	if err != nil {
 		return jsonapi.JSONErrorResponse(httpFuncs, err)
 	}
@@ -338,6 +459,7 @@ type updateWorkItemLinkFuncs interface {
 	BadRequest(r *app.JSONAPIErrors) error
 	InternalServerError(r *app.JSONAPIErrors) error
 	Unauthorized(r *app.JSONAPIErrors) error
+	Forbidden(r *app.JSONAPIErrors) error
 }
 
 func updateWorkItemLink(ctx *workItemLinkContext, httpFuncs updateWorkItemLinkFuncs, payload *app.UpdateWorkItemLinkPayload) error {
@@ -348,6 +470,9 @@ func updateWorkItemLink(ctx *workItemLinkContext, httpFuncs updateWorkItemLinkFu
 	if err != nil {
 		return jsonapi.JSONErrorResponse(httpFuncs, err)
 	}
+	if err := authorizeLinkEnds(ctx, modelLink.SourceID, modelLink.TargetID); err != nil {
+		return jsonapi.JSONErrorResponse(httpFuncs, err)
+	}
 	savedModelLink, err := ctx.Application.WorkItemLinks().Save(ctx.Context, *modelLink, *ctx.CurrentUserIdentityID)
 	if err != nil {
 		jerrors, httpStatusCode := jsonapi.ErrorToJSONAPIErrors(err)
@@ -374,6 +499,74 @@ func (c *WorkItemLinkController) Update(ctx *app.UpdateWorkItemLinkContext) erro
 	})
 }
 
+// ListRevisions runs the list-revisions action.
+func (c *WorkItemLinkController) ListRevisions(ctx *app.ListRevisionsWorkItemLinkContext) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		revisions, err := appl.WorkItemLinkRevisions().List(ctx.Context, ctx.LinkID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		// a link always has at least one revision, even after it was deleted
+		if len(revisions) == 0 {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("work item link", ctx.LinkID.String()))
+		}
+		modifierIDs := make([]uuid.UUID, len(revisions))
+		for i, r := range revisions {
+			modifierIDs[i] = r.ModifierIdentity
+		}
+		included, err := loadIdentitiesForInclusion(ctx, appl, ctx.RequestData, modifierIDs)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.WorkItemLinkRevisionList{
+			Data:     ConvertLinkRevisionsFromModel(ctx.RequestData, revisions),
+			Included: included,
+			Meta:     &app.WorkItemLinkListMeta{TotalCount: len(revisions)},
+		}
+		return ctx.OK(res)
+	})
+}
+
+// ConvertLinkRevisionsFromModel converts work item link revisions from model to REST representation
+func ConvertLinkRevisionsFromModel(request *goa.RequestData, revisions []link.Revision) []*app.WorkItemLinkRevisionData {
+	res := make([]*app.WorkItemLinkRevisionData, len(revisions))
+	for i, r := range revisions {
+		res[i] = &app.WorkItemLinkRevisionData{
+			Type: "workitemlinkrevisions",
+			ID:   r.ID,
+			Attributes: &app.WorkItemLinkRevisionAttributes{
+				RevisionType: event.Type(r.Type).String(),
+				RevisionTime: r.Time,
+				Version:      r.WorkItemLinkVersion,
+			},
+			Relationships: &app.WorkItemLinkRevisionRelationships{
+				Modifier: &app.RelationGeneric{
+					Data: ConvertUserSimple(request, r.ModifierIdentity),
+				},
+				LinkType: &app.RelationWorkItemLinkType{
+					Data: &app.RelationWorkItemLinkTypeData{
+						Type: link.EndpointWorkItemLinkTypes,
+						ID:   r.WorkItemLinkTypeID,
+					},
+				},
+				Source: &app.RelationWorkItem{
+					Data: &app.RelationWorkItemData{
+						Type: link.EndpointWorkItems,
+						ID:   r.WorkItemLinkSourceID,
+					},
+				},
+				Target: &app.RelationWorkItem{
+					Data: &app.RelationWorkItemData{
+						Type: link.EndpointWorkItems,
+						ID:   r.WorkItemLinkTargetID,
+					},
+				},
+			},
+		}
+	}
+	return res
+}
+
 // ConvertLinkFromModel converts a work item from model to REST representation
 func ConvertLinkFromModel(t link.WorkItemLink) app.WorkItemLinkSingle {
 	var converted = app.WorkItemLinkSingle{
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fabric8-services/fabric8-wit/account"
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/app/test"
 	. "github.com/fabric8-services/fabric8-wit/controller"
@@ -19,6 +20,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/rest"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
 	"github.com/fabric8-services/fabric8-wit/workitem"
@@ -42,6 +44,7 @@ type workItemLinkSuite struct {
 	gormtestsupport.DBTestSuite
 	clean                    func()
 	svc                      *goa.Service
+	testIdentity             account.Identity
 	workItemLinkTypeCtrl     *WorkItemLinkTypeController
 	workItemLinkCategoryCtrl *WorkItemLinkCategoryController
 	workItemLinkCtrl         *WorkItemLinkController
@@ -127,6 +130,7 @@ func (s *workItemLinkSuite) SetupTest() {
 	// create a test identity
 	testIdentity, err := testsupport.CreateTestIdentity(s.DB, "test user", "test provider")
 	require.Nil(s.T(), err)
+	s.testIdentity = testIdentity
 	s.svc = testsupport.ServiceAsUser("TestWorkItem-Service", almtoken.NewManagerWithPrivateKey(priv), testIdentity)
 	require.NotNil(s.T(), s.svc)
 	s.workItemCtrl = NewWorkitemController(svc, gormapplication.NewGormDB(s.DB), s.Configuration)
@@ -877,3 +881,93 @@ func (s *workItemLinkSuite) TestUnauthorizeWorkItemRelationshipsLinksCUD() {
 		return nil
 	})
 }
+
+// spaceAccessAuthzService only authorizes the access to the given spaces
+type spaceAccessAuthzService struct {
+	spaceIDs []uuid.UUID
+}
+
+func (s *spaceAccessAuthzService) Authorize(ctx context.Context, endpoint string, spaceID string) (bool, error) {
+	for _, id := range s.spaceIDs {
+		if id.String() == spaceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *spaceAccessAuthzService) Configuration() authz.AuthzConfiguration {
+	return nil
+}
+
+// serviceWithSpaceAccess returns a service for the test identity which is
+// only authorized to access the given spaces
+func (s *workItemLinkSuite) serviceWithSpaceAccess(spaceIDs ...uuid.UUID) *goa.Service {
+	priv, err := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	require.Nil(s.T(), err)
+	return testsupport.ServiceAsSpaceUser("TestWorkItemLink-Service", almtoken.NewManagerWithPrivateKey(priv), s.testIdentity, &spaceAccessAuthzService{spaceIDs: spaceIDs})
+}
+
+// createOtherSpaceWorkItem creates a work item in a new space and returns
+// the IDs of the space and of the work item
+func (s *workItemLinkSuite) createOtherSpaceWorkItem() (uuid.UUID, uuid.UUID) {
+	_, sp := test.CreateSpaceCreated(s.T(), s.svc.Context, s.svc, s.spaceCtrl, CreateSpacePayload("test-other-space-"+uuid.NewV4().String(), "description"))
+	spaceID := *sp.Data.ID
+	witPayload := newCreateWorkItemTypePayload(uuid.NewV4(), spaceID)
+	_, wit := test.CreateWorkitemtypeCreated(s.T(), s.svc.Context, s.svc, s.typeCtrl, spaceID, &witPayload)
+	payload := newCreateWorkItemPayload(spaceID, *wit.Data.ID, "other space bug")
+	_, wi := test.CreateWorkitemCreated(s.T(), s.svc.Context, s.svc, s.workItemCtrl, spaceID, payload)
+	return spaceID, *wi.Data.ID
+}
+
+// findIncludedWorkItem returns the work item with the given ID among the
+// included elements of the given link
+func findIncludedWorkItem(t *testing.T, workItemLink *app.WorkItemLinkSingle, wiID uuid.UUID) *app.WorkItem {
+	for _, included := range workItemLink.Included {
+		if wi, ok := included.(*app.WorkItem); ok && *wi.ID == wiID {
+			return wi
+		}
+	}
+	require.Fail(t, "work item not included", "work item %s", wiID)
+	return nil
+}
+
+func (s *workItemLinkSuite) TestCrossSpaceWorkItemLink() {
+	// given
+	otherSpaceID, otherWIID := s.createOtherSpaceWorkItem()
+	createPayload := newCreateWorkItemLinkPayload(s.bug1ID, otherWIID, s.bugBlockerLinkTypeID)
+
+	s.T().Run("create forbidden without access to the other space", func(t *testing.T) {
+		svc := s.serviceWithSpaceAccess(s.userSpaceID)
+		test.CreateWorkItemLinkForbidden(t, svc.Context, svc, s.workItemLinkCtrl, createPayload)
+	})
+
+	s.T().Run("show redacts the work item of the inaccessible space", func(t *testing.T) {
+		// given
+		svc := s.serviceWithSpaceAccess(s.userSpaceID, otherSpaceID)
+		_, created := test.CreateWorkItemLinkCreated(t, svc.Context, svc, s.workItemLinkCtrl, createPayload)
+		// when
+		svc = s.serviceWithSpaceAccess(s.userSpaceID)
+		_, shown := test.ShowWorkItemLinkOK(t, svc.Context, svc, s.workItemLinkCtrl, *created.Data.ID, nil, nil)
+		// then
+		redacted := findIncludedWorkItem(t, shown, otherWIID)
+		assert.Empty(t, redacted.Attributes)
+		assert.Equal(t, true, redacted.Links.Meta["redacted"])
+		assert.Equal(t, otherSpaceID, *redacted.Relationships.Space.Data.ID)
+		visible := findIncludedWorkItem(t, shown, s.bug1ID)
+		assert.Equal(t, "bug1", visible.Attributes[workitem.SystemTitle])
+		assert.Nil(t, visible.Links.Meta["redacted"])
+
+		t.Run("shown as is with access to both spaces", func(t *testing.T) {
+			svc := s.serviceWithSpaceAccess(s.userSpaceID, otherSpaceID)
+			_, shown := test.ShowWorkItemLinkOK(t, svc.Context, svc, s.workItemLinkCtrl, *created.Data.ID, nil, nil)
+			other := findIncludedWorkItem(t, shown, otherWIID)
+			assert.Equal(t, "other space bug", other.Attributes[workitem.SystemTitle])
+		})
+
+		t.Run("delete forbidden without access to the other space", func(t *testing.T) {
+			svc := s.serviceWithSpaceAccess(s.userSpaceID)
+			test.DeleteWorkItemLinkForbidden(t, svc.Context, svc, s.workItemLinkCtrl, *created.Data.ID)
+		})
+	})
+}
@@ -0,0 +1,404 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/jsonapi"
+	"github.com/fabric8-services/fabric8-wit/login"
+	"github.com/fabric8-services/fabric8-wit/rest"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/authz"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WorkItemTemplatesController implements the work_item_templates resource.
+type WorkItemTemplatesController struct {
+	*goa.Controller
+	db application.DB
+}
+
+// NewWorkItemTemplatesController creates a work_item_templates controller.
+func NewWorkItemTemplatesController(service *goa.Service, db application.DB) *WorkItemTemplatesController {
+	return &WorkItemTemplatesController{
+		Controller: service.NewController("WorkItemTemplatesController"),
+		db:         db,
+	}
+}
+
+// authorizeTemplateEditor returns an error unless the current user is
+// allowed to manage the templates of the given space and to create work items
+// in it
+func authorizeTemplateEditor(ctx context.Context, spaceID uuid.UUID) (*uuid.UUID, error) {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError(err.Error())
+	}
+	authorized, err := authz.Authorize(ctx, spaceID.String())
+	if err != nil {
+		return nil, errors.NewUnauthorizedError(err.Error())
+	}
+	if !authorized {
+		return nil, errors.NewForbiddenError("user is not authorized to access the space")
+	}
+	return currentUserIdentityID, nil
+}
+
+// List runs the list action.
+func (c *WorkItemTemplatesController) List(ctx *app.ListWorkItemTemplatesContext) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID.String()); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		templates, err := appl.WorkItemTemplates().List(ctx, ctx.SpaceID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.WorkItemTemplateList{
+			Data: make([]*app.WorkItemTemplateData, len(templates)),
+			Meta: &app.WorkItemTemplateListMeta{TotalCount: len(templates)},
+		}
+		for i, tpl := range templates {
+			res.Data[i] = ConvertWorkItemTemplate(ctx.RequestData, tpl)
+		}
+		return ctx.OK(res)
+	})
+}
+
+// Show runs the show action.
+func (c *WorkItemTemplatesController) Show(ctx *app.ShowWorkItemTemplatesContext) error {
+	return application.Transactional(c.db, func(appl application.Application) error {
+		tpl, err := appl.WorkItemTemplates().Load(ctx, ctx.SpaceID, ctx.TemplateID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.OK(&app.WorkItemTemplateSingle{
+			Data: ConvertWorkItemTemplate(ctx.RequestData, *tpl),
+		})
+	})
+}
+
+// Create runs the create action.
+func (c *WorkItemTemplatesController) Create(ctx *app.CreateWorkItemTemplatesContext) error {
+	if _, err := authorizeTemplateEditor(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.Name == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.name", nil).Expected("not nil"))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.Spaces().CheckExists(ctx, ctx.SpaceID.String()); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		items := ConvertWorkItemTemplateItemsToModel(attrs.Items)
+		if err := validateTemplateItems(ctx, appl, ctx.SpaceID, items); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		tpl, err := appl.WorkItemTemplates().Create(ctx, &template.Template{
+			SpaceID:     ctx.SpaceID,
+			Name:        *attrs.Name,
+			Description: attrs.Description,
+			Items:       items,
+		})
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.WorkItemTemplateSingle{
+			Data: ConvertWorkItemTemplate(ctx.RequestData, *tpl),
+		}
+		ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.RequestData, app.WorkItemTemplatesHref(ctx.SpaceID, tpl.ID)))
+		return ctx.Created(res)
+	})
+}
+
+// Update runs the update action.
+func (c *WorkItemTemplatesController) Update(ctx *app.UpdateWorkItemTemplatesContext) error {
+	if _, err := authorizeTemplateEditor(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	attrs := ctx.Payload.Data.Attributes
+	if attrs.Version == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.attributes.version", nil).Expected("not nil"))
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		tpl, err := appl.WorkItemTemplates().Load(ctx, ctx.SpaceID, ctx.TemplateID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		tpl.Version = *attrs.Version
+		if attrs.Name != nil {
+			tpl.Name = *attrs.Name
+		}
+		if attrs.Description != nil {
+			tpl.Description = attrs.Description
+		}
+		if attrs.Items != nil {
+			tpl.Items = ConvertWorkItemTemplateItemsToModel(attrs.Items)
+			if err := validateTemplateItems(ctx, appl, ctx.SpaceID, tpl.Items); err != nil {
+				return jsonapi.JSONErrorResponse(ctx, err)
+			}
+		}
+		tpl, err = appl.WorkItemTemplates().Save(ctx, *tpl)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.OK(&app.WorkItemTemplateSingle{
+			Data: ConvertWorkItemTemplate(ctx.RequestData, *tpl),
+		})
+	})
+}
+
+// Delete runs the delete action.
+func (c *WorkItemTemplatesController) Delete(ctx *app.DeleteWorkItemTemplatesContext) error {
+	if _, err := authorizeTemplateEditor(ctx, ctx.SpaceID); err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		if err := appl.WorkItemTemplates().Delete(ctx, ctx.SpaceID, ctx.TemplateID); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		return ctx.OK([]byte{})
+	})
+}
+
+// Instantiate runs the instantiate action.
+func (c *WorkItemTemplatesController) Instantiate(ctx *app.InstantiateWorkItemTemplatesContext) error {
+	currentUserIdentityID, err := authorizeTemplateEditor(ctx, ctx.SpaceID)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, err)
+	}
+	var parameters map[string]string
+	var iterationID, areaID *string
+	if ctx.Payload.Data.Attributes != nil {
+		parameters = ctx.Payload.Data.Attributes.Parameters
+	}
+	if rel := ctx.Payload.Data.Relationships; rel != nil {
+		if rel.Iteration != nil && rel.Iteration.Data != nil {
+			iterationID = rel.Iteration.Data.ID
+		}
+		if rel.Area != nil && rel.Area.Data != nil {
+			areaID = rel.Area.Data.ID
+		}
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		s, err := appl.Spaces().Load(ctx, ctx.SpaceID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if err := s.CheckNotArchived(); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		tpl, err := appl.WorkItemTemplates().Load(ctx, ctx.SpaceID, ctx.TemplateID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		inst := templateInstantiator{
+			appl:       appl,
+			spaceID:    ctx.SpaceID,
+			creatorID:  *currentUserIdentityID,
+			parameters: parameters,
+		}
+		if iterationID != nil {
+			id, err := uuid.FromString(*iterationID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.iteration", *iterationID))
+			}
+			itr, err := appl.Iterations().Load(ctx, id)
+			if err != nil || !uuid.Equal(itr.SpaceID, ctx.SpaceID) {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.iteration", id).Expected("iteration of the space"))
+			}
+			inst.iterationID = itr.ID
+		} else {
+			itr, err := appl.Iterations().Root(ctx, ctx.SpaceID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "unable to load the root iteration of the space"))
+			}
+			inst.iterationID = itr.ID
+		}
+		if areaID != nil {
+			id, err := uuid.FromString(*areaID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.area", *areaID))
+			}
+			ar, err := appl.Areas().Load(ctx, id)
+			if err != nil || !uuid.Equal(ar.SpaceID, ctx.SpaceID) {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.area", id).Expected("area of the space"))
+			}
+			inst.areaID = ar.ID
+		} else {
+			ar, err := appl.Areas().Root(ctx, ctx.SpaceID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "unable to load the root area of the space"))
+			}
+			inst.areaID = ar.ID
+		}
+		// the types and fields may have changed since the template was saved
+		if err := validateTemplateItems(ctx, appl, ctx.SpaceID, tpl.Items); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		if err := inst.instantiate(ctx, nil, tpl.Items); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		res := &app.WorkItemList{
+			Data:  make([]*app.WorkItem, len(inst.created)),
+			Links: &app.PagingLinks{},
+			Meta:  &app.WorkItemListResponseMeta{TotalCount: len(inst.created)},
+		}
+		for i, wi := range inst.created {
+			res.Data[i] = ConvertWorkItem(ctx.RequestData, wi)
+		}
+		return ctx.Created(res)
+	})
+}
+
+// templateInstantiator creates the work items of a template using the
+// repositories of a single transaction.
+type templateInstantiator struct {
+	appl        application.Application
+	spaceID     uuid.UUID
+	iterationID uuid.UUID
+	areaID      uuid.UUID
+	creatorID   uuid.UUID
+	parameters  map[string]string
+	// the created work items, parents before their children
+	created []workitem.WorkItem
+}
+
+// instantiate creates a work item for each of the given items and links it
+// from the given parent, if any, before creating its children.
+func (i *templateInstantiator) instantiate(ctx context.Context, parent *workitem.WorkItem, items template.Items) error {
+	for _, item := range items {
+		fields, err := template.Expand(item.Fields, i.parameters)
+		if err != nil {
+			return errors.NewBadParameterError("data.attributes.parameters", i.parameters).Expected(err.Error())
+		}
+		fields[workitem.SystemIteration] = i.iterationID.String()
+		fields[workitem.SystemArea] = i.areaID.String()
+		if err := setAreaDefaultAssignee(ctx, i.appl, i.spaceID, fields); err != nil {
+			return err
+		}
+		wi, err := i.appl.WorkItems().Create(ctx, i.spaceID, item.TypeID, fields, i.creatorID)
+		if err != nil {
+			return errs.WithStack(err)
+		}
+		i.created = append(i.created, *wi)
+		if parent != nil {
+			if _, err := i.appl.WorkItemLinks().Create(ctx, parent.ID, wi.ID, *item.LinkTypeID, i.creatorID); err != nil {
+				return errs.WithStack(err)
+			}
+		}
+		if err := i.instantiate(ctx, wi, item.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTemplateItems checks that the types of all the items are available
+// in the space, that their fields are defined by these types, and that the
+// children are linked with link types available in the space.
+func validateTemplateItems(ctx context.Context, appl application.Application, spaceID uuid.UUID, items template.Items) error {
+	if len(items) == 0 {
+		return errors.NewBadParameterError("data.attributes.items", nil).Expected("at least one item")
+	}
+	return items.Walk(func(parent *template.Item, item template.Item) error {
+		wit, err := appl.WorkItemTypes().Load(ctx, spaceID, item.TypeID)
+		if err != nil || (!uuid.Equal(wit.SpaceID, spaceID) && !uuid.Equal(wit.SpaceID, space.SystemSpace)) {
+			return errors.NewBadParameterError("data.attributes.items.type", item.TypeID).Expected("work item type available in the space")
+		}
+		for name, value := range item.Fields {
+			field, ok := wit.Fields[name]
+			if !ok {
+				return errors.NewBadParameterError(fmt.Sprintf("data.attributes.items.fields[%s]", name), value).Expected(fmt.Sprintf("field of the work item type %s", wit.Name))
+			}
+			// placeholders can only be checked once the template is instantiated
+			if name == workitem.SystemTitle || name == workitem.SystemDescription {
+				continue
+			}
+			if _, err := field.ConvertToModel(name, value); err != nil {
+				return errors.NewBadParameterError(fmt.Sprintf("data.attributes.items.fields[%s]", name), value).Expected(err.Error())
+			}
+		}
+		if title, ok := item.Fields[workitem.SystemTitle].(string); !ok || title == "" {
+			return errors.NewBadParameterError(fmt.Sprintf("data.attributes.items.fields[%s]", workitem.SystemTitle), item.Fields[workitem.SystemTitle]).Expected("not empty")
+		}
+		if parent == nil {
+			return nil
+		}
+		if item.LinkTypeID == nil {
+			return errors.NewBadParameterError("data.attributes.items.link-type", nil).Expected("link type of a child item")
+		}
+		linkType, err := appl.WorkItemLinkTypes().Load(ctx, *item.LinkTypeID)
+		if err != nil || (!uuid.Equal(linkType.SpaceID, spaceID) && !uuid.Equal(linkType.SpaceID, space.SystemSpace)) {
+			return errors.NewBadParameterError("data.attributes.items.link-type", *item.LinkTypeID).Expected("work item link type available in the space")
+		}
+		return nil
+	})
+}
+
+// ConvertWorkItemTemplateItemsToModel converts template items from the REST
+// representation to the model
+func ConvertWorkItemTemplateItemsToModel(items []*app.WorkItemTemplateItem) template.Items {
+	if items == nil {
+		return nil
+	}
+	res := make(template.Items, len(items))
+	for i, item := range items {
+		res[i] = template.Item{
+			TypeID:     item.Type,
+			Fields:     item.Fields,
+			LinkTypeID: item.LinkType,
+			Children:   ConvertWorkItemTemplateItemsToModel(item.Children),
+		}
+	}
+	return res
+}
+
+// convertWorkItemTemplateItems converts template items from the model to the
+// REST representation
+func convertWorkItemTemplateItems(items template.Items) []*app.WorkItemTemplateItem {
+	res := make([]*app.WorkItemTemplateItem, len(items))
+	for i, item := range items {
+		res[i] = &app.WorkItemTemplateItem{
+			Type:     item.TypeID,
+			Fields:   item.Fields,
+			LinkType: item.LinkTypeID,
+		}
+		if len(item.Children) > 0 {
+			res[i].Children = convertWorkItemTemplateItems(item.Children)
+		}
+	}
+	return res
+}
+
+// ConvertWorkItemTemplate converts a work item template from the model to the
+// REST representation
+func ConvertWorkItemTemplate(request *goa.RequestData, tpl template.Template) *app.WorkItemTemplateData {
+	selfURL := rest.AbsoluteURL(request, app.WorkItemTemplatesHref(tpl.SpaceID, tpl.ID))
+	spaceSelfURL := rest.AbsoluteURL(request, app.SpaceHref(tpl.SpaceID.String()))
+	return &app.WorkItemTemplateData{
+		Type: template.APIStringTypeTemplates,
+		ID:   &tpl.ID,
+		Attributes: &app.WorkItemTemplateAttributes{
+			Name:         &tpl.Name,
+			Description:  tpl.Description,
+			Version:      &tpl.Version,
+			Items:        convertWorkItemTemplateItems(tpl.Items),
+			Placeholders: tpl.Placeholders(),
+		},
+		Relationships: &app.WorkItemTemplateRelationships{
+			Space: app.NewSpaceRelation(tpl.SpaceID, spaceSelfURL),
+		},
+		Links: &app.GenericLinks{
+			Self: &selfURL,
+		},
+	}
+}
@@ -0,0 +1,216 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/app"
+	"github.com/fabric8-services/fabric8-wit/app/test"
+	. "github.com/fabric8-services/fabric8-wit/controller"
+	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	almtoken "github.com/fabric8-services/fabric8-wit/token"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+
+	"github.com/goadesign/goa"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRunWorkItemTemplatesREST(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestWorkItemTemplatesREST{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+type TestWorkItemTemplatesREST struct {
+	gormtestsupport.DBTestSuite
+	clean         func()
+	svc           *goa.Service
+	templatesCtrl *WorkItemTemplatesController
+	workitemCtrl  *WorkitemController
+	spaceID       uuid.UUID
+}
+
+func (rest *TestWorkItemTemplatesREST) SetupSuite() {
+	rest.DBTestSuite.SetupSuite()
+	ctx := migration.NewMigrationContext(context.Background())
+	rest.DBTestSuite.PopulateDBTestSuite(ctx)
+}
+
+func (rest *TestWorkItemTemplatesREST) SetupTest() {
+	rest.clean = cleaner.DeleteCreatedEntities(rest.DB)
+	testIdentity, err := testsupport.CreateTestIdentity(rest.DB, "TestWorkItemTemplatesREST-"+uuid.NewV4().String(), "test")
+	require.Nil(rest.T(), err)
+	priKey, err := almtoken.ParsePrivateKey([]byte(almtoken.RSAPrivateKey))
+	require.Nil(rest.T(), err)
+	rest.svc = testsupport.ServiceAsUser("WorkItemTemplates-Service", almtoken.NewManagerWithPrivateKey(priKey), testIdentity)
+	db := gormapplication.NewGormDB(rest.DB)
+	rest.templatesCtrl = NewWorkItemTemplatesController(rest.svc, db)
+	rest.workitemCtrl = NewWorkitemController(rest.svc, db, rest.Configuration)
+	// the space is created through the controller to get its root iteration
+	// and root area
+	spaceCtrl := NewSpaceController(rest.svc, db, rest.Configuration, &DummyResourceManager{})
+	_, sp := test.CreateSpaceCreated(rest.T(), rest.svc.Context, rest.svc, spaceCtrl, CreateSpacePayload("TestWorkItemTemplatesREST-"+uuid.NewV4().String(), ""))
+	rest.spaceID = *sp.Data.ID
+}
+
+func (rest *TestWorkItemTemplatesREST) TearDownTest() {
+	rest.clean()
+}
+
+func newCreateWorkItemTemplatePayload(items ...*app.WorkItemTemplateItem) *app.CreateWorkItemTemplatesPayload {
+	name := "TestWorkItemTemplatesREST-" + uuid.NewV4().String()
+	return &app.CreateWorkItemTemplatesPayload{
+		Data: &app.WorkItemTemplateData{
+			Type: template.APIStringTypeTemplates,
+			Attributes: &app.WorkItemTemplateAttributes{
+				Name:  &name,
+				Items: items,
+			},
+		},
+	}
+}
+
+func newInstantiateWorkItemTemplatePayload(parameters map[string]string) *app.InstantiateWorkItemTemplatesPayload {
+	return &app.InstantiateWorkItemTemplatesPayload{
+		Data: &app.WorkItemTemplateInstantiation{
+			Type: "workitemtemplateinstantiations",
+			Attributes: &app.WorkItemTemplateInstantiationAttributes{
+				Parameters: parameters,
+			},
+		},
+	}
+}
+
+// releaseTemplateItem returns a template item with a child linked with the
+// given link type
+func releaseTemplateItem(linkTypeID *uuid.UUID) *app.WorkItemTemplateItem {
+	return &app.WorkItemTemplateItem{
+		Type: workitem.SystemBug,
+		Fields: map[string]interface{}{
+			workitem.SystemTitle: "Release {{release}}",
+		},
+		Children: []*app.WorkItemTemplateItem{
+			{
+				Type: workitem.SystemBug,
+				Fields: map[string]interface{}{
+					workitem.SystemTitle:       "Notes for {{release}}",
+					workitem.SystemDescription: "Write the {{release}} release notes",
+					workitem.SystemState:       workitem.SystemStateOpen,
+				},
+				LinkType: linkTypeID,
+			},
+		},
+	}
+}
+
+func (rest *TestWorkItemTemplatesREST) TestInstantiate() {
+	// given
+	linkTypeID := link.SystemWorkItemLinkTypeParentChildID
+	_, tpl := test.CreateWorkItemTemplatesCreated(rest.T(), rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(releaseTemplateItem(&linkTypeID)))
+	assert.Equal(rest.T(), []string{"release"}, tpl.Data.Attributes.Placeholders)
+
+	rest.T().Run("ok", func(t *testing.T) {
+		// when
+		_, created := test.InstantiateWorkItemTemplatesCreated(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, *tpl.Data.ID, newInstantiateWorkItemTemplatePayload(map[string]string{"release": "1.2"}))
+		// then the parent is created before its child
+		require.Len(t, created.Data, 2)
+		parent, child := created.Data[0], created.Data[1]
+		assert.Equal(t, "Release 1.2", parent.Attributes[workitem.SystemTitle])
+		assert.Equal(t, "Notes for 1.2", child.Attributes[workitem.SystemTitle])
+		assert.Equal(t, workitem.SystemStateOpen, child.Attributes[workitem.SystemState])
+		for _, wi := range created.Data {
+			assert.Equal(t, rest.spaceID, *wi.Relationships.Space.Data.ID)
+			require.NotNil(t, wi.Relationships.Iteration.Data.ID)
+			require.NotNil(t, wi.Relationships.Area.Data.ID)
+		}
+		_, children := test.ListChildrenWorkitemOK(t, rest.svc.Context, rest.svc, rest.workitemCtrl, rest.spaceID, *parent.ID, nil, nil, nil, nil)
+		require.Len(t, children.Data, 1)
+		assert.Equal(t, *child.ID, *children.Data[0].ID)
+	})
+
+	rest.T().Run("fail - missing placeholder value", func(t *testing.T) {
+		test.InstantiateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, *tpl.Data.ID, newInstantiateWorkItemTemplatePayload(nil))
+	})
+
+	rest.T().Run("fail - unknown iteration", func(t *testing.T) {
+		// given
+		iterationID := uuid.NewV4().String()
+		payload := newInstantiateWorkItemTemplatePayload(map[string]string{"release": "1.2"})
+		payload.Data.Relationships = &app.WorkItemTemplateInstantiationRelationships{
+			Iteration: &app.RelationGeneric{Data: &app.GenericData{ID: &iterationID}},
+		}
+		// when/then
+		test.InstantiateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, *tpl.Data.ID, payload)
+	})
+
+	rest.T().Run("fail - unknown template", func(t *testing.T) {
+		test.InstantiateWorkItemTemplatesNotFound(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, uuid.NewV4(), newInstantiateWorkItemTemplatePayload(nil))
+	})
+
+	rest.T().Run("fail - archived space", func(t *testing.T) {
+		// given
+		spaceRepo := space.NewRepository(rest.DB)
+		sp, err := spaceRepo.Load(context.Background(), rest.spaceID)
+		require.Nil(t, err)
+		sp.Archived = true
+		_, err = spaceRepo.Save(context.Background(), sp)
+		require.Nil(t, err)
+		// when/then
+		test.InstantiateWorkItemTemplatesForbidden(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, *tpl.Data.ID, newInstantiateWorkItemTemplatePayload(map[string]string{"release": "1.2"}))
+	})
+}
+
+func (rest *TestWorkItemTemplatesREST) TestCreateValidatesItems() {
+	linkTypeID := link.SystemWorkItemLinkTypeParentChildID
+	unknownID := uuid.NewV4()
+
+	rest.T().Run("ok", func(t *testing.T) {
+		test.CreateWorkItemTemplatesCreated(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(releaseTemplateItem(&linkTypeID)))
+	})
+
+	rest.T().Run("fail - no items", func(t *testing.T) {
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload())
+	})
+
+	rest.T().Run("fail - unknown type", func(t *testing.T) {
+		item := releaseTemplateItem(&linkTypeID)
+		item.Type = unknownID
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(item))
+	})
+
+	rest.T().Run("fail - unknown field", func(t *testing.T) {
+		item := releaseTemplateItem(&linkTypeID)
+		item.Fields["foo"] = "bar"
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(item))
+	})
+
+	rest.T().Run("fail - invalid field value", func(t *testing.T) {
+		item := releaseTemplateItem(&linkTypeID)
+		item.Children[0].Fields[workitem.SystemState] = "foo"
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(item))
+	})
+
+	rest.T().Run("fail - empty title", func(t *testing.T) {
+		item := releaseTemplateItem(&linkTypeID)
+		item.Fields[workitem.SystemTitle] = ""
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(item))
+	})
+
+	rest.T().Run("fail - child without link type", func(t *testing.T) {
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(releaseTemplateItem(nil)))
+	})
+
+	rest.T().Run("fail - unknown link type", func(t *testing.T) {
+		test.CreateWorkItemTemplatesBadRequest(t, rest.svc.Context, rest.svc, rest.templatesCtrl, rest.spaceID, newCreateWorkItemTemplatePayload(releaseTemplateItem(&unknownID)))
+	})
+}
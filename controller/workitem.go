@@ -12,6 +12,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/app"
 	"github.com/fabric8-services/fabric8-wit/application"
 	"github.com/fabric8-services/fabric8-wit/codebase"
+	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/criteria"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
@@ -23,6 +24,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/space/authz"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
 
 	"github.com/goadesign/goa"
 	errs "github.com/pkg/errors"
@@ -31,10 +33,11 @@ import (
 
 // Defines the constants to be used in json api "type" attribute
 const (
-	APIStringTypeUser         = "identities"
-	APIStringTypeWorkItem     = "workitems"
-	APIStringTypeWorkItemType = "workitemtypes"
-	none                      = "none"
+	APIStringTypeUser           = "identities"
+	APIStringTypeWorkItem       = "workitems"
+	APIStringTypeWorkItemType   = "workitemtypes"
+	APIStringTypeWorkItemCopies = "workitemcopies"
+	none                        = "none"
 )
 
 // WorkitemController implements the workitem resource.
@@ -61,73 +64,49 @@ func NewWorkitemController(service *goa.Service, db application.DB, config WorkI
 // Prev and Next links will be present only when there actually IS a next or previous page.
 // Last will always be present. Total Item count needs to be computed from the "Last" link.
 func (c *WorkitemController) List(ctx *app.ListWorkitemContext) error {
-	var additionalQuery []string
-	exp, err := query.Parse(ctx.Filter)
-	if err != nil {
-		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("could not parse filter", err))
-	}
-	if ctx.FilterAssignee != nil {
-		if *ctx.FilterAssignee == none {
-			exp = criteria.And(exp, criteria.IsNull("system.assignees"))
-			additionalQuery = append(additionalQuery, "filter[assignee]=none")
-
-		} else {
-			exp = criteria.And(exp, criteria.Equals(criteria.Field("system.assignees"), criteria.Literal([]string{*ctx.FilterAssignee})))
-			additionalQuery = append(additionalQuery, "filter[assignee]="+*ctx.FilterAssignee)
+	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
+	return application.Transactional(c.db, func(tx application.Application) error {
+		exp, additionalQuery, err := workItemFilterExpression(ctx, tx, workItemFilterParams{
+			Filter:        ctx.Filter,
+			Assignee:      ctx.FilterAssignee,
+			Iteration:     ctx.FilterIteration,
+			Workitemtype:  ctx.FilterWorkitemtype,
+			Area:          ctx.FilterArea,
+			Workitemstate: ctx.FilterWorkitemstate,
+		})
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
 		}
-	}
-	if ctx.FilterIteration != nil {
-		exp = criteria.And(exp, criteria.Equals(criteria.Field(workitem.SystemIteration), criteria.Literal(string(*ctx.FilterIteration))))
-		additionalQuery = append(additionalQuery, "filter[iteration]="+*ctx.FilterIteration)
-		// Update filter by adding child iterations if any
-		application.Transactional(c.db, func(tx application.Application) error {
-			iterationUUID, errConversion := uuid.FromString(*ctx.FilterIteration)
-			if errConversion != nil {
-				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(errConversion, "Invalid iteration ID"))
-			}
-			childrens, err := tx.Iterations().LoadChildren(ctx.Context, iterationUUID)
+		if exp == nil {
+			exp = criteria.Literal(true)
+		}
+		if ctx.FilterParentexists != nil {
+			// no need to build expression: it is taken care in wi.List call
+			// we need additionalQuery to make sticky filters in URL links
+			additionalQuery = append(additionalQuery, "filter[parentexists]="+strconv.FormatBool(*ctx.FilterParentexists))
+		}
+		if ctx.FilterID != nil {
+			additionalQuery = append(additionalQuery, "filter[id]="+ctx.FilterID.String())
+		}
+		var sort []workitem.SortKey
+		if ctx.FilterID != nil {
+			exp, sort, err = applySavedFilter(ctx, tx, ctx.SpaceID, *ctx.FilterID, exp)
 			if err != nil {
-				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "Unable to fetch children"))
-			}
-			for _, child := range childrens {
-				childIDStr := child.ID.String()
-				exp = criteria.Or(exp, criteria.Equals(criteria.Field(workitem.SystemIteration), criteria.Literal(childIDStr)))
-				additionalQuery = append(additionalQuery, "filter[iteration]="+childIDStr)
+				return jsonapi.JSONErrorResponse(ctx, err)
 			}
-			return nil
-		})
-	}
-	if ctx.FilterWorkitemtype != nil {
-		exp = criteria.And(exp, criteria.Equals(criteria.Field("Type"), criteria.Literal([]uuid.UUID{*ctx.FilterWorkitemtype})))
-		additionalQuery = append(additionalQuery, "filter[workitemtype]="+ctx.FilterWorkitemtype.String())
-	}
-	if ctx.FilterArea != nil {
-		exp = criteria.And(exp, criteria.Equals(criteria.Field(workitem.SystemArea), criteria.Literal(string(*ctx.FilterArea))))
-		additionalQuery = append(additionalQuery, "filter[area]="+*ctx.FilterArea)
-	}
-	if ctx.FilterWorkitemstate != nil {
-		exp = criteria.And(exp, criteria.Equals(criteria.Field(workitem.SystemState), criteria.Literal(string(*ctx.FilterWorkitemstate))))
-		additionalQuery = append(additionalQuery, "filter[workitemstate]="+*ctx.FilterWorkitemstate)
-	}
-	if ctx.FilterParentexists != nil {
-		// no need to build expression: it is taken care in wi.List call
-		// we need additionalQuery to make sticky filters in URL links
-		additionalQuery = append(additionalQuery, "filter[parentexists]="+strconv.FormatBool(*ctx.FilterParentexists))
-	}
-
-	offset, limit := computePagingLimits(ctx.PageOffset, ctx.PageLimit)
-	return application.Transactional(c.db, func(tx application.Application) error {
-		workitems, tc, err := tx.WorkItems().List(ctx.Context, ctx.SpaceID, exp, ctx.FilterParentexists, &offset, &limit)
+		}
+		workitems, tc, err := tx.WorkItems().List(ctx.Context, ctx.SpaceID, exp, ctx.FilterParentexists, sort, &offset, &limit)
 		count := int(tc)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "Error listing work items"))
 		}
 		return ctx.ConditionalEntities(workitems, c.config.GetCacheControlWorkItems, func() error {
 			hasChildren := workItemIncludeHasChildren(tx, ctx)
+			links := workItemIncludeMarkupLinks(tx, ctx, workitems)
 			response := app.WorkItemList{
 				Links: &app.PagingLinks{},
 				Meta:  &app.WorkItemListResponseMeta{TotalCount: count},
-				Data:  ConvertWorkItems(ctx.RequestData, workitems, hasChildren),
+				Data:  ConvertWorkItems(ctx.RequestData, workitems, hasChildren, links),
 			}
 			setPagingLinks(response.Links, buildAbsoluteURL(ctx.RequestData), len(workitems), offset, limit, count, additionalQuery...)
 			addFilterLinks(response.Links, ctx.RequestData)
@@ -137,6 +116,79 @@ func (c *WorkitemController) List(ctx *app.ListWorkitemContext) error {
 	})
 }
 
+// workItemFilterParams holds the `filter` and `filter[...]` query parameters
+// that narrow down the work items of a list or a search
+type workItemFilterParams struct {
+	Filter        *string
+	Assignee      *string
+	Iteration     *string
+	Workitemtype  *uuid.UUID
+	Area          *string
+	Workitemstate *string
+}
+
+// workItemFilterExpression builds the structured filter from the `filter`
+// query language expression and the `filter[...]` parameters. It returns nil
+// if no filter is given, along with the query parameters to keep in the
+// paging links.
+func workItemFilterExpression(ctx context.Context, appl application.Application, params workItemFilterParams) (criteria.Expression, []string, error) {
+	var exp criteria.Expression
+	var additionalQuery []string
+	and := func(e criteria.Expression) {
+		if exp == nil {
+			exp = e
+		} else {
+			exp = criteria.And(exp, e)
+		}
+	}
+	if params.Filter != nil {
+		parsed, err := query.Parse(params.Filter)
+		if err != nil {
+			return nil, nil, errors.NewBadParameterError("could not parse filter", err)
+		}
+		and(parsed)
+		additionalQuery = append(additionalQuery, "filter="+*params.Filter)
+	}
+	if params.Assignee != nil {
+		if *params.Assignee == none {
+			and(criteria.IsNull(workitem.SystemAssignees))
+		} else {
+			and(criteria.Equals(criteria.Field(workitem.SystemAssignees), criteria.Literal([]string{*params.Assignee})))
+		}
+		additionalQuery = append(additionalQuery, "filter[assignee]="+*params.Assignee)
+	}
+	if params.Iteration != nil {
+		iterationID, err := uuid.FromString(*params.Iteration)
+		if err != nil {
+			return nil, nil, errors.NewBadParameterError("filter[iteration]", *params.Iteration).Expected("valid iteration ID")
+		}
+		// the work items of the child iterations belong to the iteration too
+		children, err := appl.Iterations().LoadChildren(ctx, iterationID)
+		if err != nil {
+			return nil, nil, errs.Wrap(err, "unable to fetch the children of the iteration")
+		}
+		iterationExp := criteria.Equals(criteria.Field(workitem.SystemIteration), criteria.Literal(iterationID.String()))
+		for _, child := range children {
+			iterationExp = criteria.Or(iterationExp, criteria.Equals(criteria.Field(workitem.SystemIteration), criteria.Literal(child.ID.String())))
+		}
+		and(iterationExp)
+		additionalQuery = append(additionalQuery, "filter[iteration]="+*params.Iteration)
+	}
+	if params.Workitemtype != nil {
+		and(criteria.Equals(criteria.Field("Type"), criteria.Literal([]uuid.UUID{*params.Workitemtype})))
+		additionalQuery = append(additionalQuery, "filter[workitemtype]="+params.Workitemtype.String())
+	}
+	if params.Area != nil {
+		and(criteria.Equals(criteria.Field(workitem.SystemArea), criteria.Literal(*params.Area)))
+		additionalQuery = append(additionalQuery, "filter[area]="+*params.Area)
+	}
+	if params.Workitemstate != nil {
+		and(criteria.Equals(criteria.Field(workitem.SystemState), criteria.Literal(*params.Workitemstate)))
+		additionalQuery = append(additionalQuery, "filter[workitemstate]="+*params.Workitemstate)
+	}
+	return exp, additionalQuery, nil
+}
+
 // Returns true if the user is the work item creator or space collaborator
 func authorizeWorkitemEditor(ctx context.Context, db application.DB, spaceID uuid.UUID, creatorID string, editorID string) (bool, error) {
 	if editorID == creatorID {
@@ -194,8 +246,12 @@ func (c *WorkitemController) Update(ctx *app.UpdateWorkitemContext) error {
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "Error updating work item"))
 		}
+		if err := saveWorkItemMarkupLinks(ctx, appl, *wi); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
 		hasChildren := workItemIncludeHasChildren(appl, ctx)
-		wi2 := ConvertWorkItem(ctx.RequestData, *wi, hasChildren)
+		links := workItemIncludeMarkupLinks(appl, ctx, []workitem.WorkItem{*wi})
+		wi2 := ConvertWorkItem(ctx.RequestData, *wi, hasChildren, links)
 		resp := &app.WorkItemSingle{
 			Data: wi2,
 			Links: &app.WorkItemLinks{
@@ -328,13 +384,20 @@ func (c *WorkitemController) Create(ctx *app.CreateWorkitemContext) error {
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Error creating work item")))
 		}
+		if err := setAreaDefaultAssignee(ctx, appl, ctx.SpaceID, wi.Fields); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Error creating work item")))
+		}
 
 		wi, err := appl.WorkItems().Create(ctx, ctx.SpaceID, *wit, wi.Fields, *currentUserIdentityID)
 		if err != nil {
 			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Error creating work item")))
 		}
+		if err := saveWorkItemMarkupLinks(ctx, appl, *wi); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
 		hasChildren := workItemIncludeHasChildren(appl, ctx)
-		wi2 := ConvertWorkItem(ctx.RequestData, *wi, hasChildren)
+		links := workItemIncludeMarkupLinks(appl, ctx, []workitem.WorkItem{*wi})
+		wi2 := ConvertWorkItem(ctx.RequestData, *wi, hasChildren, links)
 		resp := &app.WorkItemSingle{
 			Data: wi2,
 			Links: &app.WorkItemLinks{
@@ -347,17 +410,39 @@ func (c *WorkitemController) Create(ctx *app.CreateWorkitemContext) error {
 	})
 }
 
-// Show does GET workitem
+// Show does GET workitem by its ID or by its number in the space. If the
+// work item with the given number was moved to another space, the client is
+// redirected to its new location.
 func (c *WorkitemController) Show(ctx *app.ShowWorkitemContext) error {
 	return application.Transactional(c.db, func(appl application.Application) error {
-		wi, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
-		if err != nil {
-			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Fail to load work item with id %v", ctx.WiID)))
+		var wi *workitem.WorkItem
+		if number, err := strconv.Atoi(ctx.WiID); err == nil {
+			wi, err = appl.WorkItems().Load(ctx, ctx.SpaceID, number)
+			if _, ok := errs.Cause(err).(errors.NotFoundError); ok {
+				if moved, err := appl.WorkItems().LoadMoved(ctx, ctx.SpaceID, number); err == nil {
+					ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.RequestData, app.WorkitemHref(moved.SpaceID.String(), moved.ID)))
+					return ctx.MovedPermanently()
+				}
+			}
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Fail to load work item with number %d", number)))
+			}
+		} else {
+			wiID, err := uuid.FromString(ctx.WiID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("wiID", ctx.WiID).Expected("work item ID or number"))
+			}
+			wi, err = appl.WorkItems().LoadByID(ctx, wiID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Fail to load work item with id %v", wiID)))
+			}
 		}
 		return ctx.ConditionalRequest(*wi, c.config.GetCacheControlWorkItems, func() error {
-			comments := workItemIncludeCommentsAndTotal(ctx, c.db, ctx.WiID)
+			comments := workItemIncludeCommentsAndTotal(ctx, c.db, wi.ID)
 			hasChildren := workItemIncludeHasChildren(appl, ctx)
-			wi2 := ConvertWorkItem(ctx.RequestData, *wi, comments, hasChildren)
+			links := workItemIncludeMarkupLinks(appl, ctx, []workitem.WorkItem{*wi})
+			mentionedIn := workItemIncludeMentionedIn(appl, ctx)
+			wi2 := ConvertWorkItem(ctx.RequestData, *wi, comments, hasChildren, links, mentionedIn)
 			resp := &app.WorkItemSingle{
 				Data: wi2,
 			}
@@ -367,6 +452,385 @@ func (c *WorkitemController) Show(ctx *app.ShowWorkitemContext) error {
 	})
 }
 
+// Move does POST workitem move to another space
+func (c *WorkitemController) Move(ctx *app.MoveWorkitemContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	if ctx.Payload == nil || ctx.Payload.Data == nil || ctx.Payload.Data.Relationships == nil ||
+		ctx.Payload.Data.Relationships.Space == nil || ctx.Payload.Data.Relationships.Space.Data == nil ||
+		ctx.Payload.Data.Relationships.Space.Data.ID == nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.space", nil).Expected("the ID of the target space"))
+	}
+	rel := ctx.Payload.Data.Relationships
+	targetSpaceID := *rel.Space.Data.ID
+	// the user must be allowed to access both the current and the target space
+	for _, spaceID := range []uuid.UUID{ctx.SpaceID, targetSpaceID} {
+		authorized, err := authz.Authorize(ctx, spaceID.String())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+		}
+		if !authorized {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not authorized to access the space"))
+		}
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		wi, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Fail to load work item with id %v", ctx.WiID)))
+		}
+		if !uuid.Equal(wi.SpaceID, ctx.SpaceID) {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("work item", ctx.WiID.String()))
+		}
+		if err := appl.Spaces().CheckExists(ctx, targetSpaceID.String()); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.space", targetSpaceID).Expected("valid space ID"))
+		}
+		// keep the current type unless another one is given
+		typeID := wi.Type
+		if rel.BaseType != nil && rel.BaseType.Data != nil {
+			typeID = rel.BaseType.Data.ID
+		}
+		if err := checkWorkItemTypeOfSpace(ctx, appl, targetSpaceID, typeID); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, err)
+		}
+		// assign the root iteration and root area of the target space unless
+		// other ones are given
+		var iterationID uuid.UUID
+		if rel.Iteration != nil && rel.Iteration.Data != nil && rel.Iteration.Data.ID != nil {
+			iterationID, err = uuid.FromString(*rel.Iteration.Data.ID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.iteration", *rel.Iteration.Data.ID))
+			}
+			itr, err := appl.Iterations().Load(ctx, iterationID)
+			if err != nil || !uuid.Equal(itr.SpaceID, targetSpaceID) {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.iteration", iterationID).Expected("iteration of the target space"))
+			}
+		} else {
+			rootIteration, err := appl.Iterations().Root(ctx, targetSpaceID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "unable to load the root iteration of the target space"))
+			}
+			iterationID = rootIteration.ID
+		}
+		var areaID uuid.UUID
+		if rel.Area != nil && rel.Area.Data != nil && rel.Area.Data.ID != nil {
+			areaID, err = uuid.FromString(*rel.Area.Data.ID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.area", *rel.Area.Data.ID))
+			}
+			ar, err := appl.Areas().Load(ctx, areaID)
+			if err != nil || !uuid.Equal(ar.SpaceID, targetSpaceID) {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.area", areaID).Expected("area of the target space"))
+			}
+		} else {
+			rootArea, err := appl.Areas().Root(ctx, targetSpaceID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "unable to load the root area of the target space"))
+			}
+			areaID = rootArea.ID
+		}
+		movedWI, err := appl.WorkItems().Move(ctx, wi.ID, targetSpaceID, typeID, iterationID, areaID, *currentUserIdentityID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "Error moving work item"))
+		}
+		hasChildren := workItemIncludeHasChildren(appl, ctx)
+		wi2 := ConvertWorkItem(ctx.RequestData, *movedWI, hasChildren)
+		resp := &app.WorkItemSingle{
+			Data: wi2,
+			Links: &app.WorkItemLinks{
+				Self: rest.AbsoluteURL(ctx.RequestData, app.WorkitemHref(targetSpaceID.String(), movedWI.ID)),
+			},
+		}
+		ctx.ResponseData.Header().Set("Last-Modified", lastModified(*movedWI))
+		ctx.ResponseData.Header().Set("Location", rest.AbsoluteURL(ctx.RequestData, app.WorkitemHref(targetSpaceID.String(), movedWI.ID)))
+		return ctx.OK(resp)
+	})
+}
+
+// checkWorkItemTypeOfSpace returns a BadParameterError if the work item type
+// with the given ID belongs neither to the given space nor to the system space.
+func checkWorkItemTypeOfSpace(ctx context.Context, appl application.Application, spaceID, typeID uuid.UUID) error {
+	wit, err := appl.WorkItemTypes().Load(ctx, spaceID, typeID)
+	if err != nil || (!uuid.Equal(wit.SpaceID, spaceID) && !uuid.Equal(wit.SpaceID, space.SystemSpace)) {
+		return errors.NewBadParameterError("data.relationships.baseType", typeID).Expected("work item type available in the target space")
+	}
+	return nil
+}
+
+// Copy does POST workitem copy
+func (c *WorkitemController) Copy(ctx *app.CopyWorkitemContext) error {
+	currentUserIdentityID, err := login.ContextIdentity(ctx)
+	if err != nil {
+		return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+	}
+	copier := workItemCopier{
+		spaceID:     ctx.SpaceID,
+		creatorID:   *currentUserIdentityID,
+		mapping:     map[uuid.UUID]uuid.UUID{},
+		linkTypes:   map[uuid.UUID]*link.WorkItemLinkType{},
+		linkTypeIDs: map[uuid.UUID]uuid.UUID{},
+	}
+	var iterationID *string
+	if ctx.Payload != nil && ctx.Payload.Data != nil {
+		if attrs := ctx.Payload.Data.Attributes; attrs != nil {
+			copier.subtree = attrs.Subtree != nil && *attrs.Subtree
+			copier.comments = attrs.Comments != nil && *attrs.Comments
+			copier.resetState = attrs.ResetState != nil && *attrs.ResetState
+		}
+		if rel := ctx.Payload.Data.Relationships; rel != nil {
+			if rel.Space != nil && rel.Space.Data != nil && rel.Space.Data.ID != nil {
+				copier.spaceID = *rel.Space.Data.ID
+			}
+			if rel.Iteration != nil && rel.Iteration.Data != nil {
+				iterationID = rel.Iteration.Data.ID
+			}
+		}
+	}
+	// the user must be allowed to access both the current and the target space
+	for _, spaceID := range []uuid.UUID{ctx.SpaceID, copier.spaceID} {
+		authorized, err := authz.Authorize(ctx, spaceID.String())
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewUnauthorizedError(err.Error()))
+		}
+		if !authorized {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewForbiddenError("user is not authorized to access the space"))
+		}
+	}
+	return application.Transactional(c.db, func(appl application.Application) error {
+		copier.appl = appl
+		wi, err := appl.WorkItems().LoadByID(ctx, ctx.WiID)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, fmt.Sprintf("Fail to load work item with id %v", ctx.WiID)))
+		}
+		if !uuid.Equal(wi.SpaceID, ctx.SpaceID) {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewNotFoundError("work item", ctx.WiID.String()))
+		}
+		if err := appl.Spaces().CheckExists(ctx, copier.spaceID.String()); err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.space", copier.spaceID).Expected("valid space ID"))
+		}
+		if iterationID != nil {
+			id, err := uuid.FromString(*iterationID)
+			if err != nil {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.iteration", *iterationID))
+			}
+			itr, err := appl.Iterations().Load(ctx, id)
+			if err != nil || !uuid.Equal(itr.SpaceID, copier.spaceID) {
+				return jsonapi.JSONErrorResponse(ctx, errors.NewBadParameterError("data.relationships.iteration", id).Expected("iteration of the target space"))
+			}
+			copier.iterationID = &id
+		}
+		copiedWI, err := copier.copy(ctx, *wi)
+		if err != nil {
+			return jsonapi.JSONErrorResponse(ctx, errs.Wrap(err, "Error copying work item"))
+		}
+		mapping := make(map[string]uuid.UUID, len(copier.mapping))
+		for originalID, copyID := range copier.mapping {
+			mapping[originalID.String()] = copyID
+		}
+		copySelfURL := rest.AbsoluteURL(ctx.RequestData, app.WorkitemHref(copier.spaceID.String(), copiedWI.ID))
+		spaceSelfURL := rest.AbsoluteURL(ctx.RequestData, app.SpaceHref(copier.spaceID.String()))
+		copyID := copiedWI.ID.String()
+		workItemType := APIStringTypeWorkItem
+		resp := &app.WorkItemCopySingle{
+			Data: &app.WorkItemCopy{
+				Type: APIStringTypeWorkItemCopies,
+				Attributes: &app.WorkItemCopyAttributes{
+					Subtree:    &copier.subtree,
+					Comments:   &copier.comments,
+					ResetState: &copier.resetState,
+					Mapping:    mapping,
+				},
+				Relationships: &app.WorkItemCopyRelationships{
+					Space: app.NewSpaceRelation(copier.spaceID, spaceSelfURL),
+					Copy: &app.RelationGeneric{
+						Data: &app.GenericData{
+							Type: &workItemType,
+							ID:   &copyID,
+							Links: &app.GenericLinks{
+								Self: &copySelfURL,
+							},
+						},
+					},
+				},
+			},
+			Included: []interface{}{
+				ConvertWorkItem(ctx.RequestData, *copiedWI),
+			},
+		}
+		ctx.ResponseData.Header().Set("Location", app.WorkitemHref(copier.spaceID.String(), copiedWI.ID))
+		return ctx.Created(resp)
+	})
+}
+
+// workItemCopier copies work items, and optionally their subtree and
+// comments, using the repositories of a single transaction.
+type workItemCopier struct {
+	appl        application.Application
+	spaceID     uuid.UUID
+	iterationID *uuid.UUID
+	subtree     bool
+	comments    bool
+	resetState  bool
+	creatorID   uuid.UUID
+	// the IDs of the original work items mapped to the IDs of their copies
+	mapping map[uuid.UUID]uuid.UUID
+	// the link types already loaded while walking the subtree
+	linkTypes map[uuid.UUID]*link.WorkItemLinkType
+	// the IDs of the link types of the copied links mapped to the IDs of the
+	// link types available in the target space
+	linkTypeIDs map[uuid.UUID]uuid.UUID
+	// the root iteration and root area of the target space, loaded lazily
+	rootIterationID *uuid.UUID
+	rootAreaID      *uuid.UUID
+}
+
+// copy creates a copy of the given work item in the target space and, if
+// requested, copies its comments and its children along with the
+// tree-topology links to them.
+func (c *workItemCopier) copy(ctx context.Context, wi workitem.WorkItem) (*workitem.WorkItem, error) {
+	fields := make(map[string]interface{}, len(wi.Fields))
+	for name, value := range wi.Fields {
+		fields[name] = value
+	}
+	if c.resetState {
+		fields[workitem.SystemState] = workitem.SystemStateNew
+	}
+	if !uuid.Equal(wi.SpaceID, c.spaceID) {
+		if err := checkWorkItemTypeOfSpace(ctx, c.appl, c.spaceID, wi.Type); err != nil {
+			return nil, errs.WithStack(err)
+		}
+		// iterations and areas are bound to a space
+		if c.rootIterationID == nil {
+			rootIteration, err := c.appl.Iterations().Root(ctx, c.spaceID)
+			if err != nil {
+				return nil, errs.Wrap(err, "unable to load the root iteration of the target space")
+			}
+			rootArea, err := c.appl.Areas().Root(ctx, c.spaceID)
+			if err != nil {
+				return nil, errs.Wrap(err, "unable to load the root area of the target space")
+			}
+			c.rootIterationID = &rootIteration.ID
+			c.rootAreaID = &rootArea.ID
+		}
+		fields[workitem.SystemIteration] = c.rootIterationID.String()
+		fields[workitem.SystemArea] = c.rootAreaID.String()
+	}
+	if c.iterationID != nil {
+		fields[workitem.SystemIteration] = c.iterationID.String()
+	}
+	copiedWI, err := c.appl.WorkItems().Create(ctx, c.spaceID, wi.Type, fields, c.creatorID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	c.mapping[wi.ID] = copiedWI.ID
+	if c.comments {
+		if err := c.copyComments(ctx, wi.ID, copiedWI.ID); err != nil {
+			return nil, errs.WithStack(err)
+		}
+	}
+	if !c.subtree {
+		return copiedWI, nil
+	}
+	links, err := c.appl.WorkItemLinks().ListByWorkItem(ctx, wi.ID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, l := range links {
+		if !uuid.Equal(l.SourceID, wi.ID) {
+			continue
+		}
+		if _, copied := c.mapping[l.TargetID]; copied {
+			continue
+		}
+		linkType, ok := c.linkTypes[l.LinkTypeID]
+		if !ok {
+			linkType, err = c.appl.WorkItemLinkTypes().Load(ctx, l.LinkTypeID)
+			if err != nil {
+				return nil, errs.WithStack(err)
+			}
+			c.linkTypes[l.LinkTypeID] = linkType
+		}
+		if linkType.Topology != link.TopologyTree {
+			continue
+		}
+		linkTypeID, err := c.targetLinkTypeID(ctx, *linkType)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		child, err := c.appl.WorkItems().LoadByID(ctx, l.TargetID)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		copiedChild, err := c.copy(ctx, *child)
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		if _, err := c.appl.WorkItemLinks().Create(ctx, copiedWI.ID, copiedChild.ID, linkTypeID, c.creatorID); err != nil {
+			return nil, errs.WithStack(err)
+		}
+	}
+	return copiedWI, nil
+}
+
+// targetLinkTypeID returns the ID of the link type to use for the copy of a
+// link of the given type: the link type itself if it belongs to the system
+// space or to the target space, otherwise the link type of the target space
+// with the same name. Returns a BadParameterError if the target space has no
+// such link type.
+func (c *workItemCopier) targetLinkTypeID(ctx context.Context, linkType link.WorkItemLinkType) (uuid.UUID, error) {
+	if uuid.Equal(linkType.SpaceID, space.SystemSpace) || uuid.Equal(linkType.SpaceID, c.spaceID) {
+		return linkType.ID, nil
+	}
+	if linkTypeID, ok := c.linkTypeIDs[linkType.ID]; ok {
+		return linkTypeID, nil
+	}
+	targetLinkTypes, err := c.appl.WorkItemLinkTypes().List(ctx, c.spaceID)
+	if err != nil {
+		return uuid.Nil, errs.WithStack(err)
+	}
+	for _, targetLinkType := range targetLinkTypes {
+		if targetLinkType.Name == linkType.Name && targetLinkType.Topology == linkType.Topology {
+			c.linkTypeIDs[linkType.ID] = targetLinkType.ID
+			return targetLinkType.ID, nil
+		}
+	}
+	return uuid.Nil, errors.NewBadParameterError("data.relationships.space", c.spaceID).Expected(fmt.Sprintf("space with a '%s' link type", linkType.Name))
+}
+
+// copyComments copies the comments of the work item with the given ID to
+// its copy on behalf of the copying user, keeping their chronological order
+// and threads.
+func (c *workItemCopier) copyComments(ctx context.Context, wiID, copyID uuid.UUID) error {
+	comments, _, err := c.appl.Comments().List(ctx, wiID, nil, nil)
+	if err != nil {
+		return errs.WithStack(err)
+	}
+	// the copies of the comments by original comment ID, so that the copied
+	// replies reply to the copied comments
+	copiedIDs := make(map[uuid.UUID]uuid.UUID, len(comments))
+	// comments are listed from the most recent to the oldest one, and a reply
+	// is always more recent than the comment it replies to
+	for i := len(comments) - 1; i >= 0; i-- {
+		copiedComment := comment.Comment{
+			ParentID:  copyID,
+			CreatedBy: c.creatorID,
+			Body:      comments[i].Body,
+			Markup:    comments[i].Markup,
+		}
+		if comments[i].ParentCommentID != nil {
+			if parentCommentID, ok := copiedIDs[*comments[i].ParentCommentID]; ok {
+				copiedComment.ParentCommentID = &parentCommentID
+			}
+		}
+		if err := c.appl.Comments().Create(ctx, &copiedComment, c.creatorID); err != nil {
+			return errs.WithStack(err)
+		}
+		copiedIDs[comments[i].ID] = copiedComment.ID
+	}
+	return nil
+}
+
 // Delete does DELETE workitem
 func (c *WorkitemController) Delete(ctx *app.DeleteWorkitemContext) error {
 
@@ -425,6 +889,43 @@ func findLastModified(wis []workitem.WorkItem) time.Time {
 	return t
 }
 
+// setAreaDefaultAssignee assigns a new work item without assignee to the
+// default assignee of its area, if any. A work item without area is put in
+// the root area of the given space first.
+func setAreaDefaultAssignee(ctx context.Context, appl application.Application, spaceID uuid.UUID, fields map[string]interface{}) error {
+	switch assignees := fields[workitem.SystemAssignees].(type) {
+	case []string:
+		if len(assignees) > 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(assignees) > 0 {
+			return nil
+		}
+	}
+	areaID, ok := fields[workitem.SystemArea].(string)
+	if !ok || areaID == "" {
+		rootArea, err := appl.Areas().Root(ctx, spaceID)
+		if err != nil {
+			return errors.NewBadParameterError("space", spaceID).Expected("valid space ID")
+		}
+		areaID = rootArea.ID.String()
+		fields[workitem.SystemArea] = areaID
+	}
+	id, err := uuid.FromString(areaID)
+	if err != nil {
+		return errors.NewBadParameterError(workitem.SystemArea, areaID)
+	}
+	assigneeID, err := appl.Areas().NextDefaultAssignee(ctx, id)
+	if err != nil {
+		return err
+	}
+	if assigneeID != nil {
+		fields[workitem.SystemAssignees] = []string{assigneeID.String()}
+	}
+	return nil
+}
+
 // ConvertJSONAPIToWorkItem is responsible for converting given WorkItem model object into a
 // response resource object by jsonapi.org specifications
 func ConvertJSONAPIToWorkItem(ctx context.Context, method string, appl application.Application, source app.WorkItem, target *workitem.WorkItem, spaceID uuid.UUID) error {
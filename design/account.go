@@ -145,6 +145,26 @@ var _ = a.Resource("users", func() {
 		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
 	})
+
+	a.Action("mentions", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("/:id/mentions"),
+		)
+		a.Description(`List the work items and comments mentioning the user with the given identity ID, the latest first.
+		Only the mentions in the spaces of which the current user is a collaborator are listed.`)
+		a.Params(func() {
+			a.Param("id", d.String, "id")
+			a.Param("page[offset]", d.String, `Paging start position is a string pointing to
+			the beginning of pagination.  The value starts from 0 onwards.`)
+			a.Param("page[limit]", d.Integer, `Paging size is the number of items in a page`)
+		})
+		a.Response(d.OK, mentionList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
 })
 
 // userData represents an identified user object
@@ -37,6 +37,10 @@ var areaAttributes = a.Type("AreaAttributes", func() {
 	a.Attribute("parent_path_resolved", d.String, "Path to the topmost area specified by area names", func() {
 		a.Example("/devtools/planner/planner-ui")
 	})
+	a.Attribute("default-assignee-rule", d.String, `How a new work item of the area without assignee gets one:
+not at all, always the default assignee, or each owner in turn`, func() {
+		a.Enum("none", "fixed", "round-robin")
+	})
 })
 
 var areaRelationships = a.Type("AreaRelations", func() {
@@ -44,6 +48,8 @@ var areaRelationships = a.Type("AreaRelations", func() {
 	a.Attribute("parent", relationGeneric, "This defines the parents' hierarchy for areas")
 	a.Attribute("children", relationGeneric, "This defines the sub-areas present for this area")
 	a.Attribute("workitems", relationGeneric, "This defines the workitems associated with the Area")
+	a.Attribute("owners", relationGenericList, "This defines the identities owning the Area")
+	a.Attribute("default-assignee", relationGeneric, "This defines the identity assigned with the fixed default assignee rule")
 })
 
 var areaList = JSONList(
@@ -109,6 +115,62 @@ var _ = a.Resource("area", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:id"),
+		)
+		a.Description(`Update the name, the owners and the default assignee rule of the area with given id.
+The owners are replaced with the given ones.`)
+		a.Params(func() {
+			a.Param("id", d.String, "id")
+		})
+		a.Payload(areaSingle)
+		a.Response(d.OK, areaSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:id"),
+		)
+		a.Description(`Delete the area with given id along with its child areas. The work items
+of the deleted areas are moved to the target area in the same transaction.`)
+		a.Params(func() {
+			a.Param("id", d.String, "id")
+			a.Param("target", d.UUID, "ID of the area of the same space to which the work items are moved")
+			a.Required("target")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("move", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:id/move"),
+		)
+		a.Description("Move the area with given id, along with its child areas, under another area of the same space.")
+		a.Params(func() {
+			a.Param("id", d.String, "id")
+			a.Param("parent", d.UUID, "ID of the new parent area")
+			a.Required("parent")
+		})
+		a.Response(d.OK, areaSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
 })
 
 // new version of "list" for migration
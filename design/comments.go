@@ -25,6 +25,7 @@ var createComment = a.Type("CreateComment", func() {
 		a.Enum("comments")
 	})
 	a.Attribute("attributes", createCommentAttributes)
+	a.Attribute("relationships", createCommentRelationships)
 	a.Required("type", "attributes")
 })
 
@@ -45,6 +46,35 @@ var commentAttributes = a.Type("CommentAttributes", func() {
 	a.Attribute("markup", d.String, "The comment markup associated with the body", func() {
 		a.Example("Markdown")
 	})
+	a.Attribute("reactions", a.ArrayOf(commentReaction), "The emoji reactions to the comment")
+})
+
+var commentReaction = a.Type("CommentReaction", func() {
+	a.Description("The identities that reacted to a comment with an emoji")
+	a.Attribute("emoji", d.String, "The emoji of the reaction", func() {
+		a.Example("👍")
+	})
+	a.Attribute("count", d.Integer, "The number of identities that reacted with the emoji")
+	a.Attribute("identities", a.ArrayOf(d.UUID), "The identities that reacted with the emoji")
+	a.Required("emoji", "count", "identities")
+})
+
+var createCommentReaction = a.Type("CreateCommentReaction", func() {
+	a.Description(`JSONAPI store for the data of a reaction to a comment.  See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("reactions")
+	})
+	a.Attribute("attributes", createCommentReactionAttributes)
+	a.Required("type", "attributes")
+})
+
+var createCommentReactionAttributes = a.Type("CreateCommentReactionAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a reaction to a comment. +See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("emoji", d.String, "The emoji of the reaction", func() {
+		a.MinLength(1)
+		a.Example("👍")
+	})
+	a.Required("emoji")
 })
 
 var createCommentAttributes = a.Type("CreateCommentAttributes", func() {
@@ -62,6 +92,13 @@ var createCommentAttributes = a.Type("CreateCommentAttributes", func() {
 var commentRelationships = a.Type("CommentRelations", func() {
 	a.Attribute("created-by", commentCreatedBy, "This defines the created by relation")
 	a.Attribute("parent", relationGeneric, "This defines the owning resource of the comment")
+	a.Attribute("parent-comment", relationGeneric, "This defines the comment this comment replies to")
+	a.Attribute("replies", relationGenericList, "This defines the replies to the comment")
+	a.Attribute("mentions", relationGenericList, "This defines the identities mentioned in the comment")
+})
+
+var createCommentRelationships = a.Type("CreateCommentRelations", func() {
+	a.Attribute("parent-comment", relationGeneric, "This defines the comment this comment replies to")
 })
 
 var commentCreatedBy = a.Type("CommentCreatedBy", func() {
@@ -87,6 +124,8 @@ var commentRelationshipsArray = JSONList(
 
 var commentListMeta = a.Type("CommentListMeta", func() {
 	a.Attribute("totalCount", d.Integer)
+	a.Attribute("totalReplies", d.Integer, `The number of replies to the listed comments,
+	at any depth. Only the oldest 100 replies are included.`)
 	a.Required("totalCount")
 })
 
@@ -107,6 +146,11 @@ var createSingleComment = JSONSingle(
 	createComment,
 	nil,
 )
+var createSingleCommentReaction = JSONSingle(
+	"CommentReaction", "Holds the data of a reaction to a comment",
+	createCommentReaction,
+	nil,
+)
 
 var _ = a.Resource("comments", func() {
 	a.BasePath("/comments")
@@ -161,6 +205,24 @@ var _ = a.Resource("comments", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("react", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:commentId/reactions"),
+		)
+		a.Description("Toggle the reaction of the current user with the given emoji to the comment with the given commentId.")
+		a.Params(func() {
+			a.Param("commentId", d.UUID, "commentId")
+		})
+		a.Payload(createSingleCommentReaction)
+		a.Response(d.OK, func() {
+			a.Media(commentSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
 
 })
 
@@ -171,7 +233,8 @@ var _ = a.Resource("work_item_comments", func() {
 		a.Routing(
 			a.GET("comments"),
 		)
-		a.Description("List comments associated with the given work item")
+		a.Description(`List the comments associated with the given work item which do not reply to another
+		comment. Their oldest 100 replies, at any depth, are included.`)
 		a.Params(func() {
 			a.Param("page[offset]", d.String, `Paging start position is a string pointing to
 			the beginning of pagination.  The value starts from 0 onwards.`)
@@ -0,0 +1,67 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var workItemEvent = a.Type("WorkItemEvent", func() {
+	a.Description(`JSONAPI store for the data of an entry in the activity stream of a work item.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("events")
+	})
+	a.Attribute("id", d.UUID, "ID of the revision from which the event was built", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", workItemEventAttributes)
+	a.Attribute("relationships", workItemEventRelationships)
+	a.Required("type", "id", "attributes", "relationships")
+})
+
+var workItemEventAttributes = a.Type("WorkItemEventAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a work item event.
+See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("kind", d.String, "The kind of entity that changed", func() {
+		a.Enum("workitems", "workitemlinks", "comments")
+	})
+	a.Attribute("revision-type", d.String, "The type of modification that was applied on the entity", func() {
+		a.Enum("create", "update", "delete", "move")
+	})
+	a.Attribute("timestamp", d.DateTime, "When the modification occurred", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Required("kind", "revision-type", "timestamp")
+})
+
+var workItemEventRelationships = a.Type("WorkItemEventRelationships", func() {
+	a.Attribute("modifier", relationGeneric, "The identity that made the modification")
+	a.Attribute("subject", relationGeneric, "The work item, work item link or comment that changed")
+})
+
+var workItemEventList = JSONList(
+	"WorkItemEvent", "Holds the paginated activity stream of a work item",
+	workItemEvent,
+	pagingLinks,
+	meta)
+
+var _ = a.Resource("work_item_events", func() {
+	a.Parent("workitem")
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET("events"),
+		)
+		a.Description(`List the revisions of the given work item, of the links in which it is
+the source or the target and of its comments, ordered by time.`)
+		a.Params(func() {
+			a.Param("page[offset]", d.String, `Paging start position is a string pointing to
+			the beginning of pagination.  The value starts from 0 onwards.`)
+			a.Param("page[limit]", d.Integer, `Paging size is the number of items in a page`)
+		})
+		a.Response(d.OK, workItemEventList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+})
@@ -28,9 +28,82 @@ var filterAttributes = a.Type("filterAttributes", func() {
 	a.Attribute("type", d.String, "Path to the topmost parent", func() {
 		a.Example("users")
 	})
+	a.Attribute("scope", d.String, "Who the filter is shared with: system filters are built-in, space filters are visible to all the users of a space and user filters only to their creator", func() {
+		a.Enum("system", "space", "user")
+	})
 	a.Required("type", "title", "description", "query")
 })
 
+// savedFilterData is the JSONAPI store for the data of a saved filter.
+var savedFilterData = a.Type("SavedFilterData", func() {
+	a.Description(`JSONAPI store the data of a saved filter.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("filters")
+	})
+	a.Attribute("id", d.UUID, "ID of saved filter (optional during creation)", func() {
+		a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76")
+	})
+	a.Attribute("attributes", savedFilterAttributes)
+	a.Attribute("relationships", savedFilterRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+// savedFilterAttributes is the JSONAPI store for all the "attributes" of a saved filter.
+var savedFilterAttributes = a.Type("SavedFilterAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a saved filter.
+See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("title", d.String, "Name of the saved filter, unique for its creator within the space (required on creation)", nameValidationFunction)
+	a.Attribute("description", d.String, "Description of the saved filter (optional)", func() {
+		a.Example("Open bugs of the current sprint")
+	})
+	a.Attribute("query", d.String, "The filter expression, in the same language as the filter parameter of the work item list", func() {
+		a.Example(`{"system.state":"open"}`)
+	})
+	a.Attribute("sort", d.String, "Comma separated field names by which the work items are sorted, optionally prefixed with '-' for a descending order", func() {
+		a.Example("-system.updated_at,system.title")
+	})
+	a.Attribute("scope", d.String, "Whether the saved filter is visible to all the users of the space or only to its creator (defaults to user)", func() {
+		a.Enum("space", "user")
+	})
+	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
+		a.Example(0)
+	})
+	a.Attribute("created-at", d.DateTime, "When the saved filter was created", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("updated-at", d.DateTime, "When the saved filter was updated", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+})
+
+// savedFilterRelationships defines the relationships of a saved filter
+var savedFilterRelationships = a.Type("SavedFilterRelationships", func() {
+	a.Attribute("space", relationSpaces, "This defines the space in which the filter is executed.")
+	a.Attribute("creator", relationGeneric, "This defines the creator of the saved filter.")
+})
+
+// createSavedFilterPayload defines the structure of saved filter payload in JSONAPI format during creation
+var createSavedFilterPayload = a.Type("CreateSavedFilterPayload", func() {
+	a.Attribute("data", savedFilterData)
+	a.Required("data")
+})
+
+// updateSavedFilterPayload defines the structure of saved filter payload in JSONAPI format during update
+var updateSavedFilterPayload = a.Type("UpdateSavedFilterPayload", func() {
+	a.Attribute("data", savedFilterData)
+	a.Required("data")
+})
+
+// savedFilterListMeta holds meta information for a saved filter array response
+var savedFilterListMeta = a.Type("SavedFilterListMeta", func() {
+	a.Attribute("totalCount", d.Integer, func() {
+		a.Minimum(0)
+	})
+	a.Required("totalCount")
+})
+
 var filterList = JSONList(
 	"filter", "Holds the list of Filters",
 	filter,
@@ -42,6 +115,23 @@ var filterSingle = JSONSingle(
 	filter,
 	nil)
 
+// savedFilter is the media type for saved filters
+var savedFilter = JSONSingle(
+	"SavedFilter",
+	"A saved filter is a named work item query of a space.",
+	savedFilterData,
+	nil,
+)
+
+// savedFilterList contains the saved filters of a space
+var savedFilterList = JSONList(
+	"SavedFilter",
+	"Holds the response to a saved filter list request",
+	savedFilterData,
+	nil,
+	savedFilterListMeta,
+)
+
 var _ = a.Resource("filter", func() {
 	a.BasePath("/filters")
 	a.CanonicalActionName("list")
@@ -57,3 +147,96 @@ var _ = a.Resource("filter", func() {
 		a.Response(d.InternalServerError, JSONAPIErrors)
 	})
 })
+
+var _ = a.Resource("space_filters", func() {
+	a.Parent("space")
+	a.BasePath("/filters")
+
+	a.Action("show", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("/:filterID"),
+		)
+		a.Description("Retrieve the saved filter with the given ID.")
+		a.Params(func() {
+			a.Param("filterID", d.UUID, "ID of the saved filter")
+		})
+		a.Response(d.OK, func() {
+			a.Media(savedFilter)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
+
+	a.Action("list", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List the saved filters of the space that are visible to the current user.")
+		a.Response(d.OK, func() {
+			a.Media(savedFilterList)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description("Save a filter in the space.")
+		a.Payload(createSavedFilterPayload)
+		a.Response(d.Created, "/filters/.*", func() {
+			a.Media(savedFilter)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:filterID"),
+		)
+		a.Description("Update the saved filter with the given ID.")
+		a.Params(func() {
+			a.Param("filterID", d.UUID, "ID of the saved filter")
+		})
+		a.Payload(updateSavedFilterPayload)
+		a.Response(d.OK, func() {
+			a.Media(savedFilter)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:filterID"),
+		)
+		a.Description("Delete the saved filter with the given ID.")
+		a.Params(func() {
+			a.Param("filterID", d.UUID, "ID of the saved filter")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
@@ -46,6 +46,27 @@ var iterationAttributes = a.Type("IterationAttributes", func() {
 	a.Attribute("resolved_parent_path", d.String, "Path string separataed by / having names of all parent iterations", func() {
 		a.Example("/beta/Web-App/Sprint 9/Sprint 9.1")
 	})
+	a.Attribute("capacities", a.ArrayOf(iterationCapacity), `The capacity of each collaborator during the iteration, along with
+the work assigned to them. The capacities are replaced with the given ones on update.`)
+})
+
+var iterationCapacity = a.Type("IterationCapacity", func() {
+	a.Attribute("assignee", d.UUID, "ID of the identity of the collaborator", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("unit", d.String, `The unit of the capacity and of the committed work, whose work item field
+must be configured`, func() {
+		a.Enum("points", "hours")
+	})
+	a.Attribute("capacity", d.Number, "The work the collaborator can take on during the iteration", func() {
+		a.Minimum(0)
+		a.Example(13)
+	})
+	a.Attribute("committed", d.Number, `The sum of the points or hours field of the work items of the iteration
+(and of its child iterations) assigned to the collaborator`)
+	a.Attribute("committed-count", d.Integer, "The number of work items of the iteration assigned to the collaborator")
+	a.Attribute("over-allocated", d.Boolean, "Whether the committed work exceeds the capacity of the collaborator")
+	a.Required("assignee", "unit", "capacity")
 })
 
 var iterationRelationships = a.Type("IterationRelations", func() {
@@ -65,6 +86,161 @@ var iterationSingle = JSONSingle(
 	iteration,
 	nil)
 
+var iterationBurndown = a.Type("IterationBurndown", func() {
+	a.Description(`JSONAPI store for the daily remaining work of an iteration.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("burndowns")
+	})
+	a.Attribute("id", d.UUID, "ID of the iteration", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", iterationBurndownAttributes)
+	a.Attribute("relationships", iterationReportRelationships)
+	a.Required("type", "id", "attributes")
+})
+
+var iterationBurndownAttributes = a.Type("IterationBurndownAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of an iteration burndown.
+See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("points-field", d.String, "The numeric work item field from which the points are summed", func() {
+		a.Example("storypoints")
+	})
+	a.Attribute("startAt", d.DateTime, "When the iteration starts", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("endAt", d.DateTime, "When the iteration ends", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("days", a.ArrayOf(iterationBurndownDay), "The remaining work at the end of each day of the iteration")
+	a.Required("days")
+})
+
+var iterationBurndownDay = a.Type("IterationBurndownDay", func() {
+	a.Attribute("date", d.DateTime, "The day, at midnight UTC", func() {
+		a.Example("2016-11-29T00:00:00Z")
+	})
+	a.Attribute("total-count", d.Integer, "The number of work items in the iteration")
+	a.Attribute("remaining-count", d.Integer, "The number of work items in the iteration that are not closed")
+	a.Attribute("total-points", d.Number, "The sum of the points of the work items in the iteration")
+	a.Attribute("remaining-points", d.Number, "The sum of the points of the work items in the iteration that are not closed")
+	a.Required("date", "total-count", "remaining-count", "total-points", "remaining-points")
+})
+
+var iterationVelocity = a.Type("IterationVelocity", func() {
+	a.Description(`JSONAPI store for the work committed to and completed during a past iteration.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("velocities")
+	})
+	a.Attribute("id", d.UUID, "ID of the iteration", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", iterationVelocityAttributes)
+	a.Attribute("relationships", iterationReportRelationships)
+	a.Required("type", "id", "attributes")
+})
+
+var iterationVelocityAttributes = a.Type("IterationVelocityAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of an iteration velocity.
+See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("name", d.String, "The iteration name", func() {
+		a.Example("Sprint #42")
+	})
+	a.Attribute("startAt", d.DateTime, "When the iteration starts", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("endAt", d.DateTime, "When the iteration ends", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("committed-count", d.Integer, "The number of work items in the iteration at the end of its first day")
+	a.Attribute("committed-points", d.Number, "The sum of the points of the work items in the iteration at the end of its first day")
+	a.Attribute("completed-count", d.Integer, "The number of work items closed in the iteration at its end")
+	a.Attribute("completed-points", d.Number, "The sum of the points of the work items closed in the iteration at its end")
+	a.Required("name", "committed-count", "committed-points", "completed-count", "completed-points")
+})
+
+var iterationReportRelationships = a.Type("IterationReportRelations", func() {
+	a.Attribute("iteration", relationGeneric, "This defines the reported iteration")
+})
+
+var iterationBurndownSingle = JSONSingle(
+	"IterationBurndown", "Holds the burndown of an iteration",
+	iterationBurndown,
+	nil)
+
+var iterationVelocityList = JSONList(
+	"IterationVelocity", "Holds the velocity of the past iterations of a space",
+	iterationVelocity,
+	nil,
+	nil)
+
+var iterationClose = a.Type("IterationClose", func() {
+	a.Description(`JSONAPI store for the options of an iteration close request`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("iterationclosings")
+	})
+	a.Attribute("attributes", iterationCloseAttributes)
+	a.Attribute("relationships", iterationCloseRelationships)
+	a.Required("type")
+})
+
+var iterationCloseAttributes = a.Type("IterationCloseAttributes", func() {
+	a.Attribute("include-children", d.Boolean, `Whether the not-closed children of the moved work items
+(following tree-topology links) should be moved as well`)
+})
+
+var iterationCloseRelationships = a.Type("IterationCloseRelationships", func() {
+	a.Attribute("target", relationGeneric, `The iteration to which the not-closed work items are moved
+(defaults to the root iteration of the space, i.e. the backlog)`)
+})
+
+var iterationCloseSingle = JSONSingle(
+	"IterationClose", "Holds the options of an iteration close request",
+	iterationClose,
+	nil)
+
+var iterationCarryOver = a.Type("IterationCarryOver", func() {
+	a.Description(`JSONAPI store for a work item moved to another iteration when closing an iteration.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("iterationcarryovers")
+	})
+	a.Attribute("id", d.UUID, "ID of the carry-over", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", iterationCarryOverAttributes)
+	a.Attribute("relationships", iterationCarryOverRelationships)
+	a.Required("type", "id", "attributes", "relationships")
+})
+
+var iterationCarryOverAttributes = a.Type("IterationCarryOverAttributes", func() {
+	a.Attribute("created-at", d.DateTime, "When the work item was moved", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Required("created-at")
+})
+
+var iterationCarryOverRelationships = a.Type("IterationCarryOverRelationships", func() {
+	a.Attribute("iteration", relationGeneric, "The closed iteration")
+	a.Attribute("target", relationGeneric, "The iteration to which the work item was moved")
+	a.Attribute("workitem", relationGeneric, "The moved work item")
+	a.Attribute("modifier", relationGeneric, "The identity that closed the iteration")
+})
+
+var iterationCarryOverList = JSONList(
+	"IterationCarryOver", "Holds the work items moved to another iteration when closing an iteration",
+	iterationCarryOver,
+	nil,
+	meta)
+
+// pointsFieldParam is the query parameter naming the numeric work item field
+// from which the points of the iteration reports are summed
+func pointsFieldParam() {
+	a.Param("field", d.String, `The name of a numeric work item field (e.g. story points)
+		from which the points are summed. Defaults to the configured points field.`)
+}
+
 // new version of "list" for migration
 var _ = a.Resource("iteration", func() {
 	a.BasePath("/iterations")
@@ -121,6 +297,90 @@ var _ = a.Resource("iteration", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:iterationID"),
+		)
+		a.Description(`Delete the iteration with given id along with its child iterations. The work items
+of the deleted iterations are moved to the target iteration in the same transaction.`)
+		a.Params(func() {
+			a.Param("iterationID", d.String, "Iteration Identifier")
+			a.Param("target", d.UUID, "ID of the iteration of the same space to which the work items are moved")
+			a.Required("target")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("move", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:iterationID/move"),
+		)
+		a.Description("Move the iteration with given id, along with its child iterations, under another iteration of the same space.")
+		a.Params(func() {
+			a.Param("iterationID", d.String, "Iteration Identifier")
+			a.Param("parent", d.UUID, "ID of the new parent iteration")
+			a.Required("parent")
+		})
+		a.Response(d.OK, iterationSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("close", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:iterationID/close"),
+		)
+		a.Description(`Close the iteration with given id and move its not-closed work items, optionally
+with their not-closed children, to the target iteration in the same transaction. The response
+lists the moved work items, which can be retrieved later with the carry-overs action.`)
+		a.Params(func() {
+			a.Param("iterationID", d.String, "Iteration Identifier")
+		})
+		a.Payload(iterationCloseSingle)
+		a.Response(d.OK, iterationCarryOverList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("carry-overs", func() {
+		a.Routing(
+			a.GET("/:iterationID/carry-overs"),
+		)
+		a.Description("List the work items moved to another iteration when closing the iteration with given id.")
+		a.Params(func() {
+			a.Param("iterationID", d.String, "Iteration Identifier")
+		})
+		a.Response(d.OK, iterationCarryOverList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+	a.Action("burndown", func() {
+		a.Routing(
+			a.GET("/:iterationID/burndown"),
+		)
+		a.Description(`Retrieve the remaining work of the iteration with given id, and of its
+child iterations, at the end of each day between its start and its end.`)
+		a.Params(func() {
+			a.Param("iterationID", d.String, "Iteration Identifier")
+			pointsFieldParam()
+		})
+		a.Response(d.OK, iterationBurndownSingle)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
 })
 
 // new version of "list" for migration
@@ -162,4 +422,17 @@ var _ = a.Resource("space_iterations", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("velocity", func() {
+		a.Routing(
+			a.GET("velocity"),
+		)
+		a.Description("List the work committed to and completed during the past iterations of the space.")
+		a.Params(func() {
+			pointsFieldParam()
+		})
+		a.Response(d.OK, iterationVelocityList)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
 })
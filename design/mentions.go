@@ -0,0 +1,59 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+// mentionData is the JSONAPI store for the data of a mention.
+var mentionData = a.Type("MentionData", func() {
+	a.Description(`JSONAPI store for the data of the mention of a user in a work item or a comment.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("mentions")
+	})
+	a.Attribute("id", d.UUID, "ID of the mention", func() {
+		a.Example("40bbdd3d-8b5d-4fd6-ac90-7236b669af04")
+	})
+	a.Attribute("attributes", mentionAttributes)
+	a.Attribute("relationships", mentionRelationships)
+	a.Required("type", "id", "attributes", "relationships")
+})
+
+// mentionAttributes is the JSONAPI store for all the "attributes" of a mention.
+var mentionAttributes = a.Type("MentionAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a mention.
+See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("username", d.String, "The username as written in the mention, in lower case", func() {
+		a.Example("jdoe")
+	})
+	a.Attribute("created-at", d.DateTime, "When the user was first mentioned", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Required("username", "created-at")
+})
+
+// mentionRelationships is the JSONAPI store for the relationships of a mention.
+var mentionRelationships = a.Type("MentionRelationships", func() {
+	a.Attribute("identity", relationGeneric, "The mentioned user identity")
+	a.Attribute("workitem", relationGeneric, "The work item mentioning the user, or whose comment does")
+	a.Attribute("comment", relationGeneric, "The comment mentioning the user, if any")
+	a.Attribute("space", relationGeneric, "The space of the work item")
+	a.Required("identity", "workitem", "space")
+})
+
+var mentionListMeta = a.Type("MentionListMeta", func() {
+	a.Attribute("totalCount", d.Integer, func() {
+		a.Minimum(0)
+	})
+	a.Required("totalCount")
+})
+
+// mentionList contains the mentions of a user
+var mentionList = JSONList(
+	"Mention",
+	"Holds the response to a mention list request",
+	mentionData,
+	pagingLinks,
+	mentionListMeta,
+)
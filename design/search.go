@@ -31,10 +31,24 @@ var _ = a.Resource("search", func() {
 				1) "id:100" :- Look for work item hainvg id 100
 				2) "url:http://demo.openshift.io/details/500" :- Search on WI having id 500 and check 
 					if this URL is mentioned in searchable columns of work item
-				3) "simple keywords separated by space" :- Search in Work Items based on these keywords.`)
+				3) "simple keywords separated by space" :- Search in Work Items based on these keywords.
+				4) "some phrase" in double quotes :- Search in Work Items containing these words following each other.
+					A single URL in double quotes is searched as a URL.
+				5) "-keyword" or a phrase prefixed with "-" :- Exclude the Work Items containing this keyword or phrase.
+				6) "keyword1 OR keyword2" :- Search in Work Items containing either of the keywords or phrases.
+				7) "in:title", "in:description" or "in:comments" :- Restrict where the keywords must match,
+					by default in the title, the description and the comments of the Work Items.`)
 			a.Param("page[offset]", d.String, "Paging start position") // #428
 			a.Param("page[limit]", d.Integer, "Paging size")
 			a.Param("spaceID", d.String, "The optional space ID of the space to be searched in")
+			a.Param("filter", d.String, "a query language expression restricting the set of found work items")
+			a.Param("filter[assignee]", d.String, "Work Items assigned to the given user")
+			a.Param("filter[iteration]", d.String, "IterationID to filter work items")
+			a.Param("filter[workitemtype]", d.UUID, "ID of work item type to filter work items by")
+			a.Param("filter[area]", d.String, "AreaID to filter work items")
+			a.Param("filter[workitemstate]", d.String, "work item state to filter work items by")
+			a.Param("highlight[start]", d.String, "The tag inserted before the matching words in the highlighted excerpts (defaults to <b>)")
+			a.Param("highlight[stop]", d.String, "The tag inserted after the matching words in the highlighted excerpts (defaults to </b>)")
 			a.Required("q")
 		})
 		a.Response(d.OK, func() {
@@ -52,6 +66,7 @@ var _ = a.Resource("search", func() {
 			a.Param("q", d.String, "Text to match against Name or description")
 			a.Param("page[offset]", d.String, "Paging start position")
 			a.Param("page[limit]", d.Integer, "Paging size")
+			a.Param("filter[archived]", d.Boolean, "if true search the archived spaces instead of the other ones")
 			a.Required("q")
 		})
 		a.Response(d.OK, func() {
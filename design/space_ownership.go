@@ -0,0 +1,35 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+var _ = a.Resource("space_ownership", func() {
+	a.Parent("space")
+
+	a.Action("transfer", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/transfer"),
+		)
+		a.Description(`Transfer the ownership of the space to the given identity, which is added to the
+space collaborators. The transfer is rejected with a conflict if the new owner already has a space with
+the same name. Only the current owner of the space or a space admin can transfer it.`)
+		a.Payload(transferSpacePayload)
+		a.Response(d.OK, func() {
+			a.Media(spaceSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
+
+var transferSpacePayload = a.Type("TransferSpacePayload", func() {
+	a.Attribute("data", updateUserID, "The identity of the new owner of the space")
+	a.Required("data")
+})
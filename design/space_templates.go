@@ -0,0 +1,208 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+// spaceTemplateData is the JSONAPI store for the data of a space template.
+var spaceTemplateData = a.Type("SpaceTemplateData", func() {
+	a.Description(`JSONAPI store the data of a space template.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("spacetemplates")
+	})
+	a.Attribute("id", d.UUID, "ID of space template (read-only)", func() {
+		a.Example("6b8bd2c4-2bda-4a64-a0d6-9e0d5c3f1a01")
+	})
+	a.Attribute("attributes", spaceTemplateAttributes)
+	a.Attribute("relationships", spaceTemplateRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+// spaceTemplateAttributes is the JSONAPI store for all the "attributes" of a space template.
+var spaceTemplateAttributes = a.Type("SpaceTemplateAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a space template.
+See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("name", d.String, "Name of the space template, unique in the installation (required on creation)", nameValidationFunction)
+	a.Attribute("description", d.String, "Description of the space template (optional)", func() {
+		a.Example("Plan the work of the team in two weeks sprints.")
+	})
+	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (read-only)", func() {
+		a.Example(0)
+	})
+	a.Attribute("created-at", d.DateTime, "When the space template was created", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("updated-at", d.DateTime, "When the space template was updated", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("work-item-types", a.ArrayOf(spaceTemplateWorkItemType), "The work item types created in the space")
+	a.Attribute("link-types", a.ArrayOf(spaceTemplateLinkType), "The work item link types created in the space")
+	a.Attribute("areas", a.ArrayOf(spaceTemplateArea), "The areas created below the root area of the space")
+	a.Attribute("cadence", spaceTemplateCadence, "The iterations created below the root iteration of the space")
+})
+
+// spaceTemplateWorkItemType is a work item type created by a space template
+var spaceTemplateWorkItemType = a.Type("SpaceTemplateWorkItemType", func() {
+	a.Attribute("name", d.String, "The human readable name of the work item type", nameValidationFunction)
+	a.Attribute("description", d.String, "A human readable description for the work item type")
+	a.Attribute("icon", d.String, "CSS class string for an icon to use", func() {
+		a.Example("fa fa-bookmark")
+		a.MinLength(1)
+	})
+	a.Attribute("extended-type", d.UUID, "ID of the work item type of the system space whose fields are inherited (defaults to the planner item)")
+	a.Attribute("states", a.ArrayOf(d.String), "The values of the state field, in workflow order (defaults to the ones of the extended type)", func() {
+		a.Example([]string{"new", "ready", "in progress", "closed"})
+	})
+	a.Attribute("fields", a.HashOf(d.String, fieldDefinition), "The fields added to (or overriding) the ones of the extended type")
+	a.Required("name", "icon")
+})
+
+// spaceTemplateLinkType is a work item link type created by a space template
+var spaceTemplateLinkType = a.Type("SpaceTemplateLinkType", func() {
+	a.Attribute("name", d.String, "Name of the work item link type", func() {
+		a.Example("Duplicate")
+	})
+	a.Attribute("description", d.String, "Description of the work item link type")
+	a.Attribute("topology", d.String, "The topology determines the restrictions placed on the usage of the link type", func() {
+		a.Enum("network", "directed_network", "dependency", "tree")
+	})
+	a.Attribute("forward-name", d.String, `The forward oriented path from source to target is described with the forward name`, func() {
+		a.Example("duplicates")
+	})
+	a.Attribute("reverse-name", d.String, `The backwards oriented path from target to source is described with the reverse name`, func() {
+		a.Example("is duplicated by")
+	})
+	a.Attribute("link-category", d.UUID, "ID of the work item link category of the link type")
+	a.Required("name", "topology", "forward-name", "reverse-name", "link-category")
+})
+
+// spaceTemplateArea is an area created by a space template, along with its child areas
+var spaceTemplateArea = a.Type("SpaceTemplateArea", func() {
+	a.Attribute("name", d.String, "The name of the area", nameValidationFunction)
+	a.Attribute("children", a.ArrayOf("SpaceTemplateArea"), "The child areas of the area")
+	a.Required("name")
+})
+
+// spaceTemplateCadence describes the iterations created by a space template
+var spaceTemplateCadence = a.Type("SpaceTemplateCadence", func() {
+	a.Description(`A series of consecutive iterations of the same length, the first one
+starting on the day the space is created.`)
+	a.Attribute("name", d.String, "The prefix of the names of the iterations, which are numbered from 1", func() {
+		a.Example("Sprint")
+	})
+	a.Attribute("days", d.Integer, "The length of each iteration in days", func() {
+		a.Minimum(1)
+		a.Maximum(365)
+		a.Example(14)
+	})
+	a.Attribute("count", d.Integer, "The number of iterations to create", func() {
+		a.Minimum(1)
+		a.Maximum(100)
+		a.Example(4)
+	})
+	a.Required("name", "days", "count")
+})
+
+// spaceTemplateRelationships defines the relationships of a space template
+var spaceTemplateRelationships = a.Type("SpaceTemplateRelationships", func() {
+	a.Attribute("space", relationGeneric, `The space from which the work item types, link types, areas and
+iterations are exported instead of taking them from the attributes (only used on creation)`)
+})
+
+// createSpaceTemplatePayload defines the structure of space template payload in JSONAPI format during creation
+var createSpaceTemplatePayload = a.Type("CreateSpaceTemplatePayload", func() {
+	a.Attribute("data", spaceTemplateData)
+	a.Required("data")
+})
+
+// spaceTemplateListMeta holds meta information for a space template array response
+var spaceTemplateListMeta = a.Type("SpaceTemplateListMeta", func() {
+	a.Attribute("totalCount", d.Integer, func() {
+		a.Minimum(0)
+	})
+	a.Required("totalCount")
+})
+
+// ############################################################################
+//
+//  Media Type Definition
+//
+// ############################################################################
+
+// spaceTemplate is the media type for space templates
+var spaceTemplate = JSONSingle(
+	"SpaceTemplate",
+	`A space template is a named process preset which is set up in a space when it
+is created.`,
+	spaceTemplateData,
+	nil,
+)
+
+// spaceTemplateList contains all the space templates
+var spaceTemplateList = JSONList(
+	"SpaceTemplate",
+	"Holds the response to a space template list request",
+	spaceTemplateData,
+	nil,
+	spaceTemplateListMeta,
+)
+
+// ############################################################################
+//
+//  Resource Definition
+//
+// ############################################################################
+
+var _ = a.Resource("space_templates", func() {
+	a.BasePath("/spacetemplates")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET("/:templateID"),
+		)
+		a.Description("Retrieve the space template with the given ID.")
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the space template")
+		})
+		a.Response(d.OK, func() {
+			a.Media(spaceTemplate)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List the space templates.")
+		a.Response(d.OK, func() {
+			a.Media(spaceTemplateList)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description(`Create a space template, either from the given attributes or by exporting the work item types,
+link types, areas and iterations of the given space, which the current user must own. The templates are
+listed to every user, so only a space admin can create them.`)
+		a.Payload(createSpaceTemplatePayload)
+		a.Response(d.Created, "/spacetemplates/.*", func() {
+			a.Media(spaceTemplate)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
@@ -48,6 +48,8 @@ var spaceRelationships = a.Type("SpaceRelationships", func() {
 	a.Attribute("workitems", relationGeneric, "Space can have one or many work items")
 	a.Attribute("codebases", relationGeneric, "Space can have one or many codebases")
 	a.Attribute("collaborators", relationGeneric, `Space can have one or many collaborators`)
+	a.Attribute("template", relationGeneric, `The space template whose work item types, link types, areas and
+iterations are set up in the space (only used on creation)`)
 })
 
 var spaceOwnedBy = a.Type("SpaceOwnedBy", func() {
@@ -61,6 +63,14 @@ var spaceAttributes = a.Type("SpaceAttributes", func() {
 	a.Attribute("description", d.String, "Description for the space", func() {
 		a.Example("This is the foobar collaboration space")
 	})
+	a.Attribute("text-search-config", d.String, "PostgreSQL text search configuration used to index and search the work items and comments of the space (defaults to english)", func() {
+		a.Example("german")
+	})
+	a.Attribute("archived", d.Boolean, `Whether the space is archived. The work items, comments, iterations and areas of
+an archived space are read-only, and the space is hidden from the space list and search unless
+archived spaces are asked for. Only the owner can archive and unarchive a space (ignored on creation)`, func() {
+		a.Example(false)
+	})
 	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
 		a.Example(23)
 	})
@@ -106,6 +116,12 @@ var relationSpacesData = a.Type("RelationSpacesData", func() {
 	a.Attribute("links", genericLinks)
 })
 
+// importSpacePayload holds the archive of a space to import
+var importSpacePayload = a.Type("ImportSpacePayload", func() {
+	a.Attribute("data", a.HashOf(d.String, d.Any), "The archive produced by the export of a space")
+	a.Required("data")
+})
+
 var _ = a.Resource("space", func() {
 	a.BasePath("/spaces")
 
@@ -134,6 +150,7 @@ var _ = a.Resource("space", func() {
 		a.Params(func() {
 			a.Param("page[offset]", d.String, "Paging start position")
 			a.Param("page[limit]", d.Integer, "Paging size")
+			a.Param("filter[archived]", d.Boolean, "if true list the archived spaces instead of the other ones")
 		})
 		a.UseTrait("conditional")
 		a.Response(d.OK, spaceList)
@@ -194,4 +211,48 @@ var _ = a.Resource("space", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+
+	a.Action("export", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.GET("/:spaceID/export"),
+		)
+		a.Description(`Export the space with the given ID, along with its work item types, link types
+and categories, areas, iterations, work items with their revisions, links, comments and codebases, as
+a versioned JSON archive whose "data" member is the archive. The response can be posted as is to the
+import action. Only the owner of the space can export it.`)
+		a.Params(func() {
+			a.Param("spaceID", d.UUID, "ID of the space to export")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("import", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/import"),
+		)
+		a.Description(`Import the space of an archive produced by the export action as a new space
+owned by the current user. The IDs and the work item numbers of the archive are remapped, along with
+the #number references in the descriptions and comments, and the identities are mapped by username or
+else by email to the collaborators of the space (defaulting to the current user). The link categories
+of the archive must already exist, since they are shared by all the spaces. Importing the same archive
+again only adds the entities which were not imported yet to the space.`)
+		a.Payload(importSpacePayload)
+		a.Response(d.Created, "/spaces/.*", func() {
+			a.Media(spaceSingle)
+		})
+		a.Response(d.OK, func() {
+			a.Media(spaceSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
 })
@@ -93,6 +93,45 @@ var relationWorkItemData = a.Type("RelationWorkItemData", func() {
 	a.Required("type", "id")
 })
 
+// workItemLinkRevisionData is the JSONAPI store for the data of a work item link revision.
+var workItemLinkRevisionData = a.Type("WorkItemLinkRevisionData", func() {
+	a.Description(`JSONAPI store for the data of a work item link revision.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemlinkrevisions")
+	})
+	a.Attribute("id", d.UUID, "ID of work item link revision")
+	a.Attribute("attributes", workItemLinkRevisionAttributes)
+	a.Attribute("relationships", workItemLinkRevisionRelationships)
+	a.Required("type", "id", "attributes", "relationships")
+})
+
+// workItemLinkRevisionAttributes is the JSONAPI store for all the "attributes" of a work item link revision.
+var workItemLinkRevisionAttributes = a.Type("WorkItemLinkRevisionAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a work item link revision.
+See also see http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("revision-type", d.String, "The type of modification that was applied on the work item link", func() {
+		a.Enum("create", "update", "delete")
+	})
+	a.Attribute("revision-time", d.DateTime, "When the modification occurred", func() {
+		a.Example("2016-11-29T23:18:14Z")
+	})
+	a.Attribute("version", d.Integer, "Version of the work item link after the modification", func() {
+		a.Example(0)
+	})
+	a.Required("revision-type", "revision-time", "version")
+})
+
+// workItemLinkRevisionRelationships is the JSONAPI store for the relationships of a work item link revision.
+var workItemLinkRevisionRelationships = a.Type("WorkItemLinkRevisionRelationships", func() {
+	a.Description(`JSONAPI store for the relationships of a work item link revision.
+See also http://jsonapi.org/format/#document-resource-object-relationships`)
+	a.Attribute("modifier", relationGeneric, "The identity that modified the work item link.")
+	a.Attribute("link_type", relationWorkItemLinkType, "The work item link type of the work item link at the time of the revision.")
+	a.Attribute("source", relationWorkItem, "Source work item of the work item link at the time of the revision.")
+	a.Attribute("target", relationWorkItem, "Target work item of the work item link at the time of the revision.")
+})
+
 // ############################################################################
 //
 //  Media Type Definition
@@ -116,6 +155,15 @@ var workItemLinkList = JSONList(
 	workItemLinkListMeta,
 )
 
+// workItemLinkRevisionList contains the revisions of a work item link
+var workItemLinkRevisionList = JSONList(
+	"WorkItemLinkRevision",
+	"Holds the response to a work item link revisions list request",
+	workItemLinkRevisionData,
+	nil,
+	workItemLinkListMeta,
+)
+
 // ############################################################################
 //
 //  Resource Definition
@@ -128,6 +176,7 @@ var _ = a.Resource("work_item_link", func() {
 	a.Action("create", createWorkItemLink)
 	a.Action("delete", deleteWorkItemLink)
 	a.Action("update", updateWorkItemLink)
+	a.Action("list-revisions", listWorkItemLinkRevisions)
 })
 
 var _ = a.Resource("work_item_relationships_links", func() {
@@ -186,6 +235,7 @@ func createWorkItemLink() {
 	a.Response(d.BadRequest, JSONAPIErrors)
 	a.Response(d.InternalServerError, JSONAPIErrors)
 	a.Response(d.Unauthorized, JSONAPIErrors)
+	a.Response(d.Forbidden, JSONAPIErrors)
 	a.Response(d.NotFound, JSONAPIErrors)
 }
 
@@ -203,6 +253,7 @@ func deleteWorkItemLink() {
 	a.Response(d.InternalServerError, JSONAPIErrors)
 	a.Response(d.NotFound, JSONAPIErrors)
 	a.Response(d.Unauthorized, JSONAPIErrors)
+	a.Response(d.Forbidden, JSONAPIErrors)
 }
 
 func updateWorkItemLink() {
@@ -223,4 +274,19 @@ func updateWorkItemLink() {
 	a.Response(d.InternalServerError, JSONAPIErrors)
 	a.Response(d.NotFound, JSONAPIErrors)
 	a.Response(d.Unauthorized, JSONAPIErrors)
+	a.Response(d.Forbidden, JSONAPIErrors)
+}
+
+func listWorkItemLinkRevisions() {
+	a.Description("List the revisions of the work item link with the given ID.")
+	a.Routing(
+		a.GET("/:linkId/revisions"),
+	)
+	a.Params(func() {
+		a.Param("linkId", d.UUID, "ID of the work item link")
+	})
+	a.Response(d.OK, workItemLinkRevisionList)
+	a.Response(d.BadRequest, JSONAPIErrors)
+	a.Response(d.InternalServerError, JSONAPIErrors)
+	a.Response(d.NotFound, JSONAPIErrors)
 }
@@ -0,0 +1,247 @@
+package design
+
+import (
+	d "github.com/goadesign/goa/design"
+	a "github.com/goadesign/goa/design/apidsl"
+)
+
+// workItemTemplateData is the JSONAPI store for the data of a work item template.
+var workItemTemplateData = a.Type("WorkItemTemplateData", func() {
+	a.Description(`JSONAPI store the data of a work item template.
+See also http://jsonapi.org/format/#document-resource-object`)
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemtemplates")
+	})
+	a.Attribute("id", d.UUID, "ID of work item template (optional during creation)", func() {
+		a.Example("6c5610be-30b2-4880-9fec-81e4f8e4fd76")
+	})
+	a.Attribute("attributes", workItemTemplateAttributes)
+	a.Attribute("relationships", workItemTemplateRelationships)
+	a.Attribute("links", genericLinks)
+	a.Required("type", "attributes")
+})
+
+// workItemTemplateAttributes is the JSONAPI store for all the "attributes" of a work item template.
+var workItemTemplateAttributes = a.Type("WorkItemTemplateAttributes", func() {
+	a.Description(`JSONAPI store for all the "attributes" of a work item template.
+See also http://jsonapi.org/format/#document-resource-object-attributes`)
+	a.Attribute("name", d.String, "Name of the work item template, unique within its space (required on creation)", nameValidationFunction)
+	a.Attribute("description", d.String, "Description of the work item template (optional)", func() {
+		a.Example("The tasks to perform for every release.")
+	})
+	a.Attribute("version", d.Integer, "Version for optimistic concurrency control (optional during creating)", func() {
+		a.Example(0)
+	})
+	a.Attribute("items", a.ArrayOf(workItemTemplateItem), "The top-level items of the template (required on creation)")
+	a.Attribute("placeholders", a.ArrayOf(d.String), "The names of the placeholders used in the titles and descriptions of the items (read-only)", func() {
+		a.Example([]string{"release"})
+	})
+})
+
+// workItemTemplateItem is a pre-filled work item of a template, along with its children
+var workItemTemplateItem = a.Type("WorkItemTemplateItem", func() {
+	a.Description(`A pre-filled work item of a template. The title and the description may contain
+placeholders like "{{release}}" that are replaced when the template is instantiated.`)
+	a.Attribute("type", d.UUID, "ID of the type of the work item to create")
+	a.Attribute("fields", a.HashOf(d.String, d.Any), "The field values of the work item to create", func() {
+		a.Example(map[string]interface{}{"system.title": "Prepare the {{release}} release notes"})
+	})
+	a.Attribute("link-type", d.UUID, "ID of the type of the link from the parent item to this item (required for child items)")
+	a.Attribute("children", a.ArrayOf("WorkItemTemplateItem"), "The items linked from this item")
+	a.Required("type")
+})
+
+// workItemTemplateRelationships defines the relationships of a work item template
+var workItemTemplateRelationships = a.Type("WorkItemTemplateRelationships", func() {
+	a.Attribute("space", relationSpaces, "This defines the owning space of this work item template.")
+})
+
+// createWorkItemTemplatePayload defines the structure of work item template payload in JSONAPI format during creation
+var createWorkItemTemplatePayload = a.Type("CreateWorkItemTemplatePayload", func() {
+	a.Attribute("data", workItemTemplateData)
+	a.Required("data")
+})
+
+// updateWorkItemTemplatePayload defines the structure of work item template payload in JSONAPI format during update
+var updateWorkItemTemplatePayload = a.Type("UpdateWorkItemTemplatePayload", func() {
+	a.Attribute("data", workItemTemplateData)
+	a.Required("data")
+})
+
+// workItemTemplateListMeta holds meta information for a work item template array response
+var workItemTemplateListMeta = a.Type("WorkItemTemplateListMeta", func() {
+	a.Attribute("totalCount", d.Integer, func() {
+		a.Minimum(0)
+	})
+	a.Required("totalCount")
+})
+
+// instantiateWorkItemTemplatePayload defines where and with which placeholder
+// values a work item template is instantiated
+var instantiateWorkItemTemplatePayload = a.Type("InstantiateWorkItemTemplatePayload", func() {
+	a.Attribute("data", workItemTemplateInstantiation)
+	a.Required("data")
+})
+
+// workItemTemplateInstantiation is the JSONAPI store for the data of a work item template instantiation.
+var workItemTemplateInstantiation = a.Type("WorkItemTemplateInstantiation", func() {
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemtemplateinstantiations")
+	})
+	a.Attribute("attributes", workItemTemplateInstantiationAttributes)
+	a.Attribute("relationships", workItemTemplateInstantiationRelationships)
+	a.Required("type")
+})
+
+// workItemTemplateInstantiationAttributes holds the values of the placeholders
+var workItemTemplateInstantiationAttributes = a.Type("WorkItemTemplateInstantiationAttributes", func() {
+	a.Attribute("parameters", a.HashOf(d.String, d.String), "The values of the placeholders of the template", func() {
+		a.Example(map[string]string{"release": "1.2"})
+	})
+})
+
+// workItemTemplateInstantiationRelationships defines the iteration and area of the created work items
+var workItemTemplateInstantiationRelationships = a.Type("WorkItemTemplateInstantiationRelationships", func() {
+	a.Attribute("iteration", relationGeneric, "The iteration of the created work items (defaults to the root iteration of the space)")
+	a.Attribute("area", relationGeneric, "The area of the created work items (defaults to the root area of the space)")
+})
+
+// ############################################################################
+//
+//  Media Type Definition
+//
+// ############################################################################
+
+// workItemTemplate is the media type for work item templates
+var workItemTemplate = JSONSingle(
+	"WorkItemTemplate",
+	`A work item template is a named tree of pre-filled work items that can be
+instantiated in the space it belongs to.`,
+	workItemTemplateData,
+	nil,
+)
+
+// workItemTemplateList contains the work item templates of a space
+var workItemTemplateList = JSONList(
+	"WorkItemTemplate",
+	"Holds the response to a work item template list request",
+	workItemTemplateData,
+	nil,
+	workItemTemplateListMeta,
+)
+
+// ############################################################################
+//
+//  Resource Definition
+//
+// ############################################################################
+
+var _ = a.Resource("work_item_templates", func() {
+	a.Parent("space")
+	a.BasePath("/templates")
+
+	a.Action("show", func() {
+		a.Routing(
+			a.GET("/:templateID"),
+		)
+		a.Description("Retrieve the work item template with the given ID.")
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the work item template")
+		})
+		a.Response(d.OK, func() {
+			a.Media(workItemTemplate)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("list", func() {
+		a.Routing(
+			a.GET(""),
+		)
+		a.Description("List the work item templates of the space.")
+		a.Response(d.OK, func() {
+			a.Media(workItemTemplateList)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+	})
+
+	a.Action("create", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST(""),
+		)
+		a.Description("Create a work item template in the space.")
+		a.Payload(createWorkItemTemplatePayload)
+		a.Response(d.Created, "/templates/.*", func() {
+			a.Media(workItemTemplate)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("update", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.PATCH("/:templateID"),
+		)
+		a.Description("Update the work item template with the given ID.")
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the work item template")
+		})
+		a.Payload(updateWorkItemTemplatePayload)
+		a.Response(d.OK, func() {
+			a.Media(workItemTemplate)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("delete", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.DELETE("/:templateID"),
+		)
+		a.Description("Delete the work item template with the given ID.")
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the work item template")
+		})
+		a.Response(d.OK)
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+
+	a.Action("instantiate", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:templateID/instantiate"),
+		)
+		a.Description(`Create the tree of work items of the template with the given ID in the space,
+replacing the placeholders with the given parameters.`)
+		a.Params(func() {
+			a.Param("templateID", d.UUID, "ID of the work item template")
+		})
+		a.Payload(instantiateWorkItemTemplatePayload)
+		a.Response(d.Created, func() {
+			a.Media(workItemList)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+})
@@ -28,6 +28,7 @@ var workItem = a.Type("WorkItem", func() {
 	})
 	a.Attribute("relationships", workItemRelationships)
 	a.Attribute("links", genericLinksForWorkItem)
+	a.Attribute("meta", a.HashOf(d.String, d.Any), "Additional information about the work item, e.g. why it matched a search")
 	a.Required("type", "attributes")
 })
 
@@ -40,6 +41,8 @@ var workItemRelationships = a.Type("WorkItemRelationships", func() {
 	a.Attribute("iteration", relationGeneric, "This defines the iteration this work item belong to")
 	a.Attribute("area", relationGeneric, "This defines the area this work item belongs to")
 	a.Attribute("children", relationGeneric, "This defines the children of this work item")
+	a.Attribute("mentions", relationGenericList, "This defines the identities mentioned in the description of this work item")
+	a.Attribute("mentioned-in", relationGenericList, "This defines the work items and comments referencing this work item (read-only)")
 	a.Attribute("space", relationSpaces, "This defines the owning space of this work item.")
 })
 
@@ -80,6 +83,39 @@ var workItemSingle = JSONSingle(
 	workItem,
 	workItemLinks)
 
+// workItemCopy defines the options of a work item copy request and holds
+// the result of the copy in the response
+var workItemCopy = a.Type("WorkItemCopy", func() {
+	a.Attribute("type", d.String, func() {
+		a.Enum("workitemcopies")
+	})
+	a.Attribute("attributes", workItemCopyAttributes)
+	a.Attribute("relationships", workItemCopyRelationships)
+	a.Required("type")
+})
+
+// workItemCopyAttributes defines the options of a work item copy
+var workItemCopyAttributes = a.Type("WorkItemCopyAttributes", func() {
+	a.Attribute("subtree", d.Boolean, "Whether the children of the work item (following tree-topology links) should be copied as well")
+	a.Attribute("comments", d.Boolean, "Whether the comments of the copied work items should be copied as well")
+	a.Attribute("reset-state", d.Boolean, "Whether the state of the copied work items should be reset to 'new'")
+	a.Attribute("mapping", a.HashOf(d.String, d.UUID), "The IDs of the original work items mapped to the IDs of their copies (read-only)")
+})
+
+// workItemCopyRelationships defines where the work items are copied to and,
+// in the response, the copy of the work item
+var workItemCopyRelationships = a.Type("WorkItemCopyRelationships", func() {
+	a.Attribute("space", relationSpaces, "The space in which the copies are created (defaults to the space of the work item)")
+	a.Attribute("iteration", relationGeneric, "The iteration to which the copies are assigned")
+	a.Attribute("copy", relationGeneric, "The copy of the work item (read-only)")
+})
+
+// workItemCopySingle is the media type for work item copies
+var workItemCopySingle = JSONSingle(
+	"WorkItemCopy", "Holds the options and the result of a work item copy",
+	workItemCopy,
+	nil)
+
 // Reorder creates a UserTypeDefinition for Reorder action
 func Reorder(name, description string, data *d.UserTypeDefinition, position *d.UserTypeDefinition) *d.MediaTypeDefinition {
 	return a.MediaType("application/vnd."+strings.ToLower(name)+"json", func() {
@@ -109,12 +145,14 @@ var _ = a.Resource("workitem", func() {
 		a.Routing(
 			a.GET("/:wiID"),
 		)
-		a.Description("Retrieve work item with given id.")
+		a.Description(`Retrieve work item with given id or number in the space.
+If the work item with the given number was moved to another space, the response redirects to the work item in its new space.`)
 		a.Params(func() {
-			a.Param("wiID", d.UUID, "ID of a work item")
+			a.Param("wiID", d.String, "ID or number of a work item")
 		})
 		a.UseTrait("conditional")
 		a.Response(d.OK, workItemSingle)
+		a.Response(d.MovedPermanently)
 		a.Response(d.NotModified)
 		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
@@ -130,6 +168,7 @@ var _ = a.Resource("workitem", func() {
 			a.Param("page[offset]", d.String, "Paging start position")
 			a.Param("page[limit]", d.Integer, "Paging size")
 			a.Param("filter[assignee]", d.String, "Work Items assigned to the given user")
+			a.Param("filter[id]", d.UUID, "ID of a saved filter of the space whose query and sort are applied")
 			a.Param("filter[iteration]", d.String, "IterationID to filter work items")
 			a.Param("filter[workitemtype]", d.UUID, "ID of work item type to filter work items by")
 			a.Param("filter[area]", d.String, "AreaID to filter work items")
@@ -141,6 +180,7 @@ var _ = a.Resource("workitem", func() {
 		a.Response(d.NotModified)
 		a.Response(d.BadRequest, JSONAPIErrors)
 		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
 	})
 	a.Action("list-children", func() {
 		a.Routing(
@@ -213,6 +253,48 @@ var _ = a.Resource("workitem", func() {
 		a.Response(d.Unauthorized, JSONAPIErrors)
 		a.Response(d.Forbidden, JSONAPIErrors)
 	})
+	a.Action("move", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:wiID/move"),
+		)
+		a.Description(`Move the work item with the given id to the space given in the "space" relationship.
+The work item type, iteration and area can be given as relationships as well, otherwise the current work item type
+is kept and the work item is assigned to the root iteration and root area of the target space.`)
+		a.Params(func() {
+			a.Param("wiID", d.UUID, "ID of the work item to move")
+		})
+		a.Payload(workItemSingle)
+		a.Response(d.OK, func() {
+			a.Media(workItemSingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.Conflict, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
+	a.Action("copy", func() {
+		a.Security("jwt")
+		a.Routing(
+			a.POST("/:wiID/copy"),
+		)
+		a.Description(`Copy the work item with the given id, optionally with its subtree and comments.
+The copies are created in the same transaction and the response holds the mapping of the original work item IDs to the IDs of the copies.`)
+		a.Params(func() {
+			a.Param("wiID", d.UUID, "ID of the work item to copy")
+		})
+		a.Payload(workItemCopySingle)
+		a.Response(d.Created, "/workitems/.*", func() {
+			a.Media(workItemCopySingle)
+		})
+		a.Response(d.BadRequest, JSONAPIErrors)
+		a.Response(d.InternalServerError, JSONAPIErrors)
+		a.Response(d.NotFound, JSONAPIErrors)
+		a.Response(d.Unauthorized, JSONAPIErrors)
+		a.Response(d.Forbidden, JSONAPIErrors)
+	})
 	a.Action("reorder", func() {
 		a.Security("jwt")
 		a.Routing(
@@ -246,6 +328,7 @@ var _ = a.Resource("planner_backlog", func() {
 			a.Param("page[offset]", d.String, "Paging start position")
 			a.Param("page[limit]", d.Integer, "Paging size")
 			a.Param("filter[assignee]", d.String, "Work Items assigned to the given user")
+			a.Param("filter[id]", d.UUID, "ID of a saved filter of the space whose query and sort are applied")
 			a.Param("filter[workitemtype]", d.UUID, "ID of work item type to filter work items by")
 			a.Param("filter[area]", d.String, "AreaID to filter work items")
 		})
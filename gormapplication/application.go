@@ -11,11 +11,18 @@ import (
 	"github.com/fabric8-services/fabric8-wit/codebase"
 	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/mention"
 	"github.com/fabric8-services/fabric8-wit/remoteworkitem"
 	"github.com/fabric8-services/fabric8-wit/search"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
@@ -143,6 +150,66 @@ func (g *GormBase) Codebases() codebase.Repository {
 	return codebase.NewCodebaseRepository(g.db)
 }
 
+// WorkItemLinkRevisions returns a work item link revision repository
+func (g *GormBase) WorkItemLinkRevisions() link.RevisionRepository {
+	return link.NewRevisionRepository(g.db)
+}
+
+// WorkItemEvents returns a work item event repository
+func (g *GormBase) WorkItemEvents() event.Repository {
+	return event.NewEventRepository(g.db)
+}
+
+// WorkItemTemplates returns a work item template repository
+func (g *GormBase) WorkItemTemplates() template.Repository {
+	return template.NewRepository(g.db)
+}
+
+// Filters returns a saved filter repository
+func (g *GormBase) Filters() filter.Repository {
+	return filter.NewRepository(g.db)
+}
+
+// CommentReactions returns a comment reactions repository
+func (g *GormBase) CommentReactions() comment.ReactionRepository {
+	return comment.NewReactionRepository(g.db)
+}
+
+// Mentions returns the mentions of the identities
+func (g *GormBase) Mentions() mention.Repository {
+	return mention.NewRepository(g.db)
+}
+
+// WorkItemReferences returns the references to the work items
+func (g *GormBase) WorkItemReferences() reference.Repository {
+	return reference.NewRepository(g.db)
+}
+
+// IterationCarryOvers returns the work items carried over when closing iterations
+func (g *GormBase) IterationCarryOvers() iteration.CarryOverRepository {
+	return iteration.NewCarryOverRepository(g.db)
+}
+
+// IterationCapacities returns the capacities of the collaborators during iterations
+func (g *GormBase) IterationCapacities() iteration.CapacityRepository {
+	return iteration.NewCapacityRepository(g.db)
+}
+
+// SpaceTemplates returns a space template repository
+func (g *GormBase) SpaceTemplates() spacetemplate.Repository {
+	return spacetemplate.NewRepository(g.db)
+}
+
+// SpaceImportMappings returns a space import mapping repository
+func (g *GormBase) SpaceImportMappings() archive.MappingRepository {
+	return archive.NewMappingRepository(g.db)
+}
+
+// WorkItemRevisions returns a work item revision repository
+func (g *GormBase) WorkItemRevisions() workitem.RevisionRepository {
+	return workitem.NewRevisionRepository(g.db)
+}
+
 func (g *GormBase) DB() *gorm.DB {
 	return g.db
 }
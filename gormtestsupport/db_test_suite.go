@@ -8,6 +8,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/migration"
 	"github.com/fabric8-services/fabric8-wit/models"
 	"github.com/fabric8-services/fabric8-wit/resource"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"context"
@@ -65,6 +66,14 @@ func (s *DBTestSuite) PopulateDBTestSuite(ctx context.Context) {
 				"postgres_config": s.Configuration.GetPostgresConfigString(),
 			}, "failed to populate the database with common types")
 		}
+		if err := models.Transactional(s.DB, func(tx *gorm.DB) error {
+			return migration.PopulateSpaceTemplates(ctx, spacetemplate.NewRepository(tx))
+		}); err != nil {
+			log.Panic(nil, map[string]interface{}{
+				"err":             err,
+				"postgres_config": s.Configuration.GetPostgresConfigString(),
+			}, "failed to populate the database with space templates")
+		}
 	}
 }
 
@@ -0,0 +1,115 @@
+package iteration
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// The units in which the capacity of a collaborator is given
+const (
+	CapacityUnitPoints = "points"
+	CapacityUnitHours  = "hours"
+)
+
+// Capacity records the work a collaborator can take on during an iteration
+type Capacity struct {
+	IterationID uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	IdentityID  uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	Capacity    float64
+	// Unit is either CapacityUnitPoints or CapacityUnitHours
+	Unit      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (c Capacity) TableName() string {
+	return "iteration_capacities"
+}
+
+// CapacityRepository describes interactions with the capacities of the
+// collaborators during iterations
+type CapacityRepository interface {
+	// List returns the capacities recorded for the given iteration, in the
+	// order in which they were recorded.
+	List(ctx context.Context, iterationID uuid.UUID) ([]Capacity, error)
+	// Set replaces the capacities recorded for the given iteration with the
+	// given ones.
+	Set(ctx context.Context, iterationID uuid.UUID, capacities []Capacity) error
+}
+
+// NewCapacityRepository creates a new storage type.
+func NewCapacityRepository(db *gorm.DB) CapacityRepository {
+	return &GormCapacityRepository{db: db}
+}
+
+// GormCapacityRepository is the implementation of the storage interface for
+// the capacities of the collaborators during iterations.
+type GormCapacityRepository struct {
+	db *gorm.DB
+}
+
+// List returns the capacities recorded for the given iteration, in the order
+// in which they were recorded.
+func (r *GormCapacityRepository) List(ctx context.Context, iterationID uuid.UUID) ([]Capacity, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iteration_capacity", "list"}, time.Now())
+	var result []Capacity
+	if err := r.db.Where("iteration_id = ?", iterationID).Order("created_at, identity_id").Find(&result).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": iterationID,
+			"err":          err,
+		}, "unable to list the capacities of the iteration")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
+
+// Set replaces the capacities recorded for the given iteration with the given
+// ones.
+func (r *GormCapacityRepository) Set(ctx context.Context, iterationID uuid.UUID, capacities []Capacity) error {
+	defer goa.MeasureSince([]string{"goa", "db", "iteration_capacity", "set"}, time.Now())
+	seen := map[uuid.UUID]bool{}
+	for _, c := range capacities {
+		if c.Unit != CapacityUnitPoints && c.Unit != CapacityUnitHours {
+			return errors.NewBadParameterError("unit", c.Unit).Expected("one of points or hours")
+		}
+		if c.Capacity < 0 {
+			return errors.NewBadParameterError("capacity", c.Capacity).Expected("positive number")
+		}
+		if seen[c.IdentityID] {
+			return errors.NewBadParameterError("identity", c.IdentityID).Expected("a single capacity per collaborator")
+		}
+		seen[c.IdentityID] = true
+	}
+	var itr Iteration
+	if err := r.db.Select("space_id").Where("id = ?", iterationID).First(&itr).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return errors.NewInternalError(ctx, err)
+	}
+	if err := space.CheckNotArchived(ctx, r.db, itr.SpaceID); err != nil {
+		return err
+	}
+	if err := r.db.Where("iteration_id = ?", iterationID).Delete(&Capacity{}).Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	for _, c := range capacities {
+		c.IterationID = iterationID
+		if err := r.db.Create(&c).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"iteration_id": iterationID,
+				"identity_id":  c.IdentityID,
+				"err":          err,
+			}, "unable to record the capacity of the collaborator")
+			return errors.NewInternalError(ctx, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package iteration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testcommon "github.com/fabric8-services/fabric8-wit/test"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type capacityRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	clean func()
+	ctx   context.Context
+}
+
+func TestRunCapacityRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &capacityRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (s *capacityRepoBlackBoxTest) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.ctx = migration.NewMigrationContext(context.Background())
+	s.DBTestSuite.PopulateDBTestSuite(s.ctx)
+}
+
+func (s *capacityRepoBlackBoxTest) SetupTest() {
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+}
+
+func (s *capacityRepoBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *capacityRepoBlackBoxTest) TestSetAndList() {
+	// given
+	alice, err := testcommon.CreateTestIdentity(s.DB, "capacity-"+uuid.NewV4().String(), "test")
+	require.Nil(s.T(), err)
+	bob, err := testcommon.CreateTestIdentity(s.DB, "capacity-"+uuid.NewV4().String(), "test")
+	require.Nil(s.T(), err)
+	sp, err := space.NewRepository(s.DB).Create(s.ctx, &space.Space{
+		Name:    testcommon.CreateRandomValidTestName("Space Capacity"),
+		OwnerId: alice.ID,
+	})
+	require.Nil(s.T(), err)
+	itr := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID}
+	require.Nil(s.T(), iteration.NewIterationRepository(s.DB).Create(s.ctx, &itr))
+	repo := iteration.NewCapacityRepository(s.DB)
+	// when
+	err = repo.Set(s.ctx, itr.ID, []iteration.Capacity{
+		{IdentityID: alice.ID, Capacity: 13, Unit: iteration.CapacityUnitPoints},
+		{IdentityID: bob.ID, Capacity: 30, Unit: iteration.CapacityUnitHours},
+	})
+	// then
+	require.Nil(s.T(), err)
+	capacities, err := repo.List(s.ctx, itr.ID)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), capacities, 2)
+	byIdentity := map[uuid.UUID]iteration.Capacity{}
+	for _, c := range capacities {
+		assert.Equal(s.T(), itr.ID, c.IterationID)
+		byIdentity[c.IdentityID] = c
+	}
+	assert.Equal(s.T(), 13.0, byIdentity[alice.ID].Capacity)
+	assert.Equal(s.T(), iteration.CapacityUnitPoints, byIdentity[alice.ID].Unit)
+	assert.Equal(s.T(), 30.0, byIdentity[bob.ID].Capacity)
+	assert.Equal(s.T(), iteration.CapacityUnitHours, byIdentity[bob.ID].Unit)
+
+	s.T().Run("replace capacities", func(t *testing.T) {
+		// when
+		err := repo.Set(s.ctx, itr.ID, []iteration.Capacity{
+			{IdentityID: bob.ID, Capacity: 8, Unit: iteration.CapacityUnitPoints},
+		})
+		// then
+		require.Nil(t, err)
+		capacities, err := repo.List(s.ctx, itr.ID)
+		require.Nil(t, err)
+		require.Len(t, capacities, 1)
+		assert.Equal(t, bob.ID, capacities[0].IdentityID)
+		assert.Equal(t, 8.0, capacities[0].Capacity)
+	})
+
+	s.T().Run("invalid capacities", func(t *testing.T) {
+		for name, capacities := range map[string][]iteration.Capacity{
+			"unknown unit":       {{IdentityID: alice.ID, Capacity: 1, Unit: "days"}},
+			"negative capacity":  {{IdentityID: alice.ID, Capacity: -1, Unit: iteration.CapacityUnitHours}},
+			"duplicate identity": {{IdentityID: alice.ID, Capacity: 1, Unit: iteration.CapacityUnitHours}, {IdentityID: alice.ID, Capacity: 2, Unit: iteration.CapacityUnitHours}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				// when
+				err := repo.Set(s.ctx, itr.ID, capacities)
+				// then
+				require.NotNil(t, err)
+				require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+			})
+		}
+	})
+
+	s.T().Run("list capacities of other iteration", func(t *testing.T) {
+		// when
+		capacities, err := repo.List(s.ctx, uuid.NewV4())
+		// then
+		require.Nil(t, err)
+		assert.Empty(t, capacities)
+	})
+}
@@ -0,0 +1,80 @@
+package iteration
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CarryOver records a not-closed work item that was moved to another
+// iteration when its iteration was closed
+type CarryOver struct {
+	ID        uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	CreatedAt time.Time
+	// the closed iteration
+	IterationID uuid.UUID `sql:"type:uuid"`
+	// the iteration to which the work item was moved
+	TargetIterationID uuid.UUID `sql:"type:uuid"`
+	// the moved work item
+	WorkItemID uuid.UUID `sql:"type:uuid"`
+	// the identity that closed the iteration
+	ModifierID uuid.UUID `sql:"type:uuid"`
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (c CarryOver) TableName() string {
+	return "iteration_carry_overs"
+}
+
+// CarryOverRepository describes interactions with the work items carried
+// over when closing iterations
+type CarryOverRepository interface {
+	Create(ctx context.Context, c *CarryOver) error
+	// List returns the work items carried over when closing the given
+	// iteration, in the order in which they were moved.
+	List(ctx context.Context, iterationID uuid.UUID) ([]CarryOver, error)
+}
+
+// NewCarryOverRepository creates a new storage type.
+func NewCarryOverRepository(db *gorm.DB) CarryOverRepository {
+	return &GormCarryOverRepository{db: db}
+}
+
+// GormCarryOverRepository is the implementation of the storage interface for
+// the work items carried over when closing iterations.
+type GormCarryOverRepository struct {
+	db *gorm.DB
+}
+
+// Create creates a new record in the repository
+func (r *GormCarryOverRepository) Create(ctx context.Context, c *CarryOver) error {
+	defer goa.MeasureSince([]string{"goa", "db", "iteration_carry_over", "create"}, time.Now())
+	c.ID = uuid.NewV4()
+	if err := r.db.Create(c).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": c.IterationID,
+			"wi_id":        c.WorkItemID,
+			"err":          err,
+		}, "unable to record the carried over work item")
+		return errors.NewInternalError(ctx, err)
+	}
+	return nil
+}
+
+// List returns the work items carried over when closing the given iteration,
+// in the order in which they were moved.
+func (r *GormCarryOverRepository) List(ctx context.Context, iterationID uuid.UUID) ([]CarryOver, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iteration_carry_over", "list"}, time.Now())
+	var result []CarryOver
+	if err := r.db.Where("iteration_id = ?", iterationID).Order("created_at, id").Find(&result).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package iteration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testcommon "github.com/fabric8-services/fabric8-wit/test"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type carryOverRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	clean func()
+	ctx   context.Context
+}
+
+func TestRunCarryOverRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &carryOverRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (s *carryOverRepoBlackBoxTest) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.ctx = migration.NewMigrationContext(context.Background())
+	s.DBTestSuite.PopulateDBTestSuite(s.ctx)
+}
+
+func (s *carryOverRepoBlackBoxTest) SetupTest() {
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+}
+
+func (s *carryOverRepoBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *carryOverRepoBlackBoxTest) TestCreateAndList() {
+	// given
+	identity, err := testcommon.CreateTestIdentity(s.DB, "carry-over-"+uuid.NewV4().String(), "test")
+	require.Nil(s.T(), err)
+	sp, err := space.NewRepository(s.DB).Create(s.ctx, &space.Space{
+		Name:    testcommon.CreateRandomValidTestName("Space Carry Over"),
+		OwnerId: identity.ID,
+	})
+	require.Nil(s.T(), err)
+	iterationRepo := iteration.NewIterationRepository(s.DB)
+	closed := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &closed))
+	next := iteration.Iteration{Name: "Sprint 2", SpaceID: sp.ID}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &next))
+	repo := iteration.NewCarryOverRepository(s.DB)
+	var created []iteration.CarryOver
+	for i := 0; i < 2; i++ {
+		wi, err := workitem.NewWorkItemRepository(s.DB).Create(
+			s.ctx, sp.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle: "Issue",
+				workitem.SystemState: workitem.SystemStateNew,
+			}, identity.ID)
+		require.Nil(s.T(), err)
+		c := iteration.CarryOver{
+			IterationID:       closed.ID,
+			TargetIterationID: next.ID,
+			WorkItemID:        wi.ID,
+			ModifierID:        identity.ID,
+		}
+		// when
+		err = repo.Create(s.ctx, &c)
+		// then
+		require.Nil(s.T(), err)
+		assert.NotEqual(s.T(), uuid.Nil, c.ID)
+		created = append(created, c)
+	}
+
+	s.T().Run("list carry-overs of closed iteration", func(t *testing.T) {
+		// when
+		carryOvers, err := repo.List(s.ctx, closed.ID)
+		// then
+		require.Nil(t, err)
+		require.Len(t, carryOvers, 2)
+		assert.Equal(t, created[0].ID, carryOvers[0].ID)
+		assert.Equal(t, created[1].WorkItemID, carryOvers[1].WorkItemID)
+	})
+
+	s.T().Run("list carry-overs of other iteration", func(t *testing.T) {
+		// when
+		carryOvers, err := repo.List(s.ctx, next.ID)
+		// then
+		require.Nil(t, err)
+		assert.Empty(t, carryOvers)
+	})
+}
@@ -2,6 +2,7 @@ package iteration
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/path"
+	"github.com/fabric8-services/fabric8-wit/space"
 
 	"github.com/goadesign/goa"
 	"github.com/jinzhu/gorm"
@@ -27,6 +29,32 @@ const (
 	PathSepInDatabase      = "."
 )
 
+// stateTransitions holds, for each state of an iteration, the states to
+// which it can move: an iteration is planned ("new"), then runs ("start")
+// and finally ends ("close"), possibly without ever having run.
+var stateTransitions = map[string][]string{
+	IterationStateNew:   {IterationStateStart, IterationStateClose},
+	IterationStateStart: {IterationStateClose},
+	IterationStateClose: {},
+}
+
+// CheckStateTransition returns a BadParameterError if an iteration can not
+// move from the given state to the other one.
+func CheckStateTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+	if _, ok := stateTransitions[to]; !ok {
+		return errors.NewBadParameterError("state", to).Expected("one of new, start or close")
+	}
+	for _, t := range stateTransitions[from] {
+		if t == to {
+			return nil
+		}
+	}
+	return errors.NewBadParameterError("state", to).Expected(fmt.Sprintf("a state to which an iteration in state '%s' can move", from))
+}
+
 // Iteration describes a single iteration
 type Iteration struct {
 	gormsupport.Lifecycle
@@ -65,6 +93,8 @@ type Repository interface {
 	Root(ctx context.Context, spaceID uuid.UUID) (*Iteration, error)
 	Load(ctx context.Context, id uuid.UUID) (*Iteration, error)
 	Save(ctx context.Context, i Iteration) (*Iteration, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Move(ctx context.Context, id uuid.UUID, parentID uuid.UUID) (*Iteration, error)
 	CanStart(ctx context.Context, i *Iteration) (bool, error)
 	LoadMultiple(ctx context.Context, ids []uuid.UUID) ([]Iteration, error)
 	LoadChildren(ctx context.Context, parentIterationID uuid.UUID) ([]Iteration, error)
@@ -99,8 +129,14 @@ func (m *GormIterationRepository) LoadMultiple(ctx context.Context, ids []uuid.U
 func (m *GormIterationRepository) Create(ctx context.Context, u *Iteration) error {
 	defer goa.MeasureSince([]string{"goa", "db", "iteration", "create"}, time.Now())
 
+	if err := space.CheckNotArchived(ctx, m.db, u.SpaceID); err != nil {
+		return err
+	}
 	u.ID = uuid.NewV4()
 	u.State = IterationStateNew
+	if err := m.checkDates(ctx, *u); err != nil {
+		return err
+	}
 	err := m.db.Create(u).Error
 	// Composite key (name,space,path) must be unique
 	if gormsupport.IsUniqueViolation(err, "iterations_name_space_id_path_unique") {
@@ -197,6 +233,24 @@ func (m *GormIterationRepository) Save(ctx context.Context, i Iteration) (*Itera
 		}, "unknown error happened when searching the iteration")
 		return nil, errors.NewInternalError(ctx, err)
 	}
+	if err := space.CheckNotArchived(ctx, m.db, itr.SpaceID); err != nil {
+		return nil, err
+	}
+	if i.State != itr.State {
+		if err := CheckStateTransition(itr.State, i.State); err != nil {
+			return nil, err
+		}
+		if i.State == IterationStateStart {
+			if _, err := m.CanStart(ctx, &i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !sameTime(i.StartAt, itr.StartAt) || !sameTime(i.EndAt, itr.EndAt) || i.Path.Convert() != itr.Path.Convert() {
+		if err := m.checkDates(ctx, i); err != nil {
+			return nil, err
+		}
+	}
 	tx = tx.Save(&i)
 	if err := tx.Error; err != nil {
 		log.Error(ctx, map[string]interface{}{
@@ -208,11 +262,135 @@ func (m *GormIterationRepository) Save(ctx context.Context, i Iteration) (*Itera
 	return &i, nil
 }
 
+// Delete deletes the given iteration along with all its child iterations.
+// The root iteration of a space can not be deleted.
+func (m *GormIterationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "iteration", "delete"}, time.Now())
+	itr, err := m.Load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if itr.Path.IsEmpty() {
+		return errors.NewBadParameterError("iterationID", id).Expected("iteration which is not the root iteration of the space")
+	}
+	if err := space.CheckNotArchived(ctx, m.db, itr.SpaceID); err != nil {
+		return err
+	}
+	tx := m.db.Where("space_id = ? AND (id = ? OR path <@ ?)", itr.SpaceID, id, path.ToExpression(itr.Path, itr.ID)).Delete(&Iteration{})
+	if err := tx.Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": id,
+			"err":          err,
+		}, "unable to delete the iteration")
+		return errors.NewInternalError(ctx, err)
+	}
+	log.Debug(ctx, map[string]interface{}{
+		"iteration_id": id,
+		"deleted":      tx.RowsAffected,
+	}, "iteration deleted along with its child iterations")
+	return nil
+}
+
+// Move moves the given iteration, along with all its child iterations, under
+// the given parent iteration of the same space. The paths of the whole
+// subtree are rewritten, so the caller is expected to run this within a
+// transaction. The root iteration of a space can not be moved.
+func (m *GormIterationRepository) Move(ctx context.Context, id uuid.UUID, parentID uuid.UUID) (*Iteration, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "iteration", "move"}, time.Now())
+	itr, err := m.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if itr.Path.IsEmpty() {
+		return nil, errors.NewBadParameterError("iterationID", id).Expected("iteration which is not the root iteration of the space")
+	}
+	if err := space.CheckNotArchived(ctx, m.db, itr.SpaceID); err != nil {
+		return nil, err
+	}
+	parent, err := m.Load(ctx, parentID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("parent", parentID).Expected("existing iteration")
+	}
+	if !uuid.Equal(parent.SpaceID, itr.SpaceID) {
+		return nil, errors.NewBadParameterError("parent", parentID).Expected("iteration of the same space")
+	}
+	if uuid.Equal(parent.ID, itr.ID) || parent.Path.Contains(itr.ID) {
+		return nil, errors.NewBadParameterError("parent", parentID).Expected("iteration which is neither the moved iteration nor one of its children")
+	}
+	moved := *itr
+	moved.Path = append(parent.Path, parent.ID)
+	if moved.Path.Convert() == itr.Path.Convert() {
+		return itr, nil
+	}
+	if err := m.checkDates(ctx, moved); err != nil {
+		return nil, err
+	}
+	err = m.db.Model(&Iteration{}).Where("id = ?", id).Updates(map[string]interface{}{"path": moved.Path}).Error
+	if err != nil {
+		if gormsupport.IsUniqueViolation(err, "iterations_name_space_id_path_unique") {
+			return nil, errors.NewBadParameterError("name & space_id & path", itr.Name+" & "+itr.SpaceID.String()+" & "+moved.Path.String()).Expected("unique")
+		}
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": id,
+			"err":          err,
+		}, "unable to move the iteration")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	// the children keep the part of their path that follows the parent of the
+	// moved iteration
+	query := fmt.Sprintf(`UPDATE %s SET path = CAST(? AS ltree) || subpath(path, ?), updated_at = now()
+		WHERE space_id = ? AND path <@ ? AND deleted_at IS NULL`, itr.TableName())
+	err = m.db.Exec(query, moved.Path.Convert(), len(itr.Path), itr.SpaceID, path.ToExpression(itr.Path, itr.ID)).Error
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": id,
+			"err":          err,
+		}, "unable to move the child iterations")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return m.Load(ctx, id)
+}
+
+// checkDates returns a BadParameterError if the given iteration ends before
+// it starts, or if its date range overlaps the one of a sibling iteration.
+func (m *GormIterationRepository) checkDates(ctx context.Context, i Iteration) error {
+	if i.StartAt == nil || i.EndAt == nil {
+		return nil
+	}
+	if !i.EndAt.After(*i.StartAt) {
+		return errors.NewBadParameterError("endAt", *i.EndAt).Expected("date after startAt")
+	}
+	var siblings []Iteration
+	err := m.db.Where("space_id = ? AND path = ? AND id != ? AND start_at < ? AND end_at > ?", i.SpaceID, i.Path.Convert(), i.ID, *i.EndAt, *i.StartAt).Find(&siblings).Error
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": i.ID,
+			"err":          err,
+		}, "unable to look for overlapping iterations")
+		return errors.NewInternalError(ctx, err)
+	}
+	if len(siblings) > 0 {
+		log.Info(ctx, map[string]interface{}{
+			"iteration_id":         i.ID,
+			"sibling_iteration_id": siblings[0].ID,
+		}, "iteration overlaps a sibling iteration")
+		return errors.NewBadParameterError("startAt & endAt", i.StartAt.String()+" & "+i.EndAt.String()).Expected(fmt.Sprintf("date range which does not overlap the one of iteration '%s'", siblings[0].Name))
+	}
+	return nil
+}
+
+// sameTime returns true if both times are nil or equal
+func sameTime(t1, t2 *time.Time) bool {
+	if t1 == nil || t2 == nil {
+		return t1 == t2
+	}
+	return t1.Equal(*t2)
+}
+
 // CanStart checks the rule -
 // 1. Only one iteration from a space can have state=start at a time.
 // 2. Root iteration of the space can not be started.(Hence can not be closed - via UI)
-// Currently there is no State-machine for state transitions of iteraitons
-// till then we will not allow user to start root iteration.
+// The other state transitions are checked by CheckStateTransition.
 // More rules can be added as needed in this function
 func (m *GormIterationRepository) CanStart(ctx context.Context, i *Iteration) (bool, error) {
 	var count int64
@@ -11,10 +11,12 @@ import (
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/path"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/space"
 	testcommon "github.com/fabric8-services/fabric8-wit/test"
 
+	"github.com/jinzhu/gorm"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -182,7 +184,8 @@ func (test *TestIterationRepository) TestListIterationBySpace() {
 	assert.Nil(t, err)
 
 	for i := 0; i < 3; i++ {
-		start := time.Now()
+		// sibling iterations can not overlap
+		start := time.Now().Add(time.Duration(i) * time.Hour * (24 * 8 * 3))
 		end := start.Add(time.Hour * (24 * 8 * 3))
 		name := "Sprint #2" + strconv.Itoa(i)
 
@@ -418,3 +421,349 @@ func (test *TestIterationRepository) TestExistsIteration() {
 	})
 
 }
+
+func (test *TestIterationRepository) TestStateTransitions() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := iteration.NewIterationRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: testcommon.CreateRandomValidTestName("Space State Transitions"),
+	})
+	require.Nil(t, err)
+	root := iteration.Iteration{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+
+	t.Run("check transitions", func(t *testing.T) {
+		assert.Nil(t, iteration.CheckStateTransition(iteration.IterationStateNew, iteration.IterationStateStart))
+		assert.Nil(t, iteration.CheckStateTransition(iteration.IterationStateNew, iteration.IterationStateClose))
+		assert.Nil(t, iteration.CheckStateTransition(iteration.IterationStateStart, iteration.IterationStateClose))
+		assert.Nil(t, iteration.CheckStateTransition(iteration.IterationStateClose, iteration.IterationStateClose))
+		assert.IsType(t, errors.BadParameterError{}, iteration.CheckStateTransition(iteration.IterationStateStart, iteration.IterationStateNew))
+		assert.IsType(t, errors.BadParameterError{}, iteration.CheckStateTransition(iteration.IterationStateClose, iteration.IterationStateStart))
+		assert.IsType(t, errors.BadParameterError{}, iteration.CheckStateTransition(iteration.IterationStateNew, "running"))
+	})
+
+	t.Run("save with invalid transition", func(t *testing.T) {
+		// given
+		i := iteration.Iteration{Name: "Sprint closed", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &i))
+		i.State = iteration.IterationStateClose
+		_, err := repo.Save(context.Background(), i)
+		require.Nil(t, err)
+		// when
+		i.State = iteration.IterationStateNew
+		_, err = repo.Save(context.Background(), i)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("save with second running iteration", func(t *testing.T) {
+		// given
+		i1 := iteration.Iteration{Name: "Sprint running", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &i1))
+		i1.State = iteration.IterationStateStart
+		_, err := repo.Save(context.Background(), i1)
+		require.Nil(t, err)
+		i2 := iteration.Iteration{Name: "Sprint waiting", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &i2))
+		// when
+		i2.State = iteration.IterationStateStart
+		_, err = repo.Save(context.Background(), i2)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+}
+
+func (test *TestIterationRepository) TestOverlappingSiblingIterations() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := iteration.NewIterationRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: testcommon.CreateRandomValidTestName("Space Overlapping Iterations"),
+	})
+	require.Nil(t, err)
+	start := time.Now()
+	end := start.Add(time.Hour * (24 * 14))
+	i1 := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID, StartAt: &start, EndAt: &end}
+	require.Nil(t, repo.Create(context.Background(), &i1))
+
+	t.Run("create overlapping sibling", func(t *testing.T) {
+		// given
+		overlapStart := end.Add(-time.Hour)
+		overlapEnd := overlapStart.Add(time.Hour * (24 * 14))
+		i := iteration.Iteration{Name: "Sprint overlapping", SpaceID: sp.ID, StartAt: &overlapStart, EndAt: &overlapEnd}
+		// when
+		err := repo.Create(context.Background(), &i)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("create next sibling and child", func(t *testing.T) {
+		// given
+		nextEnd := end.Add(time.Hour * (24 * 14))
+		next := iteration.Iteration{Name: "Sprint 2", SpaceID: sp.ID, StartAt: &end, EndAt: &nextEnd}
+		child := iteration.Iteration{Name: "Sprint 1.1", SpaceID: sp.ID, StartAt: &start, EndAt: &end, Path: append(i1.Path, i1.ID)}
+		// when/then
+		require.Nil(t, repo.Create(context.Background(), &next))
+		require.Nil(t, repo.Create(context.Background(), &child))
+
+		t.Run("move sibling over another one", func(t *testing.T) {
+			// when
+			next.StartAt = &start
+			_, err := repo.Save(context.Background(), next)
+			// then
+			require.IsType(t, errors.BadParameterError{}, err)
+		})
+	})
+
+	t.Run("create iteration ending before it starts", func(t *testing.T) {
+		// given
+		laterStart := end.Add(time.Hour * (24 * 100))
+		i := iteration.Iteration{Name: "Sprint reversed", SpaceID: sp.ID, StartAt: &laterStart, EndAt: &end}
+		// when
+		err := repo.Create(context.Background(), &i)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+}
+
+func (test *TestIterationRepository) TestSchedulerUpdateStates() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := iteration.NewIterationRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: testcommon.CreateRandomValidTestName("Space Scheduled Iterations"),
+	})
+	require.Nil(t, err)
+	root := iteration.Iteration{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+	createIteration := func(name string, start, end time.Time, state string) iteration.Iteration {
+		i := iteration.Iteration{Name: name, SpaceID: sp.ID, StartAt: &start, EndAt: &end, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &i))
+		if state != iteration.IterationStateNew {
+			i.State = state
+			_, err := repo.Save(context.Background(), i)
+			require.Nil(t, err)
+		}
+		return i
+	}
+	now := time.Now()
+	day := 24 * time.Hour
+	ended := createIteration("Sprint ended", now.Add(-20*day), now.Add(-10*day), iteration.IterationStateStart)
+	skipped := createIteration("Sprint skipped", now.Add(-10*day), now.Add(-day), iteration.IterationStateNew)
+	current := createIteration("Sprint current", now.Add(-day), now.Add(10*day), iteration.IterationStateNew)
+	future := createIteration("Sprint future", now.Add(10*day), now.Add(20*day), iteration.IterationStateNew)
+	var closed []uuid.UUID
+	scheduler := iteration.NewScheduler(test.DB, time.Time{}, func(ctx context.Context, tx *gorm.DB, itr iteration.Iteration) error {
+		closed = append(closed, itr.ID)
+		return nil
+	})
+	checkStates := func(t *testing.T, expectedStates map[uuid.UUID]string) {
+		for id, expected := range expectedStates {
+			loaded, err := repo.Load(context.Background(), id)
+			require.Nil(t, err)
+			assert.Equal(t, expected, loaded.State, loaded.Name)
+		}
+	}
+
+	t.Run("locked by another scheduler", func(t *testing.T) {
+		// given
+		tx := test.DB.Begin()
+		defer tx.Rollback()
+		var locked bool
+		require.Nil(t, tx.Raw("SELECT pg_try_advisory_xact_lock(4242, hashtext(?))", sp.ID.String()).Row().Scan(&locked))
+		require.True(t, locked)
+		// when
+		scheduler.UpdateStates(context.Background(), now)
+		// then
+		assert.Empty(t, closed)
+		checkStates(t, map[uuid.UUID]string{
+			ended.ID:   iteration.IterationStateStart,
+			skipped.ID: iteration.IterationStateNew,
+			current.ID: iteration.IterationStateNew,
+		})
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		// when
+		scheduler.UpdateStates(context.Background(), now)
+		// then
+		assert.Equal(t, []uuid.UUID{ended.ID, skipped.ID}, closed)
+		checkStates(t, map[uuid.UUID]string{
+			ended.ID:   iteration.IterationStateClose,
+			skipped.ID: iteration.IterationStateClose,
+			current.ID: iteration.IterationStateStart,
+			future.ID:  iteration.IterationStateNew,
+			root.ID:    iteration.IterationStateNew,
+		})
+	})
+}
+
+func (test *TestIterationRepository) TestSchedulerClosesLegacyIterations() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	// given
+	repo := iteration.NewIterationRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: testcommon.CreateRandomValidTestName("Space Legacy Iterations"),
+	})
+	require.Nil(t, err)
+	root := iteration.Iteration{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+	createIteration := func(name string, start, end time.Time) iteration.Iteration {
+		i := iteration.Iteration{Name: name, SpaceID: sp.ID, StartAt: &start, EndAt: &end, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &i))
+		return i
+	}
+	now := time.Now()
+	day := 24 * time.Hour
+	legacy := createIteration("Sprint legacy", now.Add(-20*day), now.Add(-10*day))
+	ended := createIteration("Sprint ended", now.Add(-10*day), now.Add(-day))
+	var closed []uuid.UUID
+	scheduler := iteration.NewScheduler(test.DB, now.Add(-2*day), func(ctx context.Context, tx *gorm.DB, itr iteration.Iteration) error {
+		closed = append(closed, itr.ID)
+		return nil
+	})
+	// when
+	scheduler.UpdateStates(context.Background(), now)
+	// then only the iteration which ended after the scheduler was enabled has
+	// its work items carried over
+	assert.Equal(t, []uuid.UUID{ended.ID}, closed)
+	for _, id := range []uuid.UUID{legacy.ID, ended.ID} {
+		loaded, err := repo.Load(context.Background(), id)
+		require.Nil(t, err)
+		assert.Equal(t, iteration.IterationStateClose, loaded.State, loaded.Name)
+	}
+}
+
+func (test *TestIterationRepository) TestDeleteIteration() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := iteration.NewIterationRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: testcommon.CreateRandomValidTestName("Space Delete Iterations"),
+	})
+	require.Nil(t, err)
+	root := iteration.Iteration{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+
+	t.Run("delete with children", func(t *testing.T) {
+		// given
+		parent := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &parent))
+		child := iteration.Iteration{Name: "Sprint 1.1", SpaceID: sp.ID, Path: append(parent.Path, parent.ID)}
+		require.Nil(t, repo.Create(context.Background(), &child))
+		sibling := iteration.Iteration{Name: "Sprint 2", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+		require.Nil(t, repo.Create(context.Background(), &sibling))
+		// when
+		err := repo.Delete(context.Background(), parent.ID)
+		// then
+		require.Nil(t, err)
+		_, err = repo.Load(context.Background(), parent.ID)
+		assert.IsType(t, errors.NotFoundError{}, err)
+		_, err = repo.Load(context.Background(), child.ID)
+		assert.IsType(t, errors.NotFoundError{}, err)
+		_, err = repo.Load(context.Background(), sibling.ID)
+		assert.Nil(t, err)
+		// and an iteration with the same name can be created again
+		recreated := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+		assert.Nil(t, repo.Create(context.Background(), &recreated))
+	})
+
+	t.Run("delete root iteration", func(t *testing.T) {
+		// when
+		err := repo.Delete(context.Background(), root.ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("delete unknown iteration", func(t *testing.T) {
+		// when
+		err := repo.Delete(context.Background(), uuid.NewV4())
+		// then
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (test *TestIterationRepository) TestMoveIteration() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	repo := iteration.NewIterationRepository(test.DB)
+	sp, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+		Name: testcommon.CreateRandomValidTestName("Space Move Iterations"),
+	})
+	require.Nil(t, err)
+	root := iteration.Iteration{Name: "Root", SpaceID: sp.ID}
+	require.Nil(t, repo.Create(context.Background(), &root))
+	/*
+		Root ---> Release 1 ---> Sprint 1 ---> Sprint 1.1
+		     ---> Release 2
+	*/
+	release1 := iteration.Iteration{Name: "Release 1", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+	require.Nil(t, repo.Create(context.Background(), &release1))
+	release2 := iteration.Iteration{Name: "Release 2", SpaceID: sp.ID, Path: append(root.Path, root.ID)}
+	require.Nil(t, repo.Create(context.Background(), &release2))
+	sprint := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID, Path: append(release1.Path, release1.ID)}
+	require.Nil(t, repo.Create(context.Background(), &sprint))
+	subSprint := iteration.Iteration{Name: "Sprint 1.1", SpaceID: sp.ID, Path: append(sprint.Path, sprint.ID)}
+	require.Nil(t, repo.Create(context.Background(), &subSprint))
+
+	t.Run("move with children", func(t *testing.T) {
+		// when
+		moved, err := repo.Move(context.Background(), sprint.ID, release2.ID)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, path.Path{root.ID, release2.ID}.Convert(), moved.Path.Convert())
+		child, err := repo.Load(context.Background(), subSprint.ID)
+		require.Nil(t, err)
+		assert.Equal(t, path.Path{root.ID, release2.ID, sprint.ID}.Convert(), child.Path.Convert())
+		children, err := repo.LoadChildren(context.Background(), release1.ID)
+		require.Nil(t, err)
+		assert.Empty(t, children)
+	})
+
+	t.Run("move under one of its children", func(t *testing.T) {
+		// when
+		_, err := repo.Move(context.Background(), sprint.ID, subSprint.ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("move under itself", func(t *testing.T) {
+		// when
+		_, err := repo.Move(context.Background(), sprint.ID, sprint.ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("move root iteration", func(t *testing.T) {
+		// when
+		_, err := repo.Move(context.Background(), root.ID, release1.ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("move under iteration of another space", func(t *testing.T) {
+		// given
+		otherSpace, err := space.NewRepository(test.DB).Create(context.Background(), &space.Space{
+			Name: testcommon.CreateRandomValidTestName("Space Move Iterations"),
+		})
+		require.Nil(t, err)
+		otherRoot := iteration.Iteration{Name: "Other Root", SpaceID: otherSpace.ID}
+		require.Nil(t, repo.Create(context.Background(), &otherRoot))
+		// when
+		_, err = repo.Move(context.Background(), release1.ID, otherRoot.ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+
+	t.Run("move next to a sibling with the same name", func(t *testing.T) {
+		// given
+		duplicate := iteration.Iteration{Name: "Sprint 1", SpaceID: sp.ID, Path: append(release1.Path, release1.ID)}
+		require.Nil(t, repo.Create(context.Background(), &duplicate))
+		// when
+		_, err := repo.Move(context.Background(), duplicate.ID, release2.ID)
+		// then
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+}
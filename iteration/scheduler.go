@@ -0,0 +1,148 @@
+package iteration
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/models"
+
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+// schedulerLockKey is the key of the transaction-level advisory locks taken
+// (along with the hash of the space ID) by the schedulers of all the replicas
+// before updating the state of an iteration
+const schedulerLockKey = 4242
+
+// CloseFunc is called in the transaction closing the given iteration, before
+// its state is saved, to carry over its not-closed work items
+type CloseFunc func(ctx context.Context, tx *gorm.DB, itr Iteration) error
+
+// Scheduler starts the iterations whose start date passed and closes the
+// ones whose end date passed
+type Scheduler struct {
+	db    *gorm.DB
+	cr    *cron.Cron
+	since time.Time
+	close CloseFunc
+}
+
+// NewScheduler creates a new Scheduler which calls the given function when
+// closing an iteration which ended after the given time. The iterations which
+// ended before, such as the ones which ended before the scheduler was enabled,
+// are closed as they are.
+func NewScheduler(db *gorm.DB, since time.Time, close CloseFunc) *Scheduler {
+	return &Scheduler{db: db, cr: cron.New(), since: since, close: close}
+}
+
+// Start updates the states of the iterations on the given cron schedule
+// until the scheduler is stopped.
+func (s *Scheduler) Start(schedule string) error {
+	err := s.cr.AddFunc(schedule, func() {
+		s.UpdateStates(context.Background(), time.Now())
+	})
+	if err != nil {
+		return errs.Wrapf(err, "invalid iteration schedule '%s'", schedule)
+	}
+	s.cr.Start()
+	return nil
+}
+
+// Stop scheduler
+// This should be called only from main
+func (s *Scheduler) Stop() {
+	s.cr.Stop()
+}
+
+// UpdateStates closes the iterations whose end date passed at the given time,
+// then starts the new iterations whose start date passed, unless another
+// iteration of their space is running. The iterations of the archived spaces
+// are left as they are. The schedulers of several replicas can run at the same
+// time: an iteration is only updated by the one holding the advisory lock of
+// its space.
+func (s *Scheduler) UpdateStates(ctx context.Context, now time.Time) {
+	db := s.db.Where("space_id NOT IN (SELECT id FROM spaces WHERE archived)")
+	var ended []Iteration
+	err := db.Where("state IN (?) AND end_at <= ?", []string{IterationStateNew, IterationStateStart}, now).Order("end_at").Find(&ended).Error
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err": err,
+		}, "unable to list the iterations to close")
+		return
+	}
+	for _, itr := range ended {
+		s.moveTo(ctx, itr, IterationStateClose, itr.EndAt.After(s.since))
+	}
+	var started []Iteration
+	err = db.Where("state = ? AND path != '' AND start_at <= ? AND (end_at IS NULL OR end_at > ?)", IterationStateNew, now, now).Order("start_at").Find(&started).Error
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err": err,
+		}, "unable to list the iterations to start")
+		return
+	}
+	for _, itr := range started {
+		s.moveTo(ctx, itr, IterationStateStart, false)
+	}
+}
+
+// moveTo saves the given iteration with the given state in its own
+// transaction, so that a failure does not prevent the other iterations
+// from being updated. The close function is only called if asked to. The
+// iteration is skipped if the advisory lock of its space is held by another
+// scheduler or if its state changed meanwhile.
+func (s *Scheduler) moveTo(ctx context.Context, itr Iteration, state string, callClose bool) {
+	var skipped bool
+	err := models.Transactional(s.db, func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?, hashtext(?))", schedulerLockKey, itr.SpaceID.String()).Row().Scan(&locked); err != nil {
+			return errs.WithStack(err)
+		}
+		if !locked {
+			skipped = true
+			return nil
+		}
+		repo := NewIterationRepository(tx)
+		current, err := repo.Load(ctx, itr.ID)
+		if err != nil {
+			return err
+		}
+		if current.State != itr.State {
+			skipped = true
+			return nil
+		}
+		if callClose {
+			if err := s.close(ctx, tx, *current); err != nil {
+				return err
+			}
+		}
+		current.State = state
+		_, err = repo.Save(ctx, *current)
+		return err
+	})
+	if err != nil {
+		log.Info(ctx, map[string]interface{}{
+			"iteration_id": itr.ID,
+			"space_id":     itr.SpaceID,
+			"state":        state,
+			"err":          err,
+		}, "unable to update the state of the iteration")
+		return
+	}
+	if skipped {
+		log.Info(ctx, map[string]interface{}{
+			"iteration_id": itr.ID,
+			"space_id":     itr.SpaceID,
+			"state":        state,
+		}, "the iteration is being updated by another scheduler")
+		return
+	}
+	log.Info(ctx, map[string]interface{}{
+		"iteration_id": itr.ID,
+		"space_id":     itr.SpaceID,
+		"state":        state,
+	}, "updated the state of the iteration")
+}
@@ -14,6 +14,8 @@ const (
 	contextTokenManagerKey contextTMKey = iota
 	//autzSpaceServiceKey is a key that will be used to put and to get `AuthzService` from goa.context
 	autzSpaceServiceKey int = iota
+	//collaboratorsServiceKey is a key that will be used to put and to get `CollaboratorsService` from goa.context
+	collaboratorsServiceKey int = iota
 )
 
 // ReadTokenManagerFromContext returns an interface that encapsulates the
@@ -30,6 +32,13 @@ func ReadSpaceAuthzServiceFromContext(ctx context.Context) interface{} {
 	return ctx.Value(autzSpaceServiceKey)
 }
 
+// ReadCollaboratorsServiceFromContext returns an interface that encapsulates the
+// CollaboratorsService extracted from context. This interface can be safely converted to authz.CollaboratorsService.
+// Must have been set by ContextWithCollaboratorsService ONLY.
+func ReadCollaboratorsServiceFromContext(ctx context.Context) interface{} {
+	return ctx.Value(collaboratorsServiceKey)
+}
+
 // ContextWithTokenManager injects tokenManager in the context for every incoming request
 // Accepts Token.Manager in order to make sure that correct object is set in the context.
 // Only other possible value is nil
@@ -43,3 +52,10 @@ func ContextWithTokenManager(ctx context.Context, tm interface{}) context.Contex
 func ContextWithSpaceAuthzService(ctx context.Context, s interface{}) context.Context {
 	return context.WithValue(ctx, autzSpaceServiceKey, s)
 }
+
+// ContextWithCollaboratorsService injects CollaboratorsService in the context for every incoming request
+// Accepts authz.CollaboratorsService in order to make sure that correct object is set in the context.
+// Only other possible value is nil
+func ContextWithCollaboratorsService(ctx context.Context, s interface{}) context.Context {
+	return context.WithValue(ctx, collaboratorsServiceKey, s)
+}
@@ -20,14 +20,17 @@ import (
 	config "github.com/fabric8-services/fabric8-wit/configuration"
 	"github.com/fabric8-services/fabric8-wit/controller"
 	"github.com/fabric8-services/fabric8-wit/gormapplication"
+	"github.com/fabric8-services/fabric8-wit/iteration"
 	"github.com/fabric8-services/fabric8-wit/jsonapi"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/login"
 	"github.com/fabric8-services/fabric8-wit/migration"
 	"github.com/fabric8-services/fabric8-wit/models"
 	"github.com/fabric8-services/fabric8-wit/remoteworkitem"
+	"github.com/fabric8-services/fabric8-wit/search"
 	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/space/authz"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	"github.com/fabric8-services/fabric8-wit/token"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
@@ -144,6 +147,20 @@ func main() {
 				"err": err,
 			}, "failed to bootstap work item linking")
 		}
+		if err := models.Transactional(db, func(tx *gorm.DB) error {
+			return migration.PopulateSpaceTemplates(ctx, spacetemplate.NewRepository(tx))
+		}); err != nil {
+			log.Panic(ctx, map[string]interface{}{
+				"err": err,
+			}, "failed to populate space templates")
+		}
+	}
+
+	// Register the URLs understood by the search
+	if err := search.RegisterKnownURLs(configuration.GetSearchKnownURLs()); err != nil {
+		log.Panic(nil, map[string]interface{}{
+			"err": err,
+		}, "failed to register the search known URLs")
 	}
 
 	// Create service
@@ -177,6 +194,8 @@ func main() {
 	service.Use(login.InjectTokenManager(tokenManager))
 	spaceAuthzService := authz.NewAuthzService(configuration, appDB)
 	service.Use(authz.InjectAuthzService(spaceAuthzService))
+	collaboratorsService := authz.NewCollaboratorsService(appDB, auth.NewKeycloakPolicyManager(configuration))
+	service.Use(authz.InjectCollaboratorsService(collaboratorsService))
 
 	loginService := login.NewKeycloakOAuthProvider(identityRepository, userRepository, tokenManager, appDB)
 	loginCtrl := controller.NewLoginController(service, loginService, tokenManager, configuration)
@@ -217,10 +236,39 @@ func main() {
 	workItemRelationshipsLinksCtrl := controller.NewWorkItemRelationshipsLinksController(service, appDB, configuration)
 	app.MountWorkItemRelationshipsLinksController(service, workItemRelationshipsLinksCtrl)
 
+	// Mount "work item events" controller
+	workItemEventsCtrl := controller.NewWorkItemEventsController(service, appDB)
+	app.MountWorkItemEventsController(service, workItemEventsCtrl)
+
+	// Mount "work item templates" controller
+	workItemTemplatesCtrl := controller.NewWorkItemTemplatesController(service, appDB)
+	app.MountWorkItemTemplatesController(service, workItemTemplatesCtrl)
+
+	// Mount "space templates" controller
+	spaceTemplatesCtrl := controller.NewSpaceTemplatesController(service, appDB, configuration)
+	app.MountSpaceTemplatesController(service, spaceTemplatesCtrl)
+
+	// Mount "space filters" controller
+	spaceFiltersCtrl := controller.NewSpaceFiltersController(service, appDB)
+	app.MountSpaceFiltersController(service, spaceFiltersCtrl)
+
 	// Mount "comments" controller
 	commentsCtrl := controller.NewCommentsController(service, appDB, configuration)
 	app.MountCommentsController(service, commentsCtrl)
 
+	if configuration.GetFeatureIterationSchedule() {
+		// Scheduler to start and close the iterations when their dates pass
+		iterationScheduler := iteration.NewScheduler(db, time.Now(), func(ctx context.Context, tx *gorm.DB, itr iteration.Iteration) error {
+			return controller.CarryOverEndedIteration(ctx, gormapplication.NewGormDB(tx), itr)
+		})
+		if err := iterationScheduler.Start(configuration.GetIterationSchedule()); err != nil {
+			log.Panic(nil, map[string]interface{}{
+				"err": err,
+			}, "failed to start the iteration scheduler")
+		}
+		defer iterationScheduler.Stop()
+	}
+
 	if configuration.GetFeatureWorkitemRemote() {
 		// Scheduler to fetch and import remote tracker items
 		scheduler = remoteworkitem.NewScheduler(db)
@@ -309,6 +357,10 @@ func main() {
 	collaboratorsCtrl := controller.NewCollaboratorsController(service, appDB, configuration, auth.NewKeycloakPolicyManager(configuration))
 	app.MountCollaboratorsController(service, collaboratorsCtrl)
 
+	// Mount "space_ownership" controller
+	spaceOwnershipCtrl := controller.NewSpaceOwnershipController(service, appDB, configuration, auth.NewKeycloakPolicyManager(configuration))
+	app.MountSpaceOwnershipController(service, spaceOwnershipCtrl)
+
 	log.Logger().Infoln("Git Commit SHA: ", controller.Commit)
 	log.Logger().Infoln("UTC Build Time: ", controller.BuildTime)
 	log.Logger().Infoln("UTC Start Time: ", controller.StartTime)
@@ -0,0 +1,3 @@
+// Package mention contains the code that stores the identities mentioned with
+// `@username` in the description of the work items and in their comments.
+package mention
@@ -0,0 +1,30 @@
+package mention
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIStringTypeMentions is the JSON API type of the mentions
+const APIStringTypeMentions = "mentions"
+
+// Mention is the mention of an identity with `@username` in the description
+// of a work item, or in one of its comments
+type Mention struct {
+	ID        uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	CreatedAt time.Time
+	// the mentioned identity
+	IdentityID uuid.UUID `sql:"type:uuid"`
+	// the username as written in the mention, in lower case
+	Username string
+	// the work item mentioning the identity, or whose comment does
+	WorkItemID uuid.UUID `sql:"type:uuid"`
+	// the comment mentioning the identity, if any
+	CommentID *uuid.UUID `sql:"type:uuid"`
+}
+
+// TableName implements gorm.tabler
+func (m Mention) TableName() string {
+	return "mentions"
+}
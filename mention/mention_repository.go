@@ -0,0 +1,191 @@
+package mention
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository describes interactions with the mentions
+type Repository interface {
+	// Replace replaces the mentions of the description of the given work
+	// item, or of the given comment of the work item if not nil, with the
+	// given identities by username. The mentions which already existed are
+	// kept as is.
+	Replace(ctx context.Context, workItemID uuid.UUID, commentID *uuid.UUID, identities map[string]uuid.UUID) ([]Mention, error)
+	// List returns the mentions of the description of the given work item,
+	// or of the given comment of the work item if not nil.
+	List(ctx context.Context, workItemID uuid.UUID, commentID *uuid.UUID) ([]Mention, error)
+	// ListByWorkItems returns the mentions of the descriptions and of the
+	// comments of the given work items.
+	ListByWorkItems(ctx context.Context, workItemIDs []uuid.UUID) ([]Mention, error)
+	// ListSpaces returns the IDs of the spaces of the work items and
+	// comments which are not deleted and mention the given identity.
+	ListSpaces(ctx context.Context, identityID uuid.UUID) ([]uuid.UUID, error)
+	// ListByIdentity returns the mentions of the given identity in the work
+	// items and comments of the given spaces which are not deleted, the
+	// latest first, along with their total count.
+	ListByIdentity(ctx context.Context, identityID uuid.UUID, spaceIDs []uuid.UUID, start *int, limit *int) ([]Mention, int, error)
+}
+
+// NewRepository creates a new storage type.
+func NewRepository(db *gorm.DB) Repository {
+	return &GormMentionRepository{db: db}
+}
+
+// GormMentionRepository is the implementation of the storage interface for
+// the mentions.
+type GormMentionRepository struct {
+	db *gorm.DB
+}
+
+// source restricts the given query to the mentions of the description of the
+// given work item, or of the given comment of the work item if not nil
+func source(db *gorm.DB, workItemID uuid.UUID, commentID *uuid.UUID) *gorm.DB {
+	if commentID == nil {
+		return db.Where("work_item_id = ? AND comment_id IS NULL", workItemID)
+	}
+	return db.Where("work_item_id = ? AND comment_id = ?", workItemID, *commentID)
+}
+
+// Replace replaces the mentions of the description of the given work item, or
+// of the given comment of the work item if not nil, with the given identities
+// by username. The mentions which already existed are kept as is.
+func (r *GormMentionRepository) Replace(ctx context.Context, workItemID uuid.UUID, commentID *uuid.UUID, identities map[string]uuid.UUID) ([]Mention, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "mention", "replace"}, time.Now())
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, workItemID); err != nil {
+		return nil, err
+	}
+	existing, err := r.List(ctx, workItemID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	result := []Mention{}
+	for _, m := range existing {
+		if identityID, ok := identities[m.Username]; ok && uuid.Equal(identityID, m.IdentityID) {
+			result = append(result, m)
+			continue
+		}
+		if err := r.db.Delete(&m).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"mention_id": m.ID,
+				"err":        err,
+			}, "unable to delete the mention")
+			return nil, errors.NewInternalError(ctx, err)
+		}
+	}
+	for username, identityID := range identities {
+		found := false
+		for _, m := range result {
+			if m.Username == username {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		m := Mention{
+			ID:         uuid.NewV4(),
+			IdentityID: identityID,
+			Username:   username,
+			WorkItemID: workItemID,
+			CommentID:  commentID,
+		}
+		if err := r.db.Create(&m).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"work_item_id": workItemID,
+				"comment_id":   commentID,
+				"identity_id":  identityID,
+				"err":          err,
+			}, "unable to create the mention")
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// List returns the mentions of the description of the given work item, or of
+// the given comment of the work item if not nil.
+func (r *GormMentionRepository) List(ctx context.Context, workItemID uuid.UUID, commentID *uuid.UUID) ([]Mention, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "mention", "list"}, time.Now())
+	var result []Mention
+	if err := source(r.db, workItemID, commentID).Order("username").Find(&result).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
+
+// ListByWorkItems returns the mentions of the descriptions and of the comments
+// of the given work items.
+func (r *GormMentionRepository) ListByWorkItems(ctx context.Context, workItemIDs []uuid.UUID) ([]Mention, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "mention", "list_by_work_items"}, time.Now())
+	result := []Mention{}
+	if len(workItemIDs) == 0 {
+		return result, nil
+	}
+	if err := r.db.Where("work_item_id IN (?)", workItemIDs).Order("username").Find(&result).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
+
+// byIdentity restricts the given query to the mentions of the given identity
+// in the work items and comments which are not deleted. The work items are
+// joined as `w`.
+func byIdentity(db *gorm.DB, identityID uuid.UUID) *gorm.DB {
+	return db.Model(&Mention{}).
+		Joins("JOIN work_items w ON w.id = mentions.work_item_id AND w.deleted_at IS NULL").
+		Joins("LEFT JOIN comments c ON c.id = mentions.comment_id").
+		Where("mentions.identity_id = ? AND c.deleted_at IS NULL", identityID)
+}
+
+// ListSpaces returns the IDs of the spaces of the work items and comments
+// which are not deleted and mention the given identity.
+func (r *GormMentionRepository) ListSpaces(ctx context.Context, identityID uuid.UUID) ([]uuid.UUID, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "mention", "list_spaces"}, time.Now())
+	var result []uuid.UUID
+	if err := byIdentity(r.db, identityID).Pluck("DISTINCT w.space_id", &result).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
+
+// ListByIdentity returns the mentions of the given identity in the work items
+// and comments of the given spaces which are not deleted, the latest first,
+// along with their total count.
+func (r *GormMentionRepository) ListByIdentity(ctx context.Context, identityID uuid.UUID, spaceIDs []uuid.UUID, start *int, limit *int) ([]Mention, int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "mention", "list_by_identity"}, time.Now())
+	if len(spaceIDs) == 0 {
+		return []Mention{}, 0, nil
+	}
+	db := byIdentity(r.db, identityID).Where("w.space_id IN (?)", spaceIDs)
+	var count int
+	if err := db.Count(&count).Error; err != nil {
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	if start != nil {
+		if *start < 0 {
+			return nil, 0, errors.NewBadParameterError("start", *start)
+		}
+		db = db.Offset(*start)
+	}
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, 0, errors.NewBadParameterError("limit", *limit)
+		}
+		db = db.Limit(*limit)
+	}
+	var result []Mention
+	if err := db.Select("mentions.*").Order("mentions.created_at desc, mentions.id").Find(&result).Error; err != nil {
+		return nil, 0, errors.NewInternalError(ctx, err)
+	}
+	return result, count, nil
+}
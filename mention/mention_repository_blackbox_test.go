@@ -0,0 +1,255 @@
+package mention_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/mention"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestMentionRepository struct {
+	gormtestsupport.DBTestSuite
+	clean         func()
+	testIdentity  account.Identity
+	testIdentity2 account.Identity
+	repo          mention.Repository
+	wiRepo        workitem.WorkItemRepository
+	commentRepo   comment.Repository
+	ctx           context.Context
+}
+
+func TestRunMentionRepository(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestMentionRepository{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
+}
+
+func (s *TestMentionRepository) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.ctx = migration.NewMigrationContext(context.Background())
+	s.DBTestSuite.PopulateDBTestSuite(s.ctx)
+}
+
+func (s *TestMentionRepository) SetupTest() {
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+	s.repo = mention.NewRepository(s.DB)
+	s.wiRepo = workitem.NewWorkItemRepository(s.DB)
+	s.commentRepo = comment.NewRepository(s.DB)
+	var err error
+	s.testIdentity, err = testsupport.CreateTestIdentity(s.DB, "jdoe", "test")
+	require.Nil(s.T(), err)
+	s.testIdentity2, err = testsupport.CreateTestIdentity(s.DB, "jdoe2", "test")
+	require.Nil(s.T(), err)
+}
+
+func (s *TestMentionRepository) TearDownTest() {
+	s.clean()
+}
+
+func (s *TestMentionRepository) createWorkItem() *workitem.WorkItem {
+	wi, err := s.wiRepo.Create(s.ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "Mentions",
+		workitem.SystemState: workitem.SystemStateNew,
+	}, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	return wi
+}
+
+func (s *TestMentionRepository) createComment(wi *workitem.WorkItem) *comment.Comment {
+	c := &comment.Comment{
+		ParentID:  wi.ID,
+		Body:      "Hello",
+		Markup:    rendering.SystemMarkupMarkdown,
+		CreatedBy: s.testIdentity.ID,
+	}
+	require.Nil(s.T(), s.commentRepo.Create(s.ctx, c, s.testIdentity.ID))
+	return c
+}
+
+func usernames(mentions []mention.Mention) []string {
+	result := make([]string, len(mentions))
+	for i, m := range mentions {
+		result[i] = m.Username
+	}
+	return result
+}
+
+func (s *TestMentionRepository) TestReplace() {
+	// given
+	wi := s.createWorkItem()
+	c := s.createComment(wi)
+
+	s.T().Run("work item description", func(t *testing.T) {
+		// when
+		mentions, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"jdoe": s.testIdentity.ID})
+		require.Nil(t, err)
+		require.Len(t, mentions, 1)
+		first := mentions[0]
+		mentions, err = s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{
+			"jdoe":  s.testIdentity.ID,
+			"jdoe2": s.testIdentity2.ID,
+		})
+		require.Nil(t, err)
+		// then the existing mention is kept as is
+		listed, err := s.repo.List(s.ctx, wi.ID, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"jdoe", "jdoe2"}, usernames(listed))
+		assert.Equal(t, first.ID, listed[0].ID)
+		assert.Equal(t, s.testIdentity2.ID, listed[1].IdentityID)
+	})
+
+	s.T().Run("comment", func(t *testing.T) {
+		// when
+		_, err := s.repo.Replace(s.ctx, wi.ID, &c.ID, map[string]uuid.UUID{"jdoe2": s.testIdentity2.ID})
+		require.Nil(t, err)
+		// then the mentions of the description are not changed
+		listed, err := s.repo.List(s.ctx, wi.ID, &c.ID)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"jdoe2"}, usernames(listed))
+		listed, err = s.repo.List(s.ctx, wi.ID, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"jdoe", "jdoe2"}, usernames(listed))
+	})
+
+	s.T().Run("remove", func(t *testing.T) {
+		// when
+		_, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"jdoe2": s.testIdentity2.ID})
+		require.Nil(t, err)
+		// then
+		listed, err := s.repo.List(s.ctx, wi.ID, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"jdoe2"}, usernames(listed))
+	})
+
+	s.T().Run("archived space", func(t *testing.T) {
+		// given a work item of a space which is then archived
+		spaceRepo := space.NewRepository(s.DB)
+		sp, err := spaceRepo.Create(s.ctx, &space.Space{Name: testsupport.CreateRandomValidTestName("mentions-")})
+		require.Nil(t, err)
+		archivedWI, err := s.wiRepo.Create(s.ctx, sp.ID, workitem.SystemBug, map[string]interface{}{
+			workitem.SystemTitle: "Archived",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity.ID)
+		require.Nil(t, err)
+		sp.Archived = true
+		_, err = spaceRepo.Save(s.ctx, sp)
+		require.Nil(t, err)
+		// when
+		_, err = s.repo.Replace(s.ctx, archivedWI.ID, nil, map[string]uuid.UUID{"jdoe": s.testIdentity.ID})
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+}
+
+func (s *TestMentionRepository) TestListByWorkItems() {
+	// given
+	wi := s.createWorkItem()
+	c := s.createComment(wi)
+	other := s.createWorkItem()
+	_, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"jdoe": s.testIdentity.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, wi.ID, &c.ID, map[string]uuid.UUID{"jdoe2": s.testIdentity2.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, other.ID, nil, map[string]uuid.UUID{"jdoe2": s.testIdentity2.ID})
+	require.Nil(s.T(), err)
+	// when
+	mentions, err := s.repo.ListByWorkItems(s.ctx, []uuid.UUID{wi.ID})
+	// then the mentions of the description and of the comment are listed
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"jdoe", "jdoe2"}, usernames(mentions))
+	require.NotNil(s.T(), mentions[1].CommentID)
+	assert.Equal(s.T(), c.ID, *mentions[1].CommentID)
+}
+
+func (s *TestMentionRepository) TestListByIdentity() {
+	// given
+	wi := s.createWorkItem()
+	c := s.createComment(wi)
+	deletedWI := s.createWorkItem()
+	_, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"jdoe": s.testIdentity.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, wi.ID, &c.ID, map[string]uuid.UUID{"jdoe": s.testIdentity.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, deletedWI.ID, nil, map[string]uuid.UUID{"jdoe": s.testIdentity.ID})
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), s.wiRepo.Delete(s.ctx, deletedWI.ID, s.testIdentity.ID))
+	spaceIDs := []uuid.UUID{space.SystemSpace}
+
+	s.T().Run("spaces", func(t *testing.T) {
+		// when
+		spaces, err := s.repo.ListSpaces(s.ctx, s.testIdentity.ID)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, spaceIDs, spaces)
+	})
+
+	s.T().Run("other spaces", func(t *testing.T) {
+		// when
+		mentions, count, err := s.repo.ListByIdentity(s.ctx, s.testIdentity.ID, []uuid.UUID{uuid.NewV4()}, nil, nil)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, 0, count)
+		assert.Empty(t, mentions)
+	})
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		mentions, count, err := s.repo.ListByIdentity(s.ctx, s.testIdentity.ID, spaceIDs, nil, nil)
+		// then the latest first, without the deleted work item
+		require.Nil(t, err)
+		assert.Equal(t, 2, count)
+		require.Len(t, mentions, 2)
+		require.NotNil(t, mentions[0].CommentID)
+		assert.Equal(t, c.ID, *mentions[0].CommentID)
+		assert.Nil(t, mentions[1].CommentID)
+		assert.Equal(t, wi.ID, mentions[1].WorkItemID)
+	})
+
+	s.T().Run("paging", func(t *testing.T) {
+		// when
+		start, limit := 1, 1
+		mentions, count, err := s.repo.ListByIdentity(s.ctx, s.testIdentity.ID, spaceIDs, &start, &limit)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, 2, count)
+		require.Len(t, mentions, 1)
+		assert.Nil(t, mentions[0].CommentID)
+	})
+
+	s.T().Run("deleted comment", func(t *testing.T) {
+		// when
+		require.Nil(t, s.commentRepo.Delete(s.ctx, c.ID, s.testIdentity.ID))
+		mentions, count, err := s.repo.ListByIdentity(s.ctx, s.testIdentity.ID, spaceIDs, nil, nil)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, 1, count)
+		require.Len(t, mentions, 1)
+		assert.Nil(t, mentions[0].CommentID)
+	})
+
+	s.T().Run("not mentioned", func(t *testing.T) {
+		// when
+		mentions, count, err := s.repo.ListByIdentity(s.ctx, s.testIdentity2.ID, spaceIDs, nil, nil)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, 0, count)
+		assert.Empty(t, mentions)
+	})
+}
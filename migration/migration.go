@@ -12,6 +12,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/space"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 
@@ -325,6 +326,51 @@ func GetMigrations() Migrations {
 	// Version 67
 	m = append(m, steps{ExecuteSQLFile("067-comment-parentid-uuid.sql")})
 
+	// Version 68
+	m = append(m, steps{ExecuteSQLFile("068-work-item-number-redirects.sql")})
+
+	// Version 69
+	m = append(m, steps{ExecuteSQLFile("069-work-item-templates.sql")})
+
+	// Version 70
+	m = append(m, steps{ExecuteSQLFile("070-comments-search-index.sql")})
+
+	// Version 71
+	m = append(m, steps{ExecuteSQLFile("071-space-text-search-config.sql")})
+
+	// Version 72
+	m = append(m, steps{ExecuteSQLFile("072-work-item-filters.sql")})
+
+	// Version 73
+	m = append(m, steps{ExecuteSQLFile("073-comment-threads-reactions.sql")})
+
+	// Version 74
+	m = append(m, steps{ExecuteSQLFile("074-mentions.sql")})
+
+	// Version 75
+	m = append(m, steps{ExecuteSQLFile("075-work-item-references.sql")})
+
+	// Version 76
+	m = append(m, steps{ExecuteSQLFile("076-iteration-carry-overs.sql")})
+
+	// Version 77
+	m = append(m, steps{ExecuteSQLFile("077-unique-undeleted-area-iteration-names.sql")})
+
+	// Version 78
+	m = append(m, steps{ExecuteSQLFile("078-area-owners.sql")})
+
+	// Version 79
+	m = append(m, steps{ExecuteSQLFile("079-iteration-capacities.sql")})
+
+	// Version 80
+	m = append(m, steps{ExecuteSQLFile("080-space-templates.sql")})
+
+	// Version 81
+	m = append(m, steps{ExecuteSQLFile("081-space-import-mappings.sql")})
+
+	// Version 82
+	m = append(m, steps{ExecuteSQLFile("082-space-archived.sql")})
+
 	// Version N
 	//
 	// In order to add an upgrade, simply append an array of MigrationFunc to the
@@ -705,6 +751,34 @@ func PopulateCommonTypes(ctx context.Context, db *gorm.DB, witr *workitem.GormWo
 	return nil
 }
 
+// PopulateSpaceTemplates makes sure the database is populated with the
+// built-in space templates (e.g. Scrum)
+func PopulateSpaceTemplates(ctx context.Context, repo *spacetemplate.GormTemplateRepository) error {
+	populateLocker.Lock()
+	defer populateLocker.Unlock()
+	for _, tpl := range spacetemplate.BuiltIn() {
+		existing, err := repo.Load(ctx, tpl.ID)
+		cause := errs.Cause(err)
+		switch cause.(type) {
+		case errors.NotFoundError:
+			if _, err := repo.Create(ctx, &tpl); err != nil {
+				return errs.Wrapf(err, "failed to create space template %s", tpl.Name)
+			}
+		case nil:
+			log.Info(ctx, map[string]interface{}{
+				"space_template_id": tpl.ID,
+			}, "Space template %s exists, will update/overwrite the name, description and definition", tpl.Name)
+			tpl.Version = existing.Version
+			if _, err := repo.Save(ctx, tpl); err != nil {
+				return errs.Wrapf(err, "failed to update space template %s", tpl.Name)
+			}
+		default:
+			return errs.WithStack(err)
+		}
+	}
+	return nil
+}
+
 func createOrUpdateSystemPlannerItemType(ctx context.Context, witr *workitem.GormWorkItemTypeRepository, db *gorm.DB, spaceID uuid.UUID) error {
 	log.Info(ctx, nil, "Creating or updating planner item type...")
 	typeID := workitem.SystemPlannerItem
@@ -125,6 +125,21 @@ func TestMigrations(t *testing.T) {
 	t.Run("TestMigration65", testMigration65)
 	t.Run("TestMigration66", testMigration66)
 	t.Run("TestMigration67", testMigration67)
+	t.Run("TestMigration68", testMigration68)
+	t.Run("TestMigration69", testMigration69)
+	t.Run("TestMigration70", testMigration70)
+	t.Run("TestMigration71", testMigration71)
+	t.Run("TestMigration72", testMigration72)
+	t.Run("TestMigration73", testMigration73)
+	t.Run("TestMigration74", testMigration74)
+	t.Run("TestMigration75", testMigration75)
+	t.Run("TestMigration76", testMigration76)
+	t.Run("TestMigration77", testMigration77)
+	t.Run("TestMigration78", testMigration78)
+	t.Run("TestMigration79", testMigration79)
+	t.Run("TestMigration80", testMigration80)
+	t.Run("TestMigration81", testMigration81)
+	t.Run("TestMigration82", testMigration82)
 
 	// Perform the migration
 	if err := migration.Migrate(sqlDB, databaseName); err != nil {
@@ -454,6 +469,232 @@ func testMigration67(t *testing.T) {
 	assert.NotNil(t, parentID)
 }
 
+func testMigration68(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+24)], (initialMigratedVersion + 24))
+
+	assert.True(t, gormDB.HasTable("work_item_number_redirects"))
+	assert.True(t, dialect.HasIndex("work_item_number_redirects", "work_item_number_redirects_work_item_id_idx"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "068-work-item-number-redirects.sql"))
+	// a former space/number pair redirects to a single work item
+	assert.NotNil(t, runSQLscript(sqlDB, "068-work-item-number-redirects.sql"))
+}
+
+func testMigration69(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+25)], (initialMigratedVersion + 25))
+
+	assert.True(t, gormDB.HasTable("work_item_templates"))
+	assert.True(t, dialect.HasIndex("work_item_templates", "work_item_templates_name_space_id_unique"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "069-work-item-templates.sql"))
+	// the names of the templates that are not deleted are unique per space
+	assert.NotNil(t, runSQLscript(sqlDB, "069-work-item-templates.sql"))
+}
+
+func testMigration70(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+26)], (initialMigratedVersion + 26))
+
+	assert.True(t, dialect.HasColumn("comments", "tsv"))
+	assert.True(t, dialect.HasIndex("comments", "comments_fulltext_search_index"))
+
+	// the existing comments are indexed
+	var matches bool
+	err := sqlDB.QueryRow("SELECT tsv @@ to_tsquery('english', 'foo') FROM comments WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&matches)
+	require.Nil(t, err)
+	assert.True(t, matches)
+
+	assert.Nil(t, runSQLscript(sqlDB, "070-comments-search-index.sql"))
+	err = sqlDB.QueryRow("SELECT tsv @@ to_tsquery('english', 'bar') FROM comments WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&matches)
+	require.Nil(t, err)
+	assert.True(t, matches)
+}
+
+func testMigration71(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+27)], (initialMigratedVersion + 27))
+
+	assert.True(t, dialect.HasColumn("spaces", "text_search_config"))
+	var config string
+	err := sqlDB.QueryRow("SELECT text_search_config FROM spaces WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&config)
+	require.Nil(t, err)
+	assert.Equal(t, "english", config)
+
+	// the vectors of the work items and comments of the space are rebuilt with
+	// its new configuration, which does not stem the words nor drop the stop
+	// words
+	assert.Nil(t, runSQLscript(sqlDB, "071-space-text-search-config.sql"))
+	var matches bool
+	err = sqlDB.QueryRow("SELECT tsv @@ to_tsquery('simple', 'running') FROM work_items WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&matches)
+	require.Nil(t, err)
+	assert.True(t, matches)
+	err = sqlDB.QueryRow("SELECT tsv @@ to_tsquery('simple', 'a') FROM comments WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&matches)
+	require.Nil(t, err)
+	assert.True(t, matches)
+
+	// the vectors of a work item moved to another space and of its comments
+	// are rebuilt with the configuration of that space
+	assert.Nil(t, runSQLscript(sqlDB, "071-move-work-item.sql"))
+	err = sqlDB.QueryRow("SELECT tsv @@ to_tsquery('simple', 'running') FROM work_items WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&matches)
+	require.Nil(t, err)
+	assert.False(t, matches)
+	err = sqlDB.QueryRow("SELECT tsv @@ to_tsquery('simple', 'a') FROM comments WHERE id = '00000067-0000-0000-0000-000000000000'").Scan(&matches)
+	require.Nil(t, err)
+	assert.False(t, matches)
+}
+
+func testMigration72(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+28)], (initialMigratedVersion + 28))
+
+	assert.True(t, gormDB.HasTable("work_item_filters"))
+	assert.True(t, dialect.HasIndex("work_item_filters", "work_item_filters_name_unique"))
+	assert.True(t, dialect.HasIndex("work_item_filters", "work_item_filters_space_id_idx"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "072-work-item-filters.sql"))
+	// the names of the filters of a creator are unique per space
+	assert.NotNil(t, runSQLscript(sqlDB, "072-work-item-filters.sql"))
+	// a filter is shared with the space or private to its creator
+	assert.NotNil(t, runSQLscript(sqlDB, "072-invalid-work-item-filter-scope.sql"))
+}
+
+func testMigration73(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+29)], (initialMigratedVersion + 29))
+
+	assert.True(t, dialect.HasColumn("comments", "parent_comment_id"))
+	assert.True(t, dialect.HasIndex("comments", "comments_parent_comment_id_idx"))
+	assert.True(t, gormDB.HasTable("comment_reactions"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "073-comment-threads-reactions.sql"))
+	// an identity reacts once with each emoji
+	assert.NotNil(t, runSQLscript(sqlDB, "073-comment-threads-reactions.sql"))
+	assert.NotNil(t, runSQLscript(sqlDB, "073-empty-comment-reaction.sql"))
+}
+
+func testMigration74(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+30)], (initialMigratedVersion + 30))
+
+	assert.True(t, gormDB.HasTable("mentions"))
+	assert.True(t, dialect.HasIndex("mentions", "mentions_work_item_username_idx"))
+	assert.True(t, dialect.HasIndex("mentions", "mentions_comment_username_idx"))
+	assert.True(t, dialect.HasIndex("mentions", "mentions_identity_id_idx"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "074-mentions.sql"))
+	// a username is mentioned once by a description or a comment
+	assert.NotNil(t, runSQLscript(sqlDB, "074-mentions.sql"))
+}
+
+func testMigration75(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+31)], (initialMigratedVersion + 31))
+
+	assert.True(t, gormDB.HasTable("work_item_references"))
+	assert.True(t, dialect.HasIndex("work_item_references", "work_item_references_work_item_idx"))
+	assert.True(t, dialect.HasIndex("work_item_references", "work_item_references_comment_idx"))
+	assert.True(t, dialect.HasIndex("work_item_references", "work_item_references_work_item_id_idx"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "075-work-item-references.sql"))
+	// a reference is written once by a description or a comment
+	assert.NotNil(t, runSQLscript(sqlDB, "075-work-item-references.sql"))
+}
+
+func testMigration76(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+32)], (initialMigratedVersion + 32))
+
+	assert.True(t, gormDB.HasTable("iteration_carry_overs"))
+	assert.True(t, dialect.HasIndex("iteration_carry_overs", "iteration_carry_overs_iteration_id_idx"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "076-iteration-carry-overs.sql"))
+	var count int
+	err := sqlDB.QueryRow("SELECT count(*) FROM iteration_carry_overs WHERE iteration_id = '00000076-0000-0000-0000-000000000001'").Scan(&count)
+	require.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func testMigration77(t *testing.T) {
+	// migrate to previous version
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+32)], (initialMigratedVersion + 32))
+	assert.Nil(t, runSQLscript(sqlDB, "077-deleted-area-iteration.sql"))
+	// the deleted area and iteration prevent creating new ones with the same name
+	assert.NotNil(t, runSQLscript(sqlDB, "077-area.sql"))
+	assert.NotNil(t, runSQLscript(sqlDB, "077-iteration.sql"))
+	// then apply the change
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+33)], (initialMigratedVersion + 33))
+
+	assert.True(t, dialect.HasIndex("areas", "areas_name_space_id_path_unique"))
+	assert.True(t, dialect.HasIndex("iterations", "iterations_name_space_id_path_unique"))
+	// the deleted area and iteration do not prevent it anymore
+	assert.Nil(t, runSQLscript(sqlDB, "077-area.sql"))
+	assert.Nil(t, runSQLscript(sqlDB, "077-iteration.sql"))
+	// while the names of the areas and iterations which are not deleted are
+	// still unique under the same parent
+	assert.NotNil(t, runSQLscript(sqlDB, "077-area.sql"))
+	assert.NotNil(t, runSQLscript(sqlDB, "077-iteration.sql"))
+}
+
+func testMigration78(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+34)], (initialMigratedVersion + 34))
+
+	assert.True(t, gormDB.HasTable("area_owners"))
+	assert.True(t, dialect.HasColumn("areas", "default_assignee_rule"))
+	assert.True(t, dialect.HasColumn("areas", "default_assignee_id"))
+	assert.True(t, dialect.HasColumn("areas", "last_assignee_id"))
+	// the existing areas do not assign their new work items
+	var rule string
+	err := sqlDB.QueryRow("SELECT default_assignee_rule FROM areas WHERE id = '00000077-0000-0000-0000-000000000001'").Scan(&rule)
+	require.Nil(t, err)
+	assert.Equal(t, "none", rule)
+
+	assert.Nil(t, runSQLscript(sqlDB, "078-area-owners.sql"))
+	// an identity owns an area once
+	assert.NotNil(t, runSQLscript(sqlDB, "078-area-owners.sql"))
+	assert.NotNil(t, runSQLscript(sqlDB, "078-invalid-default-assignee-rule.sql"))
+	err = sqlDB.QueryRow("SELECT default_assignee_rule FROM areas WHERE id = '00000077-0000-0000-0000-000000000001'").Scan(&rule)
+	require.Nil(t, err)
+	assert.Equal(t, "round-robin", rule)
+}
+
+func testMigration79(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+35)], (initialMigratedVersion + 35))
+
+	assert.True(t, gormDB.HasTable("iteration_capacities"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "079-iteration-capacities.sql"))
+	// a collaborator has one capacity per iteration
+	assert.NotNil(t, runSQLscript(sqlDB, "079-iteration-capacities.sql"))
+	assert.NotNil(t, runSQLscript(sqlDB, "079-invalid-iteration-capacity-unit.sql"))
+	assert.NotNil(t, runSQLscript(sqlDB, "079-negative-iteration-capacity.sql"))
+}
+
+func testMigration80(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+36)], (initialMigratedVersion + 36))
+
+	assert.True(t, gormDB.HasTable("space_templates"))
+	assert.True(t, dialect.HasIndex("space_templates", "space_templates_name_unique"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "080-space-templates.sql"))
+	// the names of the templates that are not deleted are unique
+	assert.NotNil(t, runSQLscript(sqlDB, "080-space-templates.sql"))
+}
+
+func testMigration81(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+37)], (initialMigratedVersion + 37))
+
+	assert.True(t, gormDB.HasTable("space_import_mappings"))
+	assert.True(t, dialect.HasIndex("space_import_mappings", "space_import_mappings_source_idx"))
+
+	assert.Nil(t, runSQLscript(sqlDB, "081-space-import-mappings.sql"))
+	// an entity of an archive is imported once into a space
+	assert.NotNil(t, runSQLscript(sqlDB, "081-space-import-mappings.sql"))
+}
+
+func testMigration82(t *testing.T) {
+	migrateToVersion(sqlDB, migrations[:(initialMigratedVersion+38)], (initialMigratedVersion + 38))
+
+	assert.True(t, dialect.HasColumn("spaces", "archived"))
+	// the existing spaces are not archived
+	var count int
+	err := sqlDB.QueryRow("SELECT count(*) FROM spaces WHERE archived").Scan(&count)
+	require.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
 // runSQLscript loads the given filename from the packaged SQL test files and
 // executes it on the given database. Golang text/template module is used
 // to handle all the optional arguments passed to the sql test files
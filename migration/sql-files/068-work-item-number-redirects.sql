@@ -0,0 +1,11 @@
+-- keep track of the former space and number of the work items that were moved
+-- to another space, so that the old space/number pairs can still be resolved
+CREATE TABLE work_item_number_redirects (
+    created_at timestamp with time zone,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    number integer NOT NULL,
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    PRIMARY KEY (space_id, number)
+);
+
+CREATE INDEX work_item_number_redirects_work_item_id_idx ON work_item_number_redirects (work_item_id);
@@ -0,0 +1,14 @@
+-- work item templates are named trees of pre-filled work items stored per space
+CREATE TABLE work_item_templates (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4() NOT NULL,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    description text,
+    version integer DEFAULT 0 NOT NULL,
+    items jsonb NOT NULL
+);
+
+CREATE UNIQUE INDEX work_item_templates_name_space_id_unique ON work_item_templates (space_id, name) WHERE deleted_at IS NULL;
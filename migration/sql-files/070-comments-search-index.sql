@@ -0,0 +1,18 @@
+-- add a full-text search vector over the body of the comments, so that the
+-- work items can also be found by the content of their comments
+ALTER TABLE comments ADD COLUMN tsv tsvector;
+
+UPDATE comments SET tsv = to_tsvector('english', coalesce(body, ''));
+
+CREATE INDEX comments_fulltext_search_index ON comments USING GIN (tsv);
+
+-- fill the 'tsv' column with the created/modified body of the comment
+CREATE FUNCTION comment_tsv_trigger() RETURNS trigger AS $$
+begin
+  new.tsv := to_tsvector('english', coalesce(new.body, ''));
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER upd_comment_tsvector BEFORE INSERT OR UPDATE OF body ON comments
+FOR EACH ROW EXECUTE PROCEDURE comment_tsv_trigger();
@@ -0,0 +1,71 @@
+-- store the text search configuration (e.g. 'english', 'german', 'simple')
+-- used to index and search the work items and comments of each space
+ALTER TABLE spaces ADD COLUMN text_search_config text NOT NULL DEFAULT 'english';
+
+-- returns the text search configuration of the given space, or 'english' if
+-- the space does not exist
+CREATE FUNCTION space_text_search_config(uuid) RETURNS regconfig AS $$
+  SELECT coalesce((SELECT text_search_config FROM spaces WHERE id = $1), 'english')::regconfig;
+$$ LANGUAGE sql STABLE;
+
+-- fill the 'tsv' COLUMN of the work items with the configuration of their space
+CREATE OR REPLACE FUNCTION workitem_tsv_trigger() RETURNS TRIGGER AS $$
+declare
+  cfg regconfig := space_text_search_config(new.space_id);
+begin
+  new.tsv :=
+    setweight(to_tsvector(cfg, new.number::text),'A') ||
+    setweight(to_tsvector(cfg, coalesce(new.fields->>'system.title','')),'B') ||
+    setweight(to_tsvector(cfg, coalesce(new.fields#>>'{system.description, content}','')),'C');
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+-- also rebuild the vector when a work item is moved to another space
+DROP TRIGGER upd_tsvector ON work_items;
+CREATE TRIGGER upd_tsvector BEFORE INSERT OR UPDATE OF number, fields, space_id ON work_items
+FOR EACH ROW EXECUTE PROCEDURE workitem_tsv_trigger();
+
+-- fill the 'tsv' COLUMN of the comments with the configuration of the space
+-- of their work item
+CREATE OR REPLACE FUNCTION comment_tsv_trigger() RETURNS trigger AS $$
+begin
+  new.tsv := to_tsvector(
+    space_text_search_config((SELECT space_id FROM work_items WHERE id = new.parent_id)),
+    coalesce(new.body, ''));
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+-- rebuild the vectors of the comments of a work item moved to another space
+CREATE FUNCTION workitem_comments_tsv_trigger() RETURNS trigger AS $$
+begin
+  UPDATE comments SET tsv = to_tsvector(space_text_search_config(new.space_id), coalesce(body, ''))
+    WHERE parent_id = new.id;
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER upd_comments_tsvector AFTER UPDATE OF space_id ON work_items
+FOR EACH ROW WHEN (old.space_id IS DISTINCT FROM new.space_id)
+EXECUTE PROCEDURE workitem_comments_tsv_trigger();
+
+-- rebuild the vectors of the work items and comments of a space when its
+-- text search configuration changes
+CREATE FUNCTION space_tsv_trigger() RETURNS trigger AS $$
+begin
+  UPDATE work_items SET tsv =
+    setweight(to_tsvector(new.text_search_config::regconfig, number::text),'A') ||
+    setweight(to_tsvector(new.text_search_config::regconfig, coalesce(fields->>'system.title','')),'B') ||
+    setweight(to_tsvector(new.text_search_config::regconfig, coalesce(fields#>>'{system.description, content}','')),'C')
+    WHERE space_id = new.id;
+  UPDATE comments SET tsv = to_tsvector(new.text_search_config::regconfig, coalesce(body, ''))
+    FROM work_items w
+    WHERE w.id = comments.parent_id AND w.space_id = new.id;
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER upd_space_tsvector AFTER UPDATE OF text_search_config ON spaces
+FOR EACH ROW WHEN (old.text_search_config IS DISTINCT FROM new.text_search_config)
+EXECUTE PROCEDURE space_tsv_trigger();
@@ -0,0 +1,19 @@
+-- saved filters are named work item queries, shared with a space or private
+-- to their creator
+CREATE TABLE work_item_filters (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4() NOT NULL,
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    creator_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    scope text NOT NULL CHECK (scope IN ('space', 'user')),
+    name text NOT NULL,
+    description text,
+    query text NOT NULL DEFAULT '',
+    sort text NOT NULL DEFAULT '',
+    version integer DEFAULT 0 NOT NULL
+);
+
+CREATE UNIQUE INDEX work_item_filters_name_unique ON work_item_filters (space_id, creator_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX work_item_filters_space_id_idx ON work_item_filters (space_id);
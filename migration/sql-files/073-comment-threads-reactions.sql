@@ -0,0 +1,13 @@
+-- comments can reply to another comment of the same work item
+ALTER TABLE comments ADD COLUMN parent_comment_id uuid REFERENCES comments(id) ON DELETE CASCADE;
+CREATE INDEX comments_parent_comment_id_idx ON comments (parent_comment_id);
+
+-- emoji reactions of the identities to the comments, which are not part of
+-- the comment revisions
+CREATE TABLE comment_reactions (
+    created_at timestamp with time zone,
+    comment_id uuid NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    identity_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    emoji text NOT NULL CHECK (emoji <> ''),
+    PRIMARY KEY (comment_id, identity_id, emoji)
+);
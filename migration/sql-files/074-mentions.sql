@@ -0,0 +1,14 @@
+-- the identities mentioned with '@username' in the description of the work
+-- items and in their comments
+CREATE TABLE mentions (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4() NOT NULL,
+    created_at timestamp with time zone,
+    identity_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    username text NOT NULL CHECK (username <> ''),
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    comment_id uuid REFERENCES comments(id) ON DELETE CASCADE
+);
+-- a username is mentioned once by a work item description or a comment
+CREATE UNIQUE INDEX mentions_work_item_username_idx ON mentions (work_item_id, username) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX mentions_comment_username_idx ON mentions (comment_id, username) WHERE comment_id IS NOT NULL;
+CREATE INDEX mentions_identity_id_idx ON mentions (identity_id, created_at);
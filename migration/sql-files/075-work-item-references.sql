@@ -0,0 +1,14 @@
+-- the references to work items written as '#number' or 'space-name#number' in
+-- the description of the work items and in their comments
+CREATE TABLE work_item_references (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4() NOT NULL,
+    created_at timestamp with time zone,
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    reference text NOT NULL CHECK (reference <> ''),
+    source_work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    source_comment_id uuid REFERENCES comments(id) ON DELETE CASCADE
+);
+-- a reference is written once by a work item description or a comment
+CREATE UNIQUE INDEX work_item_references_work_item_idx ON work_item_references (source_work_item_id, reference) WHERE source_comment_id IS NULL;
+CREATE UNIQUE INDEX work_item_references_comment_idx ON work_item_references (source_comment_id, reference) WHERE source_comment_id IS NOT NULL;
+CREATE INDEX work_item_references_work_item_id_idx ON work_item_references (work_item_id, created_at);
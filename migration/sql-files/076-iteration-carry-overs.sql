@@ -0,0 +1,11 @@
+-- the not-closed work items moved to another iteration when closing an
+-- iteration
+CREATE TABLE iteration_carry_overs (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4() NOT NULL,
+    created_at timestamp with time zone,
+    iteration_id uuid NOT NULL REFERENCES iterations(id) ON DELETE CASCADE,
+    target_iteration_id uuid NOT NULL REFERENCES iterations(id) ON DELETE CASCADE,
+    work_item_id uuid NOT NULL REFERENCES work_items(id) ON DELETE CASCADE,
+    modifier_id uuid NOT NULL REFERENCES identities(id)
+);
+CREATE INDEX iteration_carry_overs_iteration_id_idx ON iteration_carry_overs (iteration_id, created_at);
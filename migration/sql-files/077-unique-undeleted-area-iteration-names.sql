@@ -0,0 +1,7 @@
+-- deleted areas and iterations must not prevent creating or moving an area or
+-- an iteration with the same name under the same parent
+ALTER TABLE areas DROP CONSTRAINT areas_name_space_id_path_unique;
+CREATE UNIQUE INDEX areas_name_space_id_path_unique ON areas (space_id, name, path) WHERE deleted_at IS NULL;
+
+ALTER TABLE iterations DROP CONSTRAINT iterations_name_space_id_path_unique;
+CREATE UNIQUE INDEX iterations_name_space_id_path_unique ON iterations (space_id, name, path) WHERE deleted_at IS NULL;
@@ -0,0 +1,15 @@
+-- the identities owning an area, i.e. triaging its incoming work items
+CREATE TABLE area_owners (
+    area_id uuid NOT NULL REFERENCES areas(id) ON DELETE CASCADE,
+    identity_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    created_at timestamp with time zone,
+    PRIMARY KEY (area_id, identity_id)
+);
+
+-- how the new work items of an area without assignee get one: "none",
+-- "fixed" (always the default assignee) or "round-robin" (each owner in turn,
+-- starting after the last assignee)
+ALTER TABLE areas ADD COLUMN default_assignee_rule text NOT NULL DEFAULT 'none';
+ALTER TABLE areas ADD CONSTRAINT areas_default_assignee_rule_check CHECK (default_assignee_rule IN ('none', 'fixed', 'round-robin'));
+ALTER TABLE areas ADD COLUMN default_assignee_id uuid REFERENCES identities(id) ON DELETE SET NULL;
+ALTER TABLE areas ADD COLUMN last_assignee_id uuid REFERENCES identities(id) ON DELETE SET NULL;
@@ -0,0 +1,11 @@
+-- the capacity available to each collaborator during an iteration, in points
+-- or in hours
+CREATE TABLE iteration_capacities (
+    iteration_id uuid NOT NULL REFERENCES iterations(id) ON DELETE CASCADE,
+    identity_id uuid NOT NULL REFERENCES identities(id) ON DELETE CASCADE,
+    capacity double precision NOT NULL CHECK (capacity >= 0),
+    unit text NOT NULL CHECK (unit IN ('points', 'hours')),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    PRIMARY KEY (iteration_id, identity_id)
+);
@@ -0,0 +1,13 @@
+-- space templates are process presets set up in a space when it is created
+CREATE TABLE space_templates (
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4() NOT NULL,
+    name text NOT NULL,
+    description text,
+    version integer DEFAULT 0 NOT NULL,
+    definition jsonb NOT NULL
+);
+
+CREATE UNIQUE INDEX space_templates_name_unique ON space_templates (name) WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- the entities imported from space archives, by the IDs they had in the
+-- archive, so that importing the same archive again does not duplicate them
+CREATE TABLE space_import_mappings (
+    space_id uuid NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    kind text NOT NULL,
+    source_id uuid NOT NULL,
+    target_id uuid NOT NULL,
+    number integer,
+    created_at timestamp with time zone,
+    PRIMARY KEY (space_id, kind, source_id)
+);
+CREATE INDEX space_import_mappings_source_idx ON space_import_mappings (kind, source_id);
@@ -0,0 +1,2 @@
+-- archived spaces are read-only and hidden from the space list and search
+ALTER TABLE spaces ADD COLUMN archived boolean NOT NULL DEFAULT false;
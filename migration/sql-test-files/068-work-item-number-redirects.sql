@@ -0,0 +1,3 @@
+-- the work item of space 67 used to have the number 2 in space 66
+insert into work_item_number_redirects (created_at, space_id, number, work_item_id)
+    values (now(), '00000066-0000-0000-0000-000000000000', 2, '00000067-0000-0000-0000-000000000000');
@@ -0,0 +1,5 @@
+-- a deleted template does not prevent creating another one with the same name
+insert into work_item_templates (created_at, updated_at, deleted_at, space_id, name, items)
+    values (now(), now(), now(), '00000067-0000-0000-0000-000000000000', 'sprint', '[]');
+insert into work_item_templates (created_at, updated_at, space_id, name, items)
+    values (now(), now(), '00000067-0000-0000-0000-000000000000', 'sprint', '[]');
@@ -0,0 +1,2 @@
+-- the search vector follows the changes of the body of the comment
+update comments set body = 'a bar comment' where id = '00000067-0000-0000-0000-000000000000';
@@ -0,0 +1,3 @@
+-- move the work item of space 67 to space 66, which uses the default text
+-- search configuration
+update work_items set space_id = '00000066-0000-0000-0000-000000000000', number = 71 where id = '00000067-0000-0000-0000-000000000000';
@@ -0,0 +1,4 @@
+-- index a work item of space 67, then change the text search configuration
+-- of the space
+update work_items set fields = '{"system.title": "running tests"}' where id = '00000067-0000-0000-0000-000000000000';
+update spaces set text_search_config = 'simple' where id = '00000067-0000-0000-0000-000000000000';
@@ -0,0 +1,2 @@
+insert into work_item_filters (created_at, updated_at, space_id, creator_id, scope, name)
+    values (now(), now(), '00000067-0000-0000-0000-000000000000', 'cafebabe-0000-0000-0000-000000000000', 'team', 'team filter');
@@ -0,0 +1,5 @@
+-- reply to the comment of the work item 67 and react to it
+insert into comments (parent_id, parent_comment_id, body)
+    values ('00000067-0000-0000-0000-000000000000', '00000067-0000-0000-0000-000000000000', 'a reply');
+insert into comment_reactions (created_at, comment_id, identity_id, emoji)
+    values (now(), '00000067-0000-0000-0000-000000000000', 'cafebabe-0000-0000-0000-000000000000', ':+1:');
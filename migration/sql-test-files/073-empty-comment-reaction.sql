@@ -0,0 +1,2 @@
+insert into comment_reactions (created_at, comment_id, identity_id, emoji)
+    values (now(), '00000067-0000-0000-0000-000000000000', 'cafebabe-0000-0000-0000-000000000000', '');
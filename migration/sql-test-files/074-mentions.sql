@@ -0,0 +1,6 @@
+-- the same identity mentioned by the description of the work item 67 and by
+-- its comment
+insert into mentions (created_at, identity_id, username, work_item_id)
+    values (now(), 'cafebabe-0000-0000-0000-000000000000', 'foo', '00000067-0000-0000-0000-000000000000');
+insert into mentions (created_at, identity_id, username, work_item_id, comment_id)
+    values (now(), 'cafebabe-0000-0000-0000-000000000000', 'foo', '00000067-0000-0000-0000-000000000000', '00000067-0000-0000-0000-000000000000');
@@ -0,0 +1,6 @@
+-- the same work item referenced by the description of the work item 67 and
+-- by its comment
+insert into work_item_references (created_at, work_item_id, reference, source_work_item_id)
+    values (now(), '00000066-0000-0000-0000-000000000001', 'test space 1#1', '00000067-0000-0000-0000-000000000000');
+insert into work_item_references (created_at, work_item_id, reference, source_work_item_id, source_comment_id)
+    values (now(), '00000066-0000-0000-0000-000000000001', 'test space 1#1', '00000067-0000-0000-0000-000000000000', '00000067-0000-0000-0000-000000000000');
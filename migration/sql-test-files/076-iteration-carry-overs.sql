@@ -0,0 +1,5 @@
+-- the work item 67 carried over from an iteration to the next one
+insert into iterations (id, space_id, name, path) values ('00000076-0000-0000-0000-000000000001', '00000067-0000-0000-0000-000000000000', 'sprint 1', '');
+insert into iterations (id, space_id, name, path) values ('00000076-0000-0000-0000-000000000002', '00000067-0000-0000-0000-000000000000', 'sprint 2', '');
+insert into iteration_carry_overs (created_at, iteration_id, target_iteration_id, work_item_id, modifier_id)
+    values (now(), '00000076-0000-0000-0000-000000000001', '00000076-0000-0000-0000-000000000002', '00000067-0000-0000-0000-000000000000', 'cafebabe-0000-0000-0000-000000000000');
@@ -0,0 +1,3 @@
+-- an area and an iteration of space 67 which were deleted
+insert into areas (id, space_id, name, path, deleted_at) values ('00000077-0000-0000-0000-000000000001', '00000067-0000-0000-0000-000000000000', 'backend', '', now());
+insert into iterations (id, space_id, name, path, deleted_at) values ('00000077-0000-0000-0000-000000000001', '00000067-0000-0000-0000-000000000000', 'sprint 3', '', now());
@@ -0,0 +1 @@
+insert into iterations (space_id, name, path) values ('00000067-0000-0000-0000-000000000000', 'sprint 3', '');
@@ -0,0 +1,5 @@
+-- the area of space 67 is owned by an identity, which is assigned in turn to
+-- its new work items
+insert into area_owners (area_id, identity_id, created_at)
+    select id, 'cafebabe-0000-0000-0000-000000000000', now() from areas where id = '00000077-0000-0000-0000-000000000001';
+update areas set default_assignee_rule = 'round-robin' where id = '00000077-0000-0000-0000-000000000001';
@@ -0,0 +1 @@
+update areas set default_assignee_rule = 'random' where id = '00000077-0000-0000-0000-000000000001';
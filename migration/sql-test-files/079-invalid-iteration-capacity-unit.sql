@@ -0,0 +1,2 @@
+insert into iteration_capacities (iteration_id, identity_id, capacity, unit, created_at, updated_at)
+    values ('00000076-0000-0000-0000-000000000002', 'cafebabe-0000-0000-0000-000000000000', 8, 'days', now(), now());
@@ -0,0 +1,5 @@
+-- a deleted template does not prevent creating another one with the same name
+insert into space_templates (created_at, updated_at, deleted_at, name, definition)
+    values (now(), now(), now(), 'custom process', '{}');
+insert into space_templates (created_at, updated_at, name, definition)
+    values (now(), now(), 'custom process', '{}');
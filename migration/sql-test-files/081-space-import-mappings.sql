@@ -0,0 +1,3 @@
+-- the work item 67 imported into space 67 from an archive
+insert into space_import_mappings (space_id, kind, source_id, target_id, number, created_at)
+    values ('00000067-0000-0000-0000-000000000000', 'work_item', '00000081-0000-0000-0000-000000000000', '00000067-0000-0000-0000-000000000000', 1, now());
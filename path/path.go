@@ -83,6 +83,16 @@ func (p Path) Parent() Path {
 	return Path{uuid.Nil}
 }
 
+// Contains returns true if the given UUID is one of the elements of the Path
+func (p Path) Contains(id uuid.UUID) bool {
+	for _, i := range p {
+		if uuid.Equal(i, id) {
+			return true
+		}
+	}
+	return false
+}
+
 // ConvertToLtree returns ltree form of given UUID
 func (p Path) ConvertToLtree(id uuid.UUID) string {
 	converted := strings.Replace(id.String(), "-", "_", -1)
@@ -84,6 +84,20 @@ func TestParent(t *testing.T) {
 	require.Equal(t, path.Path{uuid.Nil}, lp2.Parent())
 }
 
+func TestContains(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	t.Parallel()
+	grandParent := uuid.NewV4()
+	immediateParent := uuid.NewV4()
+	lp := path.Path{grandParent, immediateParent}
+	assert.True(t, lp.Contains(grandParent))
+	assert.True(t, lp.Contains(immediateParent))
+	assert.False(t, lp.Contains(uuid.NewV4()))
+
+	lp2 := path.Path{}
+	assert.False(t, lp2.Contains(grandParent))
+}
+
 func TestValuerImplementation(t *testing.T) {
 	resource.Require(t, resource.UnitTest)
 	t.Parallel()
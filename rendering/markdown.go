@@ -29,15 +29,30 @@ const (
 )
 
 // MarkdownCommonHighlighter uses the blackfriday.MarkdownCommon setup but also includes
-// code-prettify formatting of BlockCode segments
-func MarkdownCommonHighlighter(input []byte) []byte {
-	renderer := highlightHTMLRenderer{blackfriday.HtmlRenderer(commonHTMLFlags, "", "")}
-	return blackfriday.MarkdownOptions(input, renderer, blackfriday.Options{
+// code-prettify formatting of BlockCode segments and the references enabled by the given options
+func MarkdownCommonHighlighter(input []byte, options ...RenderOption) []byte {
+	o := renderOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+	renderer := highlightHTMLRenderer{blackfriday.HtmlRenderer(commonHTMLFlags, "", ""), o}
+	output := blackfriday.MarkdownOptions(input, renderer, blackfriday.Options{
 		Extensions: commonExtensions})
+	return o.linkReferencesInHTML(output)
 }
 
 type highlightHTMLRenderer struct {
 	blackfriday.Renderer
+	options renderOptions
+}
+
+// AutoLink overrides the standard Html Renderer to link the URLs written as is
+// to the URLs resolved by the render options, if any
+func (h highlightHTMLRenderer) AutoLink(out *bytes.Buffer, link []byte, kind int) {
+	if kind == blackfriday.LINK_TYPE_NORMAL && h.options.linkURL(out, link) {
+		return
+	}
+	h.Renderer.AutoLink(out, link, kind)
 }
 
 // BlackCode overrides the standard Html Renderer to add support for prettify of source code within block
@@ -16,15 +16,17 @@ func IsMarkupSupported(markup string) bool {
 
 // RenderMarkupToHTML converts the given `content` in HTML using the markup tool corresponding to the given `markup` argument
 // or return nil if no tool for the given `markup` is available, or returns an `error` if the command was not found or failed.
-func RenderMarkupToHTML(content, markup string) string {
+// The given options enable the links of the references in Markdown content, such as the `@username` mentions.
+func RenderMarkupToHTML(content, markup string, options ...RenderOption) string {
 	switch markup {
 	case SystemMarkupPlainText:
 		return content
 	case SystemMarkupMarkdown:
-		unsafe := MarkdownCommonHighlighter([]byte(content))
+		unsafe := MarkdownCommonHighlighter([]byte(content), options...)
 		p := bluemonday.UGCPolicy()
 		p.AllowAttrs("class").Matching(regexp.MustCompile("^language-[a-zA-Z0-9]+$|prettyprint")).OnElements("code")
 		p.AllowAttrs("class").OnElements("span")
+		p.AllowAttrs("class").Matching(regexp.MustCompile("^(" + mentionClass + "|" + workItemReferenceClass + ")$")).OnElements("a")
 		html := string(p.SanitizeBytes(unsafe))
 		return html
 	default:
@@ -0,0 +1,297 @@
+package rendering
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	errs "github.com/pkg/errors"
+)
+
+// LinkResolver returns the URL of the given reference written in markup, such
+// as the username of a mention, or false if the reference does not link anywhere
+type LinkResolver func(reference string) (string, bool)
+
+// RenderOption customizes the rendering of markup to HTML
+type RenderOption func(*renderOptions)
+
+// renderOptions are the references to turn into links while rendering markup
+type renderOptions struct {
+	references []reference
+	urls       []urlReference
+}
+
+// urlReference is a kind of URL written as is in markup, such as the URL of
+// the page of a work item
+type urlReference struct {
+	class   string
+	resolve LinkResolver
+}
+
+// reference is a kind of reference written in markup, such as `@username`
+type reference struct {
+	// the pattern of the reference, whose first submatch is the text
+	// preceding it and second submatch is the reference itself
+	pattern *regexp.Regexp
+	// the text in front of the reference, such as `@`
+	prefix  string
+	class   string
+	resolve LinkResolver
+}
+
+// mentionRegexp matches the `@username` mentions, which are neither part of a
+// word nor of an email address
+var mentionRegexp = regexp.MustCompile(`(^|[^\w@./-])@(\w(?:[\w.-]*\w)?)`)
+
+// mentionClass is the class of the links of the mentions in the rendered HTML
+const mentionClass = "mention"
+
+// WithMentions renders the `@username` mentions as links to the URLs returned
+// by the given resolver for the usernames
+func WithMentions(resolve LinkResolver) RenderOption {
+	return func(o *renderOptions) {
+		o.references = append(o.references, reference{
+			pattern: mentionRegexp,
+			prefix:  "@",
+			class:   mentionClass,
+			resolve: resolve,
+		})
+	}
+}
+
+// Mentions returns the usernames mentioned with `@username` in the given
+// content, in lower case and without duplicates. The mentions in the code of
+// a Markdown content are ignored.
+func Mentions(content, markup string) []string {
+	return findReferences(content, markup, mentionRegexp, WithMentions)
+}
+
+// workItemReferenceRegexp matches the `#number` and `space-name#number`
+// references to work items, which are neither part of a word, of a URL nor
+// of an HTML entity such as `&#39;`
+var workItemReferenceRegexp = regexp.MustCompile(`(^|[^\w@&#./-])((?:\w[\w.-]*)?#\d+)\b`)
+
+// workItemReferenceClass is the class of the links of the work item
+// references in the rendered HTML
+const workItemReferenceClass = "workitem-reference"
+
+// WithWorkItemReferences renders the `#number` and `space-name#number`
+// references to work items as links to the URLs returned by the given
+// resolver for the references
+func WithWorkItemReferences(resolve LinkResolver) RenderOption {
+	return func(o *renderOptions) {
+		o.references = append(o.references, reference{
+			pattern: workItemReferenceRegexp,
+			class:   workItemReferenceClass,
+			resolve: resolve,
+		})
+	}
+}
+
+// WorkItemReferences returns the `#number` and `space-name#number` references
+// to work items in the given content, in lower case and without duplicates.
+// The references in the code of a Markdown content are ignored.
+func WorkItemReferences(content, markup string) []string {
+	return findReferences(content, markup, workItemReferenceRegexp, WithWorkItemReferences)
+}
+
+// ReplaceWorkItemReferences returns the given content in which the numbers of
+// the `#number` references to work items of the same space are replaced with
+// the numbers returned by the given function, unless it returns false. The
+// `space-name#number` references are kept as is, and the references in the
+// code of a Markdown content are replaced as well.
+func ReplaceWorkItemReferences(content string, replace func(number int) (int, bool)) string {
+	result := bytes.Buffer{}
+	written := 0
+	for _, m := range workItemReferenceRegexp.FindAllStringSubmatchIndex(content, -1) {
+		name, number, err := ParseWorkItemReference(content[m[4]:m[5]])
+		if err != nil || name != "" {
+			continue
+		}
+		target, ok := replace(number)
+		if !ok {
+			continue
+		}
+		result.WriteString(content[written:m[4]])
+		result.WriteString("#" + strconv.Itoa(target))
+		written = m[5]
+	}
+	result.WriteString(content[written:])
+	return result.String()
+}
+
+// urlRegexp matches the URLs written as is in plain text, which are preceded
+// by a whitespace or by the beginning of the text and do not end with a
+// punctuation mark
+var urlRegexp = regexp.MustCompile(`(^|\s)(https?://[^\s<>"']*[^\s<>"'.,;:!?)])`)
+
+// WithWorkItemURLs renders the URLs written as is, such as pasted URLs of the
+// pages of work items, as links to the URLs returned by the given resolver for
+// the URLs
+func WithWorkItemURLs(resolve LinkResolver) RenderOption {
+	return func(o *renderOptions) {
+		o.urls = append(o.urls, urlReference{
+			class:   workItemReferenceClass,
+			resolve: resolve,
+		})
+	}
+}
+
+// WorkItemURLs returns the URLs written as is in the given content, in lower
+// case and without duplicates, which may be the URLs of the pages of work
+// items. The URLs in the code of a Markdown content are ignored.
+func WorkItemURLs(content, markup string) []string {
+	return findReferences(content, markup, urlRegexp, WithWorkItemURLs)
+}
+
+// ParseWorkItemReference returns the space name, which is empty for a work
+// item of the same space, and the number of the work item referenced with
+// `#number` or `space-name#number`
+func ParseWorkItemReference(ref string) (string, int, error) {
+	i := strings.LastIndex(ref, "#")
+	if i < 0 {
+		return "", 0, errs.Errorf("invalid work item reference: %s", ref)
+	}
+	number, err := strconv.Atoi(ref[i+1:])
+	if err != nil {
+		return "", 0, errs.Wrapf(err, "invalid work item reference: %s", ref)
+	}
+	return ref[:i], number, nil
+}
+
+// findReferences returns the references matching the given pattern in the
+// given content, in lower case and without duplicates.
+func findReferences(content, markup string, pattern *regexp.Regexp, option func(LinkResolver) RenderOption) []string {
+	result := []string{}
+	found := make(map[string]bool)
+	collect := func(ref string) (string, bool) {
+		ref = strings.ToLower(ref)
+		if !found[ref] {
+			found[ref] = true
+			result = append(result, ref)
+		}
+		return "", false
+	}
+	switch markup {
+	case SystemMarkupMarkdown:
+		// only the references out of the code are resolved when rendering Markdown
+		MarkdownCommonHighlighter([]byte(content), option(collect))
+	case SystemMarkupPlainText:
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			collect(match[2])
+		}
+	}
+	return result
+}
+
+// linkURL writes the link of the given URL written as is, to the URL
+// returned by the first resolver of the URLs which resolves it. It returns
+// false and writes nothing if none does.
+func (o renderOptions) linkURL(out *bytes.Buffer, link []byte) bool {
+	for _, u := range o.urls {
+		url, ok := u.resolve(string(link))
+		if !ok {
+			continue
+		}
+		out.WriteString(`<a href="`)
+		out.WriteString(html.EscapeString(url))
+		out.WriteString(`" class="`)
+		out.WriteString(u.class)
+		out.WriteString(`">`)
+		out.WriteString(html.EscapeString(string(link)))
+		out.WriteString("</a>")
+		return true
+	}
+	return false
+}
+
+// htmlTagRegexp matches the tags of the HTML rendered from Markdown
+var htmlTagRegexp = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)[^>]*>`)
+
+// unlinkedElements are the HTML elements whose text is never linked
+var unlinkedElements = map[string]bool{"a": true, "code": true, "pre": true}
+
+// linkReferencesInHTML returns the given HTML rendered from Markdown, in which
+// the references out of the code and the existing links are replaced with
+// links to their resolved URLs
+func (o renderOptions) linkReferencesInHTML(input []byte) []byte {
+	if len(o.references) == 0 {
+		return input
+	}
+	out := bytes.Buffer{}
+	depth := 0
+	for len(input) > 0 {
+		tag := htmlTagRegexp.FindSubmatchIndex(input)
+		if tag == nil {
+			o.linkReferences(&out, input, depth > 0)
+			break
+		}
+		o.linkReferences(&out, input[:tag[0]], depth > 0)
+		out.Write(input[tag[0]:tag[1]])
+		if unlinkedElements[strings.ToLower(string(input[tag[4]:tag[5]]))] {
+			if tag[3] > tag[2] {
+				if depth > 0 {
+					depth--
+				}
+			} else {
+				depth++
+			}
+		}
+		input = input[tag[1]:]
+	}
+	return out.Bytes()
+}
+
+// linkReferences writes the given text, in which the references are replaced
+// with links to their resolved URLs unless the text is already unlinked
+func (o renderOptions) linkReferences(out *bytes.Buffer, text []byte, unlinked bool) {
+	if unlinked {
+		out.Write(text)
+		return
+	}
+	matches := referenceMatches{}
+	for _, ref := range o.references {
+		for _, m := range ref.pattern.FindAllSubmatchIndex(text, -1) {
+			// the reference starts with its prefix, right before the second submatch
+			matches = append(matches, referenceMatch{ref: ref, start: m[4] - len(ref.prefix), end: m[5]})
+		}
+	}
+	sort.Sort(matches)
+	written := 0
+	for _, m := range matches {
+		if m.start < written {
+			// overlaps a previous reference
+			continue
+		}
+		url, ok := m.ref.resolve(string(text[m.start+len(m.ref.prefix) : m.end]))
+		if !ok {
+			continue
+		}
+		out.Write(text[written:m.start])
+		out.WriteString(`<a href="`)
+		out.WriteString(html.EscapeString(url))
+		out.WriteString(`" class="`)
+		out.WriteString(m.ref.class)
+		out.WriteString(`">`)
+		out.Write(text[m.start:m.end])
+		out.WriteString("</a>")
+		written = m.end
+	}
+	out.Write(text[written:])
+}
+
+// referenceMatch is the position of a reference in a text
+type referenceMatch struct {
+	ref        reference
+	start, end int
+}
+
+// referenceMatches sorts the references by their position in the text
+type referenceMatches []referenceMatch
+
+func (m referenceMatches) Len() int           { return len(m) }
+func (m referenceMatches) Less(i, j int) bool { return m[i].start < m[j].start }
+func (m referenceMatches) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
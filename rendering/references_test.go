@@ -0,0 +1,170 @@
+package rendering_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resolveUsers(usernames ...string) rendering.LinkResolver {
+	return func(username string) (string, bool) {
+		for _, u := range usernames {
+			if u == username {
+				return "https://api.openshift.io/api/users/" + username, true
+			}
+		}
+		return "", false
+	}
+}
+
+func TestRenderMarkdownContentWithMentions(t *testing.T) {
+	t.Run("known user", func(t *testing.T) {
+		content := "Hello, @jdoe!"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithMentions(resolveUsers("jdoe")))
+		assert.Equal(t, "<p>Hello, <a href=\"https://api.openshift.io/api/users/jdoe\" class=\"mention\" rel=\"nofollow\">@jdoe</a>!</p>\n", result)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		content := "Hello, @jdoe and @foo!"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithMentions(resolveUsers("foo")))
+		assert.Equal(t, "<p>Hello, @jdoe and <a href=\"https://api.openshift.io/api/users/foo\" class=\"mention\" rel=\"nofollow\">@foo</a>!</p>\n", result)
+	})
+	t.Run("user in code", func(t *testing.T) {
+		content := "Hello, `@jdoe`!\n\n```\n@jdoe\n```"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithMentions(resolveUsers("jdoe")))
+		assert.NotContains(t, result, "<a ")
+	})
+	t.Run("email address", func(t *testing.T) {
+		content := "Hello, jdoe@example.com!"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithMentions(resolveUsers("example.com")))
+		assert.NotContains(t, result, "class=\"mention\"")
+	})
+	t.Run("without mentions", func(t *testing.T) {
+		content := "Hello, @jdoe!"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown)
+		assert.Equal(t, "<p>Hello, @jdoe!</p>\n", result)
+	})
+	t.Run("plain text", func(t *testing.T) {
+		content := "Hello, @jdoe!"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupPlainText, rendering.WithMentions(resolveUsers("jdoe")))
+		assert.Equal(t, content, result)
+	})
+}
+
+func TestMentions(t *testing.T) {
+	t.Run("markdown", func(t *testing.T) {
+		content := "Hello, @jdoe, @John_Doe and @j.doe.\n\nCC @JDoe, not `@foo` nor foo@example.com"
+		assert.Equal(t, []string{"jdoe", "john_doe", "j.doe"}, rendering.Mentions(content, rendering.SystemMarkupMarkdown))
+	})
+	t.Run("plain text", func(t *testing.T) {
+		content := "Hello, @jdoe and `@foo`, not foo@example.com"
+		assert.Equal(t, []string{"jdoe", "foo"}, rendering.Mentions(content, rendering.SystemMarkupPlainText))
+	})
+	t.Run("none", func(t *testing.T) {
+		assert.Empty(t, rendering.Mentions("Hello, world", rendering.SystemMarkupMarkdown))
+	})
+}
+
+func resolveWorkItems(refs ...string) rendering.LinkResolver {
+	return func(ref string) (string, bool) {
+		for _, r := range refs {
+			if r == ref {
+				return "https://api.openshift.io/api/workitems/" + strings.Replace(ref, "#", "-", 1), true
+			}
+		}
+		return "", false
+	}
+}
+
+func TestRenderMarkdownContentWithWorkItemReferences(t *testing.T) {
+	t.Run("same space", func(t *testing.T) {
+		content := "Fixed by #42."
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithWorkItemReferences(resolveWorkItems("#42")))
+		assert.Equal(t, "<p>Fixed by <a href=\"https://api.openshift.io/api/workitems/-42\" class=\"workitem-reference\" rel=\"nofollow\">#42</a>.</p>\n", result)
+	})
+	t.Run("other space", func(t *testing.T) {
+		content := "See other-space#7 and #8"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithWorkItemReferences(resolveWorkItems("other-space#7")))
+		assert.Equal(t, "<p>See <a href=\"https://api.openshift.io/api/workitems/other-space-7\" class=\"workitem-reference\" rel=\"nofollow\">other-space#7</a> and #8</p>\n", result)
+	})
+	t.Run("with mentions", func(t *testing.T) {
+		content := "@jdoe, see #42"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown,
+			rendering.WithMentions(resolveUsers("jdoe")), rendering.WithWorkItemReferences(resolveWorkItems("#42")))
+		assert.Contains(t, result, "class=\"mention\"")
+		assert.Contains(t, result, "class=\"workitem-reference\"")
+	})
+	t.Run("not references", func(t *testing.T) {
+		content := "Don't use `#42`, http://example.com/page#42, #42abc nor a&#42;b"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithWorkItemReferences(resolveWorkItems("#42", "page#42")))
+		assert.NotContains(t, result, "workitem-reference")
+	})
+}
+
+func TestWorkItemReferences(t *testing.T) {
+	t.Run("markdown", func(t *testing.T) {
+		content := "Fixed by #42 and Other-Space#7, not `#8`.\n\nSee #42 again."
+		assert.Equal(t, []string{"#42", "other-space#7"}, rendering.WorkItemReferences(content, rendering.SystemMarkupMarkdown))
+	})
+	t.Run("plain text", func(t *testing.T) {
+		content := "Fixed by #42, not 12#3"
+		assert.Equal(t, []string{"#42", "12#3"}, rendering.WorkItemReferences(content, rendering.SystemMarkupPlainText))
+	})
+}
+
+func TestRenderMarkdownContentWithWorkItemURLs(t *testing.T) {
+	resolve := func(url string) (string, bool) {
+		if url == "https://openshift.io/jdoe/space/plan/detail/42" {
+			return "https://api.openshift.io/api/workitems/42", true
+		}
+		return "", false
+	}
+	t.Run("work item URL", func(t *testing.T) {
+		content := "Fixed by https://openshift.io/jdoe/space/plan/detail/42."
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithWorkItemURLs(resolve))
+		assert.Equal(t, "<p>Fixed by <a href=\"https://api.openshift.io/api/workitems/42\" class=\"workitem-reference\" rel=\"nofollow\">https://openshift.io/jdoe/space/plan/detail/42</a>.</p>\n", result)
+	})
+	t.Run("other URLs", func(t *testing.T) {
+		content := "See https://example.com and `https://openshift.io/jdoe/space/plan/detail/42`"
+		result := rendering.RenderMarkupToHTML(content, rendering.SystemMarkupMarkdown, rendering.WithWorkItemURLs(resolve))
+		assert.NotContains(t, result, "workitem-reference")
+		assert.Contains(t, result, "<a href=\"https://example.com\" rel=\"nofollow\">https://example.com</a>")
+	})
+}
+
+func TestWorkItemURLs(t *testing.T) {
+	t.Run("markdown", func(t *testing.T) {
+		content := "Fixed by https://openshift.io/jdoe/space/plan/detail/42, not `https://example.com`."
+		assert.Equal(t, []string{"https://openshift.io/jdoe/space/plan/detail/42"}, rendering.WorkItemURLs(content, rendering.SystemMarkupMarkdown))
+	})
+	t.Run("plain text", func(t *testing.T) {
+		content := "Fixed by https://openshift.io/jdoe/space/plan/detail/42, not ahttps://example.com"
+		assert.Equal(t, []string{"https://openshift.io/jdoe/space/plan/detail/42"}, rendering.WorkItemURLs(content, rendering.SystemMarkupPlainText))
+	})
+}
+
+func TestParseWorkItemReference(t *testing.T) {
+	name, number, err := rendering.ParseWorkItemReference("#42")
+	require.Nil(t, err)
+	assert.Equal(t, "", name)
+	assert.Equal(t, 42, number)
+	name, number, err = rendering.ParseWorkItemReference("other-space#7")
+	require.Nil(t, err)
+	assert.Equal(t, "other-space", name)
+	assert.Equal(t, 7, number)
+	_, _, err = rendering.ParseWorkItemReference("foo")
+	assert.NotNil(t, err)
+}
+
+func TestReplaceWorkItemReferences(t *testing.T) {
+	replace := func(number int) (int, bool) {
+		if number == 7 {
+			return 42, true
+		}
+		return 0, false
+	}
+	content := "Fixed by #7 and #8, not Other-Space#7 nor a&#7;b. See `#7`."
+	assert.Equal(t, "Fixed by #42 and #8, not Other-Space#7 nor a&#7;b. See `#42`.", rendering.ReplaceWorkItemReferences(content, replace))
+}
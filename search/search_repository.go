@@ -1,6 +1,7 @@
 package search
 
 import (
+	"database/sql"
 	"fmt"
 	"sync"
 
@@ -12,8 +13,16 @@ import (
 
 	"net/url"
 
+	"strconv"
+
+	"unicode"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/criteria"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"github.com/asaskevich/govalidator"
@@ -43,11 +52,117 @@ func generateSearchQuery(q string) (string, error) {
 	return q, nil
 }
 
+// Result is a work item matching a full-text search along with the reasons
+// why it matched
+type Result struct {
+	workitem.WorkItem
+	// Rank is the ts_rank score of the work item for the search query
+	Rank float64
+	// Highlights holds the excerpts of the fields that match the search query,
+	// with the matching words enclosed in the highlight tags, by field name.
+	// The text of the excerpts is HTML-escaped, so that the highlight tags
+	// are the only markup.
+	// The excerpt of the matching comment, if any, is under CommentHighlight.
+	Highlights map[string]string
+	// CommentID is the ID of the best matching comment of the work item, if
+	// any of its comments matched
+	CommentID *uuid.UUID
+}
+
+// CommentHighlight is the key of the excerpt of the matching comment in the
+// highlights of a search result
+const CommentHighlight = "comment"
+
+// search scopes restricting where the terms must match, given with the
+// `in:` prefix
+const (
+	searchScopeTitle       = "title"
+	searchScopeDescription = "description"
+	searchScopeComments    = "comments"
+)
+
+// searchScopeCondition returns the SQL condition matching the work items
+// whose text in the given scopes matches the query. With no scope, the work
+// items match by their title, description, number or comments.
+func searchScopeCondition(scopes []string) string {
+	tableName := workitem.WorkItemStorage{}.TableName()
+	if len(scopes) == 0 {
+		return fmt.Sprintf("(%s.tsv @@ query OR matching_comment.comment_id IS NOT NULL)", tableName)
+	}
+	conditions := make([]string, len(scopes))
+	for i, scope := range scopes {
+		switch scope {
+		case searchScopeTitle:
+			// see the weights of the work item search vector
+			conditions[i] = fmt.Sprintf("ts_filter(%s.tsv, '{b}') @@ query", tableName)
+		case searchScopeDescription:
+			conditions[i] = fmt.Sprintf("ts_filter(%s.tsv, '{c}') @@ query", tableName)
+		case searchScopeComments:
+			conditions[i] = "matching_comment.comment_id IS NOT NULL"
+		}
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// HighlightOptions defines how the matching words are highlighted in the
+// excerpts of the search results
+type HighlightOptions struct {
+	StartTag string
+	StopTag  string
+}
+
+// DefaultHighlightOptions are the highlight options used when none are given
+var DefaultHighlightOptions = HighlightOptions{
+	StartTag: "<b>",
+	StopTag:  "</b>",
+}
+
+// headlineOptions returns the ts_headline options for the title and for the
+// description. The whole title is returned, whereas only the matching
+// fragments of the description are.
+func (o HighlightOptions) headlineOptions() (string, string, error) {
+	for _, tag := range []string{o.StartTag, o.StopTag} {
+		if tag == "" || strings.ContainsAny(tag, `"`) {
+			return "", "", errors.NewBadParameterError("highlight tag", tag).Expected("non-empty tag without double quotes")
+		}
+	}
+	tags := fmt.Sprintf(`StartSel="%s", StopSel="%s"`, o.StartTag, o.StopTag)
+	return tags + ", HighlightAll=TRUE", tags + ", MaxFragments=3, MaxWords=20, MinWords=5", nil
+}
+
+// escapedHeadlineText returns the SQL expression that escapes the HTML special
+// characters of the given SQL text expression. The text is escaped before
+// ts_headline inserts the highlight tags, so that the user text of the
+// excerpts can't contain any markup.
+func escapedHeadlineText(text string) string {
+	return fmt.Sprintf(`replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;')`, text)
+}
+
+// storageHit is a row of the search query
+type storageHit struct {
+	storage             workitem.WorkItemStorage
+	rank                float64
+	titleHeadline       string
+	descriptionHeadline string
+	commentID           sql.NullString
+	commentHeadline     sql.NullString
+}
+
 //searchKeyword defines how a decomposed raw search query will look like
 type searchKeyword struct {
 	workItemTypes []uuid.UUID
 	number        []string
 	words         []string
+	scopes        []string
+	items         []workItemRef
+}
+
+// workItemRef identifies a work item by the owner and the name of its space
+// and by its number, as in the URLs of the work item pages of a space
+type workItemRef struct {
+	owner  string
+	space  string
+	number int
 }
 
 // KnownURL has a regex string format URL and compiled regex for the same
@@ -85,9 +200,39 @@ func RegisterAsKnownURL(name, urlRegex string) {
 	}
 }
 
+// RegisterKnownURLs registers the given regular expressions of URLs by name,
+// as read from the configuration. Each expression must have either an `id`
+// capture group or all of the `owner`, `space` and `number` ones, since the
+// space names are only unique per owner.
+func RegisterKnownURLs(urls map[string]string) error {
+	for name, urlRegex := range urls {
+		compiledRegex, err := regexp.Compile(urlRegex)
+		if err != nil {
+			return errs.Wrapf(err, "invalid regular expression of the known URL '%s'", name)
+		}
+		groups := make(map[string]bool)
+		for _, group := range compiledRegex.SubexpNames() {
+			groups[group] = true
+		}
+		if !groups["id"] && !(groups["owner"] && groups["space"] && groups["number"]) {
+			return errs.Errorf("the known URL '%s' has neither an 'id' capture group nor 'owner', 'space' and 'number' ones", name)
+		}
+	}
+	for name, urlRegex := range urls {
+		RegisterAsKnownURL(name, urlRegex)
+	}
+	return nil
+}
+
 // GetAllRegisteredURLs returns all known URLs
 func GetAllRegisteredURLs() map[string]KnownURL {
-	return knownURLs
+	knownURLLock.RLock()
+	defer knownURLLock.RUnlock()
+	result := make(map[string]KnownURL, len(knownURLs))
+	for name, known := range knownURLs {
+		result[name] = known
+	}
+	return result
 }
 
 /*
@@ -99,6 +244,8 @@ func isKnownURL(url string) (bool, string) {
 	// should check on all system's known URLs
 	var mostReleventMatchCount int
 	var mostReleventMatchName string
+	knownURLLock.RLock()
+	defer knownURLLock.RUnlock()
 	for name, known := range knownURLs {
 		match := known.compiledRegex.FindStringSubmatch(url)
 		if len(match) > mostReleventMatchCount {
@@ -118,18 +265,12 @@ func trimProtocolFromURLString(urlString string) string {
 	return urlString
 }
 
-func escapeCharFromURLString(urlString string) string {
-	// Replacer will escape `:` and `)` `(`.
-	var replacer = strings.NewReplacer(":", "\\:", "(", "\\(", ")", "\\)")
-	return replacer.Replace(urlString)
-}
-
 // sanitizeURL does cleaning of URL
 // returns DB friendly string
-// Trims protocol and escapes ":"
+// Trims protocol and quotes the URL as a single tsquery operand
 func sanitizeURL(urlString string) string {
 	trimmedURL := trimProtocolFromURLString(urlString)
-	return escapeCharFromURLString(trimmedURL)
+	return tsqueryLexeme(trimmedURL)
 }
 
 /*
@@ -140,7 +281,9 @@ Finds all string match for given pattern
 Iterates over pattern's groupNames and loads respective values into result
 */
 func getSearchQueryFromURLPattern(patternName, stringToMatch string) string {
+	knownURLLock.RLock()
 	pattern := knownURLs[patternName]
+	knownURLLock.RUnlock()
 	// TODO : handle case for 0 matches
 	match := pattern.compiledRegex.FindStringSubmatch(stringToMatch)
 	result := make(map[string]string)
@@ -159,18 +302,16 @@ func getSearchQueryFromURLPattern(patternName, stringToMatch string) string {
 	// first value from FindStringSubmatch is always full input itself, hence ignored
 	// Join rest of the tokens to make query like "demo.almighty.io/work-item/list/detail/100"
 	if len(match) > 1 {
-		searchQueryString := strings.Join(match[1:], "")
-		searchQueryString = strings.Replace(searchQueryString, ":", "\\:", -1)
-		// need to escape ":" because this string will go as an input to tsquery
-		searchQueryString = fmt.Sprintf("%s:*", searchQueryString)
+		// need to quote the string because it will go as an input to tsquery
+		searchQueryString := fmt.Sprintf("%s:*", tsqueryLexeme(strings.Join(match[1:], "")))
 		if result["id"] != "" {
 			// Look for pattern's ID field, if exists update searchQueryString
-			searchQueryString = fmt.Sprintf("(%v:* | %v)", result["id"], searchQueryString)
+			searchQueryString = fmt.Sprintf("(%v:* | %v)", tsqueryLexeme(result["id"]), searchQueryString)
 			// searchQueryString = "(" + result["id"] + ":*" + " | " + searchQueryString + ")"
 		}
 		return searchQueryString
 	}
-	return match[0] + ":*"
+	return tsqueryLexeme(match[0]) + ":*"
 }
 
 /*
@@ -191,30 +332,212 @@ func getSearchQueryFromURLString(url string) string {
 	return sanitizeURL(url) + ":*"
 }
 
-// parseSearchString accepts a raw string and generates a searchKeyword object
+// getWorkItemRefFromURLString returns the work item identified by the given
+// url string if it matches a known url with the `owner`, `space` and `number`
+// capture groups, such as the URL of the page of the work item in its space.
+func getWorkItemRefFromURLString(url string) (*workItemRef, bool) {
+	known, patternName := isKnownURL(url)
+	if !known {
+		return nil, false
+	}
+	knownURLLock.RLock()
+	pattern := knownURLs[patternName]
+	knownURLLock.RUnlock()
+	match := pattern.compiledRegex.FindStringSubmatch(url)
+	groups := make(map[string]string)
+	for i, name := range pattern.groupNamesInRegex {
+		if i > 0 && name != "" && i < len(match) {
+			groups[name] = match[i]
+		}
+	}
+	if groups["owner"] == "" || groups["space"] == "" || groups["number"] == "" {
+		return nil, false
+	}
+	number, err := strconv.Atoi(groups["number"])
+	if err != nil {
+		return nil, false
+	}
+	return &workItemRef{
+		owner:  groups["owner"],
+		space:  groups["space"],
+		number: number,
+	}, true
+}
+
+// ParseWorkItemURL returns the username of the owner and the name of the space
+// and the number of the work item identified by the given URL, if it matches
+// a known URL with the `owner`, `space` and `number` capture groups, such as
+// the URL of the page of the work item in its space.
+func ParseWorkItemURL(url string) (owner string, spaceName string, number int, ok bool) {
+	ref, ok := getWorkItemRefFromURLString(url)
+	if !ok {
+		return "", "", 0, false
+	}
+	return ref.owner, ref.space, ref.number, true
+}
+
+// searchOperatorOr is the search string operator that matches the work items
+// containing either the term before or the term after it
+const searchOperatorOr = "OR"
+
+// plainLexemeRegexp matches the words that can be used in a tsquery as they are
+var plainLexemeRegexp = regexp.MustCompile(`^[\p{L}\p{N}_./-]+$`)
+
+// numberRegexp matches the work item numbers searched with `number:`
+var numberRegexp = regexp.MustCompile(`^\d+$`)
+
+// searchToken is a term of a raw search string
+type searchToken struct {
+	text    string
+	phrase  bool // the term was quoted
+	negated bool // the term was prefixed with "-"
+}
+
+// tokenizeSearchString splits a raw search string into terms, keeping the
+// quoted phrases as a single term
+func tokenizeSearchString(rawSearchString string) ([]searchToken, error) {
+	var tokens []searchToken
+	runes := []rune(rawSearchString)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+		var tok searchToken
+		if runes[i] == '-' {
+			if i+1 == len(runes) || unicode.IsSpace(runes[i+1]) {
+				return nil, errors.NewBadParameterError("q", rawSearchString).Expected("a word or a phrase after '-'")
+			}
+			tok.negated = true
+			i++
+		}
+		if runes[i] == '"' {
+			end := strings.IndexRune(string(runes[i+1:]), '"')
+			if end < 0 {
+				return nil, errors.NewBadParameterError("q", rawSearchString).Expected("a closing quote after the phrase")
+			}
+			tok.text = string(runes[i+1:])[:end]
+			tok.phrase = true
+			i += len([]rune(tok.text)) + 2
+			if strings.TrimSpace(tok.text) == "" {
+				return nil, errors.NewBadParameterError("q", rawSearchString).Expected("a non-empty phrase between the quotes")
+			}
+		} else {
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			tok.text = string(runes[start:i])
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, nil
+}
+
+// isQuotedURL returns true if the text of the given phrase is a single URL
+func isQuotedURL(phrase string) bool {
+	phrase = strings.TrimSpace(phrase)
+	return !strings.ContainsAny(phrase, " \t\n") && govalidator.IsURL(phrase)
+}
+
+// tsqueryLexeme returns the given user input as a single tsquery operand.
+// Unless the input only consists of letters, digits and a few harmless
+// characters, it is quoted so that the tsquery special characters
+// (& | ! ( ) : * < > ' \) lose their meaning.
+func tsqueryLexeme(s string) string {
+	if plainLexemeRegexp.MatchString(s) {
+		return s
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(s) + "'"
+}
+
+// tsqueryPhrase returns a tsquery that matches the words of the given phrase
+// following each other
+func tsqueryPhrase(phrase string) string {
+	words := strings.Fields(strings.ToLower(phrase))
+	for i, word := range words {
+		words[i] = tsqueryLexeme(word)
+	}
+	if len(words) == 1 {
+		return words[0]
+	}
+	return "(" + strings.Join(words, " <-> ") + ")"
+}
+
+// parseSearchString accepts a raw string and generates a searchKeyword object.
+// Besides the `number:`, `type:` and `in:` prefixes and the URLs, the terms can be
+// - words, matching the words starting with them,
+// - quoted phrases, matching these exact words following each other,
+// - words or phrases prefixed with "-", excluding the work items that match them,
+// - two or more of the above separated with "OR", matching any of them.
+// All the terms must match.
 func parseSearchString(rawSearchString string) (searchKeyword, error) {
-	// TODO remove special characters and exclaimations if any
 	rawSearchString = strings.Trim(rawSearchString, "/") // get rid of trailing slashes
-	rawSearchString = strings.Trim(rawSearchString, "\"")
-	parts := strings.Fields(rawSearchString)
 	var res searchKeyword
-	for _, part := range parts {
-		// QueryUnescape is required in case of encoded url strings.
-		// And does not harm regular search strings
-		// but this processing is required because at this moment, we do not know if
-		// search input is a regular string or a URL
-
-		part, err := url.QueryUnescape(part)
-		if err != nil {
-			log.Warn(nil, map[string]interface{}{
-				"part": part,
-			}, "unable to escape url!")
-		}
-		// IF part is for search with number:1234
-		// TODO: need to find out the way to use ID fields.
-		if strings.HasPrefix(part, "number:") {
-			res.number = append(res.number, strings.TrimPrefix(part, "number:")+":*A")
-		} else if strings.HasPrefix(part, "type:") {
+	tokens, err := tokenizeSearchString(rawSearchString)
+	if err != nil {
+		return res, errs.WithStack(err)
+	}
+	// the operands of each OR, the groups being ANDed
+	var groups [][]string
+	// whether a group has an operand that is not excluded
+	var positiveGroups []bool
+	afterOr := false
+	afterTerm := false
+	for _, tok := range tokens {
+		if tok.text == searchOperatorOr && !tok.phrase && !tok.negated {
+			if !afterTerm || afterOr {
+				return res, errors.NewBadParameterError("q", rawSearchString).Expected("OR between two words or phrases")
+			}
+			afterOr = true
+			continue
+		}
+		part := tok.text
+		if tok.phrase && isQuotedURL(part) {
+			// a quoted URL is looked up as a URL rather than as a phrase, in
+			// the same way as an unquoted one
+			tok.phrase = false
+			part = strings.TrimSpace(part)
+		}
+		if !tok.phrase {
+			// QueryUnescape is required in case of encoded url strings.
+			// And does not harm regular search strings
+			// but this processing is required because at this moment, we do not know if
+			// search input is a regular string or a URL
+			unescaped, err := url.QueryUnescape(part)
+			if err != nil {
+				log.Warn(nil, map[string]interface{}{
+					"part": part,
+				}, "unable to escape url!")
+			} else {
+				part = unescaped
+			}
+		}
+		if !tok.phrase && (strings.HasPrefix(part, "number:") || strings.HasPrefix(part, "type:") || strings.HasPrefix(part, "in:")) {
+			if tok.negated || afterOr {
+				return res, errors.NewBadParameterError("q", rawSearchString).Expected("number:, type: and in: neither excluded nor combined with OR")
+			}
+			afterTerm = false
+			if strings.HasPrefix(part, "in:") {
+				scope := strings.ToLower(strings.TrimPrefix(part, "in:"))
+				switch scope {
+				case searchScopeTitle, searchScopeDescription, searchScopeComments:
+					res.scopes = append(res.scopes, scope)
+				default:
+					return res, errors.NewBadParameterError("q", part).Expected("in:title, in:description or in:comments")
+				}
+				continue
+			}
+			// IF part is for search with number:1234
+			// TODO: need to find out the way to use ID fields.
+			if strings.HasPrefix(part, "number:") {
+				number := strings.TrimPrefix(part, "number:")
+				if !numberRegexp.MatchString(number) {
+					return res, errors.NewBadParameterError("q", part).Expected("a work item number after number:")
+				}
+				res.number = append(res.number, number+":*A")
+				continue
+			}
 			typeIDStr := strings.TrimPrefix(part, "type:")
 			if len(typeIDStr) == 0 {
 				return res, errors.NewBadParameterError("Type ID must not be empty", part)
@@ -224,16 +547,56 @@ func parseSearchString(rawSearchString string) (searchKeyword, error) {
 				return res, errors.NewBadParameterError("failed to parse type ID string as UUID", typeIDStr)
 			}
 			res.workItemTypes = append(res.workItemTypes, typeID)
+			continue
+		}
+		if !tok.phrase && govalidator.IsURL(part) {
+			// the URL of a work item in its space is an exact reference
+			if ref, ok := getWorkItemRefFromURLString(trimProtocolFromURLString(strings.ToLower(part))); ok {
+				if tok.negated || afterOr {
+					return res, errors.NewBadParameterError("q", rawSearchString).Expected("work item URLs neither excluded nor combined with OR")
+				}
+				res.items = append(res.items, *ref)
+				afterTerm = false
+				continue
+			}
+		}
+		var fragment string
+		if tok.phrase {
+			fragment = tsqueryPhrase(part)
 		} else if govalidator.IsURL(part) {
 			part := strings.ToLower(part)
 			part = trimProtocolFromURLString(part)
-			searchQueryFromURL := getSearchQueryFromURLString(part)
-			res.words = append(res.words, searchQueryFromURL)
+			fragment = getSearchQueryFromURLString(part)
 		} else {
-			part := strings.ToLower(part)
-			part = sanitizeURL(part)
-			res.words = append(res.words, part+":*")
+			fragment = tsqueryLexeme(strings.ToLower(part)) + ":*"
+		}
+		if tok.negated {
+			fragment = "!" + fragment
+		}
+		if afterOr {
+			groups[len(groups)-1] = append(groups[len(groups)-1], fragment)
+			positiveGroups[len(groups)-1] = positiveGroups[len(groups)-1] || !tok.negated
+		} else {
+			groups = append(groups, []string{fragment})
+			positiveGroups = append(positiveGroups, !tok.negated)
 		}
+		afterOr = false
+		afterTerm = true
+	}
+	if afterOr {
+		return res, errors.NewBadParameterError("q", rawSearchString).Expected("a word or a phrase after OR")
+	}
+	positive := len(res.number) > 0 || len(res.items) > 0
+	for i, group := range groups {
+		positive = positive || positiveGroups[i]
+		if len(group) == 1 {
+			res.words = append(res.words, group[0])
+		} else {
+			res.words = append(res.words, "("+strings.Join(group, " | ")+")")
+		}
+	}
+	if len(groups) > 0 && !positive {
+		return res, errors.NewBadParameterError("q", rawSearchString).Expected("at least one word or phrase that is not excluded")
 	}
 	log.Info(nil, nil, "Search keywords: '%s' -> %v", rawSearchString, res)
 	return res, nil
@@ -253,11 +616,88 @@ func generateSQLSearchInfo(keywords searchKeyword) (sqlParameter string) {
 	return searchStr
 }
 
+// searchCandidatesCondition returns the SQL condition, along with its
+// parameters, restricting the work items to the ones whose text or comments
+// match the given query. The query is built once per text search
+// configuration of the searched spaces, so that the text search indexes of the
+// work items and of the comments can be used, whereas the per-row query of the
+// search only ranks and highlights these candidates.
+func (r *GormSearchRepository) searchCandidatesCondition(ctx context.Context, sqlSearchQueryParameter string, spaceID *string) (string, []interface{}, error) {
+	workItemTableName := workitem.WorkItemStorage{}.TableName()
+	spaceTableName := (&space.GormRepository{}).TableName()
+	db := r.db.Table(spaceTableName)
+	if spaceID != nil {
+		db = db.Where("id = ?", *spaceID)
+	}
+	var configs []string
+	if err := db.Pluck("DISTINCT text_search_config", &configs).Error; err != nil {
+		return "", nil, errs.WithStack(err)
+	}
+	if len(configs) == 0 {
+		return "FALSE", nil, nil
+	}
+	subqueries := make([]string, 0, 2*len(configs))
+	parameters := make([]interface{}, 0, 6*len(configs))
+	for _, config := range configs {
+		subqueries = append(subqueries,
+			fmt.Sprintf("SELECT w.id FROM %[1]s w JOIN %[3]s s ON s.id = w.space_id "+
+				"WHERE s.text_search_config = ? AND w.tsv @@ to_tsquery(?::regconfig, ?)",
+				workItemTableName, (&comment.GormCommentRepository{}).TableName(), spaceTableName),
+			fmt.Sprintf("SELECT c.parent_id FROM %[2]s c JOIN %[1]s w ON w.id = c.parent_id JOIN %[3]s s ON s.id = w.space_id "+
+				"WHERE s.text_search_config = ? AND c.deleted_at IS NULL AND c.tsv @@ to_tsquery(?::regconfig, ?)",
+				workItemTableName, (&comment.GormCommentRepository{}).TableName(), spaceTableName))
+		parameters = append(parameters, config, config, sqlSearchQueryParameter, config, config, sqlSearchQueryParameter)
+	}
+	log.Debug(ctx, map[string]interface{}{"configs": configs}, "searching with the text search configurations of the spaces")
+	return fmt.Sprintf("%s.id IN (%s)", workItemTableName, strings.Join(subqueries, " UNION ")), parameters, nil
+}
+
 // extracted this function from List() in order to close the rows object with "defer" for more readability
 // workaround for https://github.com/lib/pq/issues/81
-func (r *GormSearchRepository) search(ctx context.Context, sqlSearchQueryParameter string, workItemTypes []uuid.UUID, start *int, limit *int, spaceID *string) ([]workitem.WorkItemStorage, uint64, error) {
+func (r *GormSearchRepository) search(ctx context.Context, sqlSearchQueryParameter string, workItemTypes []uuid.UUID, scopes []string, items []workItemRef, filter criteria.Expression, highlight HighlightOptions, start *int, limit *int, spaceID *string) ([]storageHit, uint64, error) {
 	log.Info(ctx, nil, "Searching work items...")
-	db := r.db.Model(workitem.WorkItemStorage{}).Where("tsv @@ query")
+	titleOptions, descriptionOptions, err := highlight.headlineOptions()
+	if err != nil {
+		return nil, 0, errs.WithStack(err)
+	}
+	db := r.db.Model(workitem.WorkItemStorage{})
+	if sqlSearchQueryParameter != "" || len(items) == 0 {
+		db = db.Where(searchScopeCondition(scopes))
+	}
+	if sqlSearchQueryParameter != "" {
+		candidates, parameters, err := r.searchCandidatesCondition(ctx, sqlSearchQueryParameter, spaceID)
+		if err != nil {
+			return nil, 0, errs.WithStack(err)
+		}
+		db = db.Where(candidates, parameters...)
+	}
+	if len(items) > 0 {
+		// the referenced work items are looked up by the owner and the name
+		// of their space and by their number
+		conditions := make([]string, len(items))
+		parameters := make([]interface{}, 0, 3*len(items))
+		for i, item := range items {
+			conditions[i] = fmt.Sprintf("(%[1]s.number = ? AND %[1]s.space_id IN ("+
+				"SELECT s.id FROM %[2]s s JOIN %[3]s i ON i.id = s.owner_id "+
+				"WHERE lower(i.username) = ? AND lower(s.name) = ?))",
+				workitem.WorkItemStorage{}.TableName(), (&space.GormRepository{}).TableName(), account.Identity{}.TableName())
+			parameters = append(parameters, item.number, item.owner, item.space)
+		}
+		db = db.Where(strings.Join(conditions, " OR "), parameters...)
+	}
+	if filter != nil {
+		// the structured filter is ANDed with the full-text match, so that
+		// the ranking and the total count only consider matching work items
+		where, parameters, compileErrors := workitem.Compile(filter)
+		if len(compileErrors) > 0 {
+			log.Error(ctx, map[string]interface{}{
+				"filter": filter,
+				"errs":   compileErrors,
+			}, "unable to compile the search filter")
+			return nil, 0, errors.NewBadParameterError("filter", filter)
+		}
+		db = db.Where(where, parameters...)
+	}
 	if start != nil {
 		if *start < 0 {
 			return nil, 0, errors.NewBadParameterError("start", *start)
@@ -279,12 +719,34 @@ func (r *GormSearchRepository) search(ctx context.Context, sqlSearchQueryParamet
 		db = db.Where(query, workItemTypes)
 	}
 
-	db = db.Select("count(*) over () as cnt2 , *")
-	db = db.Joins(", to_tsquery('english', ?) as query, ts_rank(tsv, query) as rank", sqlSearchQueryParameter)
+	// the first columns hold the total count, the rank and the highlighted
+	// excerpts of the title, the description and the best matching comment,
+	// which are not part of the work item storage. The work items matching
+	// in their own text and in their comments are ranked together. The query
+	// and the excerpts use the text search configuration of the space of
+	// each work item, the same one its text and comments were indexed with.
+	db = db.Select("count(*) over () as cnt2, rank + matching_comment.comment_rank as total_rank, "+
+		"ts_headline(search_space.search_config, "+escapedHeadlineText("coalesce(fields->>'system.title', '')")+", query, ?) as title_headline, "+
+		"ts_headline(search_space.search_config, "+escapedHeadlineText("coalesce(fields#>>'{system.description, content}', '')")+", query, ?) as description_headline, "+
+		"matching_comment.comment_id, matching_comment.comment_headline, *",
+		titleOptions, descriptionOptions)
+	db = db.Joins(fmt.Sprintf(", LATERAL (SELECT s.text_search_config::regconfig as search_config FROM %[3]s s WHERE s.id = %[1]s.space_id) as search_space, "+
+		"to_tsquery(search_space.search_config, ?) as query, ts_rank(%[1]s.tsv, query) as rank, "+
+		"LATERAL (SELECT "+
+		"(array_agg(c.id ORDER BY ts_rank(c.tsv, query) DESC))[1] as comment_id, "+
+		"(array_agg(ts_headline(search_space.search_config, "+escapedHeadlineText("c.body")+", query, ?) ORDER BY ts_rank(c.tsv, query) DESC))[1] as comment_headline, "+
+		"coalesce(max(ts_rank(c.tsv, query)), 0) as comment_rank "+
+		"FROM %[2]s c WHERE c.parent_id = %[1]s.id AND c.deleted_at IS NULL AND c.tsv @@ query) as matching_comment",
+		workitem.WorkItemStorage{}.TableName(), (&comment.GormCommentRepository{}).TableName(), (&space.GormRepository{}).TableName()),
+		sqlSearchQueryParameter, descriptionOptions)
 	if spaceID != nil {
 		db = db.Where("space_id=?", *spaceID)
+	} else {
+		// the work items of the archived spaces are only found when searching
+		// in their space
+		db = db.Where(fmt.Sprintf("%s.space_id NOT IN (SELECT id FROM %s WHERE archived)", workitem.WorkItemStorage{}.TableName(), (&space.GormRepository{}).TableName()))
 	}
-	db = db.Order(fmt.Sprintf("rank desc,%s.updated_at desc", workitem.WorkItemStorage{}.TableName()))
+	db = db.Order(fmt.Sprintf("total_rank desc,%s.updated_at desc", workitem.WorkItemStorage{}.TableName()))
 
 	rows, err := db.Rows()
 	if err != nil {
@@ -292,14 +754,14 @@ func (r *GormSearchRepository) search(ctx context.Context, sqlSearchQueryParamet
 	}
 	defer rows.Close()
 
-	result := []workitem.WorkItemStorage{}
-	value := workitem.WorkItemStorage{}
+	result := []storageHit{}
 	columns, err := rows.Columns()
 	if err != nil {
 		return nil, 0, errors.NewInternalError(ctx, err)
 	}
 
-	// need to set up a result for Scan() in order to extract total count.
+	// need to set up a result for Scan() in order to extract total count,
+	// rank and highlights.
 	var count uint64
 	var ignore interface{}
 	columnValues := make([]interface{}, len(columns))
@@ -307,19 +769,22 @@ func (r *GormSearchRepository) search(ctx context.Context, sqlSearchQueryParamet
 	for index := range columnValues {
 		columnValues[index] = &ignore
 	}
-	columnValues[0] = &count
 	first := true
 
 	for rows.Next() {
-		db.ScanRows(rows, &value)
-		if first {
-			first = false
-			if err = rows.Scan(columnValues...); err != nil {
-				return nil, 0, errors.NewInternalError(ctx, err)
-			}
+		hit := storageHit{}
+		db.ScanRows(rows, &hit.storage)
+		columnValues[0] = &count
+		columnValues[1] = &hit.rank
+		columnValues[2] = &hit.titleHeadline
+		columnValues[3] = &hit.descriptionHeadline
+		columnValues[4] = &hit.commentID
+		columnValues[5] = &hit.commentHeadline
+		if err = rows.Scan(columnValues...); err != nil {
+			return nil, 0, errors.NewInternalError(ctx, err)
 		}
-		result = append(result, value)
-
+		first = false
+		result = append(result, hit)
 	}
 	if first {
 		// means 0 rows were returned from the first query,
@@ -327,11 +792,14 @@ func (r *GormSearchRepository) search(ctx context.Context, sqlSearchQueryParamet
 	}
 	log.Info(ctx, nil, "Search results: %d matches", count)
 	return result, count, nil
-	//*/
 }
 
-// SearchFullText Search returns work items for the given query
-func (r *GormSearchRepository) SearchFullText(ctx context.Context, rawSearchString string, start *int, limit *int, spaceID *string) ([]workitem.WorkItem, uint64, error) {
+// SearchFullText Search returns work items for the given query. The optional
+// filter restricts the result to the work items matching this expression, in
+// the same way as when listing work items. The matching words are highlighted
+// in the excerpts of the results with the given tags, or with the default
+// ones if the highlight options are nil.
+func (r *GormSearchRepository) SearchFullText(ctx context.Context, rawSearchString string, filter criteria.Expression, highlight *HighlightOptions, start *int, limit *int, spaceID *string) ([]Result, uint64, error) {
 	// parse
 	// generateSearchQuery
 	// ....
@@ -339,35 +807,49 @@ func (r *GormSearchRepository) SearchFullText(ctx context.Context, rawSearchStri
 	if err != nil {
 		return nil, 0, errs.WithStack(err)
 	}
+	if highlight == nil {
+		highlight = &DefaultHighlightOptions
+	}
 
 	sqlSearchQueryParameter := generateSQLSearchInfo(parsedSearchDict)
-	var rows []workitem.WorkItemStorage
-	rows, count, err := r.search(ctx, sqlSearchQueryParameter, parsedSearchDict.workItemTypes, start, limit, spaceID)
+	hits, count, err := r.search(ctx, sqlSearchQueryParameter, parsedSearchDict.workItemTypes, parsedSearchDict.scopes, parsedSearchDict.items, filter, *highlight, start, limit, spaceID)
 	if err != nil {
 		return nil, 0, errs.WithStack(err)
 	}
-	result := make([]workitem.WorkItem, len(rows))
+	result := make([]Result, len(hits))
 
-	for index, value := range rows {
+	for index, hit := range hits {
 		var err error
 		// FIXME: Against best practice http://go-database-sql.org/retrieving.html
-		wiType, err := r.wir.LoadTypeFromDB(ctx, value.Type)
+		wiType, err := r.wir.LoadTypeFromDB(ctx, hit.storage.Type)
 		if err != nil {
 			return nil, 0, errors.NewInternalError(ctx, err)
 		}
-		wiModel, err := wiType.ConvertWorkItemStorageToModel(value)
+		wiModel, err := wiType.ConvertWorkItemStorageToModel(hit.storage)
 		if err != nil {
 			return nil, 0, errors.NewConversionError(err.Error())
 		}
-		result[index] = *wiModel
+		result[index] = Result{
+			WorkItem:   *wiModel,
+			Rank:       hit.rank,
+			Highlights: map[string]string{},
+		}
+		// ts_headline returns the beginning of the text when nothing matches
+		if strings.Contains(hit.titleHeadline, highlight.StartTag) {
+			result[index].Highlights[workitem.SystemTitle] = hit.titleHeadline
+		}
+		if strings.Contains(hit.descriptionHeadline, highlight.StartTag) {
+			result[index].Highlights[workitem.SystemDescription] = hit.descriptionHeadline
+		}
+		if hit.commentID.Valid {
+			commentID, err := uuid.FromString(hit.commentID.String)
+			if err != nil {
+				return nil, 0, errors.NewInternalError(ctx, err)
+			}
+			result[index].CommentID = &commentID
+			result[index].Highlights[CommentHighlight] = hit.commentHeadline.String
+		}
 	}
 
 	return result, count, nil
 }
-
-func init() {
-	// While registering URLs do not include protocol because it will be removed before scanning starts
-	// Please do not include trailing slashes because it will be removed before scanning starts
-	RegisterAsKnownURL("test-work-item-list-details", `(?P<domain>demo.almighty.io)(?P<path>/work-item/list/detail/)(?P<id>\d*)`)
-	RegisterAsKnownURL("test-work-item-board-details", `(?P<domain>demo.almighty.io)(?P<path>/work-item/board/detail/)(?P<id>\d*)`)
-}
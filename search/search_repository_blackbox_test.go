@@ -5,6 +5,8 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/criteria"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/migration"
@@ -63,7 +65,7 @@ func (s *searchRepositoryBlackboxTest) TestRestrictByType() {
 	params := url.Values{}
 	ctx := goa.NewContext(context.Background(), nil, req, params)
 
-	res, count, err := s.searchRepo.SearchFullText(ctx, "TestRestrictByType", nil, nil, nil)
+	res, count, err := s.searchRepo.SearchFullText(ctx, "TestRestrictByType", nil, nil, nil, nil, nil)
 	require.Nil(s.T(), err)
 	require.True(s.T(), count == uint64(len(res))) // safety check for many, many instances of bogus search results.
 	for _, wi := range res {
@@ -100,13 +102,13 @@ func (s *searchRepositoryBlackboxTest) TestRestrictByType() {
 	require.Nil(s.T(), err)
 	require.NotNil(s.T(), wi2)
 
-	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType", nil, nil, nil)
+	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType", nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(2), count)
 	assert.Equal(s.T(), res[0].Fields["system.order"], wi2.Fields["system.order"])
 	assert.Equal(s.T(), res[1].Fields["system.order"], wi1.Fields["system.order"])
 
-	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+sub1.ID.String(), nil, nil, nil)
+	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+sub1.ID.String(), nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(1), count)
 	if count == 1 {
@@ -114,7 +116,7 @@ func (s *searchRepositoryBlackboxTest) TestRestrictByType() {
 		assert.Equal(s.T(), res[0].Fields["system.order"], wi1.Fields["system.order"])
 	}
 
-	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+sub2.ID.String(), nil, nil, nil)
+	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+sub2.ID.String(), nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(1), count)
 	if count == 1 {
@@ -122,25 +124,195 @@ func (s *searchRepositoryBlackboxTest) TestRestrictByType() {
 		assert.Equal(s.T(), res[0].Fields["system.order"], wi2.Fields["system.order"])
 	}
 
-	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+base.ID.String(), nil, nil, nil)
+	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+base.ID.String(), nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(2), count)
 	assert.Equal(s.T(), res[0].Fields["system.order"], wi2.Fields["system.order"])
 	assert.Equal(s.T(), res[1].Fields["system.order"], wi1.Fields["system.order"])
 
-	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+sub2.ID.String()+" type:"+sub1.ID.String(), nil, nil, nil)
+	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+sub2.ID.String()+" type:"+sub1.ID.String(), nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(2), count)
 	assert.Equal(s.T(), res[0].Fields["system.order"], wi2.Fields["system.order"])
 	assert.Equal(s.T(), res[1].Fields["system.order"], wi1.Fields["system.order"])
 
-	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+base.ID.String()+" type:"+sub1.ID.String(), nil, nil, nil)
+	res, count, err = s.searchRepo.SearchFullText(ctx, "TestRestrictByType type:"+base.ID.String()+" type:"+sub1.ID.String(), nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(2), count)
 	assert.Equal(s.T(), res[0].Fields["system.order"], wi2.Fields["system.order"])
 	assert.Equal(s.T(), res[1].Fields["system.order"], wi1.Fields["system.order"])
 
-	_, count, err = s.searchRepo.SearchFullText(ctx, "TRBTgorxi type:"+base.ID.String(), nil, nil, nil)
+	_, count, err = s.searchRepo.SearchFullText(ctx, "TRBTgorxi type:"+base.ID.String(), nil, nil, nil, nil, nil)
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), uint64(0), count)
 }
+
+func (s *searchRepositoryBlackboxTest) TestSearchWithFilter() {
+	// given
+	req := &http.Request{Host: "localhost"}
+	ctx := goa.NewContext(context.Background(), nil, req, url.Values{})
+	keyword := "TestSearchWithFilter" + uuid.NewV4().String()[:8]
+	open, err := s.wiRepo.Create(ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle:     "crash " + keyword,
+		workitem.SystemState:     workitem.SystemStateOpen,
+		workitem.SystemAssignees: []string{s.modifierID.String()},
+	}, s.modifierID)
+	require.Nil(s.T(), err)
+	_, err = s.wiRepo.Create(ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "crash " + keyword,
+		workitem.SystemState: workitem.SystemStateClosed,
+	}, s.modifierID)
+	require.Nil(s.T(), err)
+	_, err = s.wiRepo.Create(ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "freeze " + keyword,
+		workitem.SystemState: workitem.SystemStateOpen,
+	}, s.modifierID)
+	require.Nil(s.T(), err)
+
+	s.T().Run("without filter", func(t *testing.T) {
+		_, count, err := s.searchRepo.SearchFullText(ctx, "crash "+keyword, nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(2), count)
+	})
+
+	s.T().Run("with filter", func(t *testing.T) {
+		filter := criteria.And(
+			criteria.Equals(criteria.Field(workitem.SystemState), criteria.Literal(workitem.SystemStateOpen)),
+			criteria.Equals(criteria.Field(workitem.SystemAssignees), criteria.Literal([]string{s.modifierID.String()})),
+		)
+		start, limit := 0, 1
+		res, count, err := s.searchRepo.SearchFullText(ctx, "crash "+keyword, filter, nil, &start, &limit, nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(1), count)
+		require.Len(t, res, 1)
+		assert.Equal(t, open.ID, res[0].ID)
+	})
+
+	s.T().Run("with filter matching nothing", func(t *testing.T) {
+		filter := criteria.Equals(criteria.Field(workitem.SystemState), criteria.Literal(workitem.SystemStateResolved))
+		res, count, err := s.searchRepo.SearchFullText(ctx, keyword, filter, nil, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(0), count)
+		assert.Empty(t, res)
+	})
+}
+
+func (s *searchRepositoryBlackboxTest) TestSearchInComments() {
+	// given
+	req := &http.Request{Host: "localhost"}
+	ctx := goa.NewContext(context.Background(), nil, req, url.Values{})
+	keyword := "searchincomments" + uuid.NewV4().String()[:8]
+	inTitle, err := s.wiRepo.Create(ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "overflow " + keyword,
+		workitem.SystemState: workitem.SystemStateOpen,
+	}, s.modifierID)
+	require.Nil(s.T(), err)
+	inComment, err := s.wiRepo.Create(ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "unrelated " + keyword,
+		workitem.SystemState: workitem.SystemStateOpen,
+	}, s.modifierID)
+	require.Nil(s.T(), err)
+	c := comment.Comment{
+		ParentID: inComment.ID,
+		Body:     "I think this is a stack overflow",
+		Markup:   "PlainText",
+	}
+	require.Nil(s.T(), comment.NewRepository(s.DB).Create(ctx, &c, s.modifierID))
+
+	s.T().Run("everywhere", func(t *testing.T) {
+		res, count, err := s.searchRepo.SearchFullText(ctx, "overflow "+keyword, nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, uint64(2), count)
+		ids := []uuid.UUID{res[0].ID, res[1].ID}
+		assert.Contains(t, ids, inTitle.ID)
+		assert.Contains(t, ids, inComment.ID)
+		for _, r := range res {
+			if r.ID == inComment.ID {
+				require.NotNil(t, r.CommentID)
+				assert.Equal(t, c.ID, *r.CommentID)
+				assert.Contains(t, r.Highlights[search.CommentHighlight], "<b>overflow</b>")
+			} else {
+				assert.Nil(t, r.CommentID)
+				assert.Contains(t, r.Highlights[workitem.SystemTitle], "<b>overflow</b>")
+			}
+		}
+	})
+
+	s.T().Run("in comments", func(t *testing.T) {
+		res, count, err := s.searchRepo.SearchFullText(ctx, "in:comments overflow", nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), count)
+		assert.Equal(t, inComment.ID, res[0].ID)
+	})
+
+	s.T().Run("in title", func(t *testing.T) {
+		res, count, err := s.searchRepo.SearchFullText(ctx, "in:title overflow "+keyword, nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), count)
+		assert.Equal(t, inTitle.ID, res[0].ID)
+	})
+
+	s.T().Run("unknown scope", func(t *testing.T) {
+		_, _, err := s.searchRepo.SearchFullText(ctx, "in:everything overflow", nil, nil, nil, nil, nil)
+		require.NotNil(t, err)
+	})
+}
+
+func (s *searchRepositoryBlackboxTest) TestSearchWithSpaceTextSearchConfig() {
+	// given a space which does not stem the words of its work items
+	req := &http.Request{Host: "localhost"}
+	ctx := goa.NewContext(context.Background(), nil, req, url.Values{})
+	spaceRepo := space.NewRepository(s.DB)
+	sp, err := spaceRepo.Create(ctx, &space.Space{
+		Name:             "TestSearchWithSpaceTextSearchConfig-" + uuid.NewV4().String(),
+		TextSearchConfig: "simple",
+	})
+	require.Nil(s.T(), err)
+	spaceID := sp.ID.String()
+	wi, err := s.wiRepo.Create(ctx, sp.ID, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "running containers",
+		workitem.SystemState: workitem.SystemStateOpen,
+	}, s.modifierID)
+	require.Nil(s.T(), err)
+
+	s.T().Run("exact word", func(t *testing.T) {
+		res, count, err := s.searchRepo.SearchFullText(ctx, "running", nil, nil, nil, nil, &spaceID)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), count)
+		assert.Equal(t, wi.ID, res[0].ID)
+	})
+
+	s.T().Run("exact word in all spaces", func(t *testing.T) {
+		res, _, err := s.searchRepo.SearchFullText(ctx, "running", nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		found := false
+		for _, r := range res {
+			found = found || uuid.Equal(wi.ID, r.ID)
+		}
+		assert.True(t, found)
+	})
+
+	s.T().Run("stemmed word", func(t *testing.T) {
+		_, count, err := s.searchRepo.SearchFullText(ctx, "run", nil, nil, nil, nil, &spaceID)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+
+	s.T().Run("stemmed word in all spaces", func(t *testing.T) {
+		res, _, err := s.searchRepo.SearchFullText(ctx, "run", nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		for _, r := range res {
+			assert.False(t, uuid.Equal(wi.ID, r.ID))
+		}
+	})
+
+	s.T().Run("stemmed word after changing the configuration", func(t *testing.T) {
+		sp.TextSearchConfig = "english"
+		_, err := spaceRepo.Save(ctx, sp)
+		require.Nil(t, err)
+		res, count, err := s.searchRepo.SearchFullText(ctx, "run", nil, nil, nil, nil, &spaceID)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), count)
+		assert.Equal(t, wi.ID, res[0].ID)
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/fabric8-services/fabric8-wit/configuration"
+	errs "github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
 	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
 	"github.com/fabric8-services/fabric8-wit/migration"
@@ -30,6 +32,17 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+func init() {
+	// the tests rely on the known URLs of the default configuration
+	config, err := configuration.NewConfigurationData("")
+	if err != nil {
+		panic(err)
+	}
+	if err := RegisterKnownURLs(config.GetSearchKnownURLs()); err != nil {
+		panic(err)
+	}
+}
+
 func TestRunSearchRepositoryWhiteboxTest(t *testing.T) {
 	resource.Require(t, resource.Database)
 	suite.Run(t, &searchRepositoryWhiteboxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../config.yaml")})
@@ -181,16 +194,16 @@ func (s *searchRepositoryWhiteboxTest) TestSearchByText() {
 
 			// had to dynamically create this since I didn't now the URL/ID of the workitem
 			// till the test data was created.
+			// the search string is not quoted as a whole since a quoted
+			// search string is a phrase
 			searchString = searchString + workItemURLInSearchString
-			searchString = fmt.Sprintf("\"%s\"", searchString)
 			s.T().Log("using search string: " + searchString)
 			sr := NewGormSearchRepository(tx)
 			var start, limit int = 0, 100
-			workItemList, _, err := sr.SearchFullText(ctx, searchString, &start, &limit, nil)
+			workItemList, _, err := sr.SearchFullText(ctx, searchString, nil, nil, &start, &limit, nil)
 			if err != nil {
 				s.T().Fatal("Error getting search result ", err)
 			}
-			searchString = strings.Trim(searchString, "\"")
 			// Since this test adds test data, whether or not other workitems exist
 			// there must be at least 1 search result returned.
 			if len(workItemList) == minimumResults && minimumResults == 0 {
@@ -259,6 +272,59 @@ func stringInSlice(str string, list []string) bool {
 	return false
 }
 
+func (s *searchRepositoryWhiteboxTest) TestSearchByWorkItemURL() {
+	ctx := context.Background()
+	routeName := "custom-test-route-planner-details"
+	RegisterAsKnownURL(routeName, `(?P<domain>planner.me.io)(?P<path>/)(?P<owner>[^/]+)/(?P<space>[^/]+)/plan/detail/(?P<number>\d+)`)
+	defer delete(knownURLs, routeName)
+
+	owner, err := testsupport.CreateTestIdentity(s.DB, "TestSearchByWorkItemURL-"+uuid.NewV4().String(), "test")
+	require.Nil(s.T(), err)
+	spaces := make([]*space.Space, 2)
+	for i := range spaces {
+		spaces[i], err = space.NewRepository(s.DB).Create(ctx, &space.Space{
+			Name:    "TestSearchByWorkItemURL-" + uuid.NewV4().String(),
+			OwnerId: owner.ID,
+		})
+		require.Nil(s.T(), err)
+	}
+	wir := workitem.NewWorkItemRepository(s.DB)
+	// both spaces have a work item with the same number
+	items := make([]*workitem.WorkItem, len(spaces))
+	for i, sp := range spaces {
+		items[i], err = wir.Create(ctx, sp.ID, workitem.SystemBug, map[string]interface{}{
+			workitem.SystemTitle: "work item referenced by URL",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.modifierID)
+		require.Nil(s.T(), err)
+	}
+	require.Equal(s.T(), items[0].Number, items[1].Number)
+	itemURL := fmt.Sprintf("https://planner.me.io/%s/%s/plan/detail/%d", owner.Username, spaces[1].Name, items[1].Number)
+	sr := NewGormSearchRepository(s.DB)
+
+	s.T().Run("exact work item", func(t *testing.T) {
+		result, count, err := sr.SearchFullText(ctx, itemURL, nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), count)
+		assert.Equal(t, items[1].ID, result[0].WorkItem.ID)
+	})
+
+	s.T().Run("with words", func(t *testing.T) {
+		_, count, err := sr.SearchFullText(ctx, itemURL+" referenced", nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(1), count)
+		_, count, err = sr.SearchFullText(ctx, itemURL+" unrelated", nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+
+	s.T().Run("unknown space", func(t *testing.T) {
+		_, count, err := sr.SearchFullText(ctx, fmt.Sprintf("https://planner.me.io/%s/unknown/plan/detail/%d", owner.Username, items[1].Number), nil, nil, nil, nil, nil)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+}
+
 func (s *searchRepositoryWhiteboxTest) TestSearchByID() {
 
 	models.Transactional(s.DB, func(tx *gorm.DB) error {
@@ -297,7 +363,7 @@ func (s *searchRepositoryWhiteboxTest) TestSearchByID() {
 
 		var start, limit int = 0, 100
 		searchString := "number:" + strconv.Itoa(createdWorkItem.Number)
-		workItemList, _, err := sr.SearchFullText(ctx, searchString, &start, &limit, nil)
+		workItemList, _, err := sr.SearchFullText(ctx, searchString, nil, nil, &start, &limit, nil)
 		if err != nil {
 			s.T().Fatal("Error gettig search result ", err)
 		}
@@ -415,6 +481,28 @@ func TestParseSearchStringDifferentURL(t *testing.T) {
 	assert.True(t, assert.ObjectsAreEqualValues(expectedSearchRes, op))
 }
 
+func TestParseSearchStringURLSpecialCharacters(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	inputSet := []searchTestData{{
+		query:    "http://demo.redhat.io/a&b",
+		expected: searchKeyword{words: []string{"'demo.redhat.io/a&b':*"}},
+	}, {
+		query:    "http://demo.redhat.io/it's!",
+		expected: searchKeyword{words: []string{"'demo.redhat.io/it''s!':*"}},
+	}, {
+		query:    "http://demo.redhat.io:8080/a",
+		expected: searchKeyword{words: []string{"'demo.redhat.io:8080/a':*"}},
+	}}
+	for _, input := range inputSet {
+		t.Run(input.query, func(t *testing.T) {
+			op, err := parseSearchString(input.query)
+			require.Nil(t, err)
+			assert.Equal(t, input.expected, op)
+		})
+	}
+}
+
 func TestParseSearchStringCombination(t *testing.T) {
 	t.Parallel()
 	resource.Require(t, resource.UnitTest)
@@ -429,6 +517,169 @@ func TestParseSearchStringCombination(t *testing.T) {
 	assert.True(t, assert.ObjectsAreEqualValues(expectedSearchRes, op))
 }
 
+func TestParseSearchStringGrammar(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	inputSet := []searchTestData{{
+		query:    `"memory leak" crash`,
+		expected: searchKeyword{words: []string{"(memory <-> leak)", "crash:*"}},
+	}, {
+		query:    `"Leak"`,
+		expected: searchKeyword{words: []string{"leak"}},
+	}, {
+		query:    `crash -windows -"memory leak"`,
+		expected: searchKeyword{words: []string{"crash:*", "!windows:*", "!(memory <-> leak)"}},
+	}, {
+		query:    `crash OR freeze OR "stack overflow" linux`,
+		expected: searchKeyword{words: []string{"(crash:* | freeze:* | (stack <-> overflow))", "linux:*"}},
+	}, {
+		query:    `crash or freeze`,
+		expected: searchKeyword{words: []string{"crash:*", "or:*", "freeze:*"}},
+	}, {
+		query:    `crash OR -freeze number:12`,
+		expected: searchKeyword{number: []string{"12:*A"}, words: []string{"(crash:* | !freeze:*)"}},
+	}, {
+		query:    `a&b!c (d) 'e' f:* g\h`,
+		expected: searchKeyword{words: []string{"'a&b!c':*", "'(d)':*", "'''e''':*", "'f:*':*", `'g\\h':*`}},
+	}, {
+		query:    `"it's <-> done"`,
+		expected: searchKeyword{words: []string{"('it''s' <-> '<->' <-> done)"}},
+	}}
+	for _, input := range inputSet {
+		t.Run(input.query, func(t *testing.T) {
+			op, err := parseSearchString(input.query)
+			require.Nil(t, err)
+			assert.Equal(t, input.expected, op)
+		})
+	}
+}
+
+func TestParseSearchStringQuotedURL(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	for _, u := range []string{
+		"http://localhost:8080/detail/154687364529310",
+		"http://localhost/detail/876394",
+		"demo.almighty.io/work-item/list/detail/12",
+	} {
+		t.Run(u, func(t *testing.T) {
+			// a quoted URL is parsed like the unquoted one, not as a phrase
+			expected, err := parseSearchString(u)
+			require.Nil(t, err)
+			op, err := parseSearchString(`"` + u + `"`)
+			require.Nil(t, err)
+			assert.Equal(t, expected, op)
+		})
+	}
+	t.Run("phrase with a URL", func(t *testing.T) {
+		op, err := parseSearchString(`"see localhost/detail/876394"`)
+		require.Nil(t, err)
+		require.Len(t, op.words, 1)
+		assert.True(t, strings.HasPrefix(op.words[0], "(see <-> "), op.words[0])
+	})
+}
+
+func TestParseSearchStringGrammarErrors(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	for _, query := range []string{
+		`"memory leak`,
+		`crash ""`,
+		`crash -`,
+		`OR crash`,
+		`crash OR`,
+		`crash OR OR freeze`,
+		`crash OR number:12`,
+		`-number:12 crash`,
+		`-crash -"memory leak"`,
+		`-crash OR -freeze`,
+		`number:abc`,
+		`number:1)`,
+		`number:1|2`,
+		`number:`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := parseSearchString(query)
+			require.NotNil(t, err)
+			assert.IsType(t, errs.BadParameterError{}, errors.Cause(err))
+		})
+	}
+}
+
+func TestParseWorkItemURL(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	routeName := "custom-test-route-parse-work-item-url"
+	RegisterAsKnownURL(routeName, `(?P<domain>planner.they.io)(?P<path>/)(?P<owner>[^/]+)/(?P<space>[^/]+)/plan/detail/(?P<number>\d+)`)
+	defer delete(knownURLs, routeName)
+
+	owner, spaceName, number, ok := ParseWorkItemURL("https://planner.they.io/jdoe/myspace/plan/detail/42")
+	require.True(t, ok)
+	assert.Equal(t, "jdoe", owner)
+	assert.Equal(t, "myspace", spaceName)
+	assert.Equal(t, 42, number)
+
+	_, _, _, ok = ParseWorkItemURL("https://example.com/jdoe/myspace/plan/detail/42")
+	assert.False(t, ok)
+}
+
+func TestParseSearchStringWorkItemURL(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	routeName := "custom-test-route-work-item-url"
+	RegisterAsKnownURL(routeName, `(?P<domain>planner.you.io)(?P<path>/)(?P<owner>[^/]+)/(?P<space>[^/]+)/plan/detail/(?P<number>\d+)`)
+	defer delete(knownURLs, routeName)
+
+	op, err := parseSearchString("crash https://planner.you.io/JDoe/MySpace/plan/detail/42")
+	require.Nil(t, err)
+	assert.Equal(t, searchKeyword{
+		words: []string{"crash:*"},
+		items: []workItemRef{{owner: "jdoe", space: "myspace", number: 42}},
+	}, op)
+
+	for _, query := range []string{
+		"crash -https://planner.you.io/jdoe/myspace/plan/detail/42",
+		"crash OR https://planner.you.io/jdoe/myspace/plan/detail/42",
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := parseSearchString(query)
+			require.NotNil(t, err)
+			assert.IsType(t, errs.BadParameterError{}, errors.Cause(err))
+		})
+	}
+}
+
+func TestRegisterKnownURLs(t *testing.T) {
+	resource.Require(t, resource.UnitTest)
+	t.Run("invalid regular expression", func(t *testing.T) {
+		err := RegisterKnownURLs(map[string]string{"custom-test-route-invalid": `(?P<domain>google.me.io`})
+		require.NotNil(t, err)
+		_, found := knownURLs["custom-test-route-invalid"]
+		assert.False(t, found)
+	})
+	t.Run("missing capture groups", func(t *testing.T) {
+		err := RegisterKnownURLs(map[string]string{"custom-test-route-invalid": `(?P<domain>google.me.io)(?P<path>/everything/)(?P<number>\d*)`})
+		require.NotNil(t, err)
+		_, found := knownURLs["custom-test-route-invalid"]
+		assert.False(t, found)
+	})
+	t.Run("missing owner capture group", func(t *testing.T) {
+		err := RegisterKnownURLs(map[string]string{"custom-test-route-invalid": `(?P<domain>google.me.io)(?P<path>/)(?P<space>[^/]+)/(?P<number>\d+)`})
+		require.NotNil(t, err)
+		_, found := knownURLs["custom-test-route-invalid"]
+		assert.False(t, found)
+	})
+	t.Run("ok", func(t *testing.T) {
+		err := RegisterKnownURLs(map[string]string{
+			"custom-test-route-id":     `(?P<domain>google.me.io)(?P<path>/everything/)(?P<id>\d*)`,
+			"custom-test-route-number": `(?P<domain>google.me.io)(?P<path>/)(?P<owner>[^/]+)/(?P<space>[^/]+)/(?P<number>\d+)`,
+		})
+		require.Nil(t, err)
+		assert.Contains(t, knownURLs, "custom-test-route-id")
+		assert.Contains(t, knownURLs, "custom-test-route-number")
+		delete(knownURLs, "custom-test-route-id")
+		delete(knownURLs, "custom-test-route-number")
+	})
+}
+
 func TestRegisterAsKnownURL(t *testing.T) {
 	resource.Require(t, resource.UnitTest)
 	// build 2 fake urls and cross check against RegisterAsKnownURL
@@ -493,6 +744,9 @@ func TestGetSearchQueryFromURLString(t *testing.T) {
 	searchQuery := getSearchQueryFromURLString("abcd.something.com")
 	assert.Equal(t, "abcd.something.com:*", searchQuery)
 
+	searchQuery = getSearchQueryFromURLString("abcd.something.com/a&b!c")
+	assert.Equal(t, "'abcd.something.com/a&b!c':*", searchQuery)
+
 	urlRegex := `(?P<domain>google.me.io)(?P<path>/everything/)(?P<id>\d*)`
 	routeName := "custom-test-route"
 	RegisterAsKnownURL(routeName, urlRegex)
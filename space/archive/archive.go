@@ -0,0 +1,195 @@
+package archive
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// FormatVersion is the version of the archives written by the export. It is
+// increased whenever the format changes in a way that older imports cannot
+// read.
+const FormatVersion = 1
+
+// Archive is the portable representation of a space along with everything
+// it contains. All the references between the entities use the IDs of the
+// installation the space was exported from.
+type Archive struct {
+	FormatVersion int       `json:"format_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Space         Space     `json:"space"`
+	// Identities are the identities referenced by the other entities
+	Identities []Identity `json:"identities,omitempty"`
+	// LinkCategories are the categories of the link types of the space
+	LinkCategories []LinkCategory `json:"link_categories,omitempty"`
+	// WorkItemTypes are the types of the space, base types first
+	WorkItemTypes []WorkItemType `json:"work_item_types,omitempty"`
+	LinkTypes     []LinkType     `json:"link_types,omitempty"`
+	// Areas and Iterations are ordered parents first
+	Areas      []Area      `json:"areas,omitempty"`
+	Iterations []Iteration `json:"iterations,omitempty"`
+	// WorkItems are ordered by number
+	WorkItems []WorkItem `json:"work_items,omitempty"`
+	// Links are the links between the work items of the space
+	Links []Link `json:"links,omitempty"`
+	// Comments are ordered by creation time, so that the comments come
+	// before their replies
+	Comments  []Comment  `json:"comments,omitempty"`
+	Codebases []Codebase `json:"codebases,omitempty"`
+}
+
+// Space holds the attributes of the exported space
+type Space struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+}
+
+// Identity is an identity referenced by the archive. It is mapped to the
+// identity with the same username, or else with the same email, when the
+// archive is imported.
+type Identity struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username,omitempty"`
+	Email    string    `json:"email,omitempty"`
+}
+
+// LinkCategory is a work item link category. It is mapped to the category
+// with the same ID, or else with the same name, when the archive is imported,
+// and the import fails if there is no such category.
+type LinkCategory struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+}
+
+// WorkItemType is a work item type of the space
+type WorkItemType struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Icon        string    `json:"icon"`
+	// Path is the ltree path of the type, whose leading elements are the IDs
+	// of the types it extends
+	Path   string                    `json:"path"`
+	Fields workitem.FieldDefinitions `json:"fields"`
+}
+
+// LinkType is a work item link type of the space
+type LinkType struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Description    *string   `json:"description,omitempty"`
+	Topology       string    `json:"topology"`
+	ForwardName    string    `json:"forward_name"`
+	ReverseName    string    `json:"reverse_name"`
+	LinkCategoryID uuid.UUID `json:"link_category"`
+}
+
+// Area is an area of the space. The root area has no parent.
+type Area struct {
+	ID       uuid.UUID  `json:"id"`
+	ParentID *uuid.UUID `json:"parent,omitempty"`
+	Name     string     `json:"name"`
+}
+
+// Iteration is an iteration of the space. The root iteration has no parent.
+type Iteration struct {
+	ID          uuid.UUID  `json:"id"`
+	ParentID    *uuid.UUID `json:"parent,omitempty"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	StartAt     *time.Time `json:"start_at,omitempty"`
+	EndAt       *time.Time `json:"end_at,omitempty"`
+	State       string     `json:"state"`
+}
+
+// WorkItem is a work item of the space along with its history
+type WorkItem struct {
+	ID     uuid.UUID `json:"id"`
+	Number int       `json:"number"`
+	Type   uuid.UUID `json:"type"`
+	// Revisions are ordered from the oldest to the latest one, whose fields
+	// are the current ones of the work item
+	Revisions []Revision `json:"revisions"`
+}
+
+// Revision is a revision of a work item, with the fields in their stored form
+type Revision struct {
+	Time       time.Time              `json:"time"`
+	Type       workitem.RevisionType  `json:"type"`
+	ModifierID uuid.UUID              `json:"modifier"`
+	Fields     map[string]interface{} `json:"fields"`
+}
+
+// Link is a link between two work items of the space
+type Link struct {
+	ID         uuid.UUID `json:"id"`
+	LinkTypeID uuid.UUID `json:"link_type"`
+	SourceID   uuid.UUID `json:"source"`
+	TargetID   uuid.UUID `json:"target"`
+}
+
+// Comment is a comment on a work item of the space
+type Comment struct {
+	ID              uuid.UUID  `json:"id"`
+	WorkItemID      uuid.UUID  `json:"work_item"`
+	ParentCommentID *uuid.UUID `json:"parent_comment,omitempty"`
+	CreatorID       uuid.UUID  `json:"creator"`
+	Body            string     `json:"body"`
+	Markup          string     `json:"markup,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Codebase is a codebase of the space
+type Codebase struct {
+	ID      uuid.UUID `json:"id"`
+	Type    string    `json:"type"`
+	URL     string    `json:"url"`
+	StackID *string   `json:"stack_id,omitempty"`
+}
+
+// Validate returns a BadParameterError if the archive cannot be imported
+func (a Archive) Validate() error {
+	if a.FormatVersion < 1 || a.FormatVersion > FormatVersion {
+		return errors.NewBadParameterError("format_version", a.FormatVersion).Expected("supported archive format version")
+	}
+	if uuid.Equal(a.Space.ID, uuid.Nil) {
+		return errors.NewBadParameterError("space.id", a.Space.ID).Expected("not nil")
+	}
+	if strings.TrimSpace(a.Space.Name) == "" {
+		return errors.NewBadParameterError("space.name", a.Space.Name).Expected("not empty")
+	}
+	if len(a.Areas) == 0 || len(a.Iterations) == 0 {
+		return errors.NewBadParameterError("areas & iterations", nil).Expected("the root area and the root iteration of the space")
+	}
+	areas := map[uuid.UUID]bool{}
+	for _, ar := range a.Areas {
+		if (ar.ParentID == nil) != (len(areas) == 0) || (ar.ParentID != nil && !areas[*ar.ParentID]) {
+			return errors.NewBadParameterError("areas", ar.ID).Expected("a single root area and parent areas before their children")
+		}
+		areas[ar.ID] = true
+	}
+	iterations := map[uuid.UUID]bool{}
+	for _, itr := range a.Iterations {
+		if (itr.ParentID == nil) != (len(iterations) == 0) || (itr.ParentID != nil && !iterations[*itr.ParentID]) {
+			return errors.NewBadParameterError("iterations", itr.ID).Expected("a single root iteration and parent iterations before their children")
+		}
+		iterations[itr.ID] = true
+	}
+	numbers := map[int]bool{}
+	for _, wi := range a.WorkItems {
+		if len(wi.Revisions) == 0 {
+			return errors.NewBadParameterError("work_items.revisions", wi.ID).Expected("at least one revision")
+		}
+		if numbers[wi.Number] {
+			return errors.NewBadParameterError("work_items.number", wi.Number).Expected("unique work item numbers")
+		}
+		numbers[wi.Number] = true
+	}
+	return nil
+}
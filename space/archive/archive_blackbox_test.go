@@ -0,0 +1,84 @@
+package archive_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArchive() archive.Archive {
+	rootArea := uuid.NewV4()
+	rootIteration := uuid.NewV4()
+	return archive.Archive{
+		FormatVersion: archive.FormatVersion,
+		Space:         archive.Space{ID: uuid.NewV4(), Name: "Exported"},
+		Areas: []archive.Area{
+			{ID: rootArea, Name: "Exported"},
+			{ID: uuid.NewV4(), ParentID: &rootArea, Name: "UI"},
+		},
+		Iterations: []archive.Iteration{
+			{ID: rootIteration, Name: "Exported"},
+			{ID: uuid.NewV4(), ParentID: &rootIteration, Name: "Sprint 1"},
+		},
+		WorkItems: []archive.WorkItem{
+			{ID: uuid.NewV4(), Number: 1, Type: uuid.NewV4(), Revisions: []archive.Revision{{}}},
+		},
+	}
+}
+
+func TestValidateArchive(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	t.Run("ok", func(t *testing.T) {
+		assert.Nil(t, newArchive().Validate())
+	})
+
+	t.Run("unsupported format version", func(t *testing.T) {
+		arc := newArchive()
+		arc.FormatVersion = archive.FormatVersion + 1
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+
+	t.Run("missing space name", func(t *testing.T) {
+		arc := newArchive()
+		arc.Space.Name = " "
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+
+	t.Run("missing root iteration", func(t *testing.T) {
+		arc := newArchive()
+		arc.Iterations = nil
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+
+	t.Run("child area before its parent", func(t *testing.T) {
+		arc := newArchive()
+		arc.Areas[0], arc.Areas[1] = arc.Areas[1], arc.Areas[0]
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+
+	t.Run("two root iterations", func(t *testing.T) {
+		arc := newArchive()
+		arc.Iterations[1].ParentID = nil
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+
+	t.Run("work item without revision", func(t *testing.T) {
+		arc := newArchive()
+		arc.WorkItems[0].Revisions = nil
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+
+	t.Run("duplicate work item number", func(t *testing.T) {
+		arc := newArchive()
+		arc.WorkItems = append(arc.WorkItems, archive.WorkItem{ID: uuid.NewV4(), Number: 1, Type: uuid.NewV4(), Revisions: []archive.Revision{{}}})
+		require.IsType(t, errors.BadParameterError{}, arc.Validate())
+	})
+}
@@ -0,0 +1,5 @@
+// Package archive contains the code that provides all the required
+// operations to move a space between installations, i.e. the format of the
+// portable archive of a space and the record of the entities imported from
+// such archives.
+package archive
@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// The kinds of the entities imported from an archive
+const (
+	KindSpace        = "space"
+	KindLinkCategory = "link_category"
+	KindWorkItemType = "work_item_type"
+	KindLinkType     = "link_type"
+	KindArea         = "area"
+	KindIteration    = "iteration"
+	KindWorkItem     = "work_item"
+	KindLink         = "link"
+	KindComment      = "comment"
+	KindCodebase     = "codebase"
+)
+
+// Mapping records that an entity of a space was imported from the entity of
+// an archive with the given kind and ID, so that importing the same archive
+// again does not duplicate it
+type Mapping struct {
+	// SpaceID is the ID of the space the archive was imported into
+	SpaceID  uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	Kind     string    `gorm:"primary_key"`
+	SourceID uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	TargetID uuid.UUID `sql:"type:uuid"`
+	// Number is the number of the imported work item in the space
+	Number    *int
+	CreatedAt time.Time
+}
+
+// TableName overrides the table name settings in Gorm to force a specific table name
+// in the database.
+func (m Mapping) TableName() string {
+	return "space_import_mappings"
+}
+
+// MappingRepository describes interactions with the record of the entities
+// imported from archives
+type MappingRepository interface {
+	// Lookup returns the entities of the given kind imported in the given
+	// space by the IDs of the archive they were imported from.
+	Lookup(ctx context.Context, spaceID uuid.UUID, kind string) (map[uuid.UUID]Mapping, error)
+	// LookupSpace returns the ID of the existing space which was imported
+	// from the space of an archive with the given ID, or nil.
+	LookupSpace(ctx context.Context, sourceID uuid.UUID) (*uuid.UUID, error)
+	Create(ctx context.Context, m *Mapping) error
+}
+
+// NewMappingRepository creates a new storage type.
+func NewMappingRepository(db *gorm.DB) MappingRepository {
+	return &GormMappingRepository{db: db}
+}
+
+// GormMappingRepository is the implementation of the storage interface for
+// the entities imported from archives.
+type GormMappingRepository struct {
+	db *gorm.DB
+}
+
+// Lookup returns the entities of the given kind imported in the given space
+// by the IDs of the archive they were imported from.
+func (r *GormMappingRepository) Lookup(ctx context.Context, spaceID uuid.UUID, kind string) (map[uuid.UUID]Mapping, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "space_import_mapping", "lookup"}, time.Now())
+	var mappings []Mapping
+	if err := r.db.Where("space_id = ? AND kind = ?", spaceID, kind).Find(&mappings).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	result := make(map[uuid.UUID]Mapping, len(mappings))
+	for _, m := range mappings {
+		result[m.SourceID] = m
+	}
+	return result, nil
+}
+
+// LookupSpace returns the ID of the existing space which was imported from
+// the space of an archive with the given ID, or nil.
+func (r *GormMappingRepository) LookupSpace(ctx context.Context, sourceID uuid.UUID) (*uuid.UUID, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "space_import_mapping", "lookupspace"}, time.Now())
+	var mappings []Mapping
+	err := r.db.Joins("JOIN spaces ON spaces.id = space_import_mappings.space_id AND spaces.deleted_at IS NULL").
+		Where("kind = ? AND source_id = ?", KindSpace, sourceID).
+		Order("space_import_mappings.created_at DESC").Limit(1).Find(&mappings).Error
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	return &mappings[0].SpaceID, nil
+}
+
+// Create records the given imported entity
+func (r *GormMappingRepository) Create(ctx context.Context, m *Mapping) error {
+	defer goa.MeasureSince([]string{"goa", "db", "space_import_mapping", "create"}, time.Now())
+	if err := r.db.Create(m).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id":  m.SpaceID,
+			"kind":      m.Kind,
+			"source_id": m.SourceID,
+			"err":       err,
+		}, "unable to record the imported entity")
+		return errors.NewInternalError(ctx, err)
+	}
+	return nil
+}
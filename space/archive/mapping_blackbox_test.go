@@ -0,0 +1,117 @@
+package archive_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type mappingRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo  archive.MappingRepository
+	clean func()
+}
+
+func TestRunMappingRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &mappingRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *mappingRepoBlackBoxTest) SetupTest() {
+	s.repo = archive.NewMappingRepository(s.DB)
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+}
+
+func (s *mappingRepoBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *mappingRepoBlackBoxTest) createSpace() *space.Space {
+	created, err := space.NewRepository(s.DB).Create(context.Background(), &space.Space{
+		Name: "Imported " + uuid.NewV4().String(),
+	})
+	require.Nil(s.T(), err)
+	return created
+}
+
+func (s *mappingRepoBlackBoxTest) TestCreateAndLookup() {
+	// given
+	ctx := context.Background()
+	sp := s.createSpace()
+	sourceID := uuid.NewV4()
+	number := 3
+	err := s.repo.Create(ctx, &archive.Mapping{
+		SpaceID:  sp.ID,
+		Kind:     archive.KindWorkItem,
+		SourceID: sourceID,
+		TargetID: uuid.NewV4(),
+		Number:   &number,
+	})
+	require.Nil(s.T(), err)
+	// when
+	mappings, err := s.repo.Lookup(ctx, sp.ID, archive.KindWorkItem)
+	// then
+	require.Nil(s.T(), err)
+	require.Contains(s.T(), mappings, sourceID)
+	assert.Equal(s.T(), 3, *mappings[sourceID].Number)
+
+	s.T().Run("other kind", func(t *testing.T) {
+		mappings, err := s.repo.Lookup(ctx, sp.ID, archive.KindComment)
+		require.Nil(t, err)
+		assert.Empty(t, mappings)
+	})
+
+	s.T().Run("duplicate", func(t *testing.T) {
+		err := s.repo.Create(ctx, &archive.Mapping{
+			SpaceID:  sp.ID,
+			Kind:     archive.KindWorkItem,
+			SourceID: sourceID,
+			TargetID: uuid.NewV4(),
+		})
+		require.NotNil(t, err)
+	})
+}
+
+func (s *mappingRepoBlackBoxTest) TestLookupSpace() {
+	// given
+	ctx := context.Background()
+	sp := s.createSpace()
+	sourceID := uuid.NewV4()
+	err := s.repo.Create(ctx, &archive.Mapping{
+		SpaceID:  sp.ID,
+		Kind:     archive.KindSpace,
+		SourceID: sourceID,
+		TargetID: sp.ID,
+	})
+	require.Nil(s.T(), err)
+
+	s.T().Run("imported", func(t *testing.T) {
+		spaceID, err := s.repo.LookupSpace(ctx, sourceID)
+		require.Nil(t, err)
+		require.NotNil(t, spaceID)
+		assert.Equal(t, sp.ID, *spaceID)
+	})
+
+	s.T().Run("not imported", func(t *testing.T) {
+		spaceID, err := s.repo.LookupSpace(ctx, uuid.NewV4())
+		require.Nil(t, err)
+		assert.Nil(t, spaceID)
+	})
+
+	s.T().Run("deleted space", func(t *testing.T) {
+		require.Nil(t, space.NewRepository(s.DB).Delete(ctx, sp.ID))
+		spaceID, err := s.repo.LookupSpace(ctx, sourceID)
+		require.Nil(t, err)
+		assert.Nil(t, spaceID)
+	})
+}
@@ -13,13 +13,20 @@ import (
 	"github.com/fabric8-services/fabric8-wit/codebase"
 	"github.com/fabric8-services/fabric8-wit/comment"
 	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/mention"
 	"github.com/fabric8-services/fabric8-wit/resource"
 	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/space/archive"
 	"github.com/fabric8-services/fabric8-wit/space/authz"
+	spacetemplate "github.com/fabric8-services/fabric8-wit/space/template"
 	testsupport "github.com/fabric8-services/fabric8-wit/test"
 	almtoken "github.com/fabric8-services/fabric8-wit/token"
 	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
 
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/require"
@@ -180,6 +187,54 @@ func (a *app) Codebases() codebase.Repository {
 	return nil
 }
 
+func (a *app) WorkItemLinkRevisions() link.RevisionRepository {
+	return nil
+}
+
+func (a *app) WorkItemEvents() event.Repository {
+	return nil
+}
+
+func (a *app) WorkItemTemplates() template.Repository {
+	return nil
+}
+
+func (a *app) Filters() filter.Repository {
+	return nil
+}
+
+func (a *app) CommentReactions() comment.ReactionRepository {
+	return nil
+}
+
+func (a *app) Mentions() mention.Repository {
+	return nil
+}
+
+func (a *app) WorkItemReferences() reference.Repository {
+	return nil
+}
+
+func (a *app) IterationCarryOvers() iteration.CarryOverRepository {
+	return nil
+}
+
+func (a *app) IterationCapacities() iteration.CapacityRepository {
+	return nil
+}
+
+func (a *app) SpaceTemplates() spacetemplate.Repository {
+	return nil
+}
+
+func (a *app) SpaceImportMappings() archive.MappingRepository {
+	return nil
+}
+
+func (a *app) WorkItemRevisions() workitem.RevisionRepository {
+	return nil
+}
+
 func (r *resourceRepo) Create(ctx context.Context, s *space.Resource) (*space.Resource, error) {
 	return nil, nil
 }
@@ -0,0 +1,107 @@
+package authz
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/application"
+	"github.com/fabric8-services/fabric8-wit/auth"
+	"github.com/fabric8-services/fabric8-wit/log"
+	tokencontext "github.com/fabric8-services/fabric8-wit/login/tokencontext"
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CollaboratorsService represents a service listing the space collaborators
+type CollaboratorsService interface {
+	Collaborators(ctx context.Context, request *goa.RequestData, spaceID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// KeycloakCollaboratorsService implements CollaboratorsService interface
+// with the users of the Keycloak policy of the space
+type KeycloakCollaboratorsService struct {
+	db            application.DB
+	policyManager auth.AuthzPolicyManager
+}
+
+// NewCollaboratorsService constructs a new KeycloakCollaboratorsService
+func NewCollaboratorsService(db application.DB, policyManager auth.AuthzPolicyManager) *KeycloakCollaboratorsService {
+	return &KeycloakCollaboratorsService{db: db, policyManager: policyManager}
+}
+
+// Collaborators returns the identity IDs of the collaborators of the given space
+func (s *KeycloakCollaboratorsService) Collaborators(ctx context.Context, request *goa.RequestData, spaceID uuid.UUID) ([]uuid.UUID, error) {
+	var policyID string
+	err := application.Transactional(s.db, func(appl application.Application) error {
+		resource, err := appl.SpaceResources().LoadBySpace(ctx, &spaceID)
+		if err != nil {
+			return err
+		}
+		policyID = resource.PolicyID
+		return nil
+	})
+	if err != nil {
+		return nil, errs.Wrapf(err, "unable to load the resource of space %s", spaceID)
+	}
+	policy, _, err := s.policyManager.GetPolicy(ctx, request, policyID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "unable to load the policy of space %s", spaceID)
+	}
+	//UsersIDs format : "[\"<ID>\",\"<ID>\"]"
+	var result []uuid.UUID
+	for _, id := range strings.Split(policy.Config.UserIDs, ",") {
+		id = strings.Trim(id, "[]\"")
+		if id == "" {
+			continue
+		}
+		uID, err := uuid.FromString(id)
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"identity_id": id,
+				"space_id":    spaceID,
+			}, "unable to convert the identity ID to uuid v4")
+			return nil, errs.Wrapf(err, "invalid identity ID in the policy of space %s", spaceID)
+		}
+		result = append(result, uID)
+	}
+	return result, nil
+}
+
+// InjectCollaboratorsService is a middleware responsible for setting up CollaboratorsService in the context for every request.
+func InjectCollaboratorsService(service CollaboratorsService) goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			return h(tokencontext.ContextWithCollaboratorsService(ctx, service), rw, req)
+		}
+	}
+}
+
+// Collaborators returns the identity IDs of the collaborators of the given
+// space, which always include the space owner. Only the owner is returned if
+// no collaborators service was set up in the context.
+func Collaborators(ctx context.Context, appl application.Application, spaceID uuid.UUID) ([]uuid.UUID, error) {
+	s, err := appl.Spaces().Load(ctx, spaceID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "unable to load space %s", spaceID)
+	}
+	ownerID := s.OwnerId
+	srv := tokencontext.ReadCollaboratorsServiceFromContext(ctx)
+	if srv == nil {
+		log.Warn(ctx, map[string]interface{}{
+			"space_id": spaceID,
+		}, "missing collaborators service, only the space owner is a collaborator")
+		return []uuid.UUID{ownerID}, nil
+	}
+	collaborators, err := srv.(CollaboratorsService).Collaborators(ctx, goa.ContextRequest(ctx), spaceID)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	for _, id := range collaborators {
+		if uuid.Equal(id, ownerID) {
+			return collaborators, nil
+		}
+	}
+	return append(collaborators, ownerID), nil
+}
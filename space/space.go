@@ -2,6 +2,7 @@ package space
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -22,6 +23,10 @@ var (
 	SpaceType   = "spaces"
 )
 
+// DefaultTextSearchConfig is the PostgreSQL text search configuration of the
+// spaces that do not specify one
+const DefaultTextSearchConfig = "english"
+
 // Space represents a Space on the domain and db layer
 type Space struct {
 	gormsupport.Lifecycle
@@ -30,6 +35,13 @@ type Space struct {
 	Name        string
 	Description string
 	OwnerId     uuid.UUID `sql:"type:uuid"` // Belongs To Identity
+	// TextSearchConfig is the PostgreSQL text search configuration (e.g.
+	// "english" or "german") used to index and search the work items and the
+	// comments of the space
+	TextSearchConfig string
+	// Archived spaces are read-only: their work items, comments, iterations
+	// and areas cannot be changed until the space is unarchived
+	Archived bool
 }
 
 // Ensure Fields implements the Equaler interface
@@ -58,6 +70,12 @@ func (p Space) Equal(u convert.Equaler) bool {
 	if !uuid.Equal(p.OwnerId, other.OwnerId) {
 		return false
 	}
+	if p.TextSearchConfig != other.TextSearchConfig {
+		return false
+	}
+	if p.Archived != other.Archived {
+		return false
+	}
 	return true
 }
 
@@ -80,8 +98,8 @@ type Repository interface {
 	Delete(ctx context.Context, ID uuid.UUID) error
 	LoadByOwner(ctx context.Context, userID *uuid.UUID, start *int, length *int) ([]Space, uint64, error)
 	LoadByOwnerAndName(ctx context.Context, userID *uuid.UUID, spaceName *string) (*Space, error)
-	List(ctx context.Context, start *int, length *int) ([]Space, uint64, error)
-	Search(ctx context.Context, q *string, start *int, length *int) ([]Space, uint64, error)
+	List(ctx context.Context, archived *bool, start *int, length *int) ([]Space, uint64, error)
+	Search(ctx context.Context, q *string, archived *bool, start *int, length *int) ([]Space, uint64, error)
 }
 
 // NewRepository creates a new space repo
@@ -176,6 +194,9 @@ func (r *GormRepository) Save(ctx context.Context, p *Space) (*Space, error) {
 		}, "unable to find the space by ID")
 		return nil, errors.NewInternalError(ctx, err)
 	}
+	if err := r.checkTextSearchConfig(ctx, p); err != nil {
+		return nil, err
+	}
 	tx = tx.Where("Version = ?", oldVersion).Save(p)
 	if err := tx.Error; err != nil {
 		if gormsupport.IsCheckViolation(tx.Error, "spaces_name_check") {
@@ -209,6 +230,9 @@ func (r *GormRepository) Create(ctx context.Context, space *Space) (*Space, erro
 	if space.ID == uuid.Nil {
 		space.ID = uuid.NewV4()
 	}
+	if err := r.checkTextSearchConfig(ctx, space); err != nil {
+		return nil, err
+	}
 
 	tx := r.db.Create(space)
 	if err := tx.Error; err != nil {
@@ -227,9 +251,26 @@ func (r *GormRepository) Create(ctx context.Context, space *Space) (*Space, erro
 	return space, nil
 }
 
+// checkTextSearchConfig sets the default text search configuration of the
+// given space if it has none, and returns a BadParameterError if PostgreSQL
+// does not know its configuration
+func (r *GormRepository) checkTextSearchConfig(ctx context.Context, space *Space) error {
+	if space.TextSearchConfig == "" {
+		space.TextSearchConfig = DefaultTextSearchConfig
+	}
+	var count int
+	if err := r.db.Table("pg_ts_config").Where("cfgname = ?", space.TextSearchConfig).Count(&count).Error; err != nil {
+		return errors.NewInternalError(ctx, err)
+	}
+	if count == 0 {
+		return errors.NewBadParameterError("TextSearchConfig", space.TextSearchConfig).Expected("PostgreSQL text search configuration, e.g. english, german or simple")
+	}
+	return nil
+}
+
 // extracted this function from List() in order to close the rows object with "defer" for more readability
 // workaround for https://github.com/lib/pq/issues/81
-func (r *GormRepository) listSpaceFromDB(ctx context.Context, q *string, userID *uuid.UUID, start *int, limit *int) ([]Space, uint64, error) {
+func (r *GormRepository) listSpaceFromDB(ctx context.Context, q *string, userID *uuid.UUID, archived *bool, start *int, limit *int) ([]Space, uint64, error) {
 	db := r.db.Model(&Space{})
 	orgDB := db
 	if start != nil {
@@ -246,12 +287,14 @@ func (r *GormRepository) listSpaceFromDB(ctx context.Context, q *string, userID
 	}
 	db = db.Select("count(*) over () as cnt2 , *")
 	if q != nil {
-		db = db.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(*q)+"%")
-		db = db.Or("LOWER(description) LIKE ?", "%"+strings.ToLower(*q)+"%")
+		db = db.Where("(LOWER(name) LIKE ? OR LOWER(description) LIKE ?)", "%"+strings.ToLower(*q)+"%", "%"+strings.ToLower(*q)+"%")
 	}
 	if userID != nil {
 		db = db.Where("spaces.owner_id=?", userID)
 	}
+	if archived != nil {
+		db = db.Where("spaces.archived=?", *archived)
+	}
 
 	rows, err := db.Rows()
 	if err != nil {
@@ -314,19 +357,20 @@ func (r *GormRepository) listSpaceFromDB(ctx context.Context, q *string, userID
 	return result, count, nil
 }
 
-// List returns work item selected by the given criteria.Expression, starting with start (zero-based) and returning at most limit items
-func (r *GormRepository) List(ctx context.Context, start *int, limit *int) ([]Space, uint64, error) {
+// List returns work item selected by the given criteria.Expression, starting with start (zero-based) and returning at most limit items.
+// Only the archived or the unarchived spaces are returned if archived is not nil.
+func (r *GormRepository) List(ctx context.Context, archived *bool, start *int, limit *int) ([]Space, uint64, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "space", "list"}, time.Now())
-	result, count, err := r.listSpaceFromDB(ctx, nil, nil, start, limit)
+	result, count, err := r.listSpaceFromDB(ctx, nil, nil, archived, start, limit)
 	if err != nil {
 		return nil, 0, errs.WithStack(err)
 	}
 	return result, count, nil
 }
 
-func (r *GormRepository) Search(ctx context.Context, q *string, start *int, limit *int) ([]Space, uint64, error) {
+func (r *GormRepository) Search(ctx context.Context, q *string, archived *bool, start *int, limit *int) ([]Space, uint64, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "space", "search"}, time.Now())
-	result, count, err := r.listSpaceFromDB(ctx, q, nil, start, limit)
+	result, count, err := r.listSpaceFromDB(ctx, q, nil, archived, start, limit)
 	if err != nil {
 		return nil, 0, errs.WithStack(err)
 	}
@@ -334,7 +378,7 @@ func (r *GormRepository) Search(ctx context.Context, q *string, start *int, limi
 }
 
 func (r *GormRepository) LoadByOwner(ctx context.Context, userID *uuid.UUID, start *int, limit *int) ([]Space, uint64, error) {
-	result, count, err := r.listSpaceFromDB(ctx, nil, userID, start, limit)
+	result, count, err := r.listSpaceFromDB(ctx, nil, userID, nil, start, limit)
 	if err != nil {
 		return nil, 0, errs.WithStack(err)
 	}
@@ -362,3 +406,53 @@ func (r *GormRepository) LoadByOwnerAndName(ctx context.Context, userID *uuid.UU
 	}
 	return &res, nil
 }
+
+// CheckNotArchived returns a ForbiddenError if the space with the given ID is
+// archived, since the content of an archived space is read-only
+func CheckNotArchived(ctx context.Context, db *gorm.DB, spaceID uuid.UUID) error {
+	var archived []bool
+	if err := db.Model(&Space{}).Where("id = ?", spaceID).Pluck("archived", &archived).Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":      err,
+			"space_id": spaceID,
+		}, "unable to check if the space is archived")
+		return errors.NewInternalError(ctx, err)
+	}
+	if len(archived) > 0 && archived[0] {
+		return archivedError(spaceID)
+	}
+	return nil
+}
+
+// CheckWorkItemsNotArchived returns a ForbiddenError if one of the work items
+// with the given IDs belongs to an archived space
+func CheckWorkItemsNotArchived(ctx context.Context, db *gorm.DB, workItemIDs ...uuid.UUID) error {
+	var archived []uuid.UUID
+	err := db.Model(&Space{}).Where("archived AND id IN (SELECT space_id FROM work_items WHERE id IN (?))", workItemIDs).Pluck("id", &archived).Error
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"err":           err,
+			"work_item_ids": workItemIDs,
+		}, "unable to check if the spaces of the work items are archived")
+		return errors.NewInternalError(ctx, err)
+	}
+	if len(archived) > 0 {
+		return archivedError(archived[0])
+	}
+	return nil
+}
+
+// CheckNotArchived returns a ForbiddenError if the space is archived, since
+// the content of an archived space is read-only
+func (s Space) CheckNotArchived() error {
+	if s.Archived {
+		return archivedError(s.ID)
+	}
+	return nil
+}
+
+// archivedError returns the error of the changes to the content of the
+// archived space with the given ID
+func archivedError(spaceID uuid.UUID) error {
+	return errors.NewForbiddenError(fmt.Sprintf("space %s is archived and read-only, it must be unarchived before changing its content", spaceID))
+}
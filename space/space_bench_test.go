@@ -77,7 +77,7 @@ func (bench *repoSpaceBench) BenchmarkList() {
 	bench.B().ResetTimer()
 	bench.B().ReportAllocs()
 	for n := 0; n < bench.B().N; n++ {
-		if s, _, err := bench.repo.List(context.Background(), nil, nil); err != nil || (err == nil && len(s) == 0) {
+		if s, _, err := bench.repo.List(context.Background(), nil, nil, nil); err != nil || (err == nil && len(s) == 0) {
 			bench.B().Fail()
 		}
 	}
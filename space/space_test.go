@@ -105,6 +105,41 @@ func (test *repoBBTest) TestSaveFail() {
 	expectSpace(test.save(*p1), test.assertBadParameter())
 }
 
+func (test *repoBBTest) TestTextSearchConfig() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+
+	t.Run("default", func(t *testing.T) {
+		res, err := test.repo.Create(context.Background(), &space.Space{Name: uuid.NewV4().String()})
+		require.Nil(t, err)
+		assert.Equal(t, space.DefaultTextSearchConfig, res.TextSearchConfig)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		res, err := test.repo.Create(context.Background(), &space.Space{Name: uuid.NewV4().String(), TextSearchConfig: "german"})
+		require.Nil(t, err)
+		res2, err := test.repo.Load(context.Background(), res.ID)
+		require.Nil(t, err)
+		assert.Equal(t, "german", res2.TextSearchConfig)
+
+		res2.TextSearchConfig = "simple"
+		res3, err := test.repo.Save(context.Background(), res2)
+		require.Nil(t, err)
+		assert.Equal(t, "simple", res3.TextSearchConfig)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := test.repo.Create(context.Background(), &space.Space{Name: uuid.NewV4().String(), TextSearchConfig: "klingon"})
+		require.IsType(t, errors.BadParameterError{}, err)
+
+		res, err := test.repo.Create(context.Background(), &space.Space{Name: uuid.NewV4().String()})
+		require.Nil(t, err)
+		res.TextSearchConfig = "klingon"
+		_, err = test.repo.Save(context.Background(), res)
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+}
+
 func (test *repoBBTest) TestSaveNew() {
 	p := space.Space{
 		ID:      uuid.NewV4(),
@@ -148,6 +183,59 @@ func (test *repoBBTest) TestList() {
 	assert.True(test.T(), foundNewSpaceInList)
 }
 
+func (test *repoBBTest) TestArchived() {
+	t := test.T()
+	resource.Require(t, resource.Database)
+	// given
+	ctx := context.Background()
+	active, err := test.repo.Create(ctx, &space.Space{Name: "active " + uuid.NewV4().String()})
+	require.Nil(t, err)
+	archived, err := test.repo.Create(ctx, &space.Space{Name: "archived " + uuid.NewV4().String()})
+	require.Nil(t, err)
+	archived.Archived = true
+	archived, err = test.repo.Save(ctx, archived)
+	require.Nil(t, err)
+	ids := func(spaces []space.Space) map[uuid.UUID]bool {
+		result := map[uuid.UUID]bool{}
+		for _, s := range spaces {
+			result[s.ID] = true
+		}
+		return result
+	}
+
+	t.Run("list", func(t *testing.T) {
+		notArchived := false
+		spaces, _, err := test.repo.List(ctx, &notArchived, nil, nil)
+		require.Nil(t, err)
+		assert.True(t, ids(spaces)[active.ID])
+		assert.False(t, ids(spaces)[archived.ID])
+		onlyArchived := true
+		spaces, _, err = test.repo.List(ctx, &onlyArchived, nil, nil)
+		require.Nil(t, err)
+		assert.False(t, ids(spaces)[active.ID])
+		assert.True(t, ids(spaces)[archived.ID])
+	})
+
+	t.Run("search", func(t *testing.T) {
+		q := archived.Name
+		notArchived := false
+		spaces, _, err := test.repo.Search(ctx, &q, &notArchived, nil, nil)
+		require.Nil(t, err)
+		assert.Empty(t, spaces)
+		spaces, _, err = test.repo.Search(ctx, &q, nil, nil, nil)
+		require.Nil(t, err)
+		assert.True(t, ids(spaces)[archived.ID])
+	})
+
+	t.Run("check not archived", func(t *testing.T) {
+		assert.Nil(t, space.CheckNotArchived(ctx, test.DB, active.ID))
+		assert.IsType(t, errors.ForbiddenError{}, space.CheckNotArchived(ctx, test.DB, archived.ID))
+		assert.Nil(t, space.CheckNotArchived(ctx, test.DB, uuid.NewV4()))
+		assert.Nil(t, active.CheckNotArchived())
+		assert.IsType(t, errors.ForbiddenError{}, archived.CheckNotArchived())
+	})
+}
+
 func (test *repoBBTest) TestListDoNotReturnPointerToSameObject() {
 	expectSpace(test.create(testSpace), test.requireOk)
 	expectSpace(test.create(testSpace2), test.requireOk)
@@ -241,5 +329,5 @@ func (test *repoBBTest) delete(id uuid.UUID) func() (*space.Space, error) {
 }
 
 func (test *repoBBTest) list(start *int, length *int) ([]space.Space, uint64, error) {
-	return test.repo.List(context.Background(), start, length)
+	return test.repo.List(context.Background(), nil, start, length)
 }
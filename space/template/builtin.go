@@ -0,0 +1,152 @@
+package template
+
+import (
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// The IDs of the templates available in every installation
+var (
+	SystemScrum         = uuid.FromStringOrNil("6b8bd2c4-2bda-4a64-a0d6-9e0d5c3f1a01")
+	SystemKanban        = uuid.FromStringOrNil("0d5e2a9b-63a4-4f2e-8c2f-2d6c9b1e7a02")
+	SystemIssueTracking = uuid.FromStringOrNil("f3c1b7e0-8a5d-4c6b-9e4f-5a7d2c8b1e03")
+)
+
+// BuiltIn returns the templates available in every installation
+func BuiltIn() []Template {
+	return []Template{scrum(), kanban(), issueTracking()}
+}
+
+func scrum() Template {
+	description := "Plan the work of the team in two weeks sprints, breaking down epics into user stories and tasks."
+	return Template{
+		ID:          SystemScrum,
+		Name:        "Scrum",
+		Description: &description,
+		Definition: Definition{
+			WorkItemTypes: []WorkItemType{
+				{
+					Name:   "Epic",
+					Icon:   "fa fa-flag",
+					States: []string{workitem.SystemStateNew, workitem.SystemStateInProgress, workitem.SystemStateClosed},
+				},
+				{
+					Name:   "User Story",
+					Icon:   "fa fa-bookmark",
+					States: []string{workitem.SystemStateNew, "ready", workitem.SystemStateInProgress, "in review", workitem.SystemStateClosed},
+					Fields: workitem.FieldDefinitions{
+						"storypoints": {
+							Type:        workitem.SimpleType{Kind: workitem.KindFloat},
+							Label:       "Story Points",
+							Description: "The estimated effort of the user story",
+						},
+					},
+				},
+				{
+					Name:   "Task",
+					Icon:   "fa fa-tasks",
+					States: []string{workitem.SystemStateNew, workitem.SystemStateInProgress, workitem.SystemStateClosed},
+					Fields: workitem.FieldDefinitions{
+						"hours": {
+							Type:        workitem.SimpleType{Kind: workitem.KindFloat},
+							Label:       "Hours",
+							Description: "The estimated effort of the task in hours",
+						},
+					},
+				},
+				{
+					Name: "Bug",
+					Icon: "fa fa-bug",
+				},
+			},
+			LinkTypes: []LinkType{
+				duplicateLinkType(),
+			},
+			Cadence: &Cadence{
+				Name:  "Sprint",
+				Days:  14,
+				Count: 4,
+			},
+		},
+	}
+}
+
+func kanban() Template {
+	description := "Pull cards through the columns of a continuous flow, without iterations."
+	return Template{
+		ID:          SystemKanban,
+		Name:        "Kanban",
+		Description: &description,
+		Definition: Definition{
+			WorkItemTypes: []WorkItemType{
+				{
+					Name:   "Card",
+					Icon:   "fa fa-sticky-note",
+					States: []string{workitem.SystemStateNew, "ready", workitem.SystemStateInProgress, "in review", workitem.SystemStateClosed},
+				},
+			},
+			LinkTypes: []LinkType{
+				{
+					Name:           "Blocker",
+					Topology:       link.TopologyDependency,
+					ForwardName:    "blocks",
+					ReverseName:    "blocked by",
+					LinkCategoryID: link.SystemWorkItemLinkCategoryUserID,
+				},
+			},
+		},
+	}
+}
+
+func issueTracking() Template {
+	description := "Triage and resolve the issues reported on the components of a project."
+	return Template{
+		ID:          SystemIssueTracking,
+		Name:        "Issue Tracking",
+		Description: &description,
+		Definition: Definition{
+			WorkItemTypes: []WorkItemType{
+				{
+					Name: "Issue",
+					Icon: "fa fa-exclamation-circle",
+					Fields: workitem.FieldDefinitions{
+						"severity": {
+							Type: workitem.EnumType{
+								SimpleType: workitem.SimpleType{Kind: workitem.KindEnum},
+								BaseType:   workitem.SimpleType{Kind: workitem.KindString},
+								Values:     []interface{}{"low", "medium", "high", "urgent"},
+							},
+							Label:       "Severity",
+							Description: "The impact of the issue",
+						},
+					},
+				},
+				{
+					Name:   "Question",
+					Icon:   "fa fa-question-circle",
+					States: []string{workitem.SystemStateNew, workitem.SystemStateOpen, workitem.SystemStateClosed},
+				},
+			},
+			LinkTypes: []LinkType{
+				duplicateLinkType(),
+			},
+			Areas: Areas{
+				{Name: "Frontend"},
+				{Name: "Backend"},
+				{Name: "Documentation"},
+			},
+		},
+	}
+}
+
+func duplicateLinkType() LinkType {
+	return LinkType{
+		Name:           "Duplicate",
+		Topology:       link.TopologyNetwork,
+		ForwardName:    "duplicates",
+		ReverseName:    "is duplicated by",
+		LinkCategoryID: link.SystemWorkItemLinkCategoryUserID,
+	}
+}
@@ -0,0 +1,5 @@
+// Package template contains the code that provides all the required
+// operations to manage space templates, i.e. named process presets (work item
+// types and their states, link types, areas and iteration cadence) that are
+// set up in a space when it is created.
+package template
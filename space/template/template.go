@@ -0,0 +1,204 @@
+package template
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// APIStringTypeSpaceTemplates is the JSONAPI type of space templates
+	APIStringTypeSpaceTemplates = "spacetemplates"
+	spaceTemplateTableName      = "space_templates"
+	// MaxCadenceDays is the maximum length of the iterations of a cadence
+	MaxCadenceDays = 365
+	// MaxCadenceCount is the maximum number of iterations of a cadence
+	MaxCadenceCount = 100
+)
+
+// Template is a named process preset that is set up in a space when it is
+// created
+type Template struct {
+	gormsupport.Lifecycle
+	// ID
+	ID uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	// Name is the unique name of this template
+	Name string
+	// Description is an optional description of the template
+	Description *string
+	// Version for optimistic concurrency control
+	Version int
+	// Definition is the process set up by the template
+	Definition Definition `sql:"type:jsonb"`
+}
+
+// TableName implements gorm.tabler
+func (t Template) TableName() string {
+	return spaceTemplateTableName
+}
+
+// Definition describes the process which a template sets up in a space
+type Definition struct {
+	// WorkItemTypes are the work item types created in the space
+	WorkItemTypes []WorkItemType `json:"work_item_types,omitempty"`
+	// LinkTypes are the work item link types created in the space
+	LinkTypes []LinkType `json:"link_types,omitempty"`
+	// Areas are the areas created below the root area of the space
+	Areas Areas `json:"areas,omitempty"`
+	// Cadence describes the iterations created below the root iteration of
+	// the space, if any
+	Cadence *Cadence `json:"cadence,omitempty"`
+}
+
+// WorkItemType is a work item type created by a template
+type WorkItemType struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Icon        string  `json:"icon"`
+	// ExtendedTypeID is the ID of the type of the system space whose fields
+	// are inherited. It defaults to the planner item.
+	ExtendedTypeID *uuid.UUID `json:"extended_type,omitempty"`
+	// States are the values of the state field, in workflow order. The
+	// states of the extended type are kept when empty.
+	States []string `json:"states,omitempty"`
+	// Fields are the fields added to (or overriding) the ones of the extended
+	// type
+	Fields workitem.FieldDefinitions `json:"fields,omitempty"`
+}
+
+// LinkType is a work item link type created by a template
+type LinkType struct {
+	Name           string    `json:"name"`
+	Description    *string   `json:"description,omitempty"`
+	Topology       string    `json:"topology"`
+	ForwardName    string    `json:"forward_name"`
+	ReverseName    string    `json:"reverse_name"`
+	LinkCategoryID uuid.UUID `json:"link_category"`
+}
+
+// Area is an area created by a template, along with its child areas
+type Area struct {
+	Name     string `json:"name"`
+	Children Areas  `json:"children,omitempty"`
+}
+
+// Areas is a list of areas of a template
+type Areas []Area
+
+// Cadence describes a series of consecutive iterations of the same length,
+// the first one starting on the day the space is created
+type Cadence struct {
+	// Name is the prefix of the names of the iterations, which are numbered
+	// from 1 (e.g. "Sprint 1")
+	Name string `json:"name"`
+	// Days is the length of each iteration in days
+	Days int `json:"days"`
+	// Count is the number of iterations to create
+	Count int `json:"count"`
+}
+
+// Value implements the driver.Valuer interface
+func (d Definition) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+// Scan implements the sql.Scanner interface
+func (d *Definition) Scan(src interface{}) error {
+	if src == nil {
+		*d = Definition{}
+		return nil
+	}
+	data, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("unable to scan space template definition from %T", src)
+	}
+	return json.Unmarshal(data, d)
+}
+
+// Validate returns a BadParameterError if the definition cannot be set up in
+// a space
+func (d Definition) Validate() error {
+	names := map[string]bool{}
+	for _, wit := range d.WorkItemTypes {
+		if strings.TrimSpace(wit.Name) == "" {
+			return errors.NewBadParameterError("work_item_types.name", wit.Name).Expected("not empty")
+		}
+		if names[wit.Name] {
+			return errors.NewBadParameterError("work_item_types.name", wit.Name).Expected("unique")
+		}
+		names[wit.Name] = true
+		if len(wit.States) == 0 {
+			continue
+		}
+		states := map[string]bool{}
+		for _, state := range wit.States {
+			if strings.TrimSpace(state) == "" || states[state] {
+				return errors.NewBadParameterError("work_item_types.states", wit.States).Expected("unique and not empty states")
+			}
+			states[state] = true
+		}
+		// the iterations and their reports rely on the closed state
+		if !states[workitem.SystemStateClosed] {
+			return errors.NewBadParameterError("work_item_types.states", wit.States).Expected("states including " + workitem.SystemStateClosed)
+		}
+	}
+	names = map[string]bool{}
+	for _, lt := range d.LinkTypes {
+		if strings.TrimSpace(lt.Name) == "" || strings.TrimSpace(lt.ForwardName) == "" || strings.TrimSpace(lt.ReverseName) == "" {
+			return errors.NewBadParameterError("link_types", lt.Name).Expected("not empty name, forward name and reverse name")
+		}
+		if names[lt.Name] {
+			return errors.NewBadParameterError("link_types.name", lt.Name).Expected("unique")
+		}
+		names[lt.Name] = true
+		if err := link.CheckValidTopology(lt.Topology); err != nil {
+			return err
+		}
+		if uuid.Equal(lt.LinkCategoryID, uuid.Nil) {
+			return errors.NewBadParameterError("link_types.link_category", lt.LinkCategoryID)
+		}
+	}
+	if err := d.Areas.validate(); err != nil {
+		return err
+	}
+	if c := d.Cadence; c != nil {
+		if strings.TrimSpace(c.Name) == "" {
+			return errors.NewBadParameterError("cadence.name", c.Name).Expected("not empty")
+		}
+		if c.Days < 1 || c.Days > MaxCadenceDays {
+			return errors.NewBadParameterError("cadence.days", c.Days).Expected(fmt.Sprintf("between 1 and %d days", MaxCadenceDays))
+		}
+		if c.Count < 1 || c.Count > MaxCadenceCount {
+			return errors.NewBadParameterError("cadence.count", c.Count).Expected(fmt.Sprintf("between 1 and %d iterations", MaxCadenceCount))
+		}
+	}
+	return nil
+}
+
+// validate checks that the areas, and the children of each area, have unique
+// and not empty names
+func (a Areas) validate() error {
+	names := map[string]bool{}
+	for _, area := range a {
+		if strings.TrimSpace(area.Name) == "" {
+			return errors.NewBadParameterError("areas.name", area.Name).Expected("not empty")
+		}
+		if names[area.Name] {
+			return errors.NewBadParameterError("areas.name", area.Name).Expected("unique among sibling areas")
+		}
+		names[area.Name] = true
+		if err := area.Children.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package template
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository encapsulates storage & retrieval of space templates
+type Repository interface {
+	repository.Exister
+	Create(ctx context.Context, tpl *Template) (*Template, error)
+	Load(ctx context.Context, ID uuid.UUID) (*Template, error)
+	List(ctx context.Context) ([]Template, error)
+	Save(ctx context.Context, tpl Template) (*Template, error)
+}
+
+// NewRepository creates a space template repository based on gorm
+func NewRepository(db *gorm.DB) *GormTemplateRepository {
+	return &GormTemplateRepository{db}
+}
+
+// GormTemplateRepository implements Repository using gorm
+type GormTemplateRepository struct {
+	db *gorm.DB
+}
+
+// Create creates a new space template in the repository.
+// Returns BadParameterError or InternalError
+func (r *GormTemplateRepository) Create(ctx context.Context, tpl *Template) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplate", "create"}, time.Now())
+	if strings.TrimSpace(tpl.Name) == "" {
+		return nil, errors.NewBadParameterError("name", tpl.Name)
+	}
+	if err := tpl.Definition.Validate(); err != nil {
+		return nil, err
+	}
+	if tpl.ID == uuid.Nil {
+		tpl.ID = uuid.NewV4()
+	}
+	db := r.db.Create(tpl)
+	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "space_templates_name_unique") {
+			return nil, errors.NewBadParameterError("name", tpl.Name).Expected("unique")
+		}
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_template_id": tpl.ID,
+	}, "space template created")
+	return tpl, nil
+}
+
+// Load returns the space template for the given ID.
+// Returns NotFoundError or InternalError
+func (r *GormTemplateRepository) Load(ctx context.Context, ID uuid.UUID) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplate", "load"}, time.Now())
+	result := Template{}
+	db := r.db.Model(&result).Where("id = ?", ID).First(&result)
+	if db.RecordNotFound() {
+		log.Error(ctx, map[string]interface{}{
+			"space_template_id": ID,
+		}, "space template not found")
+		return nil, errors.NewNotFoundError("space template", ID.String())
+	}
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return &result, nil
+}
+
+// CheckExists returns nil if the given ID exists otherwise returns an error
+func (r *GormTemplateRepository) CheckExists(ctx context.Context, id string) error {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplate", "exists"}, time.Now())
+	return repository.CheckExists(ctx, r.db, spaceTemplateTableName, id)
+}
+
+// List returns all space templates, ordered by name
+func (r *GormTemplateRepository) List(ctx context.Context) ([]Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplate", "list"}, time.Now())
+	var rows []Template
+	db := r.db.Order("name").Find(&rows)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return rows, nil
+}
+
+// Save updates the given space template in storage. Version must be the
+// same as the one in the stored version.
+// returns NotFoundError, VersionConflictError, BadParameterError or InternalError
+func (r *GormTemplateRepository) Save(ctx context.Context, tpl Template) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "spacetemplate", "save"}, time.Now())
+	if strings.TrimSpace(tpl.Name) == "" {
+		return nil, errors.NewBadParameterError("name", tpl.Name)
+	}
+	if err := tpl.Definition.Validate(); err != nil {
+		return nil, err
+	}
+	existing, err := r.Load(ctx, tpl.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != tpl.Version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	tpl.CreatedAt = existing.CreatedAt
+	tpl.Version = tpl.Version + 1
+	db := r.db.Where("version = ?", existing.Version).Save(&tpl)
+	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "space_templates_name_unique") {
+			return nil, errors.NewBadParameterError("name", tpl.Name).Expected("unique")
+		}
+		log.Error(ctx, map[string]interface{}{
+			"space_template_id": tpl.ID,
+			"err":               db.Error,
+		}, "unable to save space template")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	log.Info(ctx, map[string]interface{}{
+		"space_template_id": tpl.ID,
+	}, "space template updated")
+	return &tpl, nil
+}
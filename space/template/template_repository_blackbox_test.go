@@ -0,0 +1,214 @@
+package template_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space/template"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestValidateDefinition(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+
+	t.Run("built-in templates", func(t *testing.T) {
+		for _, tpl := range template.BuiltIn() {
+			assert.Nil(t, tpl.Definition.Validate(), tpl.Name)
+		}
+	})
+
+	t.Run("duplicate work item type", func(t *testing.T) {
+		def := template.Definition{
+			WorkItemTypes: []template.WorkItemType{{Name: "Story", Icon: "fa"}, {Name: "Story", Icon: "fa"}},
+		}
+		require.IsType(t, errors.BadParameterError{}, def.Validate())
+	})
+
+	t.Run("states without closed", func(t *testing.T) {
+		def := template.Definition{
+			WorkItemTypes: []template.WorkItemType{{Name: "Story", Icon: "fa", States: []string{"new", "done"}}},
+		}
+		require.IsType(t, errors.BadParameterError{}, def.Validate())
+	})
+
+	t.Run("invalid topology", func(t *testing.T) {
+		def := template.Definition{
+			LinkTypes: []template.LinkType{{
+				Name:           "Blocker",
+				Topology:       "star",
+				ForwardName:    "blocks",
+				ReverseName:    "is blocked by",
+				LinkCategoryID: link.SystemWorkItemLinkCategoryUserID,
+			}},
+		}
+		require.IsType(t, errors.BadParameterError{}, def.Validate())
+	})
+
+	t.Run("duplicate sibling areas", func(t *testing.T) {
+		def := template.Definition{
+			Areas: template.Areas{{Name: "UI", Children: template.Areas{{Name: "Web"}, {Name: "Web"}}}},
+		}
+		require.IsType(t, errors.BadParameterError{}, def.Validate())
+	})
+
+	t.Run("empty cadence", func(t *testing.T) {
+		def := template.Definition{
+			Cadence: &template.Cadence{Name: "Sprint", Days: 0, Count: 2},
+		}
+		require.IsType(t, errors.BadParameterError{}, def.Validate())
+	})
+
+	t.Run("too long cadence", func(t *testing.T) {
+		for _, c := range []template.Cadence{
+			{Name: "Sprint", Days: template.MaxCadenceDays + 1, Count: 2},
+			{Name: "Sprint", Days: 14, Count: template.MaxCadenceCount + 1},
+		} {
+			def := template.Definition{Cadence: &c}
+			require.IsType(t, errors.BadParameterError{}, def.Validate())
+		}
+	})
+}
+
+type templateRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo  template.Repository
+	clean func()
+}
+
+func TestRunTemplateRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &templateRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *templateRepoBlackBoxTest) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.DBTestSuite.PopulateDBTestSuite(migration.NewMigrationContext(context.Background()))
+}
+
+func (s *templateRepoBlackBoxTest) SetupTest() {
+	s.repo = template.NewRepository(s.DB)
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+}
+
+func (s *templateRepoBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *templateRepoBlackBoxTest) newTemplate(name string) *template.Template {
+	return &template.Template{
+		Name: name,
+		Definition: template.Definition{
+			WorkItemTypes: []template.WorkItemType{
+				{
+					Name:   "Story",
+					Icon:   "fa fa-bookmark",
+					States: []string{workitem.SystemStateNew, workitem.SystemStateClosed},
+					Fields: workitem.FieldDefinitions{
+						"points": {
+							Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+							Label: "Points",
+						},
+					},
+				},
+			},
+			Areas:   template.Areas{{Name: "UI", Children: template.Areas{{Name: "Web"}}}},
+			Cadence: &template.Cadence{Name: "Sprint", Days: 7, Count: 2},
+		},
+	}
+}
+
+func (s *templateRepoBlackBoxTest) TestCreateAndLoad() {
+	// given
+	created, err := s.repo.Create(context.Background(), s.newTemplate("process "+uuid.NewV4().String()))
+	require.Nil(s.T(), err)
+	// when
+	loaded, err := s.repo.Load(context.Background(), created.ID)
+	// then
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), created.Name, loaded.Name)
+	require.Len(s.T(), loaded.Definition.WorkItemTypes, 1)
+	assert.Equal(s.T(), []string{workitem.SystemStateNew, workitem.SystemStateClosed}, loaded.Definition.WorkItemTypes[0].States)
+	assert.Equal(s.T(), workitem.KindFloat, loaded.Definition.WorkItemTypes[0].Fields["points"].Type.GetKind())
+	require.Len(s.T(), loaded.Definition.Areas, 1)
+	assert.Equal(s.T(), "Web", loaded.Definition.Areas[0].Children[0].Name)
+	assert.Equal(s.T(), template.Cadence{Name: "Sprint", Days: 7, Count: 2}, *loaded.Definition.Cadence)
+
+	s.T().Run("not found", func(t *testing.T) {
+		_, err := s.repo.Load(context.Background(), uuid.NewV4())
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (s *templateRepoBlackBoxTest) TestCreateDuplicateName() {
+	// given
+	name := "process " + uuid.NewV4().String()
+	_, err := s.repo.Create(context.Background(), s.newTemplate(name))
+	require.Nil(s.T(), err)
+	// when
+	_, err = s.repo.Create(context.Background(), s.newTemplate(name))
+	// then
+	require.IsType(s.T(), errors.BadParameterError{}, err)
+}
+
+func (s *templateRepoBlackBoxTest) TestCreateInvalidDefinition() {
+	// given
+	tpl := s.newTemplate("process " + uuid.NewV4().String())
+	tpl.Definition.WorkItemTypes[0].States = []string{"todo", "done"}
+	// when
+	_, err := s.repo.Create(context.Background(), tpl)
+	// then
+	require.IsType(s.T(), errors.BadParameterError{}, err)
+}
+
+func (s *templateRepoBlackBoxTest) TestSave() {
+	// given
+	created, err := s.repo.Create(context.Background(), s.newTemplate("process "+uuid.NewV4().String()))
+	require.Nil(s.T(), err)
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		tpl := *created
+		tpl.Definition.Cadence = nil
+		// when
+		saved, err := s.repo.Save(context.Background(), tpl)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, created.Version+1, saved.Version)
+		loaded, err := s.repo.Load(context.Background(), created.ID)
+		require.Nil(t, err)
+		assert.Nil(t, loaded.Definition.Cadence)
+	})
+
+	s.T().Run("version conflict", func(t *testing.T) {
+		// when
+		_, err := s.repo.Save(context.Background(), *created)
+		// then
+		require.IsType(t, errors.VersionConflictError{}, err)
+	})
+}
+
+func (s *templateRepoBlackBoxTest) TestListBuiltIn() {
+	// when
+	templates, err := s.repo.List(context.Background())
+	// then
+	require.Nil(s.T(), err)
+	ids := map[uuid.UUID]bool{}
+	for _, tpl := range templates {
+		ids[tpl.ID] = true
+	}
+	assert.True(s.T(), ids[template.SystemScrum])
+	assert.True(s.T(), ids[template.SystemKanban])
+	assert.True(s.T(), ids[template.SystemIssueTracking])
+}
@@ -0,0 +1,211 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// BurndownPoint holds the state of the work items of an iteration at the end
+// of a given day.
+type BurndownPoint struct {
+	// the day, truncated to midnight UTC
+	Date time.Time
+	// the number of work items in the iteration at the end of the day
+	TotalCount int
+	// the number of work items in the iteration that were not closed at the
+	// end of the day
+	RemainingCount int
+	// the sum of the points field of the work items in the iteration
+	TotalPoints float64
+	// the sum of the points field of the work items in the iteration that were
+	// not closed
+	RemainingPoints float64
+}
+
+// IterationVelocity holds the work committed to and completed during an
+// iteration that already ended.
+type IterationVelocity struct {
+	Iteration iteration.Iteration
+	// the number and points of the work items in the iteration at the end of
+	// its first day
+	CommittedCount  int
+	CommittedPoints float64
+	// the number and points of the work items that were closed in the
+	// iteration at the end of its last day
+	CompletedCount  int
+	CompletedPoints float64
+}
+
+// GetBurndownForIteration reconstructs the remaining work of the given
+// iteration (and of its child iterations) for each day between its start and
+// its end (or today if the iteration has not ended yet) from the revisions of
+// the work items. The points are summed from the given numeric field, if any.
+func (r *GormWorkItemRepository) GetBurndownForIteration(ctx context.Context, itr *iteration.Iteration, pointsField string) ([]BurndownPoint, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "getBurndownForIteration"}, time.Now())
+	if itr.StartAt == nil || itr.EndAt == nil {
+		return nil, errors.NewBadParameterError("iteration", itr.ID).Expected("an iteration with a start and an end date")
+	}
+	firstDay := itr.StartAt.UTC().Truncate(24 * time.Hour)
+	end := *itr.EndAt
+	if now := time.Now(); now.Before(end) {
+		end = now
+	}
+	points := []BurndownPoint{}
+	if end.Before(firstDay) {
+		return points, nil
+	}
+	iterationIDs, err := r.iterationSubtree(ctx, itr)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := r.revisionsInIterations(ctx, iterationIDs, end)
+	if err != nil {
+		return nil, err
+	}
+	for day := firstDay; !day.After(end); day = day.Add(24 * time.Hour) {
+		endOfDay := day.Add(24 * time.Hour)
+		p := BurndownPoint{Date: day}
+		for _, itemRevisions := range revisions {
+			// the revisions are ordered by time, so look for the last one
+			// that occurred before the end of the day
+			var last *Revision
+			for i := range itemRevisions {
+				if !itemRevisions[i].Time.Before(endOfDay) {
+					break
+				}
+				last = &itemRevisions[i]
+			}
+			if last == nil || last.Type == RevisionTypeDelete {
+				continue
+			}
+			if iterationID, ok := last.WorkItemFields[SystemIteration].(string); !ok || !iterationIDs[iterationID] {
+				continue
+			}
+			value := numericFieldValue(last.WorkItemFields, pointsField)
+			p.TotalCount++
+			p.TotalPoints += value
+			if last.WorkItemFields[SystemState] != SystemStateClosed {
+				p.RemainingCount++
+				p.RemainingPoints += value
+			}
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+// GetVelocityPerIteration returns the committed and completed work of all
+// the iterations of the given space that already ended, ordered by end date.
+// The points are summed from the given numeric field, if any.
+func (r *GormWorkItemRepository) GetVelocityPerIteration(ctx context.Context, spaceID uuid.UUID, pointsField string) ([]IterationVelocity, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "getVelocityPerIteration"}, time.Now())
+	var iterations []iteration.Iteration
+	db := r.db.Where("space_id = ? AND start_at IS NOT NULL AND end_at IS NOT NULL AND end_at < ?", spaceID, time.Now()).Order("end_at asc").Find(&iterations)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"space_id": spaceID,
+			"err":      db.Error,
+		}, "unable to list the past iterations of the space")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	velocities := make([]IterationVelocity, 0, len(iterations))
+	for _, itr := range iterations {
+		points, err := r.GetBurndownForIteration(ctx, &itr, pointsField)
+		if err != nil {
+			return nil, err
+		}
+		v := IterationVelocity{Iteration: itr}
+		if len(points) > 0 {
+			first, last := points[0], points[len(points)-1]
+			v.CommittedCount = first.TotalCount
+			v.CommittedPoints = first.TotalPoints
+			v.CompletedCount = last.TotalCount - last.RemainingCount
+			v.CompletedPoints = last.TotalPoints - last.RemainingPoints
+		}
+		velocities = append(velocities, v)
+	}
+	return velocities, nil
+}
+
+// iterationSubtree returns the IDs of the given iteration and of all its
+// child iterations
+func (r *GormWorkItemRepository) iterationSubtree(ctx context.Context, itr *iteration.Iteration) (map[string]bool, error) {
+	pathOfIteration := append(itr.Path, itr.ID)
+	var childIDs []uuid.UUID
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE path <@ ? and space_id = ?`, itr.TableName())
+	db := r.db.Raw(query, pathOfIteration.Convert(), itr.SpaceID.String())
+	db.Pluck("id", &childIDs)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"path": pathOfIteration.Convert(),
+			"err":  db.Error,
+		}, "unable to fetch children for path")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	ids := map[string]bool{itr.ID.String(): true}
+	for _, id := range childIDs {
+		ids[id.String()] = true
+	}
+	return ids, nil
+}
+
+// revisionsInIterations returns the revisions that occurred until the given
+// time of all the work items that ever were in one of the given iterations,
+// grouped by work item and ordered by time
+func (r *GormWorkItemRepository) revisionsInIterations(ctx context.Context, iterationIDs map[string]bool, until time.Time) (map[uuid.UUID][]Revision, error) {
+	ids := make([]string, 0, len(iterationIDs))
+	for id := range iterationIDs {
+		ids = append(ids, id)
+	}
+	var workItemIDs []uuid.UUID
+	query := fmt.Sprintf(`SELECT DISTINCT work_item_id FROM %s WHERE work_item_fields->>'%s' IN (?) AND revision_time < ?`, revisionTableName, SystemIteration)
+	db := r.db.Raw(query, ids, until)
+	db.Pluck("work_item_id", &workItemIDs)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_ids": ids,
+			"err":           db.Error,
+		}, "unable to fetch the work items of the iterations")
+		return nil, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to retrieve the work items of the iterations"))
+	}
+	result := map[uuid.UUID][]Revision{}
+	if len(workItemIDs) == 0 {
+		return result, nil
+	}
+	var revisions []Revision
+	if err := r.db.Where("work_item_id IN (?) AND revision_time < ?", workItemIDs, until).Order("revision_time asc").Find(&revisions).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to retrieve work item revisions"))
+	}
+	for _, rev := range revisions {
+		result[rev.WorkItemID] = append(result[rev.WorkItemID], rev)
+	}
+	return result, nil
+}
+
+// numericFieldValue returns the value of the given field as a float, or 0 if
+// the field is not set or is not numeric
+func numericFieldValue(fields Fields, name string) float64 {
+	if name == "" {
+		return 0
+	}
+	switch v := fields[name].(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/iteration"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+)
+
+// AssigneeCommitment holds the work assigned to a collaborator in an
+// iteration.
+type AssigneeCommitment struct {
+	Assignee string
+	// the number of work items assigned to the collaborator
+	Count int
+	// the sum of the given numeric field of the work items assigned to the
+	// collaborator
+	Total float64
+}
+
+// GetCommitmentPerAssignee returns the work assigned to each collaborator in
+// the given iteration (and in its child iterations), keyed by the ID of the
+// assignee. A work item with several assignees counts fully for each of them.
+// The totals are summed from the given numeric field, if any.
+func (r *GormWorkItemRepository) GetCommitmentPerAssignee(ctx context.Context, itr *iteration.Iteration, field string) (map[string]AssigneeCommitment, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "getCommitmentPerAssignee"}, time.Now())
+	iterationIDs, err := r.iterationSubtree(ctx, itr)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(iterationIDs))
+	for id := range iterationIDs {
+		ids = append(ids, id)
+	}
+	query := fmt.Sprintf(`SELECT a.assignee AS assignee,
+						count(*) AS count,
+						sum(CASE jsonb_typeof(wi.fields->?)
+								WHEN 'number' THEN (wi.fields->>?)::float8
+								ELSE 0
+							END) AS total
+					FROM %s wi, jsonb_array_elements_text(
+						CASE jsonb_typeof(wi.fields->'%[2]s')
+							WHEN 'array' THEN wi.fields->'%[2]s'
+							ELSE '[]'
+						END) AS a(assignee)
+					WHERE wi.fields->>'%[3]s' IN (?)
+					AND wi.space_id = ?
+					AND wi.deleted_at IS NULL
+					GROUP BY a.assignee`,
+		workitemTableName, SystemAssignees, SystemIteration)
+	var rows []AssigneeCommitment
+	db := r.db.Raw(query, field, field, ids, itr.SpaceID).Scan(&rows)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"iteration_id": itr.ID,
+			"field":        field,
+			"err":          db.Error,
+		}, "unable to sum the work assigned in the iteration")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	result := make(map[string]AssigneeCommitment, len(rows))
+	for _, row := range rows {
+		result[row.Assignee] = row
+	}
+	return result, nil
+}
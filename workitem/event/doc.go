@@ -0,0 +1,3 @@
+// Package event contains the code that merges the revisions of a work item,
+// of its links and of its comments into a single activity stream.
+package event
@@ -0,0 +1,69 @@
+package event
+
+import (
+	"strconv"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Kind defines the kind of entity that changed in an event
+type Kind string
+
+// Kinds of entities that are part of the activity stream of a work item
+const (
+	KindWorkItem     Kind = "workitems"
+	KindWorkItemLink Kind = "workitemlinks"
+	KindComment      Kind = "comments"
+)
+
+// Type defines the type of modification of an event. The values match the
+// revision types of the `workitem`, `link` and `comment` packages.
+type Type int
+
+const (
+	_ Type = iota // ignore first value by assigning to blank identifier
+	// TypeCreate an entity creation
+	TypeCreate // 1
+	// TypeDelete an entity deletion
+	TypeDelete // 2
+	_          // ignore 3rd value
+	// TypeUpdate an entity update
+	TypeUpdate // 4
+	_          // ignore 5th value
+	_          // ignore 6th value
+	_          // ignore 7th value
+	// TypeMove a work item move to another space
+	TypeMove // 8
+)
+
+// String returns the name of the type of modification
+func (t Type) String() string {
+	switch t {
+	case TypeCreate:
+		return "create"
+	case TypeDelete:
+		return "delete"
+	case TypeUpdate:
+		return "update"
+	case TypeMove:
+		return "move"
+	}
+	return strconv.Itoa(int(t))
+}
+
+// Event represents a single entry in the activity stream of a work item
+type Event struct {
+	// the ID of the revision from which this event was built
+	ID uuid.UUID `gorm:"column:id"`
+	// the timestamp of the modification
+	Time time.Time `gorm:"column:revision_time"`
+	// the type of modification
+	Type Type `gorm:"column:revision_type"`
+	// the kind of entity that changed
+	Kind Kind `gorm:"column:kind"`
+	// the identity of author of the modification
+	ModifierIdentity uuid.UUID `gorm:"column:modifier_id"`
+	// the ID of the work item, work item link or comment that changed
+	SubjectID uuid.UUID `gorm:"column:subject_id"`
+}
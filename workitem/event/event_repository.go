@@ -0,0 +1,92 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository encapsulates retrieval of the activity stream of work items
+type Repository interface {
+	// List returns the events of the given work item, of the links in which
+	// it is the source or the target and of its comments, ordered by time.
+	List(ctx context.Context, workitemID uuid.UUID, start *int, limit *int) ([]Event, uint64, error)
+}
+
+// NewEventRepository creates a GormEventRepository
+func NewEventRepository(db *gorm.DB) *GormEventRepository {
+	return &GormEventRepository{db: db}
+}
+
+// GormEventRepository implements Repository using gorm
+type GormEventRepository struct {
+	db *gorm.DB
+}
+
+// eventsQuery merges the work item, work item link and comment revisions of
+// a single work item. All three subqueries take the work item ID as argument.
+var eventsQuery = fmt.Sprintf(`
+	SELECT id, revision_time, revision_type, modifier_id, '%[1]s' AS kind, work_item_id AS subject_id
+	FROM %[2]s
+	WHERE work_item_id = ?
+	UNION ALL
+	SELECT id, revision_time, revision_type, modifier_id, '%[3]s' AS kind, work_item_link_id AS subject_id
+	FROM %[4]s
+	WHERE work_item_link_source_id = ? OR work_item_link_target_id = ?
+	UNION ALL
+	SELECT id, revision_time, revision_type, modifier_id, '%[5]s' AS kind, comment_id AS subject_id
+	FROM %[6]s
+	WHERE comment_parent_id = ?`,
+	KindWorkItem, workitem.Revision{}.TableName(),
+	KindWorkItemLink, link.Revision{}.TableName(),
+	KindComment, comment.Revision{}.TableName())
+
+// List returns the events of the given work item, of the links in which it
+// is the source or the target and of its comments, ordered by time.
+func (r *GormEventRepository) List(ctx context.Context, workitemID uuid.UUID, start *int, limit *int) ([]Event, uint64, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "event", "list"}, time.Now())
+	args := []interface{}{workitemID, workitemID, workitemID, workitemID}
+	var count uint64
+	countQuery := fmt.Sprintf("SELECT count(*) FROM (%s) AS events", eventsQuery)
+	if err := r.db.Raw(countQuery, args...).Row().Scan(&count); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_id": workitemID,
+			"err":   err,
+		}, "unable to count the events of the work item")
+		return nil, 0, errors.NewInternalError(ctx, errs.Wrap(err, "failed to count work item events"))
+	}
+	pageQuery := fmt.Sprintf("SELECT * FROM (%s) AS events ORDER BY revision_time ASC, id ASC", eventsQuery)
+	if start != nil {
+		if *start < 0 {
+			return nil, 0, errors.NewBadParameterError("start", *start)
+		}
+		pageQuery += fmt.Sprintf(" OFFSET %d", *start)
+	}
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, 0, errors.NewBadParameterError("limit", *limit)
+		}
+		pageQuery += fmt.Sprintf(" LIMIT %d", *limit)
+	}
+	events := make([]Event, 0)
+	db := r.db.Raw(pageQuery, args...).Scan(&events)
+	if db.Error != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_id": workitemID,
+			"err":   db.Error,
+		}, "unable to list the events of the work item")
+		return nil, 0, errors.NewInternalError(ctx, errs.Wrap(db.Error, "failed to list work item events"))
+	}
+	return events, count, nil
+}
@@ -0,0 +1,149 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/event"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRunEventRepositoryBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &eventRepositoryBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+type eventRepositoryBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repository    event.Repository
+	clean         func()
+	ctx           context.Context
+	testIdentity1 account.Identity
+	testIdentity2 account.Identity
+	testSpace     *space.Space
+}
+
+// SetupSuite overrides the DBTestSuite's function but calls it before doing anything else
+// The SetupSuite method will run before the tests in the suite are run.
+// It sets up a database connection for all the tests in this suite without polluting global space.
+func (s *eventRepositoryBlackBoxTest) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.ctx = migration.NewMigrationContext(context.Background())
+	s.DBTestSuite.PopulateDBTestSuite(s.ctx)
+}
+
+func (s *eventRepositoryBlackBoxTest) SetupTest() {
+	s.repository = event.NewEventRepository(s.DB)
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+	testIdentity1, err := testsupport.CreateTestIdentity(s.DB, "jdoe1", "test")
+	require.Nil(s.T(), err)
+	s.testIdentity1 = testIdentity1
+	testIdentity2, err := testsupport.CreateTestIdentity(s.DB, "jdoe2", "test")
+	require.Nil(s.T(), err)
+	s.testIdentity2 = testIdentity2
+	s.testSpace, err = space.NewRepository(s.DB).Create(s.ctx, &space.Space{
+		Name: testsupport.CreateRandomValidTestName("test-space"),
+	})
+	require.Nil(s.T(), err)
+}
+
+func (s *eventRepositoryBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *eventRepositoryBlackBoxTest) createWorkItem(title string) *workitem.WorkItem {
+	wi, err := workitem.NewWorkItemRepository(s.DB).Create(
+		s.ctx, s.testSpace.ID, workitem.SystemBug,
+		map[string]interface{}{
+			workitem.SystemTitle: title,
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity1.ID)
+	require.Nil(s.T(), err)
+	return wi
+}
+
+func (s *eventRepositoryBlackBoxTest) TestListMergesRevisions() {
+	// given
+	source := s.createWorkItem("Source")
+	target := s.createWorkItem("Target")
+	unrelated := s.createWorkItem("Unrelated")
+	// update the source work item
+	source.Fields[workitem.SystemTitle] = "Updated source"
+	source, err := workitem.NewWorkItemRepository(s.DB).Save(s.ctx, s.testSpace.ID, *source, s.testIdentity2.ID)
+	require.Nil(s.T(), err)
+	// link the source work item to the target one
+	l, err := link.NewWorkItemLinkRepository(s.DB).Create(s.ctx, source.ID, target.ID, link.SystemWorkItemLinkTypeBugBlockerID, s.testIdentity1.ID)
+	require.Nil(s.T(), err)
+	// comment on the source and on the unrelated work items
+	c := comment.Comment{
+		ParentID:  source.ID,
+		Body:      "a comment",
+		CreatedBy: s.testIdentity2.ID,
+	}
+	err = comment.NewRepository(s.DB).Create(s.ctx, &c, s.testIdentity2.ID)
+	require.Nil(s.T(), err)
+	err = comment.NewRepository(s.DB).Create(s.ctx, &comment.Comment{
+		ParentID:  unrelated.ID,
+		Body:      "another comment",
+		CreatedBy: s.testIdentity2.ID,
+	}, s.testIdentity2.ID)
+	require.Nil(s.T(), err)
+	// when
+	events, count, err := s.repository.List(s.ctx, source.ID, nil, nil)
+	// then
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(4), count)
+	require.Len(s.T(), events, 4)
+	assert.Equal(s.T(), event.KindWorkItem, events[0].Kind)
+	assert.Equal(s.T(), event.TypeCreate, events[0].Type)
+	assert.Equal(s.T(), source.ID, events[0].SubjectID)
+	assert.Equal(s.T(), s.testIdentity1.ID, events[0].ModifierIdentity)
+	assert.Equal(s.T(), event.KindWorkItem, events[1].Kind)
+	assert.Equal(s.T(), event.TypeUpdate, events[1].Type)
+	assert.Equal(s.T(), s.testIdentity2.ID, events[1].ModifierIdentity)
+	assert.Equal(s.T(), event.KindWorkItemLink, events[2].Kind)
+	assert.Equal(s.T(), l.ID, events[2].SubjectID)
+	assert.Equal(s.T(), event.KindComment, events[3].Kind)
+	assert.Equal(s.T(), c.ID, events[3].SubjectID)
+	// the link also shows up in the activity stream of the target
+	events, count, err = s.repository.List(s.ctx, target.ID, nil, nil)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(2), count)
+	require.Len(s.T(), events, 2)
+	assert.Equal(s.T(), event.KindWorkItemLink, events[1].Kind)
+}
+
+func (s *eventRepositoryBlackBoxTest) TestListWithPaging() {
+	// given
+	wi := s.createWorkItem("Paged")
+	for i := 0; i < 3; i++ {
+		err := comment.NewRepository(s.DB).Create(s.ctx, &comment.Comment{
+			ParentID:  wi.ID,
+			Body:      "a comment",
+			CreatedBy: s.testIdentity1.ID,
+		}, s.testIdentity1.ID)
+		require.Nil(s.T(), err)
+	}
+	start, limit := 1, 2
+	// when
+	events, count, err := s.repository.List(s.ctx, wi.ID, &start, &limit)
+	// then
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), uint64(4), count)
+	require.Len(s.T(), events, 2)
+	assert.Equal(s.T(), event.KindComment, events[0].Kind)
+	assert.Equal(s.T(), event.KindComment, events[1].Kind)
+}
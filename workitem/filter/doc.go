@@ -0,0 +1,4 @@
+// Package filter contains the code that provides all the required operations
+// to manage saved filters, i.e. named work item queries that are shared with a
+// space or kept private by their creator.
+package filter
@@ -0,0 +1,88 @@
+package filter
+
+import (
+	"github.com/fabric8-services/fabric8-wit/criteria"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	query "github.com/fabric8-services/fabric8-wit/query/simple"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// APIStringTypeFilters is the JSONAPI type of filters
+	APIStringTypeFilters = "filters"
+	// ScopeSystem is the scope of the built-in filters, which are not stored
+	ScopeSystem = "system"
+	// ScopeSpace is the scope of the filters shared with all the users of a space
+	ScopeSpace = "space"
+	// ScopeUser is the scope of the filters only visible to their creator
+	ScopeUser = "user"
+
+	filterTableName = "work_item_filters"
+)
+
+// Filter is a named work item query saved in a space
+type Filter struct {
+	gormsupport.Lifecycle
+	// ID
+	ID uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	// SpaceID is the ID of the space in which the filter is executed
+	SpaceID uuid.UUID `sql:"type:uuid"`
+	// CreatorID is the ID of the identity who saved the filter
+	CreatorID uuid.UUID `sql:"type:uuid"`
+	// Scope is either ScopeSpace or ScopeUser
+	Scope string
+	// Name is the name of this filter, unique for its creator within its space
+	Name string
+	// Description is an optional description of the filter
+	Description *string
+	// Query is the filter expression, in the same language as the `filter`
+	// parameter of the work item list
+	Query string
+	// Sort is the comma separated list of sort keys, see workitem.ParseSort
+	Sort string
+	// Version for optimistic concurrency control
+	Version int
+}
+
+// TableName implements gorm.tabler
+func (f Filter) TableName() string {
+	return filterTableName
+}
+
+// VisibleTo returns true if the filter can be seen and executed by the given
+// identity, which is nil for anonymous users.
+func (f Filter) VisibleTo(identityID *uuid.UUID) bool {
+	if f.Scope == ScopeSpace {
+		return true
+	}
+	return identityID != nil && uuid.Equal(*identityID, f.CreatorID)
+}
+
+// Expression returns the criteria expression of the query of the filter
+func (f Filter) Expression() (criteria.Expression, error) {
+	exp, err := query.Parse(&f.Query)
+	if err != nil {
+		return nil, errors.NewBadParameterError("query", f.Query).Expected("JSON object of field values")
+	}
+	return exp, nil
+}
+
+// SortKeys returns the parsed sort keys of the filter
+func (f Filter) SortKeys() ([]workitem.SortKey, error) {
+	return workitem.ParseSort(f.Sort)
+}
+
+// validate returns a BadParameterError if the filter cannot be stored
+func (f Filter) validate() error {
+	if f.Scope != ScopeSpace && f.Scope != ScopeUser {
+		return errors.NewBadParameterError("scope", f.Scope).Expected(ScopeSpace + " or " + ScopeUser)
+	}
+	if _, err := f.Expression(); err != nil {
+		return err
+	}
+	_, err := f.SortKeys()
+	return err
+}
@@ -0,0 +1,161 @@
+package filter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository encapsulates storage & retrieval of saved filters
+type Repository interface {
+	repository.Exister
+	Create(ctx context.Context, f *Filter) (*Filter, error)
+	Load(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) (*Filter, error)
+	List(ctx context.Context, spaceID uuid.UUID, identityID *uuid.UUID) ([]Filter, error)
+	Save(ctx context.Context, f Filter) (*Filter, error)
+	Delete(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) error
+}
+
+// NewRepository creates a saved filter repository based on gorm
+func NewRepository(db *gorm.DB) *GormFilterRepository {
+	return &GormFilterRepository{db}
+}
+
+// GormFilterRepository implements Repository using gorm
+type GormFilterRepository struct {
+	db *gorm.DB
+}
+
+// Create creates a new saved filter in the repository.
+// Returns BadParameterError or InternalError
+func (r *GormFilterRepository) Create(ctx context.Context, f *Filter) (*Filter, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "filter", "create"}, time.Now())
+	if strings.TrimSpace(f.Name) == "" {
+		return nil, errors.NewBadParameterError("name", f.Name)
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	if f.ID == uuid.Nil {
+		f.ID = uuid.NewV4()
+	}
+	db := r.db.Create(f)
+	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "work_item_filters_name_unique") {
+			return nil, errors.NewBadParameterError("name", f.Name).Expected("unique")
+		}
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"filter_id": f.ID,
+		"space_id":  f.SpaceID,
+	}, "saved filter created")
+	return f, nil
+}
+
+// Load returns the saved filter for the given space and ID.
+// Returns NotFoundError or InternalError
+func (r *GormFilterRepository) Load(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) (*Filter, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "filter", "load"}, time.Now())
+	result := Filter{}
+	db := r.db.Model(&result).Where("id = ? AND space_id = ?", ID, spaceID).First(&result)
+	if db.RecordNotFound() {
+		log.Error(ctx, map[string]interface{}{
+			"filter_id": ID,
+			"space_id":  spaceID,
+		}, "saved filter not found")
+		return nil, errors.NewNotFoundError("filter", ID.String())
+	}
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return &result, nil
+}
+
+// CheckExists returns nil if the given ID exists otherwise returns an error
+func (r *GormFilterRepository) CheckExists(ctx context.Context, id string) error {
+	defer goa.MeasureSince([]string{"goa", "db", "filter", "exists"}, time.Now())
+	return repository.CheckExists(ctx, r.db, filterTableName, id)
+}
+
+// List returns the saved filters of the given space that are visible to the
+// given identity (nil for anonymous users), ordered by name
+func (r *GormFilterRepository) List(ctx context.Context, spaceID uuid.UUID, identityID *uuid.UUID) ([]Filter, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "filter", "list"}, time.Now())
+	var rows []Filter
+	db := r.db.Where("space_id = ?", spaceID)
+	if identityID != nil {
+		db = db.Where("scope = ? OR creator_id = ?", ScopeSpace, *identityID)
+	} else {
+		db = db.Where("scope = ?", ScopeSpace)
+	}
+	db = db.Order("name").Find(&rows)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return rows, nil
+}
+
+// Save updates the given saved filter in storage. Version must be the same as
+// the one in the stored version. The space and the creator cannot be changed.
+// returns NotFoundError, VersionConflictError, BadParameterError or InternalError
+func (r *GormFilterRepository) Save(ctx context.Context, f Filter) (*Filter, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "filter", "save"}, time.Now())
+	if strings.TrimSpace(f.Name) == "" {
+		return nil, errors.NewBadParameterError("name", f.Name)
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	existing, err := r.Load(ctx, f.SpaceID, f.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != f.Version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	f.CreatorID = existing.CreatorID
+	f.CreatedAt = existing.CreatedAt
+	f.Version = f.Version + 1
+	db := r.db.Where("version = ?", existing.Version).Save(&f)
+	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "work_item_filters_name_unique") {
+			return nil, errors.NewBadParameterError("name", f.Name).Expected("unique")
+		}
+		log.Error(ctx, map[string]interface{}{
+			"filter_id": f.ID,
+			"err":       db.Error,
+		}, "unable to save filter")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	log.Info(ctx, map[string]interface{}{
+		"filter_id": f.ID,
+	}, "saved filter updated")
+	return &f, nil
+}
+
+// Delete deletes the saved filter with the given space and ID
+// returns NotFoundError or InternalError
+func (r *GormFilterRepository) Delete(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "filter", "delete"}, time.Now())
+	db := r.db.Where("space_id = ?", spaceID).Delete(&Filter{ID: ID})
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return errors.NewNotFoundError("filter", ID.String())
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package filter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/filter"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type filterRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo  filter.Repository
+	clean func()
+	alice uuid.UUID
+	bob   uuid.UUID
+}
+
+func TestRunFilterRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &filterRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *filterRepoBlackBoxTest) SetupTest() {
+	s.repo = filter.NewRepository(s.DB)
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+	alice, err := testsupport.CreateTestIdentity(s.DB, "alice-"+uuid.NewV4().String(), "test")
+	require.Nil(s.T(), err)
+	s.alice = alice.ID
+	bob, err := testsupport.CreateTestIdentity(s.DB, "bob-"+uuid.NewV4().String(), "test")
+	require.Nil(s.T(), err)
+	s.bob = bob.ID
+}
+
+func (s *filterRepoBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *filterRepoBlackBoxTest) newFilter(name string, scope string, creatorID uuid.UUID) *filter.Filter {
+	return &filter.Filter{
+		SpaceID:   space.SystemSpace,
+		CreatorID: creatorID,
+		Scope:     scope,
+		Name:      name,
+		Query:     `{"system.state":"open"}`,
+		Sort:      "-system.updated_at",
+	}
+}
+
+func (s *filterRepoBlackBoxTest) TestCreateAndLoad() {
+	f, err := s.repo.Create(context.Background(), s.newFilter("open", filter.ScopeSpace, s.alice))
+	require.Nil(s.T(), err)
+	require.NotEqual(s.T(), uuid.Nil, f.ID)
+
+	loaded, err := s.repo.Load(context.Background(), space.SystemSpace, f.ID)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), "open", loaded.Name)
+	assert.Equal(s.T(), filter.ScopeSpace, loaded.Scope)
+	assert.Equal(s.T(), s.alice, loaded.CreatorID)
+	sort, err := loaded.SortKeys()
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), []workitem.SortKey{{Field: workitem.SystemUpdatedAt, Descending: true}}, sort)
+
+	_, err = s.repo.Load(context.Background(), uuid.NewV4(), f.ID)
+	require.IsType(s.T(), errors.NotFoundError{}, err)
+}
+
+func (s *filterRepoBlackBoxTest) TestCreateInvalid() {
+	t := s.T()
+	t.Run("empty name", func(t *testing.T) {
+		_, err := s.repo.Create(context.Background(), s.newFilter(" ", filter.ScopeUser, s.alice))
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+	t.Run("system scope", func(t *testing.T) {
+		_, err := s.repo.Create(context.Background(), s.newFilter("system", filter.ScopeSystem, s.alice))
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+	t.Run("invalid query", func(t *testing.T) {
+		f := s.newFilter("query", filter.ScopeUser, s.alice)
+		f.Query = "system.state=open"
+		_, err := s.repo.Create(context.Background(), f)
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+	t.Run("invalid sort", func(t *testing.T) {
+		f := s.newFilter("sort", filter.ScopeUser, s.alice)
+		f.Sort = "title; drop table work_items"
+		_, err := s.repo.Create(context.Background(), f)
+		require.IsType(t, errors.BadParameterError{}, err)
+	})
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := s.repo.Create(context.Background(), s.newFilter("duplicate", filter.ScopeUser, s.alice))
+		require.Nil(t, err)
+		_, err = s.repo.Create(context.Background(), s.newFilter("duplicate", filter.ScopeSpace, s.alice))
+		require.IsType(t, errors.BadParameterError{}, err)
+		// another user may use the same name
+		_, err = s.repo.Create(context.Background(), s.newFilter("duplicate", filter.ScopeUser, s.bob))
+		require.Nil(t, err)
+	})
+}
+
+func (s *filterRepoBlackBoxTest) TestListVisibility() {
+	shared, err := s.repo.Create(context.Background(), s.newFilter("shared", filter.ScopeSpace, s.alice))
+	require.Nil(s.T(), err)
+	private, err := s.repo.Create(context.Background(), s.newFilter("private", filter.ScopeUser, s.alice))
+	require.Nil(s.T(), err)
+	_, err = s.repo.Create(context.Background(), s.newFilter("others", filter.ScopeUser, s.bob))
+	require.Nil(s.T(), err)
+
+	names := func(identityID *uuid.UUID) []string {
+		filters, err := s.repo.List(context.Background(), space.SystemSpace, identityID)
+		require.Nil(s.T(), err)
+		result := []string{}
+		for _, f := range filters {
+			if f.VisibleTo(identityID) {
+				result = append(result, f.Name)
+			}
+		}
+		return result
+	}
+	assert.Equal(s.T(), []string{private.Name, shared.Name}, names(&s.alice))
+	assert.Equal(s.T(), []string{"others", shared.Name}, names(&s.bob))
+	assert.Equal(s.T(), []string{shared.Name}, names(nil))
+}
+
+func (s *filterRepoBlackBoxTest) TestSave() {
+	f, err := s.repo.Create(context.Background(), s.newFilter("before", filter.ScopeUser, s.alice))
+	require.Nil(s.T(), err)
+
+	f.Name = "after"
+	f.Scope = filter.ScopeSpace
+	// the creator cannot be changed
+	f.CreatorID = s.bob
+	saved, err := s.repo.Save(context.Background(), *f)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), "after", saved.Name)
+	assert.Equal(s.T(), filter.ScopeSpace, saved.Scope)
+	assert.Equal(s.T(), s.alice, saved.CreatorID)
+	assert.Equal(s.T(), 1, saved.Version)
+
+	// stale version
+	f.Version = 0
+	_, err = s.repo.Save(context.Background(), *f)
+	require.IsType(s.T(), errors.VersionConflictError{}, err)
+}
+
+func (s *filterRepoBlackBoxTest) TestDelete() {
+	f, err := s.repo.Create(context.Background(), s.newFilter("delete", filter.ScopeUser, s.alice))
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), s.repo.Delete(context.Background(), space.SystemSpace, f.ID))
+	_, err = s.repo.Load(context.Background(), space.SystemSpace, f.ID)
+	require.IsType(s.T(), errors.NotFoundError{}, err)
+	require.IsType(s.T(), errors.NotFoundError{}, s.repo.Delete(context.Background(), space.SystemSpace, f.ID))
+}
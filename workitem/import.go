@@ -0,0 +1,184 @@
+package workitem
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/space"
+
+	"github.com/goadesign/goa"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReserveNumbers allocates the given count of consecutive work item numbers
+// in the given space and returns the first one, so that the numbers of the
+// work items to import are known before they are imported.
+func (r *GormWorkItemRepository) ReserveNumbers(ctx context.Context, spaceID uuid.UUID, count int) (int, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "reserve_numbers"}, time.Now())
+	if count < 1 {
+		return 0, errors.NewBadParameterError("count", count).Expected("at least one number")
+	}
+	return r.reserveNumbers(ctx, spaceID, count)
+}
+
+// Import creates a work item of the given type in the given space from the
+// given revisions of a work item of another installation, oldest first. The
+// work item gets the given number, which must have been reserved with
+// ReserveNumbers, and the fields of the latest revision, while the revisions
+// keep their modifiers and times. The fields are expected in their stored
+// form, with references already pointing to the identities, iterations and
+// areas of this installation, and are checked against the fields of the type.
+// returns BadParameterError, ConversionError or InternalError
+func (r *GormWorkItemRepository) Import(ctx context.Context, spaceID uuid.UUID, typeID uuid.UUID, number int, revisions []Revision) (*WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "import"}, time.Now())
+	if len(revisions) == 0 {
+		return nil, errors.NewBadParameterError("revisions", revisions).Expected("at least one revision")
+	}
+	if err := space.CheckNotArchived(ctx, r.db, spaceID); err != nil {
+		return nil, err
+	}
+	wiType, err := r.witr.LoadTypeFromDB(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("typeID", typeID)
+	}
+	pos, err := r.LoadHighestOrder(ctx)
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	latest := revisions[len(revisions)-1]
+	wi := WorkItemStorage{
+		Type:           typeID,
+		Version:        len(revisions) - 1,
+		Fields:         Fields{},
+		ExecutionOrder: pos + orderValue,
+		SpaceID:        spaceID,
+		Number:         number,
+	}
+	for name, def := range wiType.Fields {
+		if name == SystemCreatedAt || name == SystemUpdatedAt || name == SystemOrder {
+			continue
+		}
+		value, err := importedValue(name, def, latest.WorkItemFields[name])
+		if err != nil {
+			return nil, errors.NewBadParameterError(name, latest.WorkItemFields[name]).Expected(err.Error())
+		}
+		if value != nil {
+			wi.Fields[name] = value
+		}
+	}
+	// gorm keeps the timestamps which are already set
+	wi.CreatedAt = revisions[0].Time
+	wi.UpdatedAt = latest.Time
+	if err := r.db.Create(&wi).Error; err != nil {
+		return nil, errs.Wrapf(err, "failed to import work item")
+	}
+	for i, rev := range revisions {
+		revision := Revision{
+			Time:             rev.Time,
+			Type:             rev.Type,
+			ModifierIdentity: rev.ModifierIdentity,
+			WorkItemID:       wi.ID,
+			WorkItemTypeID:   typeID,
+			WorkItemVersion:  i,
+			WorkItemFields:   rev.WorkItemFields,
+		}
+		if err := r.db.Create(&revision).Error; err != nil {
+			return nil, errors.NewInternalError(ctx, errs.Wrap(err, "failed to import work item revision"))
+		}
+	}
+	log.Debug(ctx, map[string]interface{}{"wi_id": wi.ID, "space_id": spaceID}, "Work item imported successfully!")
+	return ConvertWorkItemStorageToModel(wiType, &wi)
+}
+
+// importedValue checks the given stored value of the field with the given
+// name and definition, as decoded from JSON, and returns it with the numbers
+// converted to the types the field kind is stored with
+func importedValue(name string, def FieldDefinition, value interface{}) (interface{}, error) {
+	if value == nil {
+		if def.Required {
+			return nil, errs.Errorf("value of the required field %s", name)
+		}
+		return nil, nil
+	}
+	switch t := def.Type.(type) {
+	case ListType:
+		values, ok := value.([]interface{})
+		if !ok {
+			return nil, errs.Errorf("list of %s values", t.ComponentType.GetKind())
+		}
+		result := make([]interface{}, len(values))
+		for i, v := range values {
+			converted, err := importedSimpleValue(t.ComponentType.GetKind(), v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case EnumType:
+		converted, err := importedSimpleValue(t.BaseType.GetKind(), value)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range t.Values {
+			if reflect.DeepEqual(v, value) || reflect.DeepEqual(v, converted) {
+				return converted, nil
+			}
+		}
+		return nil, errs.Errorf("one of the values %v", t.Values)
+	}
+	converted, err := importedSimpleValue(def.Type.GetKind(), value)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := converted.(string); ok && def.Required && def.Type.GetKind() == KindString && strings.TrimSpace(s) == "" {
+		return nil, errs.Errorf("value of the required field %s", name)
+	}
+	return converted, nil
+}
+
+// importedSimpleValue checks the given stored value of the given kind, as
+// decoded from JSON, and returns it with the numbers converted to the types
+// the kind is stored with
+func importedSimpleValue(kind Kind, value interface{}) (interface{}, error) {
+	switch kind {
+	case KindString, KindUser, KindIteration, KindArea, KindURL, KindWorkitemReference:
+		if _, ok := value.(string); ok {
+			return value, nil
+		}
+	case KindFloat:
+		if _, ok := value.(float64); ok {
+			return value, nil
+		}
+	case KindInteger, KindDuration:
+		if v, ok := value.(float64); ok && v == math.Trunc(v) {
+			return int(v), nil
+		}
+	case KindInstant:
+		if v, ok := value.(float64); ok && v == math.Trunc(v) {
+			return int64(v), nil
+		}
+	case KindMarkup:
+		if v, ok := value.(map[string]interface{}); ok {
+			content, ok := v[rendering.ContentKey].(string)
+			markup, _ := v[rendering.MarkupKey].(string)
+			markup = rendering.NilSafeGetMarkup(&markup)
+			if ok && rendering.IsMarkupSupported(markup) {
+				return rendering.NewMarkupContent(content, markup).ToMap(), nil
+			}
+		}
+	case KindCodebase:
+		if _, ok := value.(map[string]interface{}); ok {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("%s value", kind)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport"
 	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
 	"github.com/fabric8-services/fabric8-wit/workitem"
 
 	"github.com/goadesign/goa"
@@ -36,6 +37,7 @@ type WorkItemLinkRepository interface {
 	List(ctx context.Context) ([]WorkItemLink, error)
 	ListByWorkItem(ctx context.Context, wiID uuid.UUID) ([]WorkItemLink, error)
 	DeleteRelatedLinks(ctx context.Context, wiID uuid.UUID, suppressorID uuid.UUID) error
+	DeleteCrossSpaceLinks(ctx context.Context, spaceID uuid.UUID, suppressorID uuid.UUID) error
 	Delete(ctx context.Context, ID uuid.UUID, suppressorID uuid.UUID) error
 	Save(ctx context.Context, linkCat WorkItemLink, modifierID uuid.UUID) (*WorkItemLink, error)
 	ListWorkItemChildren(ctx context.Context, parentID uuid.UUID, start *int, limit *int) ([]workitem.WorkItem, uint64, error)
@@ -124,6 +126,32 @@ func (r *GormWorkItemLinkRepository) ValidateTopology(ctx context.Context, sourc
 	return nil
 }
 
+// ValidateLinkTypeSpace checks that the given link type can be used to link
+// the given source and target work items. A link type from the system space
+// can be used between any work items, otherwise it must belong to the space
+// of the source or of the target work item. The source and target work items
+// can belong to different spaces.
+func (r *GormWorkItemLinkRepository) ValidateLinkTypeSpace(ctx context.Context, sourceID, targetID uuid.UUID, linkType WorkItemLinkType) error {
+	source, err := r.workItemRepo.LoadFromDB(ctx, sourceID)
+	if err != nil {
+		return errors.NewNotFoundError("source", sourceID.String())
+	}
+	target, err := r.workItemRepo.LoadFromDB(ctx, targetID)
+	if err != nil {
+		return errors.NewNotFoundError("target", targetID.String())
+	}
+	if uuid.Equal(linkType.SpaceID, space.SystemSpace) || uuid.Equal(linkType.SpaceID, source.SpaceID) || uuid.Equal(linkType.SpaceID, target.SpaceID) {
+		return nil
+	}
+	log.Error(ctx, map[string]interface{}{
+		"wilt_id":         linkType.ID,
+		"wilt_space_id":   linkType.SpaceID,
+		"source_space_id": source.SpaceID,
+		"target_space_id": target.SpaceID,
+	}, "the link type belongs neither to the system space nor to the space of the source or target work item")
+	return errors.NewBadParameterError("data.relationships.link_type.data.id", linkType.ID).Expected("link type of the system space or of the space of the source or target work item")
+}
+
 // Create creates a new work item link in the repository.
 // Returns BadParameterError, ConversionError or InternalError
 func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targetID uuid.UUID, linkTypeID uuid.UUID, creatorID uuid.UUID) (*WorkItemLink, error) {
@@ -136,6 +164,9 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 	if err := link.CheckValidForCreation(); err != nil {
 		return nil, errs.WithStack(err)
 	}
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, sourceID, targetID); err != nil {
+		return nil, err
+	}
 
 	// Fetch the link type
 	linkType, err := r.workItemLinkTypeRepo.Load(ctx, linkTypeID)
@@ -143,6 +174,10 @@ func (r *GormWorkItemLinkRepository) Create(ctx context.Context, sourceID, targe
 		return nil, errs.Wrap(err, "failed to load link type")
 	}
 
+	if err := r.ValidateLinkTypeSpace(ctx, sourceID, targetID, *linkType); err != nil {
+		return nil, errs.WithStack(err)
+	}
+
 	if err := r.ValidateTopology(ctx, nil, targetID, *linkType); err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -204,14 +239,26 @@ func (r *GormWorkItemLinkRepository) ListByWorkItem(ctx context.Context, wiID uu
 	if err != nil {
 		return nil, errs.WithStack(err)
 	}
-	// Now fetch all links for that work item
-	db := r.db.Model(modelLinks).Where("? IN (source_id, target_id)", wi.ID).Find(&modelLinks)
+	// Now fetch all links for that work item, ignoring the dangling links
+	// whose other end was deleted or lives in a deleted space.
+	db := r.db.Model(modelLinks).Where("? IN (source_id, target_id)", wi.ID).Where(nonDanglingLinkCondition).Find(&modelLinks)
 	if db.Error != nil {
 		return nil, db.Error
 	}
 	return modelLinks, nil
 }
 
+// nonDanglingLinkCondition is a SQL condition that only matches the work item
+// links whose source and target work items as well as their spaces and the
+// link type have not been deleted.
+var nonDanglingLinkCondition = fmt.Sprintf(`
+	source_id IN (SELECT wi.id FROM %[1]s wi JOIN %[2]s s ON s.id = wi.space_id WHERE wi.deleted_at IS NULL AND s.deleted_at IS NULL)
+	AND target_id IN (SELECT wi.id FROM %[1]s wi JOIN %[2]s s ON s.id = wi.space_id WHERE wi.deleted_at IS NULL AND s.deleted_at IS NULL)
+	AND link_type_id IN (SELECT id FROM %[3]s WHERE deleted_at IS NULL)`,
+	workitem.WorkItemStorage{}.TableName(),
+	(&space.GormRepository{}).TableName(),
+	WorkItemLinkType{}.TableName())
+
 // List returns all work item links if wiID is nil; otherwise the work item links are returned
 // that have wiID as source or target.
 // TODO: Handle pagination
@@ -234,6 +281,9 @@ func (r *GormWorkItemLinkRepository) Delete(ctx context.Context, linkID uuid.UUI
 	if tx.RecordNotFound() {
 		return errors.NewNotFoundError("work item link", linkID.String())
 	}
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, lnk.SourceID, lnk.TargetID); err != nil {
+		return err
+	}
 	r.deleteLink(ctx, lnk, suppressorID)
 	return nil
 }
@@ -245,6 +295,9 @@ func (r *GormWorkItemLinkRepository) DeleteRelatedLinks(ctx context.Context, wiI
 	log.Info(ctx, map[string]interface{}{
 		"wi_id": wiID,
 	}, "Deleting the links related to work item")
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, wiID); err != nil {
+		return err
+	}
 
 	var workitemLinks = []WorkItemLink{}
 	r.db.Where("? in (source_id, target_id)", wiID).Find(&workitemLinks)
@@ -255,6 +308,33 @@ func (r *GormWorkItemLinkRepository) DeleteRelatedLinks(ctx context.Context, wiI
 	return nil
 }
 
+// DeleteCrossSpaceLinks deletes all links between a work item of the given
+// space and a work item of another space. It is meant to be called before the
+// space is deleted, so that the work items of the other spaces do not keep
+// links to work items that are no longer reachable.
+func (r *GormWorkItemLinkRepository) DeleteCrossSpaceLinks(ctx context.Context, spaceID uuid.UUID, suppressorID uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemlink", "deleteCrossSpaceLinks"}, time.Now())
+	log.Info(ctx, map[string]interface{}{
+		"space_id": spaceID,
+	}, "Deleting the links between the work items of the space and the work items of other spaces")
+	query := fmt.Sprintf(`
+		source_id IN (SELECT id FROM %[1]s WHERE space_id = ?) AND target_id NOT IN (SELECT id FROM %[1]s WHERE space_id = ?)
+		OR target_id IN (SELECT id FROM %[1]s WHERE space_id = ?) AND source_id NOT IN (SELECT id FROM %[1]s WHERE space_id = ?)`,
+		workitem.WorkItemStorage{}.TableName())
+	var workitemLinks = []WorkItemLink{}
+	db := r.db.Where(query, spaceID, spaceID, spaceID, spaceID).Find(&workitemLinks)
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, db.Error)
+	}
+	// delete one by one to trigger the creation of a new work item link revision
+	for _, workitemLink := range workitemLinks {
+		if err := r.deleteLink(ctx, workitemLink, suppressorID); err != nil {
+			return errs.WithStack(err)
+		}
+	}
+	return nil
+}
+
 // Delete deletes the work item link with the given id
 // returns NotFoundError or InternalError
 func (r *GormWorkItemLinkRepository) deleteLink(ctx context.Context, lnk WorkItemLink, suppressorID uuid.UUID) error {
@@ -305,6 +385,9 @@ func (r *GormWorkItemLinkRepository) Save(ctx context.Context, linkToSave WorkIt
 	if existingLink.Version != linkToSave.Version {
 		return nil, errors.NewVersionConflictError("version conflict")
 	}
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, existingLink.SourceID, existingLink.TargetID); err != nil {
+		return nil, err
+	}
 	// retain the creation timestamp of the existing record
 	linkToSave.CreatedAt = existingLink.CreatedAt
 	linkToSave.Version = linkToSave.Version + 1
@@ -314,6 +397,10 @@ func (r *GormWorkItemLinkRepository) Save(ctx context.Context, linkToSave WorkIt
 		return nil, errs.Wrap(err, "failed to load link type")
 	}
 
+	if err := r.ValidateLinkTypeSpace(ctx, linkToSave.SourceID, linkToSave.TargetID, *linkTypeToSave); err != nil {
+		return nil, errs.WithStack(err)
+	}
+
 	if err := r.ValidateTopology(ctx, &linkToSave.SourceID, linkToSave.TargetID, *linkTypeToSave); err != nil {
 		return nil, errs.WithStack(err)
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/workitem"
 	"github.com/fabric8-services/fabric8-wit/workitem/link"
 
+	errs "github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -351,3 +352,123 @@ func (s *linkRepoBlackBoxTest) TestExistsLink() {
 	})
 
 }
+
+func (s *linkRepoBlackBoxTest) TestCrossSpaceLinks() {
+	// given a work item in another space
+	otherSpace, err := space.NewRepository(s.DB).Create(s.ctx, &space.Space{
+		Name: testsupport.CreateRandomValidTestName("other-space"),
+	})
+	require.Nil(s.T(), err)
+	otherWI, err := s.workitemRepo.Create(
+		s.ctx, otherSpace.ID, workitem.SystemBug,
+		map[string]interface{}{
+			workitem.SystemTitle: "Other space item",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+
+	s.T().Run("ok - link type of the source space", func(t *testing.T) {
+		// when
+		l, err := s.workitemLinkRepo.Create(s.ctx, s.parent1.ID, otherWI.ID, s.testTreeLinkTypeID, s.testIdentity.ID)
+		// then
+		require.Nil(t, err)
+		links, err := s.workitemLinkRepo.ListByWorkItem(s.ctx, otherWI.ID)
+		require.Nil(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, l.ID, links[0].ID)
+	})
+
+	s.T().Run("fail - link type of an unrelated space", func(t *testing.T) {
+		// given a link type in the other space
+		linkType, err := s.workitemLinkTypeRepo.Create(s.ctx, &link.WorkItemLinkType{
+			Name:           "Other space link type " + uuid.NewV4().String(),
+			ForwardName:    "relates to",
+			ReverseName:    "is related to",
+			Topology:       link.TopologyNetwork,
+			LinkCategoryID: s.linkCategoryID,
+			SpaceID:        otherSpace.ID,
+		})
+		require.Nil(t, err)
+		// when linking two work items of the test space
+		_, err = s.workitemLinkRepo.Create(s.ctx, s.parent2.ID, s.child.ID, linkType.ID, s.testIdentity.ID)
+		// then
+		require.NotNil(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("dangling links are ignored", func(t *testing.T) {
+		// given
+		_, err := s.workitemLinkRepo.Create(s.ctx, s.parent2.ID, s.child.ID, s.testTreeLinkTypeID, s.testIdentity.ID)
+		require.Nil(t, err)
+		// when the other space is deleted
+		require.Nil(t, space.NewRepository(s.DB).Delete(s.ctx, otherSpace.ID))
+		// then
+		links, err := s.workitemLinkRepo.ListByWorkItem(s.ctx, s.parent1.ID)
+		require.Nil(t, err)
+		assert.Empty(t, links)
+		links, err = s.workitemLinkRepo.ListByWorkItem(s.ctx, s.child.ID)
+		require.Nil(t, err)
+		assert.Len(t, links, 1)
+	})
+}
+
+func (s *linkRepoBlackBoxTest) TestDeleteCrossSpaceLinks() {
+	// given a link to a work item in another space
+	otherSpace, err := space.NewRepository(s.DB).Create(s.ctx, &space.Space{
+		Name: testsupport.CreateRandomValidTestName("other-space"),
+	})
+	require.Nil(s.T(), err)
+	otherWI, err := s.workitemRepo.Create(
+		s.ctx, otherSpace.ID, workitem.SystemBug,
+		map[string]interface{}{
+			workitem.SystemTitle: "Other space item",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	crossSpaceLink, err := s.workitemLinkRepo.Create(s.ctx, s.parent1.ID, otherWI.ID, s.testTreeLinkTypeID, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	// and a link within the test space
+	localLink, err := s.workitemLinkRepo.Create(s.ctx, s.parent2.ID, s.child.ID, s.testTreeLinkTypeID, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	// when
+	err = s.workitemLinkRepo.DeleteCrossSpaceLinks(s.ctx, otherSpace.ID, s.testIdentity.ID)
+	// then
+	require.Nil(s.T(), err)
+	_, err = s.workitemLinkRepo.Load(s.ctx, crossSpaceLink.ID)
+	require.IsType(s.T(), errors.NotFoundError{}, errs.Cause(err))
+	_, err = s.workitemLinkRepo.Load(s.ctx, localLink.ID)
+	require.Nil(s.T(), err)
+	revisions, err := link.NewRevisionRepository(s.DB).List(s.ctx, crossSpaceLink.ID)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), revisions, 2)
+	assert.Equal(s.T(), link.RevisionTypeDelete, revisions[1].Type)
+}
+
+func (s *linkRepoBlackBoxTest) TestArchivedSpace() {
+	// given a link in the test space, which is then archived
+	l, err := s.workitemLinkRepo.Create(s.ctx, s.parent1.ID, s.child.ID, s.testTreeLinkTypeID, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	spaceRepository := space.NewRepository(s.DB)
+	testSpace, err := spaceRepository.Load(s.ctx, s.testSpace)
+	require.Nil(s.T(), err)
+	testSpace.Archived = true
+	_, err = spaceRepository.Save(s.ctx, testSpace)
+	require.Nil(s.T(), err)
+
+	s.T().Run("create", func(t *testing.T) {
+		_, err := s.workitemLinkRepo.Create(s.ctx, s.parent2.ID, s.parent1.ID, s.testTreeLinkTypeID, s.testIdentity.ID)
+		require.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+
+	s.T().Run("delete", func(t *testing.T) {
+		err := s.workitemLinkRepo.Delete(s.ctx, l.ID, s.testIdentity.ID)
+		require.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+
+	s.T().Run("delete related links", func(t *testing.T) {
+		err := s.workitemLinkRepo.DeleteRelatedLinks(s.ctx, s.child.ID, s.testIdentity.ID)
+		require.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+		_, err = s.workitemLinkRepo.Load(s.ctx, l.ID)
+		require.Nil(t, err)
+	})
+}
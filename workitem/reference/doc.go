@@ -0,0 +1,5 @@
+// Package reference contains the code that stores the references to work
+// items written as `#number` or `space-name#number` in the description of the
+// work items and in their comments, which are shown as "mentioned in"
+// back-references on the referenced work items.
+package reference
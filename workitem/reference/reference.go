@@ -0,0 +1,28 @@
+package reference
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Reference is the reference to a work item written in the description of
+// another work item, or in one of its comments
+type Reference struct {
+	ID        uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	CreatedAt time.Time
+	// the referenced work item
+	WorkItemID uuid.UUID `sql:"type:uuid"`
+	// the reference as written, i.e. `#number`, `space-name#number` or the
+	// URL of the page of the work item, in lower case
+	Reference string
+	// the work item referencing the work item, or whose comment does
+	SourceWorkItemID uuid.UUID `sql:"type:uuid"`
+	// the comment referencing the work item, if any
+	SourceCommentID *uuid.UUID `sql:"type:uuid"`
+}
+
+// TableName implements gorm.tabler
+func (r Reference) TableName() string {
+	return "work_item_references"
+}
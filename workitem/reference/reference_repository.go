@@ -0,0 +1,148 @@
+package reference
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/log"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository describes interactions with the references to work items
+type Repository interface {
+	// Replace replaces the references written in the description of the
+	// given work item, or in the given comment of the work item if not nil,
+	// with the given referenced work items by reference. The references
+	// which already existed are kept as is.
+	Replace(ctx context.Context, sourceWorkItemID uuid.UUID, sourceCommentID *uuid.UUID, workItems map[string]uuid.UUID) ([]Reference, error)
+	// List returns the references written in the description of the given
+	// work item, or in the given comment of the work item if not nil.
+	List(ctx context.Context, sourceWorkItemID uuid.UUID, sourceCommentID *uuid.UUID) ([]Reference, error)
+	// ListByWorkItems returns the references written in the descriptions
+	// and in the comments of the given work items.
+	ListByWorkItems(ctx context.Context, sourceWorkItemIDs []uuid.UUID) ([]Reference, error)
+	// ListReferencing returns the references to the given work item from
+	// the work items and comments which are not deleted, the oldest first.
+	ListReferencing(ctx context.Context, workItemID uuid.UUID) ([]Reference, error)
+}
+
+// NewRepository creates a new storage type.
+func NewRepository(db *gorm.DB) Repository {
+	return &GormReferenceRepository{db: db}
+}
+
+// GormReferenceRepository is the implementation of the storage interface for
+// the references to work items.
+type GormReferenceRepository struct {
+	db *gorm.DB
+}
+
+// source restricts the given query to the references written in the
+// description of the given work item, or in the given comment of the work
+// item if not nil
+func source(db *gorm.DB, sourceWorkItemID uuid.UUID, sourceCommentID *uuid.UUID) *gorm.DB {
+	if sourceCommentID == nil {
+		return db.Where("source_work_item_id = ? AND source_comment_id IS NULL", sourceWorkItemID)
+	}
+	return db.Where("source_work_item_id = ? AND source_comment_id = ?", sourceWorkItemID, *sourceCommentID)
+}
+
+// Replace replaces the references written in the description of the given
+// work item, or in the given comment of the work item if not nil, with the
+// given referenced work items by reference. The references which already
+// existed are kept as is.
+func (r *GormReferenceRepository) Replace(ctx context.Context, sourceWorkItemID uuid.UUID, sourceCommentID *uuid.UUID, workItems map[string]uuid.UUID) ([]Reference, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "work_item_reference", "replace"}, time.Now())
+	if err := space.CheckWorkItemsNotArchived(ctx, r.db, sourceWorkItemID); err != nil {
+		return nil, err
+	}
+	existing, err := r.List(ctx, sourceWorkItemID, sourceCommentID)
+	if err != nil {
+		return nil, err
+	}
+	result := []Reference{}
+	kept := make(map[string]bool, len(existing))
+	for _, ref := range existing {
+		if workItemID, ok := workItems[ref.Reference]; ok && uuid.Equal(workItemID, ref.WorkItemID) {
+			result = append(result, ref)
+			kept[ref.Reference] = true
+			continue
+		}
+		if err := r.db.Delete(&ref).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"reference_id": ref.ID,
+				"err":          err,
+			}, "unable to delete the work item reference")
+			return nil, errors.NewInternalError(ctx, err)
+		}
+	}
+	for reference, workItemID := range workItems {
+		if kept[reference] {
+			continue
+		}
+		ref := Reference{
+			ID:               uuid.NewV4(),
+			WorkItemID:       workItemID,
+			Reference:        reference,
+			SourceWorkItemID: sourceWorkItemID,
+			SourceCommentID:  sourceCommentID,
+		}
+		if err := r.db.Create(&ref).Error; err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"wi_id":               workItemID,
+				"source_work_item_id": sourceWorkItemID,
+				"source_comment_id":   sourceCommentID,
+				"err":                 err,
+			}, "unable to create the work item reference")
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		result = append(result, ref)
+	}
+	return result, nil
+}
+
+// List returns the references written in the description of the given work
+// item, or in the given comment of the work item if not nil.
+func (r *GormReferenceRepository) List(ctx context.Context, sourceWorkItemID uuid.UUID, sourceCommentID *uuid.UUID) ([]Reference, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "work_item_reference", "list"}, time.Now())
+	var result []Reference
+	if err := source(r.db, sourceWorkItemID, sourceCommentID).Order("reference").Find(&result).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
+
+// ListByWorkItems returns the references written in the descriptions and in
+// the comments of the given work items.
+func (r *GormReferenceRepository) ListByWorkItems(ctx context.Context, sourceWorkItemIDs []uuid.UUID) ([]Reference, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "work_item_reference", "list_by_work_items"}, time.Now())
+	result := []Reference{}
+	if len(sourceWorkItemIDs) == 0 {
+		return result, nil
+	}
+	if err := r.db.Where("source_work_item_id IN (?)", sourceWorkItemIDs).Order("reference").Find(&result).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
+
+// ListReferencing returns the references to the given work item from the work
+// items and comments which are not deleted, the oldest first.
+func (r *GormReferenceRepository) ListReferencing(ctx context.Context, workItemID uuid.UUID) ([]Reference, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "work_item_reference", "list_referencing"}, time.Now())
+	var result []Reference
+	err := r.db.Select("work_item_references.*").
+		Joins("JOIN work_items w ON w.id = work_item_references.source_work_item_id AND w.deleted_at IS NULL").
+		Joins("LEFT JOIN comments c ON c.id = work_item_references.source_comment_id").
+		Where("work_item_references.work_item_id = ? AND c.deleted_at IS NULL", workItemID).
+		Order("work_item_references.created_at, work_item_references.id").
+		Find(&result).Error
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,205 @@
+package reference_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/account"
+	"github.com/fabric8-services/fabric8-wit/comment"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/migration"
+	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	testsupport "github.com/fabric8-services/fabric8-wit/test"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/reference"
+
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestReferenceRepository struct {
+	gormtestsupport.DBTestSuite
+	clean        func()
+	testIdentity account.Identity
+	repo         reference.Repository
+	wiRepo       workitem.WorkItemRepository
+	commentRepo  comment.Repository
+	ctx          context.Context
+}
+
+func TestRunReferenceRepository(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &TestReferenceRepository{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *TestReferenceRepository) SetupSuite() {
+	s.DBTestSuite.SetupSuite()
+	s.ctx = migration.NewMigrationContext(context.Background())
+	s.DBTestSuite.PopulateDBTestSuite(s.ctx)
+}
+
+func (s *TestReferenceRepository) SetupTest() {
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+	s.repo = reference.NewRepository(s.DB)
+	s.wiRepo = workitem.NewWorkItemRepository(s.DB)
+	s.commentRepo = comment.NewRepository(s.DB)
+	var err error
+	s.testIdentity, err = testsupport.CreateTestIdentity(s.DB, "jdoe", "test")
+	require.Nil(s.T(), err)
+}
+
+func (s *TestReferenceRepository) TearDownTest() {
+	s.clean()
+}
+
+func (s *TestReferenceRepository) createWorkItem() *workitem.WorkItem {
+	wi, err := s.wiRepo.Create(s.ctx, space.SystemSpace, workitem.SystemBug, map[string]interface{}{
+		workitem.SystemTitle: "References",
+		workitem.SystemState: workitem.SystemStateNew,
+	}, s.testIdentity.ID)
+	require.Nil(s.T(), err)
+	return wi
+}
+
+func (s *TestReferenceRepository) TestReplace() {
+	// given
+	wi := s.createWorkItem()
+	referenced1 := s.createWorkItem()
+	referenced2 := s.createWorkItem()
+
+	s.T().Run("add", func(t *testing.T) {
+		// when
+		refs, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"#1": referenced1.ID})
+		require.Nil(t, err)
+		require.Len(t, refs, 1)
+		first := refs[0]
+		_, err = s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"#1": referenced1.ID, "#2": referenced2.ID})
+		require.Nil(t, err)
+		// then the existing reference is kept as is
+		listed, err := s.repo.List(s.ctx, wi.ID, nil)
+		require.Nil(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, first.ID, listed[0].ID)
+		assert.Equal(t, "#2", listed[1].Reference)
+		assert.Equal(t, referenced2.ID, listed[1].WorkItemID)
+	})
+
+	s.T().Run("remove", func(t *testing.T) {
+		// when
+		_, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"#2": referenced2.ID})
+		require.Nil(t, err)
+		// then
+		listed, err := s.repo.List(s.ctx, wi.ID, nil)
+		require.Nil(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "#2", listed[0].Reference)
+	})
+
+	s.T().Run("archived space", func(t *testing.T) {
+		// given a work item of a space which is then archived
+		spaceRepo := space.NewRepository(s.DB)
+		sp, err := spaceRepo.Create(s.ctx, &space.Space{Name: testsupport.CreateRandomValidTestName("references-")})
+		require.Nil(t, err)
+		archivedWI, err := s.wiRepo.Create(s.ctx, sp.ID, workitem.SystemBug, map[string]interface{}{
+			workitem.SystemTitle: "Archived",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.testIdentity.ID)
+		require.Nil(t, err)
+		sp.Archived = true
+		_, err = spaceRepo.Save(s.ctx, sp)
+		require.Nil(t, err)
+		// when
+		_, err = s.repo.Replace(s.ctx, archivedWI.ID, nil, map[string]uuid.UUID{"#1": referenced1.ID})
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.ForbiddenError{}, errs.Cause(err))
+	})
+}
+
+func (s *TestReferenceRepository) TestListByWorkItems() {
+	// given
+	referenced := s.createWorkItem()
+	wi := s.createWorkItem()
+	other := s.createWorkItem()
+	c := &comment.Comment{
+		ParentID:  wi.ID,
+		Body:      "Duplicate of #2",
+		Markup:    rendering.SystemMarkupMarkdown,
+		CreatedBy: s.testIdentity.ID,
+	}
+	require.Nil(s.T(), s.commentRepo.Create(s.ctx, c, s.testIdentity.ID))
+	_, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"#1": referenced.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, wi.ID, &c.ID, map[string]uuid.UUID{"#2": referenced.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, other.ID, nil, map[string]uuid.UUID{"#3": referenced.ID})
+	require.Nil(s.T(), err)
+	// when
+	refs, err := s.repo.ListByWorkItems(s.ctx, []uuid.UUID{wi.ID})
+	// then the references of the description and of the comment are listed
+	require.Nil(s.T(), err)
+	require.Len(s.T(), refs, 2)
+	assert.Equal(s.T(), "#1", refs[0].Reference)
+	assert.Nil(s.T(), refs[0].SourceCommentID)
+	assert.Equal(s.T(), "#2", refs[1].Reference)
+	require.NotNil(s.T(), refs[1].SourceCommentID)
+	assert.Equal(s.T(), c.ID, *refs[1].SourceCommentID)
+}
+
+func (s *TestReferenceRepository) TestListReferencing() {
+	// given
+	referenced := s.createWorkItem()
+	wi := s.createWorkItem()
+	deletedWI := s.createWorkItem()
+	c := &comment.Comment{
+		ParentID:  wi.ID,
+		Body:      "Duplicate of #1",
+		Markup:    rendering.SystemMarkupMarkdown,
+		CreatedBy: s.testIdentity.ID,
+	}
+	require.Nil(s.T(), s.commentRepo.Create(s.ctx, c, s.testIdentity.ID))
+	_, err := s.repo.Replace(s.ctx, wi.ID, nil, map[string]uuid.UUID{"#1": referenced.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, wi.ID, &c.ID, map[string]uuid.UUID{"#1": referenced.ID})
+	require.Nil(s.T(), err)
+	_, err = s.repo.Replace(s.ctx, deletedWI.ID, nil, map[string]uuid.UUID{"#1": referenced.ID})
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), s.wiRepo.Delete(s.ctx, deletedWI.ID, s.testIdentity.ID))
+
+	s.T().Run("ok", func(t *testing.T) {
+		// when
+		refs, err := s.repo.ListReferencing(s.ctx, referenced.ID)
+		// then the oldest first, without the deleted work item
+		require.Nil(t, err)
+		require.Len(t, refs, 2)
+		assert.Equal(t, wi.ID, refs[0].SourceWorkItemID)
+		assert.Nil(t, refs[0].SourceCommentID)
+		require.NotNil(t, refs[1].SourceCommentID)
+		assert.Equal(t, c.ID, *refs[1].SourceCommentID)
+	})
+
+	s.T().Run("deleted comment", func(t *testing.T) {
+		// when
+		require.Nil(t, s.commentRepo.Delete(s.ctx, c.ID, s.testIdentity.ID))
+		refs, err := s.repo.ListReferencing(s.ctx, referenced.ID)
+		// then
+		require.Nil(t, err)
+		require.Len(t, refs, 1)
+		assert.Nil(t, refs[0].SourceCommentID)
+	})
+
+	s.T().Run("not referenced", func(t *testing.T) {
+		// when
+		refs, err := s.repo.ListReferencing(s.ctx, wi.ID)
+		// then
+		require.Nil(t, err)
+		assert.Empty(t, refs)
+	})
+}
@@ -0,0 +1,120 @@
+package workitem
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+)
+
+// defaultOrder is the order of the work items when no sort key is given, and
+// the tie-breaker otherwise
+const defaultOrder = "execution_order desc"
+
+// sortFieldRegexp matches the names of the fields that can be used as sort keys
+var sortFieldRegexp = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// sortColumns maps the fields stored in their own column to the column name
+var sortColumns = map[string]string{
+	SystemNumber:    "number",
+	SystemCreatedAt: "created_at",
+	SystemUpdatedAt: "updated_at",
+	SystemOrder:     "execution_order",
+}
+
+// SortKey is a field by which a list of work items is sorted
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSort parses a comma separated list of field names, each optionally
+// prefixed with "-" for a descending order, e.g. "-system.updated_at,system.title"
+func ParseSort(sort string) ([]SortKey, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+	var result []SortKey
+	for _, token := range strings.Split(sort, ",") {
+		token = strings.TrimSpace(token)
+		key := SortKey{Field: strings.TrimPrefix(token, "-")}
+		key.Descending = key.Field != token
+		if !sortFieldRegexp.MatchString(key.Field) {
+			return nil, errors.NewBadParameterError("sort", sort).Expected("comma separated field names, optionally prefixed with '-'")
+		}
+		result = append(result, key)
+	}
+	return result, nil
+}
+
+// sortString returns the sort keys in the format accepted by ParseSort
+func sortString(keys []SortKey) string {
+	tokens := make([]string, len(keys))
+	for i, key := range keys {
+		tokens[i] = key.Field
+		if key.Descending {
+			tokens[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(tokens, ",")
+}
+
+// numericKinds are the kinds of the fields stored as JSON numbers
+var numericKinds = map[Kind]bool{
+	KindInteger:  true,
+	KindFloat:    true,
+	KindDuration: true,
+	KindInstant:  true,
+}
+
+// compileSort returns the ORDER BY clause for the given sort keys. The fields
+// not stored in their own column are compared by their JSON value, cast to a
+// number when the given kind of the field is numeric and compared as text
+// otherwise.
+func compileSort(keys []SortKey, kinds map[string]Kind) (string, error) {
+	clauses := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		if !sortFieldRegexp.MatchString(key.Field) {
+			return "", errors.NewBadParameterError("sort", sortString(keys))
+		}
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			column = fmt.Sprintf("Fields->>'%s'", key.Field)
+			if numericKinds[kinds[key.Field]] {
+				column = "(" + column + ")::numeric"
+			}
+		}
+		direction := "asc"
+		if key.Descending {
+			direction = "desc"
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+	return strings.Join(append(clauses, defaultOrder), ", "), nil
+}
+
+// sortFieldKinds returns the kinds of the JSON fields used as sort keys, as
+// defined by the given work item types
+func sortFieldKinds(keys []SortKey, types []WorkItemType) map[string]Kind {
+	kinds := map[string]Kind{}
+	for _, key := range keys {
+		if _, ok := sortColumns[key.Field]; ok {
+			continue
+		}
+		for _, t := range types {
+			if def, ok := t.Fields[key.Field]; ok {
+				switch enum := def.Type.(type) {
+				case EnumType:
+					kinds[key.Field] = enum.BaseType.GetKind()
+				case *EnumType:
+					kinds[key.Field] = enum.BaseType.GetKind()
+				default:
+					kinds[key.Field] = def.Type.GetKind()
+				}
+				break
+			}
+		}
+	}
+	return kinds
+}
@@ -0,0 +1,34 @@
+package workitem
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileSort(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	types := []WorkItemType{
+		{
+			Fields: FieldDefinitions{
+				"storypoints": {Type: SimpleType{Kind: KindInteger}},
+				"severity":    {Type: EnumType{BaseType: SimpleType{Kind: KindFloat}}},
+				SystemTitle:   {Type: SimpleType{Kind: KindString}},
+			},
+		},
+	}
+	keys := []SortKey{
+		{Field: "storypoints", Descending: true},
+		{Field: "severity"},
+		{Field: SystemTitle},
+		{Field: SystemNumber},
+	}
+	// when
+	order, err := compileSort(keys, sortFieldKinds(keys, types))
+	// then
+	require.Nil(t, err)
+	assert.Equal(t, "(Fields->>'storypoints')::numeric desc, (Fields->>'severity')::numeric asc, Fields->>'system.title' asc, number asc, "+defaultOrder, order)
+}
@@ -0,0 +1,4 @@
+// Package template contains the code that provides all the required
+// operations to manage work item templates, i.e. named trees of pre-filled
+// work items that can be instantiated in a space.
+package template
@@ -0,0 +1,179 @@
+package template
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	errs "github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// APIStringTypeTemplates is the JSONAPI type of work item templates
+	APIStringTypeTemplates = "workitemtemplates"
+	templateTableName      = "work_item_templates"
+)
+
+// placeholderRegexp matches the placeholders like `{{release}}` in the titles
+// and descriptions of the template items
+var placeholderRegexp = regexp.MustCompile(`{{\s*([A-Za-z0-9_.-]+)\s*}}`)
+
+// Template is a named tree of pre-filled work items that belongs to a space
+type Template struct {
+	gormsupport.Lifecycle
+	// ID
+	ID uuid.UUID `sql:"type:uuid default uuid_generate_v4()" gorm:"primary_key"`
+	// SpaceID is the ID of the space to which this template belongs
+	SpaceID uuid.UUID `sql:"type:uuid"`
+	// Name is the name of this template, unique within its space
+	Name string
+	// Description is an optional description of the template
+	Description *string
+	// Version for optimistic concurrency control
+	Version int
+	// Items are the top-level items of the template
+	Items Items `sql:"type:jsonb"`
+}
+
+// TableName implements gorm.tabler
+func (t Template) TableName() string {
+	return templateTableName
+}
+
+// Item is a pre-filled work item of a template, along with its children
+type Item struct {
+	// TypeID is the ID of the type of the work item to create
+	TypeID uuid.UUID `json:"type"`
+	// Fields are the field values of the work item to create. The title and
+	// the description may contain placeholders.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// LinkTypeID is the ID of the type of the link from the parent item to
+	// this item. It is ignored for the top-level items.
+	LinkTypeID *uuid.UUID `json:"link_type,omitempty"`
+	// Children are the items linked from this item
+	Children Items `json:"children,omitempty"`
+}
+
+// Items is a list of template items stored as JSON
+type Items []Item
+
+// Value implements the driver.Valuer interface
+func (i Items) Value() (driver.Value, error) {
+	return json.Marshal(i)
+}
+
+// Scan implements the sql.Scanner interface
+func (i *Items) Scan(src interface{}) error {
+	if src == nil {
+		*i = nil
+		return nil
+	}
+	data, ok := src.([]byte)
+	if !ok {
+		return errs.Errorf("unable to scan template items from %T", src)
+	}
+	return json.Unmarshal(data, i)
+}
+
+// Walk calls the given function for each item of the tree, parents before
+// their children. The parent is nil for the top-level items.
+func (i Items) Walk(fn func(parent *Item, item Item) error) error {
+	return i.walk(nil, fn)
+}
+
+func (i Items) walk(parent *Item, fn func(parent *Item, item Item) error) error {
+	for idx := range i {
+		if err := fn(parent, i[idx]); err != nil {
+			return err
+		}
+		if err := i[idx].Children.walk(&i[idx], fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Placeholders returns the sorted names of the placeholders used in the
+// titles and descriptions of all the items of the template
+func (t Template) Placeholders() []string {
+	names := map[string]bool{}
+	t.Items.Walk(func(_ *Item, item Item) error {
+		for _, s := range placeholderFields(item.Fields) {
+			for _, match := range placeholderRegexp.FindAllStringSubmatch(s, -1) {
+				names[match[1]] = true
+			}
+		}
+		return nil
+	})
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// placeholderFields returns the text of the fields that may contain
+// placeholders.
+func placeholderFields(fields map[string]interface{}) []string {
+	result := []string{}
+	if title, ok := fields[workitem.SystemTitle].(string); ok {
+		result = append(result, title)
+	}
+	switch description := fields[workitem.SystemDescription].(type) {
+	case string:
+		result = append(result, description)
+	case map[string]interface{}:
+		if content, ok := description["content"].(string); ok {
+			result = append(result, content)
+		}
+	}
+	return result
+}
+
+// Expand returns a copy of the given fields in which the placeholders of the
+// title and the description are replaced with the given values. It returns an
+// error if a placeholder has no value.
+func Expand(fields map[string]interface{}, values map[string]string) (map[string]interface{}, error) {
+	var missing []string
+	replace := func(s string) string {
+		return placeholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+			value, ok := values[name]
+			if !ok {
+				missing = append(missing, name)
+				return placeholder
+			}
+			return value
+		})
+	}
+	result := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		result[name] = value
+	}
+	if title, ok := result[workitem.SystemTitle].(string); ok {
+		result[workitem.SystemTitle] = replace(title)
+	}
+	switch description := result[workitem.SystemDescription].(type) {
+	case string:
+		result[workitem.SystemDescription] = replace(description)
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(description))
+		for k, v := range description {
+			expanded[k] = v
+		}
+		if content, ok := description["content"].(string); ok {
+			expanded["content"] = replace(content)
+		}
+		result[workitem.SystemDescription] = expanded
+	}
+	if len(missing) > 0 {
+		return nil, errs.Errorf("missing value for placeholder(s): %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package template_test
+
+import (
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceholders(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	tpl := template.Template{
+		Items: template.Items{
+			{
+				TypeID: workitem.SystemBug,
+				Fields: map[string]interface{}{
+					workitem.SystemTitle:       "Release {{release}}",
+					workitem.SystemDescription: "Ship {{ release }} on {{date}}",
+				},
+				Children: template.Items{
+					{
+						TypeID: workitem.SystemBug,
+						Fields: map[string]interface{}{
+							workitem.SystemTitle: "Notes for {{release}}",
+							workitem.SystemDescription: map[string]interface{}{
+								"content": "Written by {{author}}",
+								"markup":  "Markdown",
+							},
+							workitem.SystemState: "{{ignored}}",
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{"author", "date", "release"}, tpl.Placeholders())
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	values := map[string]string{"release": "1.2", "author": "jane"}
+
+	t.Run("title and plain description", func(t *testing.T) {
+		// given
+		fields := map[string]interface{}{
+			workitem.SystemTitle:       "Release {{release}}",
+			workitem.SystemDescription: "Ship {{ release }}",
+			workitem.SystemState:       "{{release}}",
+		}
+		// when
+		expanded, err := template.Expand(fields, values)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, "Release 1.2", expanded[workitem.SystemTitle])
+		assert.Equal(t, "Ship 1.2", expanded[workitem.SystemDescription])
+		// only the title and the description are expanded
+		assert.Equal(t, "{{release}}", expanded[workitem.SystemState])
+		// the given fields are left untouched
+		assert.Equal(t, "Release {{release}}", fields[workitem.SystemTitle])
+	})
+
+	t.Run("markup description", func(t *testing.T) {
+		// given
+		description := map[string]interface{}{
+			"content": "Written by {{author}}",
+			"markup":  "Markdown",
+		}
+		fields := map[string]interface{}{
+			workitem.SystemTitle:       "Notes",
+			workitem.SystemDescription: description,
+		}
+		// when
+		expanded, err := template.Expand(fields, values)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"content": "Written by jane",
+			"markup":  "Markdown",
+		}, expanded[workitem.SystemDescription])
+		assert.Equal(t, "Written by {{author}}", description["content"])
+	})
+
+	t.Run("no placeholder", func(t *testing.T) {
+		// given
+		fields := map[string]interface{}{
+			workitem.SystemTitle:     "Release",
+			workitem.SystemAssignees: []interface{}{uuid.NewV4().String()},
+		}
+		// when
+		expanded, err := template.Expand(fields, nil)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, fields, expanded)
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		// given
+		fields := map[string]interface{}{
+			workitem.SystemTitle:       "Release {{release}} of {{product}}",
+			workitem.SystemDescription: "Due on {{date}}",
+		}
+		// when
+		_, err := template.Expand(fields, values)
+		// then
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "product")
+		assert.Contains(t, err.Error(), "date")
+	})
+}
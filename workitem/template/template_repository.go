@@ -0,0 +1,153 @@
+package template
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/fabric8-services/fabric8-wit/application/repository"
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport"
+	"github.com/fabric8-services/fabric8-wit/log"
+
+	"github.com/goadesign/goa"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Repository encapsulates storage & retrieval of work item templates
+type Repository interface {
+	repository.Exister
+	Create(ctx context.Context, tpl *Template) (*Template, error)
+	Load(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) (*Template, error)
+	List(ctx context.Context, spaceID uuid.UUID) ([]Template, error)
+	Save(ctx context.Context, tpl Template) (*Template, error)
+	Delete(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) error
+}
+
+// NewRepository creates a work item template repository based on gorm
+func NewRepository(db *gorm.DB) *GormTemplateRepository {
+	return &GormTemplateRepository{db}
+}
+
+// GormTemplateRepository implements Repository using gorm
+type GormTemplateRepository struct {
+	db *gorm.DB
+}
+
+// Create creates a new work item template in the repository.
+// Returns BadParameterError or InternalError
+func (r *GormTemplateRepository) Create(ctx context.Context, tpl *Template) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "create"}, time.Now())
+	if strings.TrimSpace(tpl.Name) == "" {
+		return nil, errors.NewBadParameterError("name", tpl.Name)
+	}
+	if len(tpl.Items) == 0 {
+		return nil, errors.NewBadParameterError("items", tpl.Items).Expected("at least one item")
+	}
+	if tpl.ID == uuid.Nil {
+		tpl.ID = uuid.NewV4()
+	}
+	db := r.db.Create(tpl)
+	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "work_item_templates_name_space_id_unique") {
+			return nil, errors.NewBadParameterError("name & space_id", tpl.Name+" & "+tpl.SpaceID.String()).Expected("unique")
+		}
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	log.Info(ctx, map[string]interface{}{
+		"template_id": tpl.ID,
+		"space_id":    tpl.SpaceID,
+	}, "work item template created")
+	return tpl, nil
+}
+
+// Load returns the work item template for the given space and ID.
+// Returns NotFoundError or InternalError
+func (r *GormTemplateRepository) Load(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "load"}, time.Now())
+	result := Template{}
+	db := r.db.Model(&result).Where("id = ? AND space_id = ?", ID, spaceID).First(&result)
+	if db.RecordNotFound() {
+		log.Error(ctx, map[string]interface{}{
+			"template_id": ID,
+			"space_id":    spaceID,
+		}, "work item template not found")
+		return nil, errors.NewNotFoundError("work item template", ID.String())
+	}
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return &result, nil
+}
+
+// CheckExists returns nil if the given ID exists otherwise returns an error
+func (r *GormTemplateRepository) CheckExists(ctx context.Context, id string) error {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "exists"}, time.Now())
+	return repository.CheckExists(ctx, r.db, templateTableName, id)
+}
+
+// List returns all work item templates of the given space, ordered by name
+func (r *GormTemplateRepository) List(ctx context.Context, spaceID uuid.UUID) ([]Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "list"}, time.Now())
+	var rows []Template
+	db := r.db.Where("space_id = ?", spaceID).Order("name").Find(&rows)
+	if db.Error != nil {
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	return rows, nil
+}
+
+// Save updates the given work item template in storage. Version must be the
+// same as the one in the stored version.
+// returns NotFoundError, VersionConflictError, BadParameterError or InternalError
+func (r *GormTemplateRepository) Save(ctx context.Context, tpl Template) (*Template, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "save"}, time.Now())
+	if strings.TrimSpace(tpl.Name) == "" {
+		return nil, errors.NewBadParameterError("name", tpl.Name)
+	}
+	if len(tpl.Items) == 0 {
+		return nil, errors.NewBadParameterError("items", tpl.Items).Expected("at least one item")
+	}
+	existing, err := r.Load(ctx, tpl.SpaceID, tpl.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Version != tpl.Version {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	tpl.CreatedAt = existing.CreatedAt
+	tpl.Version = tpl.Version + 1
+	db := r.db.Where("version = ?", existing.Version).Save(&tpl)
+	if db.Error != nil {
+		if gormsupport.IsUniqueViolation(db.Error, "work_item_templates_name_space_id_unique") {
+			return nil, errors.NewBadParameterError("name & space_id", tpl.Name+" & "+tpl.SpaceID.String()).Expected("unique")
+		}
+		log.Error(ctx, map[string]interface{}{
+			"template_id": tpl.ID,
+			"err":         db.Error,
+		}, "unable to save work item template")
+		return nil, errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	log.Info(ctx, map[string]interface{}{
+		"template_id": tpl.ID,
+	}, "work item template updated")
+	return &tpl, nil
+}
+
+// Delete deletes the work item template with the given space and ID
+// returns NotFoundError or InternalError
+func (r *GormTemplateRepository) Delete(ctx context.Context, spaceID uuid.UUID, ID uuid.UUID) error {
+	defer goa.MeasureSince([]string{"goa", "db", "workitemtemplate", "delete"}, time.Now())
+	db := r.db.Where("space_id = ?", spaceID).Delete(&Template{ID: ID})
+	if db.Error != nil {
+		return errors.NewInternalError(ctx, db.Error)
+	}
+	if db.RowsAffected == 0 {
+		return errors.NewNotFoundError("work item template", ID.String())
+	}
+	return nil
+}
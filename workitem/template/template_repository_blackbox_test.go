@@ -0,0 +1,162 @@
+package template_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fabric8-services/fabric8-wit/errors"
+	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
+	"github.com/fabric8-services/fabric8-wit/gormtestsupport"
+	"github.com/fabric8-services/fabric8-wit/resource"
+	"github.com/fabric8-services/fabric8-wit/space"
+	"github.com/fabric8-services/fabric8-wit/workitem"
+	"github.com/fabric8-services/fabric8-wit/workitem/link"
+	"github.com/fabric8-services/fabric8-wit/workitem/template"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestPlaceholdersAndExpand(t *testing.T) {
+	t.Parallel()
+	resource.Require(t, resource.UnitTest)
+	tpl := template.Template{
+		Items: template.Items{
+			{
+				TypeID: workitem.SystemFeature,
+				Fields: map[string]interface{}{
+					workitem.SystemTitle: "Release {{ release }}",
+				},
+				Children: template.Items{
+					{
+						TypeID:     workitem.SystemTask,
+						LinkTypeID: &link.SystemWorkItemLinkTypeParentChildID,
+						Fields: map[string]interface{}{
+							workitem.SystemTitle:       "Write the notes of {{release}}",
+							workitem.SystemDescription: map[string]interface{}{"content": "Ask {{owner}}", "markup": "PlainText"},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{"owner", "release"}, tpl.Placeholders())
+
+	t.Run("ok", func(t *testing.T) {
+		fields, err := template.Expand(tpl.Items[0].Children[0].Fields, map[string]string{"release": "1.2", "owner": "joe"})
+		require.Nil(t, err)
+		assert.Equal(t, "Write the notes of 1.2", fields[workitem.SystemTitle])
+		assert.Equal(t, map[string]interface{}{"content": "Ask joe", "markup": "PlainText"}, fields[workitem.SystemDescription])
+		// the template is left untouched
+		assert.Equal(t, "Write the notes of {{release}}", tpl.Items[0].Children[0].Fields[workitem.SystemTitle])
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		_, err := template.Expand(tpl.Items[0].Fields, map[string]string{"owner": "joe"})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "release")
+	})
+}
+
+type templateRepoBlackBoxTest struct {
+	gormtestsupport.DBTestSuite
+	repo  template.Repository
+	clean func()
+}
+
+func TestRunTemplateRepoBlackBoxTest(t *testing.T) {
+	resource.Require(t, resource.Database)
+	suite.Run(t, &templateRepoBlackBoxTest{DBTestSuite: gormtestsupport.NewDBTestSuite("../../config.yaml")})
+}
+
+func (s *templateRepoBlackBoxTest) SetupTest() {
+	s.repo = template.NewRepository(s.DB)
+	s.clean = cleaner.DeleteCreatedEntities(s.DB)
+}
+
+func (s *templateRepoBlackBoxTest) TearDownTest() {
+	s.clean()
+}
+
+func (s *templateRepoBlackBoxTest) newTemplate(name string) *template.Template {
+	return &template.Template{
+		SpaceID: space.SystemSpace,
+		Name:    name,
+		Items: template.Items{
+			{
+				TypeID: workitem.SystemTask,
+				Fields: map[string]interface{}{workitem.SystemTitle: "Prepare {{release}}"},
+			},
+		},
+	}
+}
+
+func (s *templateRepoBlackBoxTest) TestCreateAndLoad() {
+	// given
+	created, err := s.repo.Create(context.Background(), s.newTemplate("release "+uuid.NewV4().String()))
+	require.Nil(s.T(), err)
+	// when
+	loaded, err := s.repo.Load(context.Background(), space.SystemSpace, created.ID)
+	// then
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), created.Name, loaded.Name)
+	require.Len(s.T(), loaded.Items, 1)
+	assert.Equal(s.T(), workitem.SystemTask, loaded.Items[0].TypeID)
+	assert.Equal(s.T(), "Prepare {{release}}", loaded.Items[0].Fields[workitem.SystemTitle])
+
+	s.T().Run("not found in another space", func(t *testing.T) {
+		_, err := s.repo.Load(context.Background(), uuid.NewV4(), created.ID)
+		require.IsType(t, errors.NotFoundError{}, err)
+	})
+}
+
+func (s *templateRepoBlackBoxTest) TestCreateDuplicateName() {
+	// given
+	name := "release " + uuid.NewV4().String()
+	_, err := s.repo.Create(context.Background(), s.newTemplate(name))
+	require.Nil(s.T(), err)
+	// when
+	_, err = s.repo.Create(context.Background(), s.newTemplate(name))
+	// then
+	require.IsType(s.T(), errors.BadParameterError{}, err)
+}
+
+func (s *templateRepoBlackBoxTest) TestSave() {
+	// given
+	created, err := s.repo.Create(context.Background(), s.newTemplate("release "+uuid.NewV4().String()))
+	require.Nil(s.T(), err)
+
+	s.T().Run("ok", func(t *testing.T) {
+		tpl := *created
+		tpl.Name = "hotfix " + uuid.NewV4().String()
+		saved, err := s.repo.Save(context.Background(), tpl)
+		require.Nil(t, err)
+		assert.Equal(t, tpl.Name, saved.Name)
+		assert.Equal(t, created.Version+1, saved.Version)
+	})
+
+	s.T().Run("version conflict", func(t *testing.T) {
+		tpl := *created
+		_, err := s.repo.Save(context.Background(), tpl)
+		require.IsType(t, errors.VersionConflictError{}, err)
+	})
+}
+
+func (s *templateRepoBlackBoxTest) TestDelete() {
+	// given
+	created, err := s.repo.Create(context.Background(), s.newTemplate("release "+uuid.NewV4().String()))
+	require.Nil(s.T(), err)
+	// when
+	err = s.repo.Delete(context.Background(), space.SystemSpace, created.ID)
+	// then
+	require.Nil(s.T(), err)
+	_, err = s.repo.Load(context.Background(), space.SystemSpace, created.ID)
+	require.IsType(s.T(), errors.NotFoundError{}, err)
+	templates, err := s.repo.List(context.Background(), space.SystemSpace)
+	require.Nil(s.T(), err)
+	for _, tpl := range templates {
+		assert.NotEqual(s.T(), created.ID, tpl.ID)
+	}
+}
@@ -1,6 +1,8 @@
 package workitem
 
 import (
+	"time"
+
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -18,3 +20,21 @@ const (
 func (w WorkItemNumberSequence) TableName() string {
 	return workitemNumberTableName
 }
+
+// WorkItemNumberRedirect keeps track of the number that a work item had in a
+// space it was moved out of, so that the former number can still be resolved.
+type WorkItemNumberRedirect struct {
+	CreatedAt  time.Time
+	SpaceID    uuid.UUID `sql:"type:uuid" gorm:"primary_key"`
+	Number     int       `gorm:"primary_key"`
+	WorkItemID uuid.UUID `sql:"type:uuid"`
+}
+
+const (
+	workitemNumberRedirectTableName = "work_item_number_redirects"
+)
+
+// TableName implements gorm.tabler
+func (w WorkItemNumberRedirect) TableName() string {
+	return workitemNumberRedirectTableName
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/fabric8-services/fabric8-wit/iteration"
 	"github.com/fabric8-services/fabric8-wit/log"
 	"github.com/fabric8-services/fabric8-wit/rendering"
+	"github.com/fabric8-services/fabric8-wit/space"
 
 	"github.com/goadesign/goa"
 	"github.com/jinzhu/gorm"
@@ -35,15 +36,23 @@ const (
 type WorkItemRepository interface {
 	repository.Exister
 	LoadByID(ctx context.Context, id uuid.UUID) (*WorkItem, error)
+	LoadBatchByID(ctx context.Context, ids []uuid.UUID) ([]*WorkItem, error)
 	Load(ctx context.Context, spaceID uuid.UUID, wiNumber int) (*WorkItem, error)
+	LoadMoved(ctx context.Context, spaceID uuid.UUID, wiNumber int) (*WorkItem, error)
 	Save(ctx context.Context, spaceID uuid.UUID, wi WorkItem, modifierID uuid.UUID) (*WorkItem, error)
 	Reorder(ctx context.Context, direction DirectionType, targetID *uuid.UUID, wi WorkItem, modifierID uuid.UUID) (*WorkItem, error)
 	Delete(ctx context.Context, id uuid.UUID, suppressorID uuid.UUID) error
 	Create(ctx context.Context, spaceID uuid.UUID, typeID uuid.UUID, fields map[string]interface{}, creatorID uuid.UUID) (*WorkItem, error)
-	List(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, start *int, length *int) ([]WorkItem, int, error)
+	ReserveNumbers(ctx context.Context, spaceID uuid.UUID, count int) (int, error)
+	Import(ctx context.Context, spaceID uuid.UUID, typeID uuid.UUID, number int, revisions []Revision) (*WorkItem, error)
+	Move(ctx context.Context, id uuid.UUID, spaceID uuid.UUID, typeID uuid.UUID, iterationID uuid.UUID, areaID uuid.UUID, modifierID uuid.UUID) (*WorkItem, error)
+	List(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, sort []SortKey, start *int, length *int) ([]WorkItem, int, error)
 	Fetch(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression) (*WorkItem, error)
 	GetCountsPerIteration(ctx context.Context, spaceID uuid.UUID) (map[string]WICountsPerIteration, error)
 	GetCountsForIteration(ctx context.Context, itr *iteration.Iteration) (map[string]WICountsPerIteration, error)
+	GetBurndownForIteration(ctx context.Context, itr *iteration.Iteration, pointsField string) ([]BurndownPoint, error)
+	GetVelocityPerIteration(ctx context.Context, spaceID uuid.UUID, pointsField string) ([]IterationVelocity, error)
+	GetCommitmentPerAssignee(ctx context.Context, itr *iteration.Iteration, field string) (map[string]AssigneeCommitment, error)
 	Count(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression) (int, error)
 }
 
@@ -99,6 +108,32 @@ func (r *GormWorkItemRepository) LoadByID(ctx context.Context, id uuid.UUID) (*W
 	return ConvertWorkItemStorageToModel(wiType, res)
 }
 
+// LoadBatchByID returns the work items with the given IDs, in no particular
+// order. The IDs of the work items which do not exist are ignored.
+func (r *GormWorkItemRepository) LoadBatchByID(ctx context.Context, ids []uuid.UUID) ([]*WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "loadBatchById"}, time.Now())
+	result := []*WorkItem{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	var rows []WorkItemStorage
+	if err := r.db.Where("id IN (?)", ids).Find(&rows).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	for i := range rows {
+		wiType, err := r.witr.LoadTypeFromDB(ctx, rows[i].Type)
+		if err != nil {
+			return nil, errors.NewInternalError(ctx, err)
+		}
+		wi, err := ConvertWorkItemStorageToModel(wiType, &rows[i])
+		if err != nil {
+			return nil, errs.WithStack(err)
+		}
+		result = append(result, wi)
+	}
+	return result, nil
+}
+
 // Load returns the work item for the given spaceID and item id
 // returns NotFoundError, ConversionError or InternalError
 func (r *GormWorkItemRepository) Load(ctx context.Context, spaceID uuid.UUID, wiNumber int) (*WorkItem, error) {
@@ -110,6 +145,23 @@ func (r *GormWorkItemRepository) Load(ctx context.Context, spaceID uuid.UUID, wi
 	return ConvertWorkItemStorageToModel(wiType, wiStorage)
 }
 
+// LoadMoved returns the work item that had the given number in the given
+// space before it was moved to another space. The work item is returned with
+// its current space and number.
+// returns NotFoundError, ConversionError or InternalError
+func (r *GormWorkItemRepository) LoadMoved(ctx context.Context, spaceID uuid.UUID, wiNumber int) (*WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "loadMoved"}, time.Now())
+	redirect := WorkItemNumberRedirect{}
+	tx := r.db.Model(&WorkItemNumberRedirect{}).Where("space_id = ? AND number = ?", spaceID, wiNumber).First(&redirect)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("work item", strconv.Itoa(wiNumber))
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	return r.LoadByID(ctx, redirect.WorkItemID)
+}
+
 // CheckExists returns nil if the given ID exists otherwise returns an error
 func (m *GormWorkItemRepository) CheckExists(ctx context.Context, workitemID string) error {
 	defer goa.MeasureSince([]string{"goa", "db", "workitem", "exists"}, time.Now())
@@ -199,7 +251,10 @@ func (r *GormWorkItemRepository) Delete(ctx context.Context, workitemID uuid.UUI
 	var workItem = WorkItemStorage{}
 	workItem.ID = workitemID
 	// retrieve the current version of the work item to delete
-	r.db.Select("id, version, type").Where("id = ?", workitemID).Find(&workItem)
+	r.db.Select("id, version, type, space_id").Where("id = ?", workitemID).Find(&workItem)
+	if err := space.CheckNotArchived(ctx, r.db, workItem.SpaceID); err != nil {
+		return err
+	}
 	// delete the work item
 	tx := r.db.Delete(workItem)
 	if err := tx.Error; err != nil {
@@ -284,6 +339,9 @@ func (r *GormWorkItemRepository) Reorder(ctx context.Context, direction Directio
 	if err := tx.Error; err != nil {
 		return nil, errors.NewInternalError(ctx, err)
 	}
+	if err := space.CheckNotArchived(ctx, r.db, res.SpaceID); err != nil {
+		return nil, err
+	}
 	if res.Version != wi.Version {
 		log.Info(ctx, map[string]interface{}{
 			"wi_id":           wi.ID.String(),
@@ -403,6 +461,9 @@ func (r *GormWorkItemRepository) Reorder(ctx context.Context, direction Directio
 // returns NotFoundError, VersionConflictError, ConversionError or InternalError
 func (r *GormWorkItemRepository) Save(ctx context.Context, spaceID uuid.UUID, updatedWorkItem WorkItem, modifierID uuid.UUID) (*WorkItem, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitem", "save"}, time.Now())
+	if err := space.CheckNotArchived(ctx, r.db, spaceID); err != nil {
+		return nil, err
+	}
 	wiStorage, wiType, err := r.loadWorkItemStorage(ctx, spaceID, updatedWorkItem.Number, true)
 	if err != nil {
 		return nil, err
@@ -454,21 +515,16 @@ func (r *GormWorkItemRepository) Save(ctx context.Context, spaceID uuid.UUID, up
 // returns BadParameterError, ConversionError or InternalError
 func (r *GormWorkItemRepository) Create(ctx context.Context, spaceID uuid.UUID, typeID uuid.UUID, fields map[string]interface{}, creatorID uuid.UUID) (*WorkItem, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitem", "create"}, time.Now())
+	if err := space.CheckNotArchived(ctx, r.db, spaceID); err != nil {
+		return nil, err
+	}
 
 	wiType, err := r.witr.LoadTypeFromDB(ctx, typeID)
 	if err != nil {
 		return nil, errors.NewBadParameterError("typeID", typeID)
 	}
-	// retrieve the current issue number in the given space
-	numberSequence := WorkItemNumberSequence{}
-	tx := r.db.Model(&WorkItemNumberSequence{}).Set("gorm:query_option", "FOR UPDATE").Where("space_id = ?", spaceID).First(&numberSequence)
-	if tx.RecordNotFound() {
-		numberSequence.SpaceID = spaceID
-		numberSequence.CurrentVal = 1
-	} else {
-		numberSequence.CurrentVal++
-	}
-	if err = r.db.Save(&numberSequence).Error; err != nil {
+	number, err := r.nextNumber(ctx, spaceID)
+	if err != nil {
 		return nil, errs.Wrapf(err, "failed to create work item")
 	}
 
@@ -483,7 +539,7 @@ func (r *GormWorkItemRepository) Create(ctx context.Context, spaceID uuid.UUID,
 		Fields:         Fields{},
 		ExecutionOrder: pos,
 		SpaceID:        spaceID,
-		Number:         numberSequence.CurrentVal,
+		Number:         number,
 	}
 	fields[SystemCreator] = creatorID.String()
 	for fieldName, fieldDef := range wiType.Fields {
@@ -520,6 +576,125 @@ func (r *GormWorkItemRepository) Create(ctx context.Context, spaceID uuid.UUID,
 	return witem, nil
 }
 
+// nextNumber allocates the next work item number in the given space
+func (r *GormWorkItemRepository) nextNumber(ctx context.Context, spaceID uuid.UUID) (int, error) {
+	return r.reserveNumbers(ctx, spaceID, 1)
+}
+
+// reserveNumbers allocates the given count of consecutive work item numbers
+// in the given space and returns the first one
+func (r *GormWorkItemRepository) reserveNumbers(ctx context.Context, spaceID uuid.UUID, count int) (int, error) {
+	// retrieve the current issue number in the given space
+	numberSequence := WorkItemNumberSequence{}
+	tx := r.db.Model(&WorkItemNumberSequence{}).Set("gorm:query_option", "FOR UPDATE").Where("space_id = ?", spaceID).First(&numberSequence)
+	if tx.RecordNotFound() {
+		numberSequence.SpaceID = spaceID
+	}
+	first := numberSequence.CurrentVal + 1
+	numberSequence.CurrentVal += count
+	if err := r.db.Save(&numberSequence).Error; err != nil {
+		return 0, errors.NewInternalError(ctx, err)
+	}
+	return first, nil
+}
+
+// Move moves the work item with the given id to another space. The work item
+// gets a new number in the target space and is assigned the given type,
+// iteration and area, which must belong to the target space. The former
+// space and number of the work item are kept so that they still resolve to
+// the moved work item (see LoadMoved). Comments, revisions and links are kept
+// since they refer to the work item by its ID.
+// returns NotFoundError, BadParameterError, ConversionError or InternalError
+func (r *GormWorkItemRepository) Move(ctx context.Context, id uuid.UUID, spaceID uuid.UUID, typeID uuid.UUID, iterationID uuid.UUID, areaID uuid.UUID, modifierID uuid.UUID) (*WorkItem, error) {
+	defer goa.MeasureSince([]string{"goa", "db", "workitem", "move"}, time.Now())
+	wiStorage := WorkItemStorage{}
+	// SELECT ... FOR UPDATE will lock the row to prevent concurrent update while until surrounding transaction ends.
+	tx := r.db.Set("gorm:query_option", "FOR UPDATE").Model(&wiStorage).Where("id = ?", id).First(&wiStorage)
+	if tx.RecordNotFound() {
+		return nil, errors.NewNotFoundError("work item", id.String())
+	}
+	if tx.Error != nil {
+		return nil, errors.NewInternalError(ctx, tx.Error)
+	}
+	if uuid.Equal(wiStorage.SpaceID, spaceID) {
+		return nil, errors.NewBadParameterError("space", spaceID).Expected("a space other than the current space of the work item")
+	}
+	for _, id := range []uuid.UUID{wiStorage.SpaceID, spaceID} {
+		if err := space.CheckNotArchived(ctx, r.db, id); err != nil {
+			return nil, err
+		}
+	}
+	oldType, err := r.witr.LoadTypeFromDB(ctx, wiStorage.Type)
+	if err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	newType, err := r.witr.LoadTypeFromDB(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewBadParameterError("typeID", typeID)
+	}
+	wi, err := ConvertWorkItemStorageToModel(oldType, &wiStorage)
+	if err != nil {
+		return nil, errs.WithStack(err)
+	}
+	// keep a redirect from the former space and number to the work item
+	redirect := WorkItemNumberRedirect{
+		SpaceID:    wiStorage.SpaceID,
+		Number:     wiStorage.Number,
+		WorkItemID: wiStorage.ID,
+	}
+	if err := r.db.Create(&redirect).Error; err != nil {
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	number, err := r.nextNumber(ctx, spaceID)
+	if err != nil {
+		return nil, errs.Wrapf(err, "failed to move work item")
+	}
+	oldSpaceID := wiStorage.SpaceID
+	oldVersion := wiStorage.Version
+	wiStorage.SpaceID = spaceID
+	wiStorage.Number = number
+	wiStorage.Type = typeID
+	wiStorage.Version = wiStorage.Version + 1
+	wiStorage.Fields = Fields{}
+	wi.Fields[SystemIteration] = iterationID.String()
+	wi.Fields[SystemArea] = areaID.String()
+	// only keep the fields that are supported by the new work item type
+	for fieldName, fieldDef := range newType.Fields {
+		if fieldName == SystemCreatedAt || fieldName == SystemUpdatedAt || fieldName == SystemOrder {
+			continue
+		}
+		fieldValue := wi.Fields[fieldName]
+		wiStorage.Fields[fieldName], err = fieldDef.ConvertToModel(fieldName, fieldValue)
+		if err != nil {
+			return nil, errors.NewBadParameterError(fieldName, fieldValue)
+		}
+	}
+	tx = r.db.Where("Version = ?", oldVersion).Save(&wiStorage)
+	if err := tx.Error; err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"wi_id":           id,
+			"space_id":        oldSpaceID,
+			"target_space_id": spaceID,
+			"err":             err,
+		}, "unable to move the work item")
+		return nil, errors.NewInternalError(ctx, err)
+	}
+	if tx.RowsAffected == 0 {
+		return nil, errors.NewVersionConflictError("version conflict")
+	}
+	// store a revision of the moved work item
+	if err := r.wirr.Create(context.Background(), modifierID, RevisionTypeMove, wiStorage); err != nil {
+		return nil, errs.Wrapf(err, "error while moving work item")
+	}
+	log.Info(ctx, map[string]interface{}{
+		"wi_id":           id,
+		"space_id":        oldSpaceID,
+		"target_space_id": spaceID,
+		"wi_number":       number,
+	}, "Moved work item to another space")
+	return ConvertWorkItemStorageToModel(newType, &wiStorage)
+}
+
 // ConvertWorkItemStorageToModel convert work item model to app WI
 func ConvertWorkItemStorageToModel(wiType *WorkItemType, wi *WorkItemStorage) (*WorkItem, error) {
 	result, err := wiType.ConvertWorkItemStorageToModel(*wi)
@@ -541,7 +716,7 @@ func ConvertWorkItemStorageToModel(wiType *WorkItemType, wi *WorkItemStorage) (*
 
 // extracted this function from List() in order to close the rows object with "defer" for more readability
 // workaround for https://github.com/lib/pq/issues/81
-func (r *GormWorkItemRepository) listItemsFromDB(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, start *int, limit *int) ([]WorkItemStorage, int, error) {
+func (r *GormWorkItemRepository) listItemsFromDB(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, sort []SortKey, start *int, limit *int) ([]WorkItemStorage, int, error) {
 	where, parameters, compileError := Compile(criteria)
 	if compileError != nil {
 		return nil, 0, errors.NewBadParameterError("expression", criteria)
@@ -574,7 +749,20 @@ func (r *GormWorkItemRepository) listItemsFromDB(ctx context.Context, spaceID uu
 		db = db.Limit(*limit)
 	}
 
-	db = db.Select("count(*) over () as cnt2 , *").Order("execution_order desc")
+	var kinds map[string]Kind
+	if len(sort) > 0 {
+		// the field kinds decide whether the JSON values are sorted as numbers
+		var types []WorkItemType
+		if err := r.db.Where("space_id IN (?)", []uuid.UUID{spaceID, space.SystemSpace}).Find(&types).Error; err != nil {
+			return nil, 0, errs.WithStack(err)
+		}
+		kinds = sortFieldKinds(sort, types)
+	}
+	order, err := compileSort(sort, kinds)
+	if err != nil {
+		return nil, 0, err
+	}
+	db = db.Select("count(*) over () as cnt2 , *").Order(order)
 
 	rows, err := db.Rows()
 	if err != nil {
@@ -626,10 +814,11 @@ func (r *GormWorkItemRepository) listItemsFromDB(ctx context.Context, spaceID uu
 	return result, count, nil
 }
 
-// List returns work item selected by the given criteria.Expression, starting with start (zero-based) and returning at most limit items
-func (r *GormWorkItemRepository) List(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, start *int, limit *int) ([]WorkItem, int, error) {
+// List returns work item selected by the given criteria.Expression, sorted by the given keys (by
+// execution order if none is given), starting with start (zero-based) and returning at most limit items
+func (r *GormWorkItemRepository) List(ctx context.Context, spaceID uuid.UUID, criteria criteria.Expression, parentExists *bool, sort []SortKey, start *int, limit *int) ([]WorkItem, int, error) {
 	defer goa.MeasureSince([]string{"goa", "db", "workitem", "list"}, time.Now())
-	result, count, err := r.listItemsFromDB(ctx, spaceID, criteria, parentExists, start, limit)
+	result, count, err := r.listItemsFromDB(ctx, spaceID, criteria, parentExists, sort, start, limit)
 	if err != nil {
 		return nil, 0, errs.WithStack(err)
 	}
@@ -669,7 +858,7 @@ func (r *GormWorkItemRepository) Fetch(ctx context.Context, spaceID uuid.UUID, c
 	defer goa.MeasureSince([]string{"goa", "db", "workitem", "fetch"}, time.Now())
 
 	limit := 1
-	results, count, err := r.List(ctx, spaceID, criteria, nil, nil, &limit)
+	results, count, err := r.List(ctx, spaceID, criteria, nil, nil, nil, &limit)
 	if err != nil {
 		return nil, err
 	}
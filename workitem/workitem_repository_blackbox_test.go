@@ -3,7 +3,9 @@ package workitem_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/fabric8-services/fabric8-wit/area"
 	"github.com/fabric8-services/fabric8-wit/codebase"
 	"github.com/fabric8-services/fabric8-wit/errors"
 	"github.com/fabric8-services/fabric8-wit/gormsupport/cleaner"
@@ -94,6 +96,32 @@ func (s *workItemRepoBlackBoxTest) TestFailLoadNilID() {
 	assert.IsType(s.T(), errors.NotFoundError{}, errs.Cause(err))
 }
 
+func (s *workItemRepoBlackBoxTest) TestLoadBatchByID() {
+	// given
+	wi1, err := s.repo.Create(
+		s.ctx, s.spaceID, workitem.SystemBug,
+		map[string]interface{}{
+			workitem.SystemTitle: "Title 1",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.creatorID)
+	require.Nil(s.T(), err, "Could not create workitem")
+	wi2, err := s.repo.Create(
+		s.ctx, s.spaceID, workitem.SystemBug,
+		map[string]interface{}{
+			workitem.SystemTitle: "Title 2",
+			workitem.SystemState: workitem.SystemStateNew,
+		}, s.creatorID)
+	require.Nil(s.T(), err, "Could not create workitem")
+	// when
+	loaded, err := s.repo.LoadBatchByID(s.ctx, []uuid.UUID{wi1.ID, wi2.ID, uuid.NewV4()})
+	// then the unknown ID is ignored
+	require.Nil(s.T(), err)
+	require.Len(s.T(), loaded, 2)
+	titles := []interface{}{loaded[0].Fields[workitem.SystemTitle], loaded[1].Fields[workitem.SystemTitle]}
+	assert.Contains(s.T(), titles, "Title 1")
+	assert.Contains(s.T(), titles, "Title 2")
+}
+
 func (s *workItemRepoBlackBoxTest) TestSaveAssignees() {
 	// given
 	wi, err := s.repo.Create(
@@ -286,6 +314,310 @@ func (s *workItemRepoBlackBoxTest) TestGetCountsPerIteration() {
 	assert.Equal(s.T(), 0, countsMap[iteration2.ID.String()].Closed)
 }
 
+// TestGetBurndownForIteration makes sure that the remaining work of each day
+// of an iteration is reconstructed from the revisions of the work items
+func (s *workItemRepoBlackBoxTest) TestGetBurndownForIteration() {
+	// given
+	spaceRepo := space.NewRepository(s.DB)
+	spaceInstance := space.Space{
+		Name: "Testing space" + uuid.NewV4().String(),
+	}
+	_, err := spaceRepo.Create(s.ctx, &spaceInstance)
+	require.Nil(s.T(), err)
+	extended := workitem.SystemBug
+	wit, err := workitem.NewWorkItemTypeRepository(s.DB).Create(s.ctx, spaceInstance.ID, nil, &extended, "story", nil, "fa-bomb", map[string]workitem.FieldDefinition{
+		"storypoints": {
+			Label: "Story Points",
+			Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+		},
+	})
+	require.Nil(s.T(), err)
+	iterationRepo := iteration.NewIterationRepository(s.DB)
+	start := time.Now().Add(-48 * time.Hour)
+	end := time.Now().Add(72 * time.Hour)
+	sprint := iteration.Iteration{
+		Name:    "Sprint 1",
+		SpaceID: spaceInstance.ID,
+		StartAt: &start,
+		EndAt:   &end,
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &sprint))
+	otherSprint := iteration.Iteration{
+		Name:    "Sprint 2",
+		SpaceID: spaceInstance.ID,
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &otherSprint))
+	createItem := func(iterationID uuid.UUID, points float64) *workitem.WorkItem {
+		wi, err := s.repo.Create(
+			s.ctx, spaceInstance.ID, wit.ID,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Story",
+				workitem.SystemState:     workitem.SystemStateNew,
+				workitem.SystemIteration: iterationID.String(),
+				"storypoints":            points,
+			}, s.creatorID)
+		require.Nil(s.T(), err)
+		// pretend the work item was created at the start of the iteration
+		err = s.DB.Model(&workitem.Revision{}).Where("work_item_id = ?", wi.ID).Update("revision_time", start).Error
+		require.Nil(s.T(), err)
+		return wi
+	}
+	wi1 := createItem(sprint.ID, 3)
+	createItem(sprint.ID, 5)
+	createItem(otherSprint.ID, 8)
+	wi1.Fields[workitem.SystemState] = workitem.SystemStateClosed
+	_, err = s.repo.Save(s.ctx, spaceInstance.ID, *wi1, s.creatorID)
+	require.Nil(s.T(), err)
+	// when
+	points, err := s.repo.GetBurndownForIteration(s.ctx, &sprint, "storypoints")
+	// then
+	require.Nil(s.T(), err)
+	require.Len(s.T(), points, 3)
+	for i, p := range points[:2] {
+		assert.Equal(s.T(), start.UTC().Truncate(24*time.Hour).Add(time.Duration(i)*24*time.Hour), p.Date)
+		assert.Equal(s.T(), 2, p.TotalCount)
+		assert.Equal(s.T(), 2, p.RemainingCount)
+		assert.Equal(s.T(), 8.0, p.TotalPoints)
+		assert.Equal(s.T(), 8.0, p.RemainingPoints)
+	}
+	assert.Equal(s.T(), 2, points[2].TotalCount)
+	assert.Equal(s.T(), 1, points[2].RemainingCount)
+	assert.Equal(s.T(), 8.0, points[2].TotalPoints)
+	assert.Equal(s.T(), 5.0, points[2].RemainingPoints)
+
+	s.T().Run("without points field", func(t *testing.T) {
+		// when
+		points, err := s.repo.GetBurndownForIteration(s.ctx, &sprint, "")
+		// then
+		require.Nil(t, err)
+		require.Len(t, points, 3)
+		assert.Equal(t, 1, points[2].RemainingCount)
+		assert.Equal(t, 0.0, points[2].TotalPoints)
+	})
+
+	s.T().Run("iteration without dates", func(t *testing.T) {
+		// when
+		_, err := s.repo.GetBurndownForIteration(s.ctx, &otherSprint, "")
+		// then
+		require.NotNil(t, err)
+		require.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+}
+
+// TestGetVelocityPerIteration makes sure that the committed and completed
+// work of the past iterations of a space is returned
+func (s *workItemRepoBlackBoxTest) TestGetVelocityPerIteration() {
+	// given
+	spaceRepo := space.NewRepository(s.DB)
+	spaceInstance := space.Space{
+		Name: "Testing space" + uuid.NewV4().String(),
+	}
+	_, err := spaceRepo.Create(s.ctx, &spaceInstance)
+	require.Nil(s.T(), err)
+	iterationRepo := iteration.NewIterationRepository(s.DB)
+	start := time.Now().Add(-96 * time.Hour)
+	end := time.Now().Add(-48 * time.Hour)
+	pastSprint := iteration.Iteration{
+		Name:    "Sprint 1",
+		SpaceID: spaceInstance.ID,
+		StartAt: &start,
+		EndAt:   &end,
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &pastSprint))
+	futureEnd := time.Now().Add(48 * time.Hour)
+	currentSprint := iteration.Iteration{
+		Name:    "Sprint 2",
+		SpaceID: spaceInstance.ID,
+		StartAt: &end,
+		EndAt:   &futureEnd,
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &currentSprint))
+	for i, state := range []string{workitem.SystemStateNew, workitem.SystemStateClosed, workitem.SystemStateClosed} {
+		wi, err := s.repo.Create(
+			s.ctx, spaceInstance.ID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle:     fmt.Sprintf("Issue #%d", i),
+				workitem.SystemState:     state,
+				workitem.SystemIteration: pastSprint.ID.String(),
+			}, s.creatorID)
+		require.Nil(s.T(), err)
+		err = s.DB.Model(&workitem.Revision{}).Where("work_item_id = ?", wi.ID).Update("revision_time", start).Error
+		require.Nil(s.T(), err)
+	}
+	// when
+	velocities, err := s.repo.GetVelocityPerIteration(s.ctx, spaceInstance.ID, "")
+	// then
+	require.Nil(s.T(), err)
+	require.Len(s.T(), velocities, 1)
+	assert.Equal(s.T(), pastSprint.ID, velocities[0].Iteration.ID)
+	assert.Equal(s.T(), 3, velocities[0].CommittedCount)
+	assert.Equal(s.T(), 2, velocities[0].CompletedCount)
+}
+
+// TestGetCommitmentPerAssignee makes sure that the work assigned to each
+// collaborator in an iteration and its child iterations is summed
+func (s *workItemRepoBlackBoxTest) TestGetCommitmentPerAssignee() {
+	// given
+	spaceRepo := space.NewRepository(s.DB)
+	spaceInstance := space.Space{
+		Name: "Testing space" + uuid.NewV4().String(),
+	}
+	_, err := spaceRepo.Create(s.ctx, &spaceInstance)
+	require.Nil(s.T(), err)
+	extended := workitem.SystemBug
+	wit, err := workitem.NewWorkItemTypeRepository(s.DB).Create(s.ctx, spaceInstance.ID, nil, &extended, "story", nil, "fa-bomb", map[string]workitem.FieldDefinition{
+		"storypoints": {
+			Label: "Story Points",
+			Type:  workitem.SimpleType{Kind: workitem.KindFloat},
+		},
+	})
+	require.Nil(s.T(), err)
+	iterationRepo := iteration.NewIterationRepository(s.DB)
+	sprint := iteration.Iteration{
+		Name:    "Sprint 1",
+		SpaceID: spaceInstance.ID,
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &sprint))
+	childSprint := iteration.Iteration{
+		Name:    "Sprint 1.1",
+		SpaceID: spaceInstance.ID,
+		Path:    append(sprint.Path, sprint.ID),
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &childSprint))
+	otherSprint := iteration.Iteration{
+		Name:    "Sprint 2",
+		SpaceID: spaceInstance.ID,
+	}
+	require.Nil(s.T(), iterationRepo.Create(s.ctx, &otherSprint))
+	alice, bob := uuid.NewV4().String(), uuid.NewV4().String()
+	createItem := func(iterationID uuid.UUID, points float64, assignees ...string) {
+		_, err := s.repo.Create(
+			s.ctx, spaceInstance.ID, wit.ID,
+			map[string]interface{}{
+				workitem.SystemTitle:     "Story",
+				workitem.SystemState:     workitem.SystemStateNew,
+				workitem.SystemIteration: iterationID.String(),
+				workitem.SystemAssignees: assignees,
+				"storypoints":            points,
+			}, s.creatorID)
+		require.Nil(s.T(), err)
+	}
+	createItem(sprint.ID, 3, alice)
+	createItem(childSprint.ID, 5, alice, bob)
+	createItem(sprint.ID, 8)
+	createItem(otherSprint.ID, 13, bob)
+	// when
+	commitments, err := s.repo.GetCommitmentPerAssignee(s.ctx, &sprint, "storypoints")
+	// then
+	require.Nil(s.T(), err)
+	require.Len(s.T(), commitments, 2)
+	assert.Equal(s.T(), 2, commitments[alice].Count)
+	assert.Equal(s.T(), 8.0, commitments[alice].Total)
+	assert.Equal(s.T(), 1, commitments[bob].Count)
+	assert.Equal(s.T(), 5.0, commitments[bob].Total)
+
+	s.T().Run("without field", func(t *testing.T) {
+		// when
+		commitments, err := s.repo.GetCommitmentPerAssignee(s.ctx, &sprint, "")
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, 2, commitments[alice].Count)
+		assert.Equal(t, 0.0, commitments[alice].Total)
+	})
+}
+
+func (s *workItemRepoBlackBoxTest) TestMove() {
+	// createWorkItem creates a work item in the system space
+	createWorkItem := func(t *testing.T) *workitem.WorkItem {
+		wi, err := s.repo.Create(
+			s.ctx, s.spaceID, workitem.SystemBug,
+			map[string]interface{}{
+				workitem.SystemTitle: "Title",
+				workitem.SystemState: workitem.SystemStateNew,
+			}, s.creatorID)
+		require.Nil(t, err)
+		return wi
+	}
+	// createTargetSpace creates a target space with an iteration and an area
+	createTargetSpace := func(t *testing.T) (space.Space, iteration.Iteration, area.Area) {
+		targetSpace := space.Space{
+			Name: "Target space" + uuid.NewV4().String(),
+		}
+		_, err := space.NewRepository(s.DB).Create(s.ctx, &targetSpace)
+		require.Nil(t, err)
+		targetIteration := iteration.Iteration{
+			Name:    "Sprint 1",
+			SpaceID: targetSpace.ID,
+		}
+		require.Nil(t, iteration.NewIterationRepository(s.DB).Create(s.ctx, &targetIteration))
+		targetArea := area.Area{
+			Name:    "Area 1",
+			SpaceID: targetSpace.ID,
+		}
+		require.Nil(t, area.NewAreaRepository(s.DB).Create(s.ctx, &targetArea))
+		return targetSpace, targetIteration, targetArea
+	}
+
+	s.T().Run("ok", func(t *testing.T) {
+		// given
+		wi := createWorkItem(t)
+		targetSpace, targetIteration, targetArea := createTargetSpace(t)
+		// when
+		movedWI, err := s.repo.Move(s.ctx, wi.ID, targetSpace.ID, workitem.SystemBug, targetIteration.ID, targetArea.ID, s.creatorID)
+		// then
+		require.Nil(t, err)
+		assert.Equal(t, wi.ID, movedWI.ID)
+		assert.Equal(t, targetSpace.ID, movedWI.SpaceID)
+		assert.Equal(t, 1, movedWI.Number)
+		assert.Equal(t, wi.Version+1, movedWI.Version)
+		assert.Equal(t, "Title", movedWI.Fields[workitem.SystemTitle])
+		assert.Equal(t, targetIteration.ID.String(), movedWI.Fields[workitem.SystemIteration])
+		assert.Equal(t, targetArea.ID.String(), movedWI.Fields[workitem.SystemArea])
+		// the former number no longer loads the work item
+		_, err = s.repo.Load(s.ctx, s.spaceID, wi.Number)
+		require.NotNil(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+		// but still resolves to the moved work item
+		loadedWI, err := s.repo.LoadMoved(s.ctx, s.spaceID, wi.Number)
+		require.Nil(t, err)
+		assert.Equal(t, wi.ID, loadedWI.ID)
+		assert.Equal(t, targetSpace.ID, loadedWI.SpaceID)
+		// and a move revision was recorded
+		revisions, err := workitem.NewRevisionRepository(s.DB).List(s.ctx, wi.ID)
+		require.Nil(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, workitem.RevisionTypeMove, revisions[1].Type)
+	})
+
+	s.T().Run("fail - same space", func(t *testing.T) {
+		// given
+		wi := createWorkItem(t)
+		// when
+		_, err := s.repo.Move(s.ctx, wi.ID, s.spaceID, workitem.SystemBug, uuid.NewV4(), uuid.NewV4(), s.creatorID)
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.BadParameterError{}, errs.Cause(err))
+	})
+
+	s.T().Run("fail - unknown work item", func(t *testing.T) {
+		// given
+		targetSpace, targetIteration, targetArea := createTargetSpace(t)
+		// when
+		_, err := s.repo.Move(s.ctx, uuid.NewV4(), targetSpace.ID, workitem.SystemBug, targetIteration.ID, targetArea.ID, s.creatorID)
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+
+	s.T().Run("fail - former number not moved", func(t *testing.T) {
+		// when
+		_, err := s.repo.LoadMoved(s.ctx, s.spaceID, createWorkItem(t).Number)
+		// then
+		require.NotNil(t, err)
+		assert.IsType(t, errors.NotFoundError{}, errs.Cause(err))
+	})
+}
+
 func (s *workItemRepoBlackBoxTest) TestCodebaseAttributes() {
 	// given
 	title := "solution on global warming"
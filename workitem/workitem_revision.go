@@ -18,6 +18,11 @@ const (
 	_                  // ignore 3rd value
 	// RevisionTypeUpdate a work item update
 	RevisionTypeUpdate // 4
+	_                  // ignore 5th value
+	_                  // ignore 6th value
+	_                  // ignore 7th value
+	// RevisionTypeMove a work item move to another space
+	RevisionTypeMove // 8
 )
 
 // Revision represents a version of a work item